@@ -14,7 +14,6 @@ import (
 	"github.com/localstack/lstk/internal/endpoint"
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/terminal"
 	"github.com/spf13/cobra"
 )
@@ -163,7 +162,7 @@ Examples:
 				}
 				endpointURL = target.URL
 			} else {
-				rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+				rt, err := newRuntime(cfg)
 				if err != nil {
 					return err
 				}
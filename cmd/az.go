@@ -14,7 +14,6 @@ import (
 	"github.com/localstack/lstk/internal/endpoint"
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/terminal"
 	"github.com/localstack/lstk/internal/ui"
 	"github.com/spf13/cobra"
@@ -213,7 +212,7 @@ func azPreflight(ctx context.Context, cfg *env.Env, sink output.Sink, target *en
 		}
 	}
 
-	rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+	rt, err := newRuntime(cfg)
 	if err != nil {
 		return "", err
 	}
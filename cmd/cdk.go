@@ -10,7 +10,6 @@ import (
 	cdkcli "github.com/localstack/lstk/internal/iac/cdk/cli"
 	"github.com/localstack/lstk/internal/log"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -119,7 +118,7 @@ Examples:
 				return cdkcli.Run(cmd.Context(), target.URL, region, sink, logger, cdkArgs)
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
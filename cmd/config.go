@@ -2,42 +2,209 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 
 	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/env"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/proc"
 	"github.com/spf13/cobra"
 )
 
-func newConfigCmd() *cobra.Command {
+const configKeyHelp = `Keys are dotted paths into config.toml. An index selects one [[containers]] block
+(or one of its [[containers.init]] steps), counting from 0 in file order:
+
+  runtime
+  containers[0].tag
+  containers[1].expose_ports
+  env.debug.DEBUG
+  cli.update_skipped_version`
+
+func newConfigCmd(cfg *env.Env) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage configuration",
+		Long: "Manage configuration. lstk reads the user's global config.toml and, when one is found " +
+			"in the working directory or a parent of it up to the home directory, a project lstk.toml " +
+			"(or .lstk/config.toml) layered over it. A project that declares [[containers]] runs those emulators instead of the " +
+			"global ones. --config names a single file to use instead.",
 	}
 	requireSubcommand(cmd)
-	cmd.AddCommand(newConfigPathCmd())
+	cmd.AddCommand(newConfigPathCmd(cfg))
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigValidateCmd())
+	cmd.AddCommand(newConfigEditCmd())
+	cmd.AddCommand(newConfigTrustCmd())
 	return cmd
 }
 
-func newConfigPathCmd() *cobra.Command {
+func newConfigPathCmd(cfg *env.Env) *cobra.Command {
 	return &cobra.Command{
 		Use:   "path",
 		Short: "Print the configuration file path",
+		Long: "Print the configuration file path. When a project lstk.toml is layered over the global\n" +
+			"config.toml, both are printed, one per line, the project file first. Without any config\n" +
+			"file, prints where lstk would create one.",
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			sink := jsonAwareSink(cmd, cfg, cmd.OutOrStdout())
 			path, err := cmd.Flags().GetString("config")
 			if err != nil {
 				return err
 			}
 			if path != "" {
-				_, err := fmt.Fprintln(cmd.OutOrStdout(), path)
-				return err
+				sink.Emit(output.ConfigPathEvent{Path: path, Sources: []output.ConfigSource{{Path: path, Scope: config.SourceExplicit}}})
+				return nil
 			}
 
+			sources, err := config.Sources()
+			if err != nil {
+				return failGetConfig(sink, cfg, err)
+			}
 			configPath, err := config.ConfigFilePath()
+			if err != nil {
+				return failGetConfig(sink, cfg, err)
+			}
+			event := output.ConfigPathEvent{Path: configPath, Sources: []output.ConfigSource{}}
+			for _, s := range sources {
+				event.Sources = append(event.Sources, output.ConfigSource{Path: s.Path, Scope: s.Scope})
+			}
+			sink.Emit(event)
+			return nil
+		},
+	}
+}
+
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get KEY",
+		Short: "Print the value of a configuration key",
+		Long: "Print the value of a configuration key. Scalars print as-is; lists and tables print as TOML.\n" +
+			"Keys that are not set in config.toml show lstk's built-in default, if any.\n\n" + configKeyHelp,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := config.Value(args[0])
 			if err != nil {
 				return err
 			}
+			formatted, err := config.FormatValue(args[0], value)
+			if err != nil {
+				return err
+			}
+			output.NewPlainSink(cmd.OutOrStdout()).Emit(output.ConfigValueEvent{Key: args[0], Value: formatted})
+			return nil
+		},
+	}
+}
 
-			_, err = fmt.Fprintln(cmd.OutOrStdout(), configPath)
-			return err
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set a configuration key in the configuration file",
+		Long: "Set a configuration key in the project lstk.toml when there is one, else in the global\n" +
+			"config.toml. Only the value is rewritten: comments and\n" +
+			"formatting elsewhere in the file are kept. List keys take a TOML array, e.g.\n" +
+			"'[\"debug\", \"ci\"]'. A missing key or [table] is added, but a [[containers]] block\n" +
+			"must already exist. A value that would make the config invalid is refused.\n\n" + configKeyHelp,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.EnsureCreated(); err != nil {
+				return err
+			}
+			return config.SetValue(args[0], args[1])
 		},
 	}
 }
+
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the configuration for problems",
+		Long: "Check the configuration for problems: TOML syntax, ports, volumes, expose_ports, init\n" +
+			"steps, references to [env.*] profiles and clashes between [[containers]] blocks. A\n" +
+			"project lstk.toml is checked together with the global config it is layered over, and\n" +
+			"every problem is listed with the file and line that cause it. Exits non-zero if any\n" +
+			"are found.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, err := configSourcesForValidation(cmd)
+			if err != nil {
+				return err
+			}
+			sink := output.NewPlainSink(cmd.OutOrStdout())
+			if len(sources) == 0 {
+				sink.Emit(output.ConfigProblemsEvent{})
+				return nil
+			}
+			return config.ReportProblems(sink, sources)
+		},
+	}
+}
+
+func newConfigEditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: "Open the configuration file in your editor",
+		Long: "Open the configuration file in $VISUAL or $EDITOR (falling back to vi, or notepad on\n" +
+			"Windows): the project lstk.toml when there is one, else the global config.toml, which is\n" +
+			"created with the defaults first if it does not exist. The configuration is validated\n" +
+			"once the editor exits.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sources, err := configSourcesForValidation(cmd)
+			if err != nil {
+				return err
+			}
+			if len(sources) == 0 {
+				if err := config.EnsureCreated(); err != nil {
+					return err
+				}
+				if sources, err = config.Sources(); err != nil {
+					return err
+				}
+			}
+			editor := config.EditorCommand()
+			c := exec.CommandContext(cmd.Context(), editor[0], append(editor[1:], sources[0].Path)...)
+			c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+			if err := proc.Run(c); err != nil {
+				return fmt.Errorf("editor %s failed: %w", editor[0], err)
+			}
+			return config.ReportProblems(output.NewPlainSink(cmd.OutOrStdout()), sources)
+		},
+	}
+}
+
+func newConfigTrustCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "trust",
+		Short: "Trust the project configuration file",
+		Long: "Trust the project lstk.toml (or .lstk/config.toml) found in the working directory or a\n" +
+			"parent of it. Until it is trusted, lstk refuses the init steps (shell, aws and terraform)\n" +
+			"and the host volumes it declares, since they run code or mount files on this machine. Review the\n" +
+			"file first: any later change to it needs trusting again.",
+		Args:    cobra.NoArgs,
+		PreRunE: initConfigDeferCreate(nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.TrustProject()
+			if err != nil {
+				return err
+			}
+			output.NewPlainSink(cmd.OutOrStdout()).Emit(output.MessageEvent{Severity: output.SeveritySuccess, Text: "Trusted " + path})
+			return nil
+		},
+	}
+}
+
+// configSourcesForValidation is config.SourcesForValidation for the --config
+// file, if any.
+func configSourcesForValidation(cmd *cobra.Command) ([]config.Source, error) {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, err
+	}
+	return config.SourcesForValidation(path)
+}
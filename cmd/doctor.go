@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/localstack/lstk/internal/api"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/doctor"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/env"
+	"github.com/localstack/lstk/internal/log"
+	"github.com/localstack/lstk/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newDoctorCmd(cfg *env.Env, logger log.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the setup for problems",
+		Long: `Run every check lstk makes before starting an emulator or running a tool against it, and report whether each passed:
+
+  - the config file is valid
+  - the container runtime is reachable, and which one it is
+  - the ports each emulator publishes are free
+  - localhost.localstack.cloud resolves to the local machine
+  - an auth token is set, and the license server accepts it for each emulator
+  - the localstack AWS profile points at the AWS emulator
+  - the installed aws, terraform, cdk, sam and az are recent enough for lstk
+
+Each check reports pass, warn or fail, or skip when it does not apply, e.g. to a tool that is not installed. The command exits successfully once every check has run, whatever their outcome.
+
+With --json, the checks are written along with the lstk version, OS, runtime, config files and emulators, as a support bundle to attach to a bug report. It holds no auth token.`,
+		Example: `  lstk doctor
+  lstk doctor --json > lstk-doctor.json`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := doctor.Options{
+				RuntimeBackend:  runtimeBackend(cfg),
+				LocalStackHost:  cfg.LocalStackHost,
+				AuthToken:       cfg.AuthToken,
+				AuthTokenSource: authTokenSource(cfg),
+				Platform:        api.NewPlatformClient(cfg.APIEndpoint, logger),
+				ResolveHost:     endpoint.ResolveHost,
+				ToolVersion:     doctor.ToolVersion,
+			}
+			if opts.RuntimeBackend == "" {
+				opts.RuntimeBackend = "auto"
+			}
+			opts.Runtime, opts.RuntimeErr = newRuntime(cfg)
+
+			// Reporting a config that fails to load is one of the checks, so
+			// its failure is not the command's.
+			sources, err := configSourcesForValidation(cmd)
+			if err != nil {
+				opts.ConfigErr = err
+			} else if appConfig, err := config.Get(); err != nil {
+				opts.ConfigSources, opts.ConfigErr = sources, err
+			} else {
+				opts.ConfigSources, opts.Containers = sources, appConfig.Containers
+			}
+
+			if isInteractiveMode(cfg) {
+				return ui.RunDoctor(cmd.Context(), opts)
+			}
+			return doctor.Run(cmd.Context(), jsonAwareSink(cmd, cfg, os.Stdout), opts)
+		},
+	}
+}
+
+// authTokenSource names where the resolved auth token came from.
+func authTokenSource(cfg *env.Env) string {
+	switch {
+	case cfg.AuthToken == "":
+		return ""
+	case strings.TrimSpace(os.Getenv("LOCALSTACK_AUTH_TOKEN")) != "":
+		return "LOCALSTACK_AUTH_TOKEN"
+	default:
+		return "the credentials stored by lstk login"
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/localstack/lstk/internal/extension"
 	"github.com/localstack/lstk/internal/log"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/telemetry"
 	"github.com/spf13/cobra"
 )
@@ -94,7 +93,7 @@ func dispatchExtension(ctx context.Context, cfg *env.Env, tel *telemetry.Client,
 // unavailable) it returns nil, which Environ renders as an empty array; the
 // extension is still executed.
 func resolveEmulators(ctx context.Context, cfg *env.Env, logger log.Logger) []extension.Emulator {
-	rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+	rt, err := newRuntime(cfg)
 	if err != nil {
 		logger.Info("extension: runtime unavailable, omitting emulator context: %v", err)
 		return nil
@@ -122,6 +121,7 @@ func resolveEmulators(ctx context.Context, cfg *env.Env, logger log.Logger) []ex
 		host, _ := endpoint.ResolveHost(ctx, hostPort, cfg.LocalStackHost)
 		emulators = append(emulators, extension.Emulator{
 			Type:     string(c.Type),
+			Instance: c.Instance,
 			Endpoint: "http://" + host,
 			Port:     hostPort,
 		})
@@ -29,8 +29,8 @@ func newLogoutCmd(cfg *env.Env, logger log.Logger) *cobra.Command {
 				return fmt.Errorf("failed to get config: %w", err)
 			}
 			var rt runtime.Runtime
-			if dockerRuntime, err := runtime.NewDockerRuntime(cfg.DockerHost); err == nil {
-				rt = dockerRuntime
+			if containerRuntime, err := newRuntime(cfg); err == nil {
+				rt = containerRuntime
 			}
 
 			if isInteractiveMode(cfg) {
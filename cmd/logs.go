@@ -3,24 +3,49 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/localstack/lstk/internal/config"
 	"github.com/localstack/lstk/internal/container"
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 func newLogsCmd(cfg *env.Env) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "logs",
-		Short:   "Show emulator logs",
-		Long:    "Show logs from the emulator. Use --follow to stream in real-time and --tail to limit output to the last N lines.",
-		PreRunE: initConfigDeferCreate(nil),
+		Use:   "logs",
+		Short: "Show emulator logs",
+		Long: `Show logs from the emulator. Use --follow to stream in real-time and --tail to limit output to the last N lines.
+
+Narrow the output with --level (debug, info, warn, error; lines at or above it), --logger (a glob on the logger name), --since and --until (a duration such as 10m, or a timestamp) and --grep (a regular expression). Lines that continue a log record, such as a traceback, follow the record's header line. --tail counts the lines left after filtering.
+
+  lstk logs --level warn --logger 'l.s.lambda*' --since 10m
+  lstk logs --grep 'ResourceNotFound' --tail 20
+
+lstk saves the logs of the emulators it starts, so they outlive the container. Use --previous to show those of the last session that ended, e.g. to see why an emulator crashed.
+
+With --json, every log line is written as its own JSON object (NDJSON), with its timestamp, level, thread, logger and message, with or without --follow.`,
+		PreRunE:     initConfigDeferCreate(nil),
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// Logs have no natural end under --follow, and can be long without
+			// it, so --json always streams one NDJSON line per log line.
+			var sink output.Sink = output.NewPlainSink(os.Stdout)
+			if cfg.JSON {
+				sink = output.NewNDJSONSink(os.Stdout, "logs")
+			}
+			failWithCode := func(err error, code output.ErrorCode) error {
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: code})
+				return output.NewSilentError(err)
+			}
+
 			follow, err := cmd.Flags().GetBool("follow")
 			if err != nil {
 				return err
@@ -34,29 +59,137 @@ func newLogsCmd(cfg *env.Env) *cobra.Command {
 				return err
 			}
 			if err := validateTail(tail); err != nil {
+				return failWithCode(err, output.ErrValidationError)
+			}
+			filter, err := logFilterFromFlags(cmd, time.Now())
+			if err != nil {
+				return failWithCode(err, output.ErrValidationError)
+			}
+			if follow && !filter.Until.IsZero() {
+				return failWithCode(fmt.Errorf("--until cannot be combined with --follow"), output.ErrValidationError)
+			}
+			previous, err := cmd.Flags().GetBool("previous")
+			if err != nil {
 				return err
 			}
-			sink := output.NewPlainSink(os.Stdout)
+			if previous && follow {
+				return failWithCode(fmt.Errorf("--previous cannot be combined with --follow: the session has ended"), output.ErrValidationError)
+			}
+			filter.Verbose = verbose
 			if err := rejectEndpointURL(cmd, sink, "logs"); err != nil {
 				return err
 			}
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
 			appConfig, err := config.Get()
 			if err != nil {
-				return fmt.Errorf("failed to get config: %w", err)
+				return failWithCode(fmt.Errorf("failed to get config: %w", err), classifyConfigError(err).Code)
+			}
+			containers, err := filterContainersByFlags(cmd, appConfig.Containers)
+			if err != nil {
+				return failWithCode(err, output.ErrEmulatorNotConfigured)
 			}
 			if isInteractiveMode(cfg) {
-				return ui.RunLogs(cmd.Context(), rt, appConfig.Containers, follow, tail, verbose)
+				return ui.RunLogs(cmd.Context(), rt, containers, follow, tail, filter, previous)
+			}
+			if previous {
+				return container.PreviousLogs(cmd.Context(), rt, sink, containers, tail, filter, config.SessionLogDir)
 			}
-			return container.Logs(cmd.Context(), rt, sink, appConfig.Containers, follow, tail, verbose)
+			return container.Logs(cmd.Context(), rt, sink, containers, follow, tail, filter)
 		},
 	}
 	cmd.Flags().BoolP("follow", "f", false, "Follow log output")
+	cmd.Flags().BoolP("previous", "p", false, "Show the saved logs of the last emulator session that ended")
 	cmd.Flags().BoolP("verbose", "v", false, "Show all log output without filtering")
 	cmd.Flags().StringP("tail", "n", "all", "Number of lines to show from the end of the logs")
+	cmd.Flags().String("level", "", "Only show lines at or above this level: debug, info, warn, error")
+	cmd.Flags().String("logger", "", "Only show lines whose logger name matches this glob, e.g. 'l.s.lambda*'")
+	cmd.Flags().String("since", "", "Only show lines logged after this time: a duration such as 10m, or a timestamp")
+	cmd.Flags().String("until", "", "Only show lines logged before this time: a duration such as 10m, or a timestamp")
+	cmd.Flags().String("grep", "", "Only show lines matching this regular expression")
+	addEmulatorFilterFlags(cmd)
+	cmd.AddCommand(newLogsRequestsCmd(cfg))
+	return cmd
+}
+
+func newLogsRequestsCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "requests",
+		Short: "Show the requests the emulator served",
+		Long: `Show the requests the emulator served, read from its request log: the time, status code and AWS operation (or HTTP method and path) of each, with the error code of a failed AWS request, followed by the error rate of every operation. The account and region are shown when LocalStack logs them, in trace mode (LS_LOG=trace). LocalStack does not log how long a request took.
+
+Use --follow to watch requests as they arrive; the summary follows when you stop. Narrow the requests with --service, --operation (a glob), --status (a code such as 404 or a class such as 4xx), --errors (any failure) and --since/--until. --tail counts the requests shown. Requests LocalStack makes to itself and to its /_localstack endpoints are hidden unless --verbose is set.
+
+  lstk logs requests --follow --errors
+  lstk logs requests --service s3,sqs --status 4xx --since 10m
+
+With --json, every request is written as its own JSON object (NDJSON), followed by a summary line.`,
+		PreRunE:     initConfigDeferCreate(nil),
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sink output.Sink = output.NewPlainSink(os.Stdout)
+			if cfg.JSON {
+				sink = output.NewNDJSONSink(os.Stdout, "logs requests")
+			}
+			failWithCode := func(err error, code output.ErrorCode) error {
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: code})
+				return output.NewSilentError(err)
+			}
+
+			follow, err := cmd.Flags().GetBool("follow")
+			if err != nil {
+				return err
+			}
+			tail, err := cmd.Flags().GetString("tail")
+			if err != nil {
+				return err
+			}
+			if err := validateTail(tail); err != nil {
+				return failWithCode(err, output.ErrValidationError)
+			}
+			filter, err := requestFilterFromFlags(cmd, time.Now())
+			if err != nil {
+				return failWithCode(err, output.ErrValidationError)
+			}
+			if follow && !filter.Until.IsZero() {
+				return failWithCode(fmt.Errorf("--until cannot be combined with --follow"), output.ErrValidationError)
+			}
+			if err := rejectEndpointURL(cmd, sink, "logs requests"); err != nil {
+				return err
+			}
+			rt, err := newRuntime(cfg)
+			if err != nil {
+				return err
+			}
+			appConfig, err := config.Get()
+			if err != nil {
+				return failWithCode(fmt.Errorf("failed to get config: %w", err), classifyConfigError(err).Code)
+			}
+			containers, err := filterContainersByFlags(cmd, appConfig.Containers)
+			if err != nil {
+				return failWithCode(err, output.ErrEmulatorNotConfigured)
+			}
+			if isInteractiveMode(cfg) {
+				return ui.RunRequests(cmd.Context(), rt, containers, follow, tail, filter)
+			}
+			return container.Requests(cmd.Context(), rt, sink, containers, follow, tail, filter)
+		},
+	}
+	cmd.Flags().BoolP("follow", "f", false, "Follow requests as they arrive")
+	cmd.Flags().BoolP("verbose", "v", false, "Also show requests LocalStack makes to itself and to its /_localstack endpoints")
+	cmd.Flags().StringP("tail", "n", "all", "Number of requests to show from the end of the logs")
+	cmd.Flags().StringSlice("service", nil, "Only show requests to these AWS services, e.g. s3,sqs")
+	cmd.Flags().String("operation", "", "Only show requests whose AWS operation (or HTTP path) matches this glob, e.g. 'Get*'")
+	cmd.Flags().StringSlice("status", nil, "Only show requests with these status codes, e.g. 404 or 4xx,5xx")
+	cmd.Flags().Bool("errors", false, "Only show requests that failed (status 400 or above)")
+	cmd.Flags().String("since", "", "Only show requests made after this time: a duration such as 10m, or a timestamp")
+	cmd.Flags().String("until", "", "Only show requests made before this time: a duration such as 10m, or a timestamp")
+	addEmulatorFilterFlags(cmd)
 	return cmd
 }
 
@@ -69,3 +202,120 @@ func validateTail(tail string) error {
 	}
 	return nil
 }
+
+// logFilterFromFlags builds the log query from --level, --logger, --since,
+// --until and --grep. Relative times are resolved against now.
+func logFilterFromFlags(cmd *cobra.Command, now time.Time) (container.LogFilter, error) {
+	var filter container.LogFilter
+	level, err := cmd.Flags().GetString("level")
+	if err != nil {
+		return filter, err
+	}
+	if level != "" {
+		if filter.Level, err = container.ParseLogLevel(level); err != nil {
+			return filter, err
+		}
+	}
+	if filter.Logger, err = cmd.Flags().GetString("logger"); err != nil {
+		return filter, err
+	}
+	if filter.Logger != "" {
+		if err := container.ValidateLoggerPattern(filter.Logger); err != nil {
+			return filter, err
+		}
+	}
+	if filter.Since, filter.Until, err = timeRangeFromFlags(cmd, now); err != nil {
+		return filter, err
+	}
+	grep, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		return filter, err
+	}
+	if grep != "" {
+		if filter.Grep, err = regexp.Compile(grep); err != nil {
+			return filter, fmt.Errorf("invalid --grep value %q: %w", grep, err)
+		}
+	}
+	return filter, nil
+}
+
+// requestFilterFromFlags builds the request query of `logs requests` from
+// its flags. Relative times are resolved against now.
+func requestFilterFromFlags(cmd *cobra.Command, now time.Time) (container.RequestFilter, error) {
+	var filter container.RequestFilter
+	var err error
+	if filter.Verbose, err = cmd.Flags().GetBool("verbose"); err != nil {
+		return filter, err
+	}
+	if filter.Services, err = cmd.Flags().GetStringSlice("service"); err != nil {
+		return filter, err
+	}
+	if filter.Operation, err = cmd.Flags().GetString("operation"); err != nil {
+		return filter, err
+	}
+	if filter.Operation != "" {
+		if err := container.ValidateOperationPattern(filter.Operation); err != nil {
+			return filter, err
+		}
+	}
+	statuses, err := cmd.Flags().GetStringSlice("status")
+	if err != nil {
+		return filter, err
+	}
+	for _, s := range statuses {
+		pattern, err := container.ParseStatusPattern(s)
+		if err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, pattern)
+	}
+	if filter.ErrorsOnly, err = cmd.Flags().GetBool("errors"); err != nil {
+		return filter, err
+	}
+	if filter.Since, filter.Until, err = timeRangeFromFlags(cmd, now); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// timeRangeFromFlags reads --since and --until. Relative times are resolved
+// against now.
+func timeRangeFromFlags(cmd *cobra.Command, now time.Time) (since, until time.Time, err error) {
+	for _, bound := range []struct {
+		flag string
+		dst  *time.Time
+	}{{"since", &since}, {"until", &until}} {
+		value, err := cmd.Flags().GetString(bound.flag)
+		if err != nil {
+			return since, until, err
+		}
+		if value == "" {
+			continue
+		}
+		if *bound.dst, err = parseLogTime(bound.flag, value, now); err != nil {
+			return since, until, err
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return since, until, fmt.Errorf("--until must not be before --since")
+	}
+	return since, until, nil
+}
+
+// logTimeLayouts are the timestamps --since and --until accept besides a
+// duration. Without a zone they are read as UTC, like the emulator's own.
+var logTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseLogTime parses a --since/--until value: a duration before now, or a
+// timestamp.
+func parseLogTime(flag, value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	for _, layout := range logTimeLayouts {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s value %q: expected a duration such as 10m or a timestamp such as 2026-03-16T17:56:00Z", flag, value)
+}
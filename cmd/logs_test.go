@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2026, 3, 16, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"10m", now.Add(-10 * time.Minute)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"2026-03-16T17:56:00Z", time.Date(2026, 3, 16, 17, 56, 0, 0, time.UTC)},
+		{"2026-03-16T17:56:00+01:00", time.Date(2026, 3, 16, 16, 56, 0, 0, time.UTC)},
+		{"2026-03-16T17:56:00", time.Date(2026, 3, 16, 17, 56, 0, 0, time.UTC)},
+		{"2026-03-16", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseLogTime("since", tt.value, now)
+		require.NoError(t, err, tt.value)
+		assert.True(t, tt.want.Equal(got), "%s: got %s, want %s", tt.value, got, tt.want)
+	}
+
+	_, err := parseLogTime("until", "yesterday", now)
+	assert.ErrorContains(t, err, `invalid --until value "yesterday"`)
+}
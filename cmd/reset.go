@@ -62,8 +62,13 @@ To wipe the on-disk volume (certificates, persistence data, cached tools) instea
 					return failGetConfig(sink, cfg, err)
 				}
 
+				containers, err := filterContainersByInstanceFlag(cmd, appConfig.Containers)
+				if err != nil {
+					return failWithCode(err.Error(), output.ErrEmulatorNotConfigured)
+				}
+
 				var found bool
-				for _, c := range appConfig.Containers {
+				for _, c := range containers {
 					if c.Type == config.EmulatorAWS {
 						awsContainer = c
 						found = true
@@ -74,7 +79,7 @@ To wipe the on-disk volume (certificates, persistence data, cached tools) instea
 					return failWithCode("reset is only supported for the AWS emulator", output.ErrEmulatorNotConfigured)
 				}
 
-				rt, err = runtime.NewDockerRuntime(cfg.DockerHost)
+				rt, err = newRuntime(cfg)
 				if err != nil {
 					return err
 				}
@@ -97,5 +102,6 @@ To wipe the on-disk volume (certificates, persistence data, cached tools) instea
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+	addInstanceFlag(cmd)
 	return cmd
 }
@@ -9,7 +9,6 @@ import (
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/log"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/telemetry"
 	"github.com/localstack/lstk/internal/ui"
 	"github.com/spf13/cobra"
@@ -26,7 +25,7 @@ func newRestartCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobr
 				return err
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
@@ -35,6 +34,10 @@ func newRestartCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobr
 			if err != nil {
 				return fmt.Errorf("failed to get config: %w", err)
 			}
+			appConfig.Containers, err = filterContainersByFlags(cmd, appConfig.Containers)
+			if err != nil {
+				return err
+			}
 
 			persist, err := cmd.Flags().GetBool("persist")
 			if err != nil {
@@ -55,5 +58,6 @@ func newRestartCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobr
 		},
 	}
 	cmd.Flags().Bool("persist", false, "Persist emulator state across restarts")
+	addEmulatorFilterFlags(cmd)
 	return cmd
 }
@@ -80,7 +80,7 @@ func NewRootCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.C
 			if err := rejectEndpointURL(cmd, output.NewPlainSink(os.Stdout), "start"); err != nil {
 				return err
 			}
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
@@ -98,10 +98,14 @@ func NewRootCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.C
 			if err != nil {
 				return err
 			}
+			instance, err := cmd.Flags().GetString("instance")
+			if err != nil {
+				return err
+			}
 			if err := applyTimeoutFlag(cmd, cfg); err != nil {
 				return err
 			}
-			return startEmulator(cmd.Context(), rt, cfg, tel, logger, persist, firstRun, snapshotFlag, noSnapshot, emulatorType)
+			return startEmulator(cmd.Context(), rt, output.NewPlainSink(os.Stdout), cfg, tel, logger, persist, firstRun, snapshotFlag, noSnapshot, emulatorType, instance)
 		},
 	}
 
@@ -147,6 +151,7 @@ func NewRootCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.C
 	root.PersistentFlags().String("endpoint-url", "", "Target an existing, externally-managed emulator at this URL")
 	root.Flags().Bool("persist", false, "Persist emulator state across restarts")
 	addEmulatorTypeFlag(root)
+	addStartInstanceFlag(root)
 	addSnapshotStartFlags(root)
 	addTimeoutFlag(root)
 
@@ -180,9 +185,11 @@ func NewRootCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.C
 		newLoginCmd(cfg, tel, logger),
 		newLogoutCmd(cfg, logger),
 		newStatusCmd(cfg),
+		newWaitCmd(cfg),
 		newLogsCmd(cfg),
 		newSetupCmd(cfg),
-		newConfigCmd(),
+		newConfigCmd(cfg),
+		newDoctorCmd(cfg, logger),
 		newVolumeCmd(cfg),
 		newUpdateCmd(cfg),
 		newDocsCmd(),
@@ -243,6 +250,9 @@ func Execute(ctx context.Context) error {
 	if len(os.Args) > 1 && os.Args[1] == telemetry.FlushCommandName {
 		return runFlushTelemetry(ctx, os.Args[2:])
 	}
+	if len(os.Args) > 1 && os.Args[1] == container.SaveLogsCommandName {
+		return runSaveLogs(ctx, os.Args[2:])
+	}
 
 	cfg := env.Init()
 
@@ -332,10 +342,13 @@ func buildStartOptions(cfg *env.Env, appConfig *config.Config, logger log.Logger
 		StartupTimeout:   cfg.StartupTimeout,
 		Logger:           logger,
 		Telemetry:        tel,
+		SessionLogs:      newSessionLogs(cfg),
 	}
 }
 
-func startEmulator(ctx context.Context, rt runtime.Runtime, cfg *env.Env, tel *telemetry.Client, logger log.Logger, persist bool, firstRun bool, snapshotFlag string, noSnapshot bool, emulatorType config.EmulatorType) error {
+// startEmulator starts the configured emulators. sink receives the output of the
+// non-interactive path; the interactive path renders through the TUI instead.
+func startEmulator(ctx context.Context, rt runtime.Runtime, sink output.Sink, cfg *env.Env, tel *telemetry.Client, logger log.Logger, persist bool, firstRun bool, snapshotFlag string, noSnapshot bool, emulatorType config.EmulatorType, instance string) error {
 	appConfig, err := config.Get()
 	if err != nil {
 		return fmt.Errorf("failed to get config: %w", err)
@@ -347,11 +360,11 @@ func startEmulator(ctx context.Context, rt runtime.Runtime, cfg *env.Env, tel *t
 	}
 
 	// Apply the --type flag before resolving snapshot and start options so
-	// everything downstream reflects the selected emulator. Messages go to a plain
-	// sink even in interactive mode because the config mutation has to happen before
-	// the TUI starts (the auto-load loader and start options are built from it).
+	// everything downstream reflects the selected emulator. Messages go to sink even
+	// in interactive mode because the config mutation has to happen before the TUI
+	// starts (the auto-load loader and start options are built from it).
 	if emulatorType != "" {
-		newContainers, applyErr := container.ApplyEmulatorType(ctx, rt, output.NewPlainSink(os.Stdout), emulatorType, appConfig.Containers, firstRun, configPath)
+		newContainers, applyErr := container.ApplyEmulatorType(ctx, rt, sink, emulatorType, appConfig.Containers, firstRun, configPath)
 		if applyErr != nil {
 			return applyErr
 		}
@@ -360,6 +373,11 @@ func startEmulator(ctx context.Context, rt runtime.Runtime, cfg *env.Env, tel *t
 		// first run: skip the interactive picker and the default-emulator notice.
 		firstRun = false
 	}
+	if instance != "" {
+		if appConfig.Containers, err = config.FilterByInstance(appConfig.Containers, instance); err != nil {
+			return err
+		}
+	}
 
 	ref, err := resolveStartSnapshotRef(appConfig, snapshotFlag, noSnapshot)
 	if err != nil {
@@ -393,7 +411,6 @@ func startEmulator(ctx context.Context, rt runtime.Runtime, cfg *env.Env, tel *t
 		})
 	}
 
-	sink := output.NewPlainSink(os.Stdout)
 	if firstRun && len(appConfig.Containers) > 0 {
 		emName := appConfig.Containers[0].Type.ShortName()
 		sink.Emit(output.MessageEvent{
@@ -425,6 +442,12 @@ func addEmulatorTypeFlag(cmd *cobra.Command) {
 	cmd.Flags().StringP("type", "t", "", "Emulator type to start (aws, snowflake, azure)")
 }
 
+// addStartInstanceFlag registers the --instance flag on a start-capable command,
+// starting only the [[containers]] block with that instance name.
+func addStartInstanceFlag(cmd *cobra.Command) {
+	cmd.Flags().String("instance", "", "Emulator instance to start (the instance name of a [[containers]] block)")
+}
+
 // resolveEmulatorTypeFlag resolves the requested emulator type from the --type
 // flag. It returns "" when the flag is unset.
 func resolveEmulatorTypeFlag(cmd *cobra.Command) (config.EmulatorType, error) {
@@ -438,6 +461,46 @@ func resolveEmulatorTypeFlag(cmd *cobra.Command) (config.EmulatorType, error) {
 	return config.ParseEmulatorType(flagVal)
 }
 
+// addEmulatorFilterFlags registers the --type/-t and --instance flags on a command
+// that acts on configured emulators (status, logs, stop, restart), narrowing it
+// to some of them.
+func addEmulatorFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("type", "t", "", "Only act on the emulator of this type (aws, snowflake, azure)")
+	addInstanceFlag(cmd)
+}
+
+// addInstanceFlag registers the --instance flag selecting one named emulator
+// instance (the instance field of a [[containers]] block).
+func addInstanceFlag(cmd *cobra.Command) {
+	cmd.Flags().String("instance", "", "Only act on the emulator instance with this name")
+}
+
+// filterContainersByFlags narrows containers to the emulators named by the
+// --type and --instance flags, whichever of them the command registers. It
+// returns containers unchanged when neither is set.
+func filterContainersByFlags(cmd *cobra.Command, containers []config.ContainerConfig) ([]config.ContainerConfig, error) {
+	if cmd.Flags().Lookup("type") != nil {
+		emulatorType, err := resolveEmulatorTypeFlag(cmd)
+		if err != nil {
+			return nil, err
+		}
+		if containers, err = config.FilterByType(containers, emulatorType); err != nil {
+			return nil, err
+		}
+	}
+	return filterContainersByInstanceFlag(cmd, containers)
+}
+
+// filterContainersByInstanceFlag narrows containers to the one named by the
+// --instance flag, or returns them unchanged when the flag is unset.
+func filterContainersByInstanceFlag(cmd *cobra.Command, containers []config.ContainerConfig) ([]config.ContainerConfig, error) {
+	instance, err := cmd.Flags().GetString("instance")
+	if err != nil {
+		return nil, err
+	}
+	return config.FilterByInstance(containers, instance)
+}
+
 // addTimeoutFlag registers the --timeout flag on a start-capable command. It is
 // a per-run override of LSTK_STARTUP_TIMEOUT / the startup_timeout config; 0
 // (the default) leaves the env/config value in place, which in turn falls back
@@ -652,6 +715,21 @@ func isInteractiveMode(cfg *env.Env) bool {
 	return !cfg.NonInteractive && !cfg.JSON && ui.IsInteractive()
 }
 
+// newRuntime connects to the container runtime selected by LSTK_RUNTIME or the
+// config's top-level `runtime` key, auto-detecting it when neither is set.
+func newRuntime(cfg *env.Env) (runtime.Runtime, error) {
+	return runtime.New(cfg.DockerHost, runtimeBackend(cfg))
+}
+
+// runtimeBackend is the container runtime backend selected by LSTK_RUNTIME,
+// else by the config.
+func runtimeBackend(cfg *env.Env) string {
+	if cfg.Runtime != "" {
+		return cfg.Runtime
+	}
+	return config.RuntimeBackend()
+}
+
 const maxLogSize = 1 << 20 // 1 MB
 
 func newLogger() (log.Logger, func(), error) {
@@ -692,6 +770,18 @@ func initConfigWith(firstRun *bool, load func() (bool, error)) func(*cobra.Comma
 		if firstRun != nil {
 			*firstRun = isFirstRun
 		}
-		return err
+		if err != nil {
+			return err
+		}
+		// The note is a courtesy: failing to read or record it must not stop
+		// the command. --json keeps stderr free of plain text, so the note
+		// waits for the next run without it.
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return nil
+		}
+		if note, ok, noteErr := config.LegacyProjectNotice(); noteErr == nil && ok {
+			output.NewPlainSink(os.Stderr).Emit(output.MessageEvent{Severity: output.SeverityNote, Text: note})
+		}
+		return nil
 	}
 }
@@ -10,7 +10,6 @@ import (
 	samcli "github.com/localstack/lstk/internal/iac/sam/cli"
 	"github.com/localstack/lstk/internal/log"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -116,7 +115,7 @@ Examples:
 				return samcli.Run(cmd.Context(), target.URL, account, region, regionSelected, sink, logger, samArgs)
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
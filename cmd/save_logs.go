@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/container"
+	"github.com/localstack/lstk/internal/env"
+	"github.com/localstack/lstk/internal/proc"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// newSessionLogs saves the logs of the emulators lstk starts under the cache
+// directory, handing each over to a detached lstk once it is ready.
+func newSessionLogs(cfg *env.Env) *container.SessionLogs {
+	backend := runtimeBackend(cfg)
+	return &container.SessionLogs{
+		Dir: config.SessionLogDir,
+		Follow: func(containerID, path string, skip int) error {
+			return spawnLogSaver(cfg.DockerHost, backend, containerID, path, skip)
+		},
+	}
+}
+
+// spawnLogSaver launches the current binary as a detached subprocess that runs
+// container.SaveLogs, without waiting for it to finish.
+func spawnLogSaver(dockerHost, backend, containerID, path string, skip int) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(exe, container.SaveLogsCommandName,
+		"--docker-host", dockerHost,
+		"--runtime", backend,
+		"--container", containerID,
+		"--file", path,
+		"--skip", strconv.Itoa(skip))
+	cmd.SysProcAttr = proc.DetachedSysProcAttr()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// runSaveLogs handles the log saver subprocess. Like runFlushTelemetry, it
+// bypasses the normal Execute() boot path, so it runs no telemetry and loads
+// no config: everything it needs is on its command line.
+func runSaveLogs(ctx context.Context, args []string) error {
+	flags := map[string]string{}
+	for i := 0; i+1 < len(args); i += 2 {
+		flags[args[i]] = args[i+1]
+	}
+	containerID, path := flags["--container"], flags["--file"]
+	if containerID == "" || path == "" {
+		return fmt.Errorf("missing --container or --file")
+	}
+	skip, err := strconv.Atoi(flags["--skip"])
+	if err != nil {
+		return fmt.Errorf("invalid --skip: %w", err)
+	}
+
+	rt, err := runtime.New(flags["--docker-host"], flags["--runtime"])
+	if err != nil {
+		return err
+	}
+	return container.SaveLogs(ctx, rt, containerID, path, skip)
+}
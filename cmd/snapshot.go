@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,17 +32,30 @@ const snapshotListLong = `List Cloud Pod snapshots available on the LocalStack p
 
 By default only snapshots you created are listed. Pass --all to include all snapshots in your organisation.
 
+To list the snapshots saved to the local library with "lstk snapshot save local:NAME", pass --local (no emulator or platform account needed):
+
+  lstk snapshot list --local
+
 To list snapshots in your own S3 bucket, pass an s3:// location (requires a running emulator). Credentials are read from AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY, from --profile, or from the profile named by AWS_PROFILE:
 
   lstk snapshot list s3://my-bucket/prefix
-  lstk snapshot list s3://my-bucket/prefix --profile my-aws-profile`
+  lstk snapshot list s3://my-bucket/prefix --profile my-aws-profile
+
+To list snapshots pushed to an OCI registry, pass an oras:// repository (no emulator needed). Credentials are read from the Docker credential store; run "docker login <registry>" first:
+
+  lstk snapshot list oras://registry.example.com/team/baseline`
 
 const snapshotShowLong = `Show metadata for a cloud snapshot on the LocalStack platform. Defaults to the latest version; append a version to inspect an older one.
 
   lstk snapshot show pod:my-baseline      # prints name, version, created date, size, services, and resource counts
   lstk snapshot show pod:my-baseline:3    # the same, for version 3 specifically
 
-Use "lstk snapshot versions pod:my-baseline" to see which versions exist.`
+Use "lstk snapshot versions pod:my-baseline" to see which versions exist.
+
+Local snapshots are inspected from their archive, without a running emulator or a platform account — e.g. to check what a teammate sent before loading it:
+
+  lstk snapshot show ./baseline.snapshot  # prints size, LocalStack version, services, accounts, regions, and state files per service
+  lstk snapshot show local:my-baseline    # the same for the newest save in the local library, plus its path and created date`
 
 const snapshotVersionsLong = `List the version history of a Cloud Pod. Every save to an existing pod adds a new version.
 
@@ -62,12 +76,62 @@ To skip the confirmation prompt in non-interactive mode, use --force:
 
   lstk snapshot remove pod:my-baseline --force`
 
+const snapshotPruneLong = `Delete old snapshots from the local library, keeping the newest N saves of each name.
+
+  lstk snapshot prune --keep 3                      # every name in the library
+  lstk snapshot prune --keep 1 local:my-baseline    # only local:my-baseline
+
+--keep 0 deletes every save of the names it applies to. This operation cannot be undone.`
+
+const snapshotScheduleLong = `Save a snapshot of the running emulator at a fixed interval until interrupted with Ctrl-C. The first save is made straight away.
+
+  lstk snapshot schedule                                  # local:scheduled-<container> every 15m, keeping 8
+  lstk snapshot schedule --every 1h --keep 24 local:soak  # hourly, keeping a day of saves
+  lstk snapshot schedule --every 30m pod:nightly          # each save adds a Cloud Pod version
+
+Saves to the local library rotate: once a save succeeds, all but the newest --keep saves of that name are deleted. Cloud Pods keep every version, so --keep does not apply to them.
+
+Saves to the local library are encrypted with age when --encrypt or --recipient is passed, as for lstk snapshot save, or when the container sets encrypt = true in the config, which uses the passphrase in LSTK_SNAPSHOT_PASSPHRASE. Cloud Pods are written by the emulator and are never encrypted.
+
+A save that fails, or is due while the emulator is stopped, is reported and the schedule carries on with the next one.
+
+The schedule runs in the foreground and reports each save in its own output. lstk start returns once the emulator is up, so its output does not show scheduled saves; run the schedule in a second terminal, or in the background with its output redirected to a file.`
+
+const snapshotExportLong = `Export the running emulator's deployed resources as a manifest: one entry per resource with its type, identifier, account and region, sorted so the file can be committed, diffed in code review or used as a golden file in tests.
+
+Without [file] the manifest is written to stdout, and progress and errors to stderr:
+
+  lstk snapshot export > resources.json
+  lstk snapshot export --format yaml resources.yaml
+
+The manifest lists what the emulator reports in /_localstack/resources; it records no state and cannot be loaded.`
+
+const snapshotDiffLong = `Show what changed between snapshots, per service.
+
+Pass two local snapshot files to compare them without a running emulator or a platform account, e.g. to review a snapshot change in a pull request:
+
+  lstk snapshot diff ./before.snapshot ./after.snapshot
+
+Pass a single local file to compare it with the running emulator's state; the additions and modifications are what loading the file would bring:
+
+  lstk snapshot diff ./baseline.snapshot
+
+A Cloud Pod is compared with the running state by the emulator, as "lstk snapshot load pod:NAME --dry-run" does:
+
+  lstk snapshot diff pod:my-baseline
+  lstk snapshot diff pod:my-baseline:3 --merge=service-merge`
+
 func snapshotSaveLong(cmdName string) string {
 	return fmt.Sprintf(`Save a snapshot of the running emulator's state.
 
-Pass [destination] as an absolute or relative path for the exported file:
+Without [destination], the snapshot is kept in the local library as a new timestamped entry named after the emulator, e.g. local:localstack-aws. Use the local: prefix to pick the name. Each save adds an entry; list them with "lstk snapshot list --local", and trim them with "lstk snapshot prune":
+
+  lstk %[1]s                     # saves to local:<container name>
+  lstk %[1]s local:my-baseline   # saves to local:my-baseline
 
-  lstk %[1]s                         # saves to ./snapshot-<YYYY-MM-DDTHH-mm-ss>-<hex>.snapshot
+To write a file instead, pass [destination] as an absolute or relative path. A directory gets a timestamped file inside it. The file records the emulator's LocalStack version and the saved services, which "lstk snapshot show" displays and load checks:
+
+  lstk %[1]s .                       # saves to ./snapshot-<YYYY-MM-DDTHH-mm-ss>-<hex>.snapshot
   lstk %[1]s ./my-snapshot.snapshot  # saves to ./my-snapshot.snapshot
   lstk %[1]s /tmp/my-state           # saves to /tmp/my-state.snapshot
 
@@ -80,10 +144,20 @@ To save to your own S3 bucket, pass an s3:// location with an optional pod name
   lstk %[1]s my-pod s3://my-bucket/prefix
   lstk %[1]s my-pod s3://my-bucket/prefix --profile my-aws-profile
 
+To push to an OCI registry as an artifact, pass an oras:// reference (the tag defaults to "latest"). Credentials are read from the Docker credential store; run "docker login <registry>" first:
+
+  lstk %[1]s oras://registry.example.com/team/baseline:v3
+
 Use -s/--services to limit the snapshot to a subset of services, for any destination:
 
   lstk %[1]s --services s3,lambda
-  lstk %[1]s pod:my-baseline --services s3,lambda`, cmdName)
+  lstk %[1]s pod:my-baseline --services s3,lambda
+
+To encrypt a local file, local: save or S3 snapshot with age, pass --encrypt to use the passphrase in LSTK_SNAPSHOT_PASSPHRASE, or --recipient with an age public key. The plain archive never touches disk; an encrypted S3 snapshot is exported by lstk and uploaded as <pod-name>.snapshot under the s3:// location. Cloud Pods and OCI snapshots are written unencrypted; to keep an encrypted copy there, save a local file with --encrypt and upload it yourself:
+
+  lstk %[1]s ./baseline.snapshot --encrypt
+  lstk %[1]s local:my-baseline --recipient age1...
+  lstk %[1]s my-pod s3://my-bucket/prefix --encrypt`, cmdName)
 }
 
 const snapshotLoadCanonical = "snapshot load"
@@ -95,6 +169,7 @@ REF identifies the snapshot to load:
 
   lstk %[1]s my-baseline             # loads ./my-baseline or ./my-baseline.snapshot
   lstk %[1]s ./checkpoint.snapshot   # loads from explicit path
+  lstk %[1]s local:my-baseline       # loads the newest save of my-baseline from the local library
   lstk %[1]s pod:my-baseline         # loads from LocalStack Cloud Pods
   lstk %[1]s pod:my-baseline:3       # loads version 3 from LocalStack Cloud Pods
 
@@ -103,13 +178,28 @@ To load from your own S3 bucket, pass the pod name and an s3:// location. Creden
   lstk %[1]s my-pod s3://my-bucket/prefix
   lstk %[1]s my-pod s3://my-bucket/prefix --profile my-aws-profile
 
+To pull from an OCI registry, pass an oras:// reference by tag or digest. Credentials are read from the Docker credential store:
+
+  lstk %[1]s oras://registry.example.com/team/baseline:v3
+
 Merge strategies control how snapshot state is combined with running state:
 
   --merge=account-region-merge  (default) snapshot wins on (service, account, region) overlap
   --merge=overwrite             wipe running state, then load
   --merge=service-merge         snapshot wins per-resource; non-overlapping resources combined
 
-Alternatively, set LSTK_MERGE_STRATEGY to specify the merge strategy.`, cmdName)
+Alternatively, set LSTK_MERGE_STRATEGY to specify the merge strategy.
+
+An encrypted local snapshot is decrypted on the way in, with the passphrase in LSTK_SNAPSHOT_PASSPHRASE or an age identity file passed as --identity (default LSTK_SNAPSHOT_IDENTITY). With either set, an S3 load first looks for an encrypted snapshot saved with --encrypt, and loads the pod through the emulator when there is none:
+
+  lstk %[1]s ./baseline.snapshot --identity ~/.config/age/key.txt
+  lstk %[1]s my-pod s3://my-bucket/prefix --identity ~/.config/age/key.txt
+
+Before a local file or local: snapshot is loaded, the LocalStack version it was saved with is compared with the running emulator's. A snapshot saved by a newer version is refused, with the image tag to run instead; in interactive mode you are offered a restart on that tag, and --force loads it anyway. One saved by an older major version loads with a warning. Encrypted snapshots are checked once decrypted.
+
+To load only some services, pass --services with a comma-separated list. State for every other service in the snapshot is skipped and left as it is in the emulator; with --merge=overwrite only the selected services are wiped first. Pods and S3 snapshots are filtered by the emulator, so they need LocalStack 4.4 or later:
+
+  lstk %[1]s my-baseline --services s3,dynamodb`, cmdName)
 }
 
 func newSnapshotCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.Command {
@@ -118,12 +208,17 @@ func newSnapshotCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cob
 		Short: "Manage emulator snapshots",
 	}
 	requireSubcommand(cmd)
+	cmd.PersistentFlags().String("instance", "", "Only act on the emulator instance with this name")
 	cmd.AddCommand(newSnapshotSaveCmd(cfg))
 	cmd.AddCommand(newSnapshotLoadCmd(cfg, tel, logger))
 	cmd.AddCommand(newSnapshotListCmd(cfg, logger))
 	cmd.AddCommand(newSnapshotRemoveCmd(cfg))
 	cmd.AddCommand(newSnapshotShowCmd(cfg, logger))
 	cmd.AddCommand(newSnapshotVersionsCmd(cfg, logger))
+	cmd.AddCommand(newSnapshotDiffCmd(cfg))
+	cmd.AddCommand(newSnapshotPruneCmd(cfg))
+	cmd.AddCommand(newSnapshotScheduleCmd(cfg))
+	cmd.AddCommand(newSnapshotExportCmd(cfg))
 	return cmd
 }
 
@@ -146,8 +241,9 @@ func snapshotFlags(cmd *cobra.Command) (snapshotFlag string, noSnapshot bool, er
 }
 
 // resolveStartSnapshotRef resolves the snapshot REF to auto-load on start.
-// Precedence: --no-snapshot disables it; otherwise --snapshot wins over the
-// AWS container's configured snapshot. Returns "" when nothing should be loaded.
+// Precedence: --no-snapshot disables it; otherwise --snapshot wins, then what
+// the config has the AWS emulator start from (see snapshot.ConfiguredStartRef).
+// Returns "" when nothing should be loaded.
 func resolveStartSnapshotRef(appConfig *config.Config, snapshotFlag string, noSnapshot bool) (string, error) {
 	if noSnapshot && snapshotFlag != "" {
 		return "", errors.New("--snapshot and --no-snapshot cannot be used together")
@@ -158,12 +254,26 @@ func resolveStartSnapshotRef(appConfig *config.Config, snapshotFlag string, noSn
 	if snapshotFlag != "" {
 		return snapshotFlag, nil
 	}
-	for _, c := range appConfig.Containers {
-		if c.Type == config.EmulatorAWS && c.Snapshot != "" {
-			return c.Snapshot, nil
-		}
+	// Without a library, autosaves count as missing and the configured
+	// snapshot is used.
+	lib, _ := snapshotLibrary()
+	return snapshot.ConfiguredStartRef(appConfig.Containers, lib), nil
+}
+
+// newAutosaveHook returns the StopOptions.BeforeStop hook that autosaves
+// containers, or nil when none of them has autosave on.
+func newAutosaveHook(cfg *env.Env, containers []config.ContainerConfig) (func(context.Context, output.Sink, config.ContainerConfig), error) {
+	if !snapshot.AutosaveEnabled(containers) {
+		return nil, nil
 	}
-	return "", nil
+	lib, err := snapshotLibrary()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.NewAutosaveHook(aws.NewClient(), lib, cfg.SnapshotPassphrase, func(ctx context.Context, c config.ContainerConfig) string {
+		host, _ := endpoint.ResolveHost(ctx, c.Port, cfg.LocalStackHost)
+		return "http://" + host
+	}), nil
 }
 
 // newSnapshotAutoLoader returns a loader that imports the given REF into the
@@ -194,8 +304,16 @@ func newSnapshotAutoLoader(cfg *env.Env, rt runtime.Runtime, appConfig *config.C
 		return nil, err
 	}
 
+	var lib snapshot.Library
+	if src.Kind == snapshot.KindLibrary {
+		if lib, err = snapshotLibrary(); err != nil {
+			return nil, err
+		}
+	}
+
 	client := aws.NewClient()
 	containers := []config.ContainerConfig{awsContainer}
+	dec := envDecryption(cfg)
 	return func(ctx context.Context, sink output.Sink) error {
 		host, _ := endpoint.ResolveHost(ctx, awsContainer.Port, cfg.LocalStackHost)
 		// Always http://: auto-load only ever runs after a local Docker start,
@@ -203,13 +321,26 @@ func newSnapshotAutoLoader(cfg *env.Env, rt runtime.Runtime, appConfig *config.C
 		baseURL := "http://" + host
 		switch src.Kind {
 		case snapshot.KindPod:
-			return snapshot.LoadPod(ctx, rt, containers, client, baseURL, src.Value, src.Version, cfg.AuthToken, "", nil, sink)
+			return snapshot.LoadPod(ctx, rt, containers, client, baseURL, src.Value, src.Version, cfg.AuthToken, "", nil, nil, sink)
+		case snapshot.KindOCI:
+			return snapshot.LoadOCI(ctx, rt, containers, client, baseURL, src.Value, "", nil, nil, sink)
+		case snapshot.KindLibrary:
+			return snapshot.LoadLibrary(ctx, rt, containers, client, baseURL, lib, src.Value, "", nil, dec, nil, nil, false, sink)
 		default:
-			return snapshot.LoadLocal(ctx, rt, containers, client, baseURL, src.Value, "", nil, sink)
+			return snapshot.LoadLocal(ctx, rt, containers, client, baseURL, src.Value, "", nil, dec, nil, nil, false, sink)
 		}
 	}, nil
 }
 
+// snapshotLibrary returns the local snapshot library that local: refs address.
+func snapshotLibrary() (snapshot.Library, error) {
+	dir, err := config.SnapshotLibraryDir()
+	if err != nil {
+		return snapshot.Library{}, err
+	}
+	return snapshot.Library{Dir: dir}, nil
+}
+
 func buildStarter(cfg *env.Env, rt runtime.Runtime, appConfig *config.Config, logger log.Logger, tel *telemetry.Client) snapshot.Starter {
 	return func(ctx context.Context, sink output.Sink) error {
 		opts := buildStartOptions(cfg, appConfig, logger, tel, false)
@@ -218,6 +349,19 @@ func buildStarter(cfg *env.Env, rt runtime.Runtime, appConfig *config.Config, lo
 	}
 }
 
+// buildRestarter restarts the target emulator on another image tag, so a
+// snapshot saved by a newer LocalStack version can be loaded into a matching one.
+// Only the config of this run is changed; the config file keeps its tag.
+func buildRestarter(cfg *env.Env, rt runtime.Runtime, appConfig *config.Config, target config.ContainerConfig, logger log.Logger, tel *telemetry.Client) snapshot.Restarter {
+	return func(ctx context.Context, sink output.Sink, tag string) error {
+		c := target
+		c.Tag = tag
+		startOpts := buildStartOptions(cfg, appConfig, logger, tel, false)
+		startOpts.Containers = []config.ContainerConfig{c}
+		return container.Restart(ctx, rt, sink, container.StopOptions{Telemetry: tel}, startOpts, false)
+	}
+}
+
 func newSnapshotLoadCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "load REF",
@@ -230,6 +374,9 @@ func newSnapshotLoadCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger)
 	addMergeFlag(cmd)
 	addProfileFlag(cmd)
 	addDryRunFlag(cmd)
+	addIdentityFlag(cmd)
+	addLoadServicesFlag(cmd)
+	addLoadForceFlag(cmd)
 	return cmd
 }
 
@@ -246,9 +393,24 @@ func newLoadCmd(cfg *env.Env, tel *telemetry.Client, logger log.Logger) *cobra.C
 	addMergeFlag(cmd)
 	addProfileFlag(cmd)
 	addDryRunFlag(cmd)
+	addIdentityFlag(cmd)
+	addLoadServicesFlag(cmd)
+	addLoadForceFlag(cmd)
 	return cmd
 }
 
+// addLoadServicesFlag registers the --services flag used to limit a load to a
+// subset of the services held in the snapshot.
+func addLoadServicesFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("services", "s", "", "Comma-separated list of services to load from the snapshot (all by default)")
+}
+
+// addLoadForceFlag registers the --force flag that loads a local snapshot saved
+// by a newer LocalStack version instead of refusing it.
+func addLoadForceFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("force", false, "Load a local snapshot even if it was saved by a newer LocalStack version")
+}
+
 func addMergeFlag(cmd *cobra.Command) {
 	cmd.Flags().String("merge", snapshot.MergeStrategyAccountRegion, "Merge strategy: overwrite, account-region-merge, service-merge")
 }
@@ -281,6 +443,54 @@ func resolveLoadStrategy(cmd *cobra.Command, cfg *env.Env) (string, error) {
 	return strategy, nil
 }
 
+func addEncryptFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("encrypt", false, "Encrypt the snapshot file with age, using the passphrase in LSTK_SNAPSHOT_PASSPHRASE")
+	cmd.Flags().StringArray("recipient", nil, "Encrypt the snapshot file to this age public key instead of a passphrase (repeatable; implies --encrypt)")
+}
+
+// resolveEncryption builds the encryption for a save to a destination of the
+// given kind from --encrypt and --recipient; see snapshot.ResolveEncryption.
+func resolveEncryption(cmd *cobra.Command, cfg *env.Env, kind snapshot.DestinationKind) (snapshot.Encryption, error) {
+	encrypt, err := cmd.Flags().GetBool("encrypt")
+	if err != nil {
+		return snapshot.Encryption{}, err
+	}
+	recipients, err := cmd.Flags().GetStringArray("recipient")
+	if err != nil {
+		return snapshot.Encryption{}, err
+	}
+	return snapshot.ResolveEncryption(kind, encrypt, recipients, cfg.SnapshotPassphrase)
+}
+
+func addIdentityFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("identity", nil, "age identity file to decrypt an encrypted snapshot with (repeatable; default LSTK_SNAPSHOT_IDENTITY)")
+}
+
+// resolveDecryption collects what may open an encrypted snapshot on load:
+// --identity files, falling back to LSTK_SNAPSHOT_IDENTITY, and the
+// LSTK_SNAPSHOT_PASSPHRASE passphrase.
+func resolveDecryption(cmd *cobra.Command, cfg *env.Env) (snapshot.Decryption, error) {
+	identities, err := cmd.Flags().GetStringArray("identity")
+	if err != nil {
+		return snapshot.Decryption{}, err
+	}
+	dec := envDecryption(cfg)
+	if len(identities) > 0 {
+		dec.IdentityFiles = identities
+	}
+	return dec, nil
+}
+
+// envDecryption is the decryption the environment provides, for loads that
+// take no flags such as the snapshot auto-load on start.
+func envDecryption(cfg *env.Env) snapshot.Decryption {
+	dec := snapshot.Decryption{Passphrase: cfg.SnapshotPassphrase}
+	if cfg.SnapshotIdentity != "" {
+		dec.IdentityFiles = []string{cfg.SnapshotIdentity}
+	}
+	return dec
+}
+
 func addDryRunFlag(cmd *cobra.Command) {
 	cmd.Flags().Bool("dry-run", false, "Preview what would change without modifying state (pod refs only)")
 }
@@ -300,6 +510,21 @@ func runSnapshotLoad(cfg *env.Env, tel *telemetry.Client, logger log.Logger) fun
 		if err != nil {
 			return err
 		}
+		servicesFlag, err := cmd.Flags().GetString("services")
+		if err != nil {
+			return err
+		}
+		services, err := validate.ServiceList(servicesFlag)
+		if err != nil {
+			return err
+		}
+		if dryRun && len(services) > 0 {
+			return errors.New("--dry-run cannot be combined with --services")
+		}
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return err
+		}
 
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -331,15 +556,32 @@ func runSnapshotLoad(cfg *env.Env, tel *telemetry.Client, logger log.Logger) fun
 			if err != nil {
 				return err
 			}
+			dec, err := resolveDecryption(cmd, cfg)
+			if err != nil {
+				return err
+			}
 			var starter snapshot.Starter
 			if !external {
 				starter = buildStarter(cfg, rt, appConfig, logger, tel)
 			}
+			// Only a key can open an encrypted upload, so without one the
+			// pod is left to the emulator.
+			if dec.Enabled() {
+				if isInteractiveMode(cfg) {
+					var restarter snapshot.Restarter
+					if !external {
+						restarter = buildRestarter(cfg, rt, appConfig, containers[0], logger, tel)
+					}
+					return ui.RunSnapshotLoadRemoteS3Encrypted(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, services, dec, starter, restarter, force)
+				}
+				sink := output.NewPlainSink(os.Stdout)
+				return snapshot.LoadRemoteS3Encrypted(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, services, dec, starter, nil, force, sink)
+			}
 			if isInteractiveMode(cfg) {
-				return ui.RunSnapshotLoadRemoteS3(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, starter)
+				return ui.RunSnapshotLoadRemoteS3(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, services, starter)
 			}
 			sink := output.NewPlainSink(os.Stdout)
-			return snapshot.LoadRemoteS3(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, starter, sink)
+			return snapshot.LoadRemoteS3(cmd.Context(), rt, containers, client, host, podName, src.Value, creds, cfg.AuthToken, strategy, services, starter, sink)
 		}
 
 		src, err := snapshot.ParseSource(args[0], home)
@@ -354,6 +596,15 @@ func runSnapshotLoad(cfg *env.Env, tel *telemetry.Client, logger log.Logger) fun
 			return execDiff(cmd, cfg, src.Value, src.Version, strategy)
 		}
 
+		dec, err := resolveDecryption(cmd, cfg)
+		if err != nil {
+			return err
+		}
+		lib, err := snapshotLibrary()
+		if err != nil {
+			return err
+		}
+
 		rt, client, host, containers, appConfig, external, err := resolveSnapshotDeps(cmd.Context(), cmd, cfg)
 		if err != nil {
 			return err
@@ -365,14 +616,22 @@ func runSnapshotLoad(cfg *env.Env, tel *telemetry.Client, logger log.Logger) fun
 		}
 
 		if isInteractiveMode(cfg) {
-			return ui.RunSnapshotLoad(cmd.Context(), rt, containers, client, host, src, cfg.AuthToken, strategy, starter)
+			var restarter snapshot.Restarter
+			if !external {
+				restarter = buildRestarter(cfg, rt, appConfig, containers[0], logger, tel)
+			}
+			return ui.RunSnapshotLoad(cmd.Context(), rt, containers, client, host, src, lib, cfg.AuthToken, strategy, services, dec, starter, restarter, force)
 		}
 		sink := output.NewPlainSink(os.Stdout)
 		switch src.Kind {
 		case snapshot.KindPod:
-			return snapshot.LoadPod(cmd.Context(), rt, containers, client, host, src.Value, src.Version, cfg.AuthToken, strategy, starter, sink)
+			return snapshot.LoadPod(cmd.Context(), rt, containers, client, host, src.Value, src.Version, cfg.AuthToken, strategy, services, starter, sink)
+		case snapshot.KindOCI:
+			return snapshot.LoadOCI(cmd.Context(), rt, containers, client, host, src.Value, strategy, services, starter, sink)
+		case snapshot.KindLibrary:
+			return snapshot.LoadLibrary(cmd.Context(), rt, containers, client, host, lib, src.Value, strategy, services, dec, starter, nil, force, sink)
 		default:
-			return snapshot.LoadLocal(cmd.Context(), rt, containers, client, host, src.Value, strategy, starter, sink)
+			return snapshot.LoadLocal(cmd.Context(), rt, containers, client, host, src.Value, strategy, services, dec, starter, nil, force, sink)
 		}
 	}
 }
@@ -469,8 +728,12 @@ func resolveSnapshotDeps(ctx context.Context, cmd *cobra.Command, cfg *env.Env)
 	if len(appConfig.Containers) == 0 {
 		return nil, nil, "", nil, nil, false, fmt.Errorf("no emulator is configured")
 	}
+	// --instance narrows both the target and what the load command would auto-start.
+	if appConfig.Containers, err = filterContainersByInstanceFlag(cmd, appConfig.Containers); err != nil {
+		return nil, nil, "", nil, nil, false, err
+	}
 
-	rt, err = runtime.NewDockerRuntime(cfg.DockerHost)
+	rt, err = newRuntime(cfg)
 	if err != nil {
 		return nil, nil, "", nil, nil, false, err
 	}
@@ -563,7 +826,7 @@ func resolveS3Credentials(profile string) (snapshot.S3Credentials, error) {
 
 func newSnapshotListCmd(cfg *env.Env, logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list [s3://bucket/prefix]",
+		Use:     "list [--local | s3://bucket/prefix | oras://registry/repository]",
 		Short:   "List Cloud Pod snapshots available on the LocalStack platform",
 		Long:    snapshotListLong,
 		Args:    cobra.MaximumNArgs(1),
@@ -571,6 +834,8 @@ func newSnapshotListCmd(cfg *env.Env, logger log.Logger) *cobra.Command {
 		RunE:    runSnapshotList(cfg, logger),
 	}
 	cmd.Flags().Bool("all", false, "List all snapshots in the organisation")
+	cmd.Flags().Bool("local", false, "List the snapshots in the local library")
+	cmd.MarkFlagsMutuallyExclusive("all", "local")
 	addProfileFlag(cmd)
 	return cmd
 }
@@ -581,11 +846,29 @@ func runSnapshotList(cfg *env.Env, logger log.Logger) func(*cobra.Command, []str
 		if err != nil {
 			return err
 		}
+		local, err := cmd.Flags().GetBool("local")
+		if err != nil {
+			return err
+		}
 		profile, err := cmd.Flags().GetString("profile")
 		if err != nil {
 			return err
 		}
 
+		if local {
+			if len(args) == 1 {
+				return fmt.Errorf("unexpected argument %q: snapshot list --local takes no location", args[0])
+			}
+			lib, err := snapshotLibrary()
+			if err != nil {
+				return err
+			}
+			if isInteractiveMode(cfg) {
+				return ui.RunSnapshotListLibrary(cmd.Context(), lib)
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.ListLibrary(lib, sink)
+		}
 		if len(args) == 1 && snapshot.IsS3Ref(args[0]) {
 			home, err := os.UserHomeDir()
 			if err != nil {
@@ -609,8 +892,19 @@ func runSnapshotList(cfg *env.Env, logger log.Logger) func(*cobra.Command, []str
 			sink := output.NewPlainSink(os.Stdout)
 			return snapshot.ListRemoteS3(cmd.Context(), rt, containers, client, host, src.Value, creds, cfg.AuthToken, sink)
 		}
+		if len(args) == 1 && snapshot.IsOCIRef(args[0]) {
+			repository, err := snapshot.ParseOCIRepository(args[0])
+			if err != nil {
+				return err
+			}
+			if isInteractiveMode(cfg) {
+				return ui.RunSnapshotListOCI(cmd.Context(), repository)
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.ListOCI(cmd.Context(), repository, sink)
+		}
 		if len(args) == 1 {
-			return fmt.Errorf("unexpected argument %q: snapshot list takes an optional s3:// location", args[0])
+			return fmt.Errorf("unexpected argument %q: snapshot list takes an optional s3:// or oras:// location", args[0])
 		}
 
 		creator := "me"
@@ -629,7 +923,7 @@ func runSnapshotList(cfg *env.Env, logger log.Logger) func(*cobra.Command, []str
 func newSnapshotShowCmd(cfg *env.Env, logger log.Logger) *cobra.Command {
 	return &cobra.Command{
 		Use:     "show REF",
-		Short:   "Show metadata for a cloud snapshot",
+		Short:   "Show metadata for a cloud or local snapshot",
 		Long:    snapshotShowLong,
 		Args:    cobra.ExactArgs(1),
 		PreRunE: initConfigDeferCreate(nil),
@@ -653,6 +947,25 @@ func runSnapshotShow(cfg *env.Env, logger log.Logger) func(*cobra.Command, []str
 			return err
 		}
 
+		if ref.Kind == snapshot.KindLibrary {
+			lib, err := snapshotLibrary()
+			if err != nil {
+				return err
+			}
+			if isInteractiveMode(cfg) {
+				return ui.RunSnapshotShowLibrary(cmd.Context(), lib, ref.Value)
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.ShowLibrary(lib, ref.Value, sink)
+		}
+		if ref.Kind == snapshot.KindLocal {
+			if isInteractiveMode(cfg) {
+				return ui.RunSnapshotShowLocal(cmd.Context(), ref.Value)
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.ShowLocal(ref.Value, sink)
+		}
+
 		client := api.NewPlatformClient(cfg.APIEndpoint, logger)
 		if isInteractiveMode(cfg) {
 			return ui.RunSnapshotShow(cmd.Context(), client, cfg.AuthToken, ref.Value, ref.Version)
@@ -698,6 +1011,244 @@ func runSnapshotVersions(cfg *env.Env, logger log.Logger) func(*cobra.Command, [
 	}
 }
 
+func newSnapshotPruneCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "prune --keep N [local:NAME]",
+		Short:   "Delete old snapshots from the local library",
+		Long:    snapshotPruneLong,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE:    runSnapshotPrune(cfg),
+	}
+	cmd.Flags().Int("keep", 0, "Number of newest saves to keep of each name")
+	_ = cmd.MarkFlagRequired("keep")
+	return cmd
+}
+
+func runSnapshotPrune(cfg *env.Env) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return err
+		}
+		if keep < 0 {
+			return fmt.Errorf("--keep must be zero or more, got %d", keep)
+		}
+
+		var name string
+		if len(args) == 1 {
+			if !strings.HasPrefix(strings.ToLower(args[0]), "local:") {
+				return fmt.Errorf("%q: snapshot prune only applies to the local library — use a local: ref, e.g. local:my-baseline", args[0])
+			}
+			ref, err := snapshot.ParseSource(args[0], "")
+			if err != nil {
+				return err
+			}
+			name = ref.Value
+		}
+
+		lib, err := snapshotLibrary()
+		if err != nil {
+			return err
+		}
+		if isInteractiveMode(cfg) {
+			return ui.RunSnapshotPrune(cmd.Context(), lib, name, keep)
+		}
+		sink := output.NewPlainSink(os.Stdout)
+		return snapshot.PruneLibrary(lib, name, keep, sink)
+	}
+}
+
+func newSnapshotScheduleCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "schedule [destination]",
+		Short:   "Save snapshots periodically while the emulator runs",
+		Long:    snapshotScheduleLong,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE:    runSnapshotSchedule(cfg),
+	}
+	cmd.Flags().Duration("every", snapshot.DefaultScheduleEvery, "Time between saves, e.g. 15m or 1h")
+	cmd.Flags().Int("keep", snapshot.DefaultScheduleKeep, "Number of newest saves to keep in the local library")
+	addServicesFlag(cmd)
+	addEncryptFlags(cmd)
+	return cmd
+}
+
+func newSnapshotExportCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "export [file]",
+		Short:   "Export the emulator's deployed resources as a manifest",
+		Long:    snapshotExportLong,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE:    runSnapshotExport(cfg),
+	}
+	cmd.Flags().String("format", snapshot.ManifestFormatJSON, "Manifest format: json or yaml")
+	return cmd
+}
+
+func runSnapshotExport(cfg *env.Env) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if err := snapshot.ValidateManifestFormat(format); err != nil {
+			return err
+		}
+		var dest string
+		if len(args) > 0 {
+			if dest, err = filepath.Abs(args[0]); err != nil {
+				return err
+			}
+		}
+
+		rt, client, host, containers, _, _, err := resolveSnapshotDeps(cmd.Context(), cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		// A manifest on stdout must stay machine-readable, so it is never
+		// mixed with the TUI or progress lines.
+		if dest == "" {
+			return snapshot.ExportManifest(cmd.Context(), rt, containers, client, host, format, "", os.Stdout, output.NewPlainSink(os.Stderr))
+		}
+		if isInteractiveMode(cfg) {
+			return ui.RunSnapshotExport(cmd.Context(), rt, containers, client, host, format, dest)
+		}
+		return snapshot.ExportManifest(cmd.Context(), rt, containers, client, host, format, dest, nil, output.NewPlainSink(os.Stdout))
+	}
+}
+
+func runSnapshotSchedule(cfg *env.Env) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		every, err := cmd.Flags().GetDuration("every")
+		if err != nil {
+			return err
+		}
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return err
+		}
+		servicesFlag, err := cmd.Flags().GetString("services")
+		if err != nil {
+			return err
+		}
+		services, err := validate.ServiceList(servicesFlag)
+		if err != nil {
+			return err
+		}
+		var destArg string
+		if len(args) == 1 {
+			destArg = args[0]
+		}
+		dest, err := snapshot.ParseScheduleDestination(destArg, cmd.Flags().Changed("keep"))
+		if err != nil {
+			return err
+		}
+		enc, err := resolveEncryption(cmd, cfg, dest.Kind)
+		if err != nil {
+			return err
+		}
+		opts := snapshot.ScheduleOptions{
+			Dest:       dest,
+			Every:      every,
+			Keep:       keep,
+			AuthToken:  cfg.AuthToken,
+			Services:   services,
+			Encryption: enc,
+			Passphrase: cfg.SnapshotPassphrase,
+		}
+		if err := opts.Validate(); err != nil {
+			return err
+		}
+		if opts.Lib, err = snapshotLibrary(); err != nil {
+			return err
+		}
+
+		rt, client, host, containers, _, _, err := resolveSnapshotDeps(cmd.Context(), cmd, cfg)
+		if err != nil {
+			return err
+		}
+		if isInteractiveMode(cfg) {
+			return ui.RunSnapshotSchedule(cmd.Context(), rt, containers, client, host, opts)
+		}
+		sink := output.NewPlainSink(os.Stdout)
+		return snapshot.Schedule(cmd.Context(), rt, containers, client, host, opts, sink)
+	}
+}
+
+func newSnapshotDiffCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "diff REF [REF]",
+		Short:   "Show what changed between snapshots",
+		Long:    snapshotDiffLong,
+		Args:    cobra.RangeArgs(1, 2),
+		PreRunE: initConfigDeferCreate(nil),
+		RunE:    runSnapshotDiff(cfg),
+	}
+	addMergeFlag(cmd)
+	return cmd
+}
+
+func runSnapshotDiff(cfg *env.Env) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		refs := make([]snapshot.Destination, len(args))
+		for i, arg := range args {
+			if refs[i], err = snapshot.ParseSource(arg, home); err != nil {
+				return err
+			}
+			// A library snapshot is a local file underneath: diff its newest save.
+			if refs[i].Kind == snapshot.KindLibrary {
+				lib, err := snapshotLibrary()
+				if err != nil {
+					return err
+				}
+				entry, err := lib.Latest(refs[i].Value)
+				if err != nil {
+					return err
+				}
+				refs[i] = snapshot.Destination{Kind: snapshot.KindLocal, Value: entry.Path}
+			}
+		}
+
+		if len(refs) == 2 {
+			if refs[0].Kind != snapshot.KindLocal || refs[1].Kind != snapshot.KindLocal {
+				return fmt.Errorf("only two local snapshot files can be compared with each other; compare a pod: snapshot with the running state instead (lstk snapshot diff pod:NAME)")
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.DiffLocal(refs[0].Value, refs[1].Value, sink)
+		}
+
+		src := refs[0]
+		switch src.Kind {
+		case snapshot.KindPod:
+			strategy, err := resolveLoadStrategy(cmd, cfg)
+			if err != nil {
+				return err
+			}
+			return execDiff(cmd, cfg, src.Value, src.Version, strategy)
+		case snapshot.KindLocal:
+			rt, client, host, containers, _, _, err := resolveSnapshotDeps(cmd.Context(), cmd, cfg)
+			if err != nil {
+				return err
+			}
+			if isInteractiveMode(cfg) {
+				return ui.RunSnapshotDiffLocalRunning(cmd.Context(), rt, containers, client, host, src.Value)
+			}
+			sink := output.NewPlainSink(os.Stdout)
+			return snapshot.DiffLocalRunning(cmd.Context(), rt, containers, client, host, src.Value, sink)
+		default:
+			return fmt.Errorf("%q: snapshot diff only supports local files and pod: refs", args[0])
+		}
+	}
+}
+
 func newSnapshotSaveCmd(cfg *env.Env) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "save [destination]",
@@ -709,6 +1260,7 @@ func newSnapshotSaveCmd(cfg *env.Env) *cobra.Command {
 	}
 	addProfileFlag(cmd)
 	addServicesFlag(cmd)
+	addEncryptFlags(cmd)
 	return cmd
 }
 
@@ -724,6 +1276,7 @@ func newSaveCmd(cfg *env.Env) *cobra.Command {
 	}
 	addProfileFlag(cmd)
 	addServicesFlag(cmd)
+	addEncryptFlags(cmd)
 	return cmd
 }
 
@@ -741,7 +1294,6 @@ func runSnapshotSave(cfg *env.Env) func(*cobra.Command, []string) error {
 		if err != nil {
 			return err
 		}
-
 		podName, s3URL, isRemote, err := classifyRemoteArgs(args)
 		if err != nil {
 			return err
@@ -757,6 +1309,10 @@ func runSnapshotSave(cfg *env.Env) func(*cobra.Command, []string) error {
 			if err != nil {
 				return err
 			}
+			enc, err := resolveEncryption(cmd, cfg, dest.Kind)
+			if err != nil {
+				return err
+			}
 			if podName == "" {
 				podName = snapshot.DefaultRemotePodName(time.Now())
 			} else if err := snapshot.ValidateRemotePodName(podName); err != nil {
@@ -770,6 +1326,13 @@ func runSnapshotSave(cfg *env.Env) func(*cobra.Command, []string) error {
 			if err != nil {
 				return err
 			}
+			if enc.Enabled() {
+				if isInteractiveMode(cfg) {
+					return ui.RunSnapshotSaveRemoteS3Encrypted(cmd.Context(), rt, containers, client, client, host, podName, dest.Value, creds, services, enc)
+				}
+				sink := output.NewPlainSink(os.Stdout)
+				return snapshot.SaveRemoteS3Encrypted(cmd.Context(), rt, containers, client, client, host, podName, dest.Value, creds, services, enc, sink)
+			}
 			if isInteractiveMode(cfg) {
 				return ui.RunSnapshotSaveRemoteS3(cmd.Context(), rt, containers, client, host, podName, dest.Value, creds, cfg.AuthToken, services)
 			}
@@ -785,6 +1348,14 @@ func runSnapshotSave(cfg *env.Env) func(*cobra.Command, []string) error {
 		if err != nil {
 			return err
 		}
+		enc, err := resolveEncryption(cmd, cfg, dest.Kind)
+		if err != nil {
+			return err
+		}
+		lib, err := snapshotLibrary()
+		if err != nil {
+			return err
+		}
 
 		rt, client, host, containers, _, _, err := resolveSnapshotDeps(cmd.Context(), cmd, cfg)
 		if err != nil {
@@ -792,14 +1363,18 @@ func runSnapshotSave(cfg *env.Env) func(*cobra.Command, []string) error {
 		}
 
 		if isInteractiveMode(cfg) {
-			return ui.RunSnapshotSave(cmd.Context(), rt, containers, client, host, dest, cfg.AuthToken, services)
+			return ui.RunSnapshotSave(cmd.Context(), rt, containers, client, host, dest, lib, cfg.AuthToken, services, enc)
 		}
 		sink := output.NewPlainSink(os.Stdout)
 		switch dest.Kind {
 		case snapshot.KindPod:
 			return snapshot.SavePod(cmd.Context(), rt, containers, client, host, dest.Value, cfg.AuthToken, services, sink)
+		case snapshot.KindOCI:
+			return snapshot.SaveOCI(cmd.Context(), rt, containers, client, host, dest.Value, services, sink)
+		case snapshot.KindLibrary:
+			return snapshot.SaveLibrary(cmd.Context(), rt, containers, client, host, lib, dest.Value, services, enc, sink)
 		default:
-			return snapshot.SaveLocal(cmd.Context(), rt, containers, client, host, dest.Value, services, sink)
+			return snapshot.SaveLocal(cmd.Context(), rt, containers, client, host, dest.Value, services, enc, sink)
 		}
 	}
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/localstack/lstk/internal/env"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encryptTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "save"}
+	addEncryptFlags(cmd)
+	addIdentityFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestResolveDecryption(t *testing.T) {
+	t.Run("environment", func(t *testing.T) {
+		dec, err := resolveDecryption(encryptTestCmd(t), &env.Env{SnapshotPassphrase: "secret", SnapshotIdentity: "/keys/env.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Decryption{Passphrase: "secret", IdentityFiles: []string{"/keys/env.txt"}}, dec)
+	})
+
+	t.Run("identity flag overrides the environment identity", func(t *testing.T) {
+		dec, err := resolveDecryption(encryptTestCmd(t, "--identity", "/keys/a.txt", "--identity", "/keys/b.txt"), &env.Env{SnapshotIdentity: "/keys/env.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/keys/a.txt", "/keys/b.txt"}, dec.IdentityFiles)
+	})
+}
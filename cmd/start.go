@@ -6,8 +6,6 @@ import (
 
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/log"
-	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/telemetry"
 	"github.com/spf13/cobra"
 )
@@ -23,20 +21,26 @@ Host environment variables prefixed with LOCALSTACK_ are forwarded to the emulat
 
 Use --type (aws, snowflake, azure) to select the emulator non-interactively; it records the selection in config, switching the configured type in place when it differs.
 
-If a snapshot is configured for the AWS emulator (the snapshot field in [[containers]]), it is auto-loaded once the emulator starts. Use --snapshot REF to override it for one run, or --no-snapshot to skip it.`,
+Use --instance NAME to start only the [[containers]] block with that instance name, e.g. one of several AWS emulators.
+
+If a snapshot is configured for the AWS emulator (the snapshot field in [[containers]]), it is auto-loaded once the emulator starts. Use --snapshot REF to override it for one run, or --no-snapshot to skip it.
+
+Init steps configured in [[containers.init]] (shell commands, aws CLI commands, Terraform directories) run in order once a freshly started emulator is healthy; the first failing step fails the start.`,
 		Args: func(_ *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				return fmt.Errorf("unexpected argument %q; select the emulator with --type (e.g. lstk start --type %s)", args[0], args[0])
 			}
 			return nil
 		},
-		PreRunE: initConfigDeferCreate(&firstRun),
+		PreRunE:     initConfigDeferCreate(&firstRun),
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
 		RunE: func(c *cobra.Command, args []string) error {
-			if err := rejectEndpointURL(c, output.NewPlainSink(os.Stdout), "start"); err != nil {
+			sink := jsonAwareSink(c, cfg, os.Stdout)
+			if err := rejectEndpointURL(c, sink, "start"); err != nil {
 				return err
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
@@ -52,14 +56,19 @@ If a snapshot is configured for the AWS emulator (the snapshot field in [[contai
 			if err != nil {
 				return err
 			}
+			instance, err := c.Flags().GetString("instance")
+			if err != nil {
+				return err
+			}
 			if err := applyTimeoutFlag(c, cfg); err != nil {
 				return err
 			}
-			return startEmulator(c.Context(), rt, cfg, tel, logger, persist, firstRun, snapshotFlag, noSnapshot, emulatorType)
+			return startEmulator(c.Context(), rt, sink, cfg, tel, logger, persist, firstRun, snapshotFlag, noSnapshot, emulatorType, instance)
 		},
 	}
 	cmd.Flags().Bool("persist", false, "Persist emulator state across restarts")
 	addEmulatorTypeFlag(cmd)
+	addStartInstanceFlag(cmd)
 	addSnapshotStartFlags(cmd)
 	addTimeoutFlag(cmd)
 	return cmd
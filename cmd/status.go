@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/localstack/lstk/internal/config"
 	"github.com/localstack/lstk/internal/container"
@@ -13,22 +14,59 @@ import (
 	"github.com/localstack/lstk/internal/endpoint"
 	"github.com/localstack/lstk/internal/env"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/localstack/lstk/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 func newStatusCmd(cfg *env.Env) *cobra.Command {
-	return &cobra.Command{
-		Use:     "status",
-		Short:   "Show emulator status and deployed resources",
-		Long:    "Show the status of a running emulator and its deployed resources",
-		PreRunE: initConfigDeferCreate(nil),
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show emulator status and deployed resources",
+		Long: "Show the status of a running emulator: its endpoint, version and uptime, the state of each\n" +
+			"service in /_localstack/health (services in a state other than running, available or\n" +
+			"disabled, e.g. error, are listed by name), and its deployed resources.\n\n" +
+			"With --watch, keep refreshing every --interval and highlight resources that appeared or\n" +
+			"disappeared since the previous refresh. Without a terminal (or with --json), --watch writes\n" +
+			"one JSON line for the first refresh and one per later refresh that changed something.",
+		Example: `  lstk status
+  lstk status --watch
+  lstk status --watch --interval 10s --json`,
+		PreRunE:     initConfigDeferCreate(nil),
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			target, err := endpoint.Resolve(cmd.Context(), cmd)
+			watch, err := cmd.Flags().GetBool("watch")
 			if err != nil {
 				return err
 			}
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+			// A watch never ends on its own, so it streams NDJSON lines instead
+			// of the single envelope --json otherwise produces.
+			streaming := watch && !isInteractiveMode(cfg)
+			var sink output.Sink
+			if streaming {
+				sink = output.NewNDJSONSink(os.Stdout, "status")
+			} else {
+				sink = jsonAwareSink(cmd, cfg, os.Stdout)
+			}
+			failWithCode := func(err error, code output.ErrorCode) error {
+				if !cfg.JSON && !streaming {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: code})
+				return output.NewSilentError(err)
+			}
+
+			if watch && interval < time.Second {
+				return failWithCode(fmt.Errorf("--interval must be at least 1s, got %s", interval), output.ErrValidationError)
+			}
+
+			target, err := endpoint.Resolve(cmd.Context(), cmd)
+			if err != nil {
+				return failWithCode(err, output.ErrEmulatorNotRunning)
+			}
 
 			clients := map[config.EmulatorType]emulator.Client{
 				config.EmulatorAWS:       aws.NewClient(),
@@ -37,25 +75,48 @@ func newStatusCmd(cfg *env.Env) *cobra.Command {
 			}
 
 			if target != nil {
+				if watch {
+					if streaming {
+						return container.WatchStatusExternal(cmd.Context(), target, clients, interval, sink)
+					}
+					return ui.RunStatusWatchExternal(cmd.Context(), target, clients, interval)
+				}
 				if isInteractiveMode(cfg) {
 					return ui.RunStatusExternal(cmd.Context(), target, clients)
 				}
-				return container.StatusExternal(cmd.Context(), target, clients, output.NewPlainSink(os.Stdout))
+				return container.StatusExternal(cmd.Context(), target, clients, sink)
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
 			appCfg, err := config.Get()
 			if err != nil {
-				return fmt.Errorf("failed to get config: %w", err)
+				if streaming {
+					return failWithCode(fmt.Errorf("failed to get config: %w", err), classifyConfigError(err).Code)
+				}
+				return failGetConfig(sink, cfg, err)
+			}
+			containers, err := filterContainersByFlags(cmd, appCfg.Containers)
+			if err != nil {
+				return failWithCode(err, output.ErrEmulatorNotConfigured)
 			}
 
+			if watch {
+				if streaming {
+					return container.WatchStatus(cmd.Context(), rt, containers, cfg.LocalStackHost, clients, interval, sink)
+				}
+				return ui.RunStatusWatch(cmd.Context(), rt, containers, cfg.LocalStackHost, clients, interval)
+			}
 			if isInteractiveMode(cfg) {
-				return ui.RunStatus(cmd.Context(), rt, appCfg.Containers, cfg.LocalStackHost, clients)
+				return ui.RunStatus(cmd.Context(), rt, containers, cfg.LocalStackHost, clients)
 			}
-			return container.Status(cmd.Context(), rt, appCfg.Containers, cfg.LocalStackHost, clients, output.NewPlainSink(os.Stdout))
+			return container.Status(cmd.Context(), rt, containers, cfg.LocalStackHost, clients, sink)
 		},
 	}
+	cmd.Flags().Bool("watch", false, "Keep refreshing and highlight resources that appeared or disappeared")
+	cmd.Flags().Duration("interval", 3*time.Second, "Time between refreshes with --watch")
+	addEmulatorFilterFlags(cmd)
+	return cmd
 }
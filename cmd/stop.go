@@ -6,14 +6,14 @@ import (
 	"github.com/localstack/lstk/internal/config"
 	"github.com/localstack/lstk/internal/container"
 	"github.com/localstack/lstk/internal/env"
-	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/output"
 	"github.com/localstack/lstk/internal/telemetry"
 	"github.com/localstack/lstk/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 func newStopCmd(cfg *env.Env, tel *telemetry.Client) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:         "stop",
 		Short:       "Stop emulator",
 		Long:        "Stop emulator and services",
@@ -26,7 +26,7 @@ func newStopCmd(cfg *env.Env, tel *telemetry.Client) *cobra.Command {
 				return err
 			}
 
-			rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+			rt, err := newRuntime(cfg)
 			if err != nil {
 				return err
 			}
@@ -34,15 +34,30 @@ func newStopCmd(cfg *env.Env, tel *telemetry.Client) *cobra.Command {
 			if err != nil {
 				return failGetConfig(sink, cfg, err)
 			}
+			containers, err := filterContainersByFlags(cmd, appConfig.Containers)
+			if err != nil {
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: output.ErrEmulatorNotConfigured})
+				return output.NewSilentError(err)
+			}
 
+			beforeStop, err := newAutosaveHook(cfg, containers)
+			if err != nil {
+				return err
+			}
 			stopOpts := container.StopOptions{
-				Telemetry: tel,
+				Telemetry:  tel,
+				BeforeStop: beforeStop,
 			}
 
 			if isInteractiveMode(cfg) {
-				return ui.RunStop(cmd.Context(), rt, appConfig.Containers, stopOpts)
+				return ui.RunStop(cmd.Context(), rt, containers, stopOpts)
 			}
-			return container.Stop(cmd.Context(), rt, sink, appConfig.Containers, stopOpts)
+			return container.Stop(cmd.Context(), rt, sink, containers, stopOpts)
 		},
 	}
+	addEmulatorFilterFlags(cmd)
+	return cmd
 }
@@ -10,7 +10,6 @@ import (
 	tfcli "github.com/localstack/lstk/internal/iac/terraform/cli"
 	"github.com/localstack/lstk/internal/log"
 	"github.com/localstack/lstk/internal/output"
-	"github.com/localstack/lstk/internal/runtime"
 	"github.com/spf13/cobra"
 )
 
@@ -120,7 +119,7 @@ Examples:
 				}
 				endpointURL = target.URL
 			} else {
-				rt, err := runtime.NewDockerRuntime(cfg.DockerHost)
+				rt, err := newRuntime(cfg)
 				if err != nil {
 					return err
 				}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/container"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/env"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newWaitCmd(cfg *env.Env) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until the emulator is ready",
+		Long: `Wait until the emulator reports healthy in /_localstack/health, for scripts and CI.
+
+An emulator that is not running yet is waited for too, so "lstk wait" can follow a "lstk start" sent to the background. With --services, also wait until each listed service reports "available" or "running". Exits non-zero if that does not happen within --timeout.
+
+With --endpoint-url (or LSTK_ENDPOINT_URL/AWS_ENDPOINT_URL), waits for the emulator at that URL instead.`,
+		Example: `  lstk wait
+  lstk wait --services s3,sqs --timeout 2m
+  lstk wait --endpoint-url http://localstack:4566 --json`,
+		Args:        cobra.NoArgs,
+		PreRunE:     initConfigDeferCreate(nil),
+		Annotations: map[string]string{jsonSupportedAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sink := jsonAwareSink(cmd, cfg, os.Stdout)
+			if err := applyTimeoutFlag(cmd, cfg); err != nil {
+				return err
+			}
+			services, err := cmd.Flags().GetStringSlice("services")
+			if err != nil {
+				return err
+			}
+			opts := container.WaitOptions{Timeout: cfg.StartupTimeout, Services: normalizeServices(services)}
+
+			endpointURL, external, err := endpoint.ResolveURL(cmd)
+			if err != nil {
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: output.ErrValidationError})
+				return output.NewSilentError(err)
+			}
+			if external {
+				return container.WaitExternal(cmd.Context(), endpointURL, opts, sink)
+			}
+
+			rt, err := newRuntime(cfg)
+			if err != nil {
+				return err
+			}
+			appConfig, err := config.Get()
+			if err != nil {
+				return failGetConfig(sink, cfg, err)
+			}
+			containers, err := filterContainersByFlags(cmd, appConfig.Containers)
+			if err != nil {
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: output.ErrEmulatorNotConfigured})
+				return output.NewSilentError(err)
+			}
+			if len(opts.Services) > 0 && len(containers) > 1 {
+				err := errors.New("--services needs a single emulator; pick one with --type or --instance")
+				if !cfg.JSON {
+					return err
+				}
+				sink.Emit(output.ErrorEvent{Title: err.Error(), Code: output.ErrValidationError})
+				return output.NewSilentError(err)
+			}
+			return container.Wait(cmd.Context(), rt, containers, cfg.LocalStackHost, opts, sink)
+		},
+	}
+	cmd.Flags().StringSlice("services", nil, "Also wait for these services to be available, e.g. s3,sqs")
+	cmd.Flags().Duration("timeout", 0, "Maximum time to wait (overrides LSTK_STARTUP_TIMEOUT; 0 uses the default)")
+	addEmulatorFilterFlags(cmd)
+	return cmd
+}
+
+// normalizeServices lowercases and trims the --services list, dropping empty
+// entries, to match the service names in /_localstack/health.
+func normalizeServices(services []string) []string {
+	var out []string
+	for _, s := range services {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -62,6 +62,12 @@ type ExecOptions struct {
 	AccountSelected bool
 	// UsePTY runs the child under a pseudo-terminal.
 	UsePTY bool
+	// Dir is the working directory relative paths (e.g. file://seed.json)
+	// resolve against. Empty runs in lstk's own.
+	Dir string
+	// NoStdin gives the child an empty stdin instead of lstk's, for commands
+	// run where nobody can answer a prompt.
+	NoStdin bool
 }
 
 // Exec runs `aws <args...>` against opts.EndpointURL. When opts.UsePTY is true
@@ -92,7 +98,10 @@ func Exec(ctx context.Context, opts ExecOptions, stdout, stderr io.Writer, args
 	)
 
 	cmd := exec.CommandContext(ctx, awsBin, cmdArgs...)
-	cmd.Stdin = os.Stdin
+	if !opts.NoStdin {
+		cmd.Stdin = os.Stdin
+	}
+	cmd.Dir = opts.Dir
 	cmd.Env = execEnv(os.Environ(), opts)
 
 	var runErr error
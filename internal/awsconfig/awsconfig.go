@@ -82,31 +82,33 @@ func ResolveProfileHost(ctx context.Context, containers []config.ContainerConfig
 	return "", false, fmt.Errorf("no aws emulator configured")
 }
 
-// profileStatus holds which AWS profile files need to be written or updated.
-type profileStatus struct {
+// ProfileStatus holds which AWS profile files need to be written or updated.
+type ProfileStatus struct {
 	configNeeded bool
 	credsNeeded  bool
 }
 
-func (s profileStatus) anyNeeded() bool {
+// AnyNeeded reports whether either profile file needs to be written, i.e. the
+// localstack profile is missing or does not point at LocalStack.
+func (s ProfileStatus) AnyNeeded() bool {
 	return s.configNeeded || s.credsNeeded
 }
 
 // CheckProfileStatus determines which AWS profile files need to be written or updated.
-func CheckProfileStatus(resolvedHost string) (profileStatus, error) {
+func CheckProfileStatus(resolvedHost string) (ProfileStatus, error) {
 	configPath, credsPath, err := awsPaths()
 	if err != nil {
-		return profileStatus{}, err
+		return ProfileStatus{}, err
 	}
 	configNeeded, err := configNeedsWrite(configPath, resolvedHost)
 	if err != nil {
-		return profileStatus{}, err
+		return ProfileStatus{}, err
 	}
 	credsNeeded, err := credsNeedWrite(credsPath)
 	if err != nil {
-		return profileStatus{}, err
+		return ProfileStatus{}, err
 	}
-	return profileStatus{configNeeded: configNeeded, credsNeeded: credsNeeded}, nil
+	return ProfileStatus{configNeeded: configNeeded, credsNeeded: credsNeeded}, nil
 }
 
 func configNeedsWrite(path, resolvedHost string) (bool, error) {
@@ -214,24 +216,24 @@ func emitMissingProfileNote(sink output.Sink) {
 
 // checkProfileSetup returns both the profile status (which files need writing) and presence (which files exist).
 // This avoids loading the same files twice by combining needsProfileSetup and profilePresence.
-func checkProfileSetup(resolvedHost string) (profileStatus, bool, bool, error) {
+func checkProfileSetup(resolvedHost string) (ProfileStatus, bool, bool, error) {
 	configPath, credsPath, err := awsPaths()
 	if err != nil {
-		return profileStatus{}, false, false, err
+		return ProfileStatus{}, false, false, err
 	}
 
 	status, err := CheckProfileStatus(resolvedHost)
 	if err != nil {
-		return profileStatus{}, false, false, err
+		return ProfileStatus{}, false, false, err
 	}
 
 	configOK, err := sectionExists(configPath, configSectionName)
 	if err != nil {
-		return profileStatus{}, false, false, err
+		return ProfileStatus{}, false, false, err
 	}
 	credsOK, err := sectionExists(credsPath, credsSectionName)
 	if err != nil {
-		return profileStatus{}, false, false, err
+		return ProfileStatus{}, false, false, err
 	}
 
 	return status, configOK, credsOK, nil
@@ -246,7 +248,7 @@ func EnsureProfile(ctx context.Context, sink output.Sink, interactive bool, reso
 		sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: fmt.Sprintf("could not check AWS profile: %v", err)})
 		return nil
 	}
-	if !status.anyNeeded() {
+	if !status.AnyNeeded() {
 		return nil
 	}
 	if interactive && !configOK && !credsOK {
@@ -260,7 +262,7 @@ func EnsureProfile(ctx context.Context, sink output.Sink, interactive bool, reso
 // applyProfile writes the config and/or credentials sections indicated by status
 // and emits a success message. It performs no prompting, so it is shared by both
 // the interactive (Setup) and non-interactive (SetupNonInteractive) paths.
-func applyProfile(sink output.Sink, resolvedHost, configPath, credsPath string, status profileStatus) error {
+func applyProfile(sink output.Sink, resolvedHost, configPath, credsPath string, status ProfileStatus) error {
 	if status.configNeeded {
 		if err := writeConfigProfile(configPath, resolvedHost); err != nil {
 			return fmt.Errorf("could not update ~/.aws/config: %w", err)
@@ -293,8 +295,8 @@ func applyProfile(sink output.Sink, resolvedHost, configPath, credsPath string,
 // failure must surface a non-zero exit. It is false for the best-effort post-start
 // convenience flow (EnsureProfile during `lstk start`), where a write failure must
 // only warn and must not abort an already-running emulator.
-func Setup(ctx context.Context, sink output.Sink, resolvedHost string, status profileStatus, skipConfirm, explicit bool) error {
-	if !status.anyNeeded() {
+func Setup(ctx context.Context, sink output.Sink, resolvedHost string, status ProfileStatus, skipConfirm, explicit bool) error {
+	if !status.AnyNeeded() {
 		sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: "LocalStack AWS profile is already configured."})
 		return nil
 	}
@@ -342,7 +344,7 @@ func SetupNonInteractive(ctx context.Context, sink output.Sink, resolvedHost str
 		sink.Emit(output.ErrorEvent{Title: "Could not check the LocalStack AWS profile", Summary: err.Error()})
 		return output.NewSilentError(err)
 	}
-	if !status.anyNeeded() {
+	if !status.AnyNeeded() {
 		sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: "LocalStack AWS profile is already configured."})
 		return nil
 	}
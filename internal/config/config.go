@@ -4,13 +4,12 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strings"
+	"slices"
 
 	"github.com/localstack/lstk/internal/validate"
-	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/viper"
 )
 
@@ -22,6 +21,9 @@ type CLIConfig struct {
 }
 
 type Config struct {
+	// Runtime selects the container runtime backend: "auto" (default),
+	// "docker", "podman" or "kubernetes". LSTK_RUNTIME overrides it.
+	Runtime    string                       `mapstructure:"runtime"`
 	Containers []ContainerConfig            `mapstructure:"containers"`
 	Env        map[string]map[string]string `mapstructure:"env"`
 	CLI        CLIConfig                    `mapstructure:"cli"`
@@ -37,50 +39,33 @@ func setDefaults() {
 	})
 }
 
+// loadConfig loads the file at path on its own, as with --config.
 func loadConfig(path string) error {
-	viper.Reset()
-	setDefaults()
-	viper.SetConfigType("toml")
-	viper.SetConfigFile(path)
-
-	if err := viper.ReadInConfig(); err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
-	}
-	return nil
+	return loadSources([]Source{{Path: path, Scope: SourceExplicit}})
 }
 
 func InitFromPath(path string) error {
 	return loadConfig(path)
 }
 
-// Load reads config.toml without creating it; callers that need to create the
-// default config on first run should call EnsureCreated once ready to persist it
-// (e.g. after an emulator-selection prompt, or after a successful default start).
+// Load reads the config without creating it: the nearest project lstk.toml (or
+// .lstk/config.toml) layered over the global config.toml, either of which may be
+// absent. Callers that need to create the default config on first run should call
+// EnsureCreated once ready to persist it (e.g. after an emulator-selection prompt,
+// or after a successful default start).
 func Load() (firstRun bool, err error) {
 	viper.Reset()
 	setDefaults()
-	viper.SetConfigName(configName)
 	viper.SetConfigType(configType)
 
-	dirs, err := configSearchDirs()
+	sources, err := discoverSources()
 	if err != nil {
 		return false, err
 	}
-	for _, dir := range dirs {
-		viper.AddConfigPath(dir)
-	}
-
-	if err := viper.ReadInConfig(); err != nil {
-		var notFoundErr viper.ConfigFileNotFoundError
-		if errors.As(err, &notFoundErr) {
-			return true, nil
-		}
-		if used := viper.ConfigFileUsed(); filepath.Ext(used) == ".yaml" || filepath.Ext(used) == ".yml" {
-			return false, fmt.Errorf("%s is from an old lstk version; lstk now uses TOML format — remove it or replace it with a config.toml file", used)
-		}
-		return false, fmt.Errorf("failed to read config file: %w", err)
+	if len(sources) == 0 {
+		return true, nil
 	}
-	return false, nil
+	return false, loadSources(sources)
 }
 
 func EnsureCreated() error {
@@ -100,7 +85,7 @@ func EnsureCreated() error {
 	f, err := os.OpenFile(configPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
 	if err != nil {
 		if errors.Is(err, os.ErrExist) {
-			return loadConfig(configPath)
+			return loadSources([]Source{{Path: configPath, Scope: SourceGlobal}})
 		}
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
@@ -114,77 +99,90 @@ func EnsureCreated() error {
 		_ = os.Remove(configPath)
 		return fmt.Errorf("failed to close config file: %w", closeErr)
 	}
-	return loadConfig(configPath)
+	return loadSources([]Source{{Path: configPath, Scope: SourceGlobal}})
 }
 
 func resolvedConfigPath() string {
 	return viper.ConfigFileUsed()
 }
 
+// Set records user-level state such as cli.update_skipped_version, writing it to
+// the global config file when one is loaded so it does not end up in a project's
+// lstk.toml.
 func Set(key string, value any) error {
 	viper.Set(key, value)
-	path := resolvedConfigPath()
+	path := userConfigPath()
 	if path == "" {
 		return nil // no config file yet; keep in memory only
 	}
 	return setInFile(path, key, value)
 }
 
-// setInFile inserts or updates a single "section.field" key in the TOML config
-// file without rewriting unrelated content, preserving comments and formatting.
+// setInFile inserts or updates a single key (e.g. "cli.update_skipped_version" or
+// "containers[0].tag") in the TOML config file without rewriting unrelated content,
+// preserving comments and formatting.
 func setInFile(path, key string, value any) error {
-	parts := strings.SplitN(key, ".", 2)
-	if len(parts) != 2 {
-		return viper.WriteConfig()
-	}
-	section, field := parts[0], parts[1]
-
-	// Encode value using go-toml for correct scalar quoting.
-	type wrapper struct {
-		V any `toml:"v"`
-	}
-	enc, err := toml.Marshal(wrapper{V: value})
+	encoded, err := encodeTOMLValue(value)
 	if err != nil {
-		return fmt.Errorf("failed to encode value: %w", err)
+		return err
 	}
-	line := strings.TrimSpace(string(enc))
-	assignment := field + " =" + line[strings.IndexByte(line, '=')+1:]
-
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
-	content := string(data)
-
-	re := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(field) + `\s*=.*$`)
-	secRe := regexp.MustCompile(`(?m)^\[` + regexp.QuoteMeta(section) + `\][^\n]*$`)
-	if re.MatchString(content) {
-		content = re.ReplaceAllString(content, assignment)
-	} else if secRe.MatchString(content) {
-		content = secRe.ReplaceAllStringFunc(content, func(header string) string {
-			return header + "\n" + assignment
-		})
-	} else {
-		content = strings.TrimRight(content, "\n") + "\n\n[" + section + "]\n" + assignment + "\n"
+	doc, err := parseTOMLDocument(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
 	}
-
-	return os.WriteFile(path, []byte(content), 0644)
+	content, err := doc.set(key, encoded)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
 }
 
 func SetUpdateSkippedVersion(version string) error {
 	return Set("cli.update_skipped_version", version)
 }
 
+// RuntimeBackend returns the top-level `runtime` key of the loaded config, or
+// "" when it is unset. Unlike Get it never fails, so a command can still reach
+// the runtime to report an otherwise invalid config.
+func RuntimeBackend() string {
+	return viper.GetString("runtime")
+}
+
 func Get() (*Config, error) {
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	src, ok, err := containersFile()
+	if err != nil {
+		return nil, err
+	}
+	untrusted := false
+	if ok && src.Scope == SourceProject {
+		trusted, err := projectTrusted(src.Path)
+		if err != nil {
+			return nil, err
+		}
+		untrusted = !trusted
+	}
 	for i := range cfg.Containers {
+		if ok {
+			cfg.Containers[i].configDir = filepath.Dir(src.Path)
+			if untrusted {
+				cfg.Containers[i].untrustedProject = src.Path
+			}
+		}
 		if err := cfg.Containers[i].Validate(); err != nil {
 			return nil, fmt.Errorf("invalid container config: %w", err)
 		}
 	}
+	if err := validateContainerSet(cfg.Containers); err != nil {
+		return nil, fmt.Errorf("invalid container config: %w", err)
+	}
 	if err := validateNamedEnvs(cfg.Env); err != nil {
 		return nil, err
 	}
@@ -194,15 +192,30 @@ func Get() (*Config, error) {
 // validateNamedEnvs rejects malformed variables defined in the top-level [env.*]
 // config sections before they are injected into a container's environment.
 func validateNamedEnvs(envs map[string]map[string]string) error {
-	for name, vars := range envs {
-		for key, value := range vars {
-			if err := validate.EnvVarName(key); err != nil {
-				return fmt.Errorf("invalid variable in [env.%s]: %w", name, err)
+	if errs := namedEnvErrors(envs); len(errs) > 0 {
+		return errs[0].err
+	}
+	return nil
+}
+
+// namedEnvErrors collects every variable validateNamedEnvs rejects, sorted by
+// profile and variable name.
+func namedEnvErrors(envs map[string]map[string]string) []fieldError {
+	var errs []fieldError
+	for _, name := range slices.Sorted(maps.Keys(envs)) {
+		vars := envs[name]
+		for _, key := range slices.Sorted(maps.Keys(vars)) {
+			err := validate.EnvVarName(key)
+			if err == nil {
+				err = validate.NoControlChars("value for "+key, vars[key])
 			}
-			if err := validate.NoControlChars("value for "+key, value); err != nil {
-				return fmt.Errorf("invalid variable in [env.%s]: %w", name, err)
+			if err != nil {
+				errs = append(errs, fieldError{
+					key: "env." + name + "." + key,
+					err: fmt.Errorf("invalid variable in [env.%s]: %w", name, err),
+				})
 			}
 		}
 	}
-	return nil
+	return errs
 }
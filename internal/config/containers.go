@@ -134,6 +134,11 @@ type ContainerConfig struct {
 	// image and a type name, against which an unqualified name reads ambiguously; the field is
 	// CustomName because Name is already a method (the same pairing as CustomImage/Image).
 	CustomName string `mapstructure:"container_name"`
+	// Instance names this block when several blocks of the same type run side by side
+	// (e.g. a "pristine" and a "scratch" AWS emulator). It is part of the derived
+	// container name, and so of the default persistence directory, and is what
+	// --instance matches on lifecycle commands. Empty for the unnamed default instance.
+	Instance string `mapstructure:"instance"`
 	// Volume is the legacy single-host-directory knob for the persistence mount
 	// (target /var/lib/localstack). It is still honored; new configs can express the
 	// same mount as a Volumes entry targeting persistenceTarget instead.
@@ -159,6 +164,29 @@ type ContainerConfig struct {
 	// auto-loaded after the emulator starts. AWS emulator only. Never written by lstk:
 	// `snapshot save` does not persist its destination here.
 	Snapshot string `mapstructure:"snapshot"`
+	// Autosave makes `lstk stop` save the emulator's state to the local snapshot
+	// library before the container is removed, and `lstk start` restore the newest
+	// such save in place of Snapshot. AWS emulator only. See AutosaveName in the
+	// snapshot package for where the saves are kept.
+	Autosave bool `mapstructure:"autosave"`
+	// AutosaveKeep bounds how many autosave generations are kept; older ones are
+	// pruned after each save. Zero means DefaultAutosaveKeep.
+	AutosaveKeep int `mapstructure:"autosave_keep"`
+	// Encrypt seals the snapshots lstk saves on its own, autosaves and
+	// `snapshot schedule` saves to the local library, with the passphrase in
+	// LSTK_SNAPSHOT_PASSPHRASE. AWS emulator only.
+	Encrypt bool `mapstructure:"encrypt"`
+	// Init lists the [[containers.init]] steps run, in order, once a freshly started
+	// emulator is healthy — e.g. to seed buckets and queues. See InitStep.
+	Init []InitStep `mapstructure:"init"`
+
+	// configDir is the directory of the config file the block was read from, which
+	// its relative paths resolve against. Get sets it; when empty, as for a block
+	// built in code, relativePathsDir falls back to configDirForRelativePaths.
+	configDir string
+	// untrustedProject is the project config file the block was read from, when
+	// the user has not trusted it. See UntrustedProjectError.
+	untrustedProject string
 }
 
 // persistenceTarget is the container path of the managed persistence/cache mount.
@@ -263,9 +291,26 @@ func configDirForRelativePaths() string {
 	return filepath.Dir(cfgPath)
 }
 
+// requireTrust fails with an UntrustedProjectError when the block comes from a
+// project config file the user has not trusted.
+func (c *ContainerConfig) requireTrust(what string) error {
+	if c.untrustedProject == "" {
+		return nil
+	}
+	return &UntrustedProjectError{Path: c.untrustedProject, What: what}
+}
+
+// relativePathsDir returns the directory the block's relative paths resolve against.
+func (c *ContainerConfig) relativePathsDir() string {
+	if c.configDir != "" {
+		return c.configDir
+	}
+	return configDirForRelativePaths()
+}
+
 // parsedVolumes parses every entry in Volumes, resolving sources against the config dir.
 func (c *ContainerConfig) parsedVolumes() ([]VolumeMount, error) {
-	configDir := configDirForRelativePaths()
+	configDir := c.relativePathsDir()
 	mounts := make([]VolumeMount, 0, len(c.Volumes))
 	for _, spec := range c.Volumes {
 		m, err := parseVolume(spec, configDir)
@@ -291,6 +336,11 @@ func (c *ContainerConfig) ExtraVolumes() ([]VolumeMount, error) {
 		}
 		extras = append(extras, m)
 	}
+	if len(extras) > 0 {
+		if err := c.requireTrust("host volumes"); err != nil {
+			return nil, err
+		}
+	}
 	return extras, nil
 }
 
@@ -311,11 +361,11 @@ func (c *ContainerConfig) VolumeDir() (string, error) {
 	}
 	for _, m := range mounts {
 		if m.Target == persistenceTarget {
-			return m.Source, nil
+			return m.Source, c.requireTrust("a host volume")
 		}
 	}
 	if c.Volume != "" {
-		return c.Volume, nil
+		return c.Volume, c.requireTrust("a host volume")
 	}
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
@@ -470,14 +520,73 @@ func validateTag(tag string) error {
 }
 
 func (c *ContainerConfig) Validate() error {
-	if err := validateTag(c.Tag); err != nil {
-		return err
+	if errs := c.fieldErrors(); len(errs) > 0 {
+		return errs[0].err
+	}
+	return nil
+}
+
+// fieldError is a validation failure tied to the config key it concerns, so
+// `lstk config validate` can point at the offending line.
+type fieldError struct {
+	key string // relative to the [[containers]] block (e.g. "port", "init[1]") or, for checks across blocks, to the config root
+	err error
+}
+
+// fieldErrors runs every check of Validate, collecting each failure in order
+// instead of stopping at the first.
+func (c *ContainerConfig) fieldErrors() []fieldError {
+	var errs []fieldError
+	add := func(key string, err error) {
+		if err != nil {
+			errs = append(errs, fieldError{key: key, err: err})
+		}
 	}
+	add("tag", validateTag(c.Tag))
 	if c.CustomName != "" {
 		if err := validate.ContainerName(c.CustomName); err != nil {
-			return fmt.Errorf("invalid container name %q: %w", c.CustomName, err)
+			add("container_name", fmt.Errorf("invalid container name %q: %w", c.CustomName, err))
+		}
+	}
+	if c.Instance != "" {
+		if err := validate.ContainerName(c.Instance); err != nil {
+			add("instance", fmt.Errorf("invalid instance name %q: %w", c.Instance, err))
+		}
+	}
+	add("port", c.validatePort())
+	_, err := c.ExposedPorts()
+	add("expose_ports", err)
+	for i, step := range c.Init {
+		if err := step.validate(c.Type); err != nil {
+			add(fmt.Sprintf("init[%d]", i), fmt.Errorf("init step %d: %w", i+1, err))
 		}
 	}
+	add("volumes", c.validateVolumes())
+	if c.Autosave && c.Type != EmulatorAWS {
+		add("autosave", fmt.Errorf("autosave is only supported for the AWS emulator"))
+	}
+	if c.Encrypt && c.Type != EmulatorAWS {
+		add("encrypt", fmt.Errorf("encrypt is only supported for the AWS emulator"))
+	}
+	if c.AutosaveKeep < 0 {
+		add("autosave_keep", fmt.Errorf("autosave_keep must be zero or more, got %d", c.AutosaveKeep))
+	}
+	return errs
+}
+
+// DefaultAutosaveKeep is how many autosave generations are kept when
+// autosave_keep is not set.
+const DefaultAutosaveKeep = 3
+
+// AutosaveGenerations returns how many autosave generations to keep.
+func (c *ContainerConfig) AutosaveGenerations() int {
+	if c.AutosaveKeep > 0 {
+		return c.AutosaveKeep
+	}
+	return DefaultAutosaveKeep
+}
+
+func (c *ContainerConfig) validatePort() error {
 	if c.Port == "" {
 		return fmt.Errorf("port is required for %s emulator", c.Type)
 	}
@@ -488,10 +597,80 @@ func (c *ContainerConfig) Validate() error {
 	if port < 1 || port > 65535 {
 		return fmt.Errorf("port %d is out of range (must be 1–65535)", port)
 	}
-	if _, err := c.ExposedPorts(); err != nil {
-		return err
+	return nil
+}
+
+// validateContainerSet checks the enabled [[containers]] blocks against each other. Each
+// block runs as its own container on its own host port, so two blocks sharing either would
+// collide at start time — the second `docker run` failing on a name or port conflict after
+// the first emulator is already up.
+func validateContainerSet(containers []ContainerConfig) error {
+	if errs := containerSetErrors(containers); len(errs) > 0 {
+		return errs[0].err
 	}
-	return c.validateVolumes()
+	return nil
+}
+
+// containerSetErrors collects every clash validateContainerSet reports.
+func containerSetErrors(containers []ContainerConfig) []fieldError {
+	var errs []fieldError
+	names := map[string]int{}
+	ports := map[string]int{}
+	for i, c := range containers {
+		if j, ok := names[c.Name()]; ok {
+			errs = append(errs, fieldError{
+				key: fmt.Sprintf("containers[%d]", i),
+				err: fmt.Errorf("[[containers]] blocks %d and %d both use container name %q; set a distinct instance or container_name on one of them", j+1, i+1, c.Name()),
+			})
+		} else {
+			names[c.Name()] = i
+		}
+		if j, ok := ports[c.Port]; ok {
+			errs = append(errs, fieldError{
+				key: fmt.Sprintf("containers[%d].port", i),
+				err: fmt.Errorf("[[containers]] blocks %d and %d both use port %s; each emulator needs its own port", j+1, i+1, c.Port),
+			})
+		} else {
+			ports[c.Port] = i
+		}
+	}
+	return errs
+}
+
+// FilterByType returns the blocks of the given emulator type, for commands that address
+// one emulator out of several configured ones. An empty type selects every block.
+func FilterByType(containers []ContainerConfig, t EmulatorType) ([]ContainerConfig, error) {
+	if t == "" {
+		return containers, nil
+	}
+	var matched []ContainerConfig
+	for _, c := range containers {
+		if c.Type == t {
+			matched = append(matched, c)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no %s emulator is configured; add a [[containers]] block with type = %q", t.ShortName(), t)
+	}
+	return matched, nil
+}
+
+// FilterByInstance returns the block named instance, for commands that address one
+// named instance out of several configured ones. An empty name selects every block.
+func FilterByInstance(containers []ContainerConfig, instance string) ([]ContainerConfig, error) {
+	if instance == "" {
+		return containers, nil
+	}
+	var matched []ContainerConfig
+	for _, c := range containers {
+		if c.Instance == instance {
+			matched = append(matched, c)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no emulator instance named %q is configured; add instance = %q to a [[containers]] block", instance, instance)
+	}
+	return matched, nil
 }
 
 // validateVolumes checks each Volumes entry is structurally parseable and guards against
@@ -512,7 +691,7 @@ func (c *ContainerConfig) validateVolumes() error {
 		}
 	}
 	if c.Volume != "" && persistenceSource != "" {
-		resolved, err := resolveHostPath(c.Volume, configDirForRelativePaths())
+		resolved, err := resolveHostPath(c.Volume, c.relativePathsDir())
 		if err != nil {
 			return err
 		}
@@ -574,13 +753,18 @@ func (c *ContainerConfig) Name() string {
 	return c.defaultName()
 }
 
-// defaultName is the derived container name: "localstack-{type}" or "localstack-{type}-{tag}" if tag != latest
+// defaultName is the derived container name: "localstack-{type}", followed by "-{instance}"
+// for a named instance and "-{tag}" if tag != latest.
 func (c *ContainerConfig) defaultName() string {
+	name := fmt.Sprintf("localstack-%s", c.Type)
+	if c.Instance != "" {
+		name += "-" + c.Instance
+	}
 	tag := c.Tag
 	if tag == "" || tag == "latest" {
-		return fmt.Sprintf("localstack-%s", c.Type)
+		return name
 	}
-	return fmt.Sprintf("localstack-%s-%s", c.Type, tag)
+	return name + "-" + tag
 }
 
 func (c *ContainerConfig) HealthPath() (string, error) {
@@ -600,8 +784,18 @@ func (c *ContainerConfig) ContainerPort() (string, error) {
 	}
 }
 
+// DisplayName names the emulator in messages, e.g. "LocalStack AWS Emulator", with a
+// named instance appended in parentheses.
 func (c *ContainerConfig) DisplayName() string {
-	return c.Type.DisplayName()
+	return InstanceDisplayName(c.Type, c.Instance)
+}
+
+// InstanceDisplayName is DisplayName for callers that only hold the type and instance.
+func InstanceDisplayName(t EmulatorType, instance string) string {
+	if instance == "" {
+		return t.DisplayName()
+	}
+	return fmt.Sprintf("%s (%s)", t.DisplayName(), instance)
 }
 
 func (c *ContainerConfig) ProductName() (string, error) {
@@ -194,6 +194,15 @@ func TestName_DerivedWhenNoCustomName(t *testing.T) {
 	}
 }
 
+func TestName_IncludesInstance(t *testing.T) {
+	c := &ContainerConfig{Type: EmulatorAWS, Port: "4567", Instance: "scratch"}
+	assert.Equal(t, "localstack-aws-scratch", c.Name())
+
+	c.Tag = "2026.4"
+	assert.Equal(t, "localstack-aws-scratch-2026.4", c.Name())
+	assert.Equal(t, "LocalStack AWS Emulator (scratch)", c.DisplayName())
+}
+
 func TestName_CustomNameWins(t *testing.T) {
 	c := &ContainerConfig{Type: EmulatorAWS, Port: "4566", Tag: "2026.4", CustomName: "ls-jenkins"}
 	assert.Equal(t, "ls-jenkins", c.Name())
@@ -487,3 +496,85 @@ func TestValidate_AllowsMatchingPersistenceSources(t *testing.T) {
 	}
 	assert.NoError(t, c.Validate())
 }
+
+func TestValidateContainerSet_AcceptsDistinctBlocks(t *testing.T) {
+	err := validateContainerSet([]ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566"},
+		{Type: EmulatorSnowflake, Port: "4567"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateContainerSet_RejectsSharedPort(t *testing.T) {
+	err := validateContainerSet([]ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566"},
+		{Type: EmulatorSnowflake, Port: "4566"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both use port 4566")
+}
+
+func TestValidateContainerSet_RejectsSharedName(t *testing.T) {
+	err := validateContainerSet([]ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566", CustomName: "ls"},
+		{Type: EmulatorSnowflake, Port: "4567", CustomName: "ls"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `both use container name "ls"`)
+}
+
+func TestFilterByType(t *testing.T) {
+	containers := []ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566"},
+		{Type: EmulatorSnowflake, Port: "4567"},
+	}
+
+	all, err := FilterByType(containers, "")
+	require.NoError(t, err)
+	assert.Equal(t, containers, all)
+
+	snowflake, err := FilterByType(containers, EmulatorSnowflake)
+	require.NoError(t, err)
+	assert.Equal(t, containers[1:], snowflake)
+
+	_, err = FilterByType(containers, EmulatorAzure)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Azure emulator is configured")
+}
+
+func TestValidate_InstanceName(t *testing.T) {
+	c := &ContainerConfig{Type: EmulatorAWS, Port: "4566", Instance: "scratch"}
+	assert.NoError(t, c.Validate())
+
+	c.Instance = "my scratch"
+	err := c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid instance name "my scratch"`)
+}
+
+func TestValidateContainerSet_AcceptsNamedInstancesOfOneType(t *testing.T) {
+	err := validateContainerSet([]ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566", Instance: "pristine"},
+		{Type: EmulatorAWS, Port: "4567", Instance: "scratch"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestFilterByInstance(t *testing.T) {
+	containers := []ContainerConfig{
+		{Type: EmulatorAWS, Port: "4566", Instance: "pristine"},
+		{Type: EmulatorAWS, Port: "4567", Instance: "scratch"},
+	}
+
+	all, err := FilterByInstance(containers, "")
+	require.NoError(t, err)
+	assert.Equal(t, containers, all)
+
+	scratch, err := FilterByInstance(containers, "scratch")
+	require.NoError(t, err)
+	assert.Equal(t, containers[1:], scratch)
+
+	_, err = FilterByInstance(containers, "other")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no emulator instance named "other" is configured`)
+}
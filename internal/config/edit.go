@@ -0,0 +1,22 @@
+package config
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// EditorCommand returns the user's editor command line, which may carry
+// arguments (e.g. "code --wait"): $VISUAL, then $EDITOR, falling back to vi, or
+// notepad on Windows.
+func EditorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
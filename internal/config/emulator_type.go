@@ -79,5 +79,5 @@ func SetEmulatorType(to EmulatorType) error {
 	if err := os.WriteFile(path, updated, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
-	return loadConfig(path)
+	return reload()
 }
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Init step kinds, as reported by InitStep.Kind.
+const (
+	InitKindShell     = "shell"
+	InitKindAWS       = "aws"
+	InitKindTerraform = "terraform"
+)
+
+// InitStep is one [[containers.init]] entry: a seeding action lstk runs on the host
+// against a freshly started emulator once it is healthy. Steps run in order, and
+// exactly one of Shell, AWS and Terraform is set per step. Relative paths are
+// resolved against the config file's directory, which is also the working directory
+// every step runs in.
+type InitStep struct {
+	// Name labels the step in output. Defaults to a description of the command.
+	Name string `mapstructure:"name"`
+	// Shell is a command line run through the system shell (sh -c, or cmd /C on
+	// Windows), e.g. "./scripts/seed.sh".
+	Shell string `mapstructure:"shell"`
+	// AWS lists aws CLI invocations run one after another, each written without the
+	// leading "aws" (e.g. "s3 mb s3://assets"). Arguments are split on whitespace;
+	// single or double quotes keep an argument containing spaces together.
+	AWS []string `mapstructure:"aws"`
+	// Terraform is a directory of Terraform configuration applied against the
+	// emulator, the same way `lstk terraform` does: init, then apply -auto-approve.
+	Terraform string `mapstructure:"terraform"`
+}
+
+// Kind reports which of the step's commands is set.
+func (s InitStep) Kind() string {
+	switch {
+	case s.Shell != "":
+		return InitKindShell
+	case len(s.AWS) > 0:
+		return InitKindAWS
+	default:
+		return InitKindTerraform
+	}
+}
+
+// Label is the step's Name, or a short description of its command when unnamed.
+func (s InitStep) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	switch s.Kind() {
+	case InitKindShell:
+		return s.Shell
+	case InitKindAWS:
+		label := "aws " + s.AWS[0]
+		if len(s.AWS) > 1 {
+			label += fmt.Sprintf(" (+%d more)", len(s.AWS)-1)
+		}
+		return label
+	default:
+		return "terraform " + s.Terraform
+	}
+}
+
+// AWSCommands returns the step's aws CLI invocations split into arguments.
+func (s InitStep) AWSCommands() ([][]string, error) {
+	cmds := make([][]string, 0, len(s.AWS))
+	for _, line := range s.AWS {
+		args, err := splitCommandLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aws command %q: %w", line, err)
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("invalid aws command %q: command is empty", line)
+		}
+		if args[0] == "aws" {
+			return nil, fmt.Errorf("invalid aws command %q: leave out the leading \"aws\"", line)
+		}
+		cmds = append(cmds, args)
+	}
+	return cmds, nil
+}
+
+// TerraformDir resolves the step's Terraform directory against configDir.
+func (s InitStep) TerraformDir(configDir string) (string, error) {
+	return resolveHostPath(s.Terraform, configDir)
+}
+
+func (s InitStep) validate(t EmulatorType) error {
+	set := 0
+	if strings.TrimSpace(s.Shell) != "" {
+		set++
+	}
+	if len(s.AWS) > 0 {
+		set++
+	}
+	if strings.TrimSpace(s.Terraform) != "" {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("each init step needs exactly one of shell, aws or terraform")
+	}
+	if s.Kind() != InitKindShell && t != EmulatorAWS {
+		return fmt.Errorf("%s init steps need the AWS emulator, not %s", s.Kind(), t.ShortName())
+	}
+	_, err := s.AWSCommands()
+	return err
+}
+
+// CheckInitTrusted fails with an UntrustedProjectError when the block has init
+// steps and comes from a project config file the user has not trusted. Every
+// kind runs a program on the host with arguments from the file: an aws step
+// can point the CLI at another endpoint or write the host's AWS config.
+func (c *ContainerConfig) CheckInitTrusted() error {
+	if len(c.Init) == 0 {
+		return nil
+	}
+	return c.requireTrust(c.Init[0].Kind() + " init steps")
+}
+
+// InitDir is the directory init steps run in and resolve relative paths against:
+// the directory of the config file that declared them.
+func (c *ContainerConfig) InitDir() string {
+	return c.relativePathsDir()
+}
+
+// splitCommandLine splits s into arguments on whitespace, honoring single and
+// double quotes around arguments that contain spaces. It does no expansion.
+func splitCommandLine(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	var quote rune
+	inArg := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet_InitSteps(t *testing.T) {
+	// Cannot run in parallel: mutates process-wide viper state.
+	configFile := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+[[containers]]
+type = "aws"
+port = "4566"
+
+[[containers.init]]
+name = "buckets"
+aws = ["s3 mb s3://assets", "s3 mb s3://logs"]
+
+[[containers.init]]
+shell = "./seed.sh"
+
+[[containers.init]]
+terraform = "./infra"
+`), 0600))
+
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Len(t, cfg.Containers, 1)
+	assert.Equal(t, []InitStep{
+		{Name: "buckets", AWS: []string{"s3 mb s3://assets", "s3 mb s3://logs"}},
+		{Shell: "./seed.sh"},
+		{Terraform: "./infra"},
+	}, cfg.Containers[0].Init)
+	assert.Equal(t, filepath.Dir(configFile), cfg.Containers[0].InitDir())
+}
+
+func TestValidate_InitSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     EmulatorType
+		step    InitStep
+		wantErr string
+	}{
+		{name: "shell", typ: EmulatorAWS, step: InitStep{Shell: "./seed.sh"}},
+		{name: "aws", typ: EmulatorAWS, step: InitStep{AWS: []string{"sqs create-queue --queue-name jobs"}}},
+		{name: "terraform", typ: EmulatorAWS, step: InitStep{Terraform: "infra"}},
+		{name: "shell on snowflake", typ: EmulatorSnowflake, step: InitStep{Shell: "./seed.sh"}},
+		{name: "empty", typ: EmulatorAWS, step: InitStep{Name: "nothing"}, wantErr: "exactly one of shell, aws or terraform"},
+		{name: "two commands", typ: EmulatorAWS, step: InitStep{Shell: "x", Terraform: "infra"}, wantErr: "exactly one of shell, aws or terraform"},
+		{name: "aws on snowflake", typ: EmulatorSnowflake, step: InitStep{AWS: []string{"s3 ls"}}, wantErr: "aws init steps need the AWS emulator"},
+		{name: "terraform on azure", typ: EmulatorAzure, step: InitStep{Terraform: "infra"}, wantErr: "terraform init steps need the AWS emulator"},
+		{name: "leading aws", typ: EmulatorAWS, step: InitStep{AWS: []string{"aws s3 ls"}}, wantErr: `leave out the leading "aws"`},
+		{name: "unterminated quote", typ: EmulatorAWS, step: InitStep{AWS: []string{`s3 cp "a b s3://x`}}, wantErr: "unterminated"},
+		{name: "blank command", typ: EmulatorAWS, step: InitStep{AWS: []string{"  "}}, wantErr: "command is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ContainerConfig{Type: tt.typ, Port: "4566", Init: []InitStep{{Shell: "true"}, tt.step}}
+			err := c.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, "init step 2: ")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestInitStep_AWSCommandsSplitsQuotedArguments(t *testing.T) {
+	step := InitStep{AWS: []string{
+		`s3 mb s3://assets`,
+		`ssm put-parameter --name /app/greeting --value "hello world" --type String`,
+		`sns publish --topic-arn arn:x --message '{"k": "v"}'`,
+	}}
+	cmds, err := step.AWSCommands()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"s3", "mb", "s3://assets"},
+		{"ssm", "put-parameter", "--name", "/app/greeting", "--value", "hello world", "--type", "String"},
+		{"sns", "publish", "--topic-arn", "arn:x", "--message", `{"k": "v"}`},
+	}, cmds)
+}
+
+func TestInitStep_Label(t *testing.T) {
+	assert.Equal(t, "seed", InitStep{Name: "seed", Shell: "./seed.sh"}.Label())
+	assert.Equal(t, "./seed.sh", InitStep{Shell: "./seed.sh"}.Label())
+	assert.Equal(t, "aws s3 mb s3://a", InitStep{AWS: []string{"s3 mb s3://a"}}.Label())
+	assert.Equal(t, "aws s3 mb s3://a (+2 more)", InitStep{AWS: []string{"s3 mb s3://a", "s3 mb s3://b", "s3 mb s3://c"}}.Label())
+	assert.Equal(t, "terraform ./infra", InitStep{Terraform: "./infra"}.Label())
+}
+
+func TestInitStep_TerraformDirResolvesAgainstConfigDir(t *testing.T) {
+	dir, err := InitStep{Terraform: "infra"}.TerraformDir("/cfg")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/cfg", "infra"), dir)
+
+	abs := filepath.Join(t.TempDir(), "infra")
+	dir, err = InitStep{Terraform: abs}.TerraformDir("/cfg")
+	require.NoError(t, err)
+	assert.Equal(t, abs, dir)
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/viper"
+)
+
+// keyPart is one dotted part of a config key, e.g. "containers[1]" is the name
+// "containers" with index 1. index is -1 when the part has no index.
+type keyPart struct {
+	name  string
+	index int
+}
+
+func parseKey(key string) ([]keyPart, error) {
+	if key == "" {
+		return nil, fmt.Errorf("config key is empty")
+	}
+	var parts []keyPart
+	for _, s := range strings.Split(key, ".") {
+		part := keyPart{name: s, index: -1}
+		if open := strings.IndexByte(s, '['); open >= 0 {
+			if !strings.HasSuffix(s, "]") {
+				return nil, fmt.Errorf("invalid config key %q: unterminated index", key)
+			}
+			n, err := strconv.Atoi(s[open+1 : len(s)-1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid config key %q: index %q is not a number", key, s[open+1:len(s)-1])
+			}
+			part = keyPart{name: s[:open], index: n}
+		}
+		if part.name == "" {
+			return nil, fmt.Errorf("invalid config key %q: empty key part", key)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// keyType resolves a config key to the type of the Config field it names, following
+// mapstructure tags; any key is accepted inside a map such as [env.*].
+func keyType(key string) (reflect.Type, error) {
+	parts, err := parseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(Config{})
+	for _, part := range parts {
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByTag(t, part.name)
+			if !ok {
+				return nil, fmt.Errorf("unknown config key %q", key)
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key %q", key)
+		}
+		if part.index >= 0 {
+			if t.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("invalid config key %q: %s is not a list", key, part.name)
+			}
+			t = t.Elem()
+		}
+	}
+	return t, nil
+}
+
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.EqualFold(f.Tag.Get("mapstructure"), name) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Value returns the loaded value of a config key such as "runtime",
+// "containers[0].port" or "env.debug.DEBUG". Values are read from the config files
+// as written, so keys inside a table such as [env.debug] keep their case. Built-in
+// defaults count as loaded, so "containers" has a value even without a config file.
+func Value(key string) (any, error) {
+	if _, err := keyType(key); err != nil {
+		return nil, err
+	}
+	parts, _ := parseKey(key)
+	settings, err := fileValues()
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := lookupValue(settings, parts); ok {
+		return v, nil
+	}
+	if v, ok := lookupValue(viper.AllSettings(), parts); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("%s is not set", key)
+}
+
+// fileValues merges the loaded config files the way loadSources does, keeping the
+// keys as written in each file.
+func fileValues() (map[string]any, error) {
+	if resolvedConfigPath() == "" {
+		return nil, nil
+	}
+	sources, err := Sources()
+	if err != nil {
+		return nil, err
+	}
+	files, err := readConfigFiles(sources)
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]any{}
+	for i := len(files) - 1; i >= 0; i-- {
+		doc, err := parseTOMLDocument(files[i].data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", files[i].path, err)
+		}
+		merged = mergeSettings(merged, doc.values)
+	}
+	return merged, nil
+}
+
+// lookupValue follows parts through settings. A key part matches its exact
+// spelling first and otherwise any case, as viper matches keys.
+func lookupValue(settings map[string]any, parts []keyPart) (any, bool) {
+	var v any = settings
+	for _, part := range parts {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[part.name]; !ok {
+			for k, item := range m {
+				if strings.EqualFold(k, part.name) {
+					v, ok = item, true
+					break
+				}
+			}
+			if !ok {
+				return nil, false
+			}
+		}
+		if part.index >= 0 {
+			items := reflect.ValueOf(v)
+			if items.Kind() != reflect.Slice || part.index >= items.Len() {
+				return nil, false
+			}
+			v = items.Index(part.index).Interface()
+		}
+	}
+	return v, true
+}
+
+// FormatValue renders a value returned by Value for `config get`: scalars as-is,
+// and lists and tables as TOML, with a list of blocks printed as [[name]] tables.
+func FormatValue(key string, value any) (string, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+	case reflect.Slice:
+		blocks := v.Len() > 0 && reflect.ValueOf(v.Index(0).Interface()).Kind() == reflect.Map
+		if !blocks {
+			enc, err := toml.Marshal(map[string]any{"v": value})
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(strings.TrimPrefix(string(enc), "v = ")), nil
+		}
+		name := key[strings.LastIndexByte(key, '.')+1:]
+		value = map[string]any{name: value}
+	default:
+		return fmt.Sprint(value), nil
+	}
+	enc, err := toml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(enc), "\n"), nil
+}
+
+// SetValue assigns a config key in the most specific config file (a project's
+// lstk.toml when there is one), editing only that value so the file's comments and
+// formatting are kept. value is taken as a string for string keys, and as a TOML
+// array (e.g. ["debug", "ci"]) for list keys. A missing key or table is added; an
+// indexed block such as containers[2] must already exist in that file. The change
+// is refused if it makes the config invalid, and the config is reloaded.
+func SetValue(key, value string) error {
+	t, err := keyType(key)
+	if err != nil {
+		return err
+	}
+	var typed any
+	switch {
+	case t.Kind() == reflect.String:
+		typed = value
+	case t.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s is a boolean; write true or false", key)
+		}
+		typed = b
+	case t.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s is a number; write an integer, e.g. 3", key)
+		}
+		typed = n
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String:
+		var parsed struct {
+			V []any `toml:"v"`
+		}
+		if err := toml.Unmarshal([]byte("v = "+value), &parsed); err != nil {
+			return fmt.Errorf("%s is a list; write the value as a TOML array, e.g. [\"a\", \"b\"]", key)
+		}
+		typed = parsed.V
+	case t.Kind() == reflect.Slice:
+		return fmt.Errorf("%s is a list of blocks; set a key of one block instead, e.g. %s[0].<key>", key, key)
+	default:
+		return fmt.Errorf("%s is a table; set one of its keys instead, e.g. %s.<key>", key, key)
+	}
+
+	sources, err := Sources()
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 || resolvedConfigPath() == "" {
+		return fmt.Errorf("no config file loaded")
+	}
+	files, err := readConfigFiles(sources)
+	if err != nil {
+		return err
+	}
+	before, err := validateFiles(files)
+	if err != nil {
+		return err
+	}
+	doc, err := parseTOMLDocument(files[0].data)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	encoded, err := encodeTOMLValue(typed)
+	if err != nil {
+		return err
+	}
+	content, err := doc.set(key, encoded)
+	if err != nil {
+		return err
+	}
+	edited := []configFile{{path: files[0].path, data: content}}
+	for _, f := range files[1:] {
+		edited = append(edited, configFile{path: f.path, data: f.data})
+	}
+	after, err := validateFiles(edited)
+	if err != nil {
+		return err
+	}
+	if introduced := newProblems(before, after); len(introduced) > 0 {
+		return fmt.Errorf("setting %s would make the config invalid: %s", key, introduced[0].Message)
+	}
+	// A trusted project file stays trusted through lstk's own edit of it.
+	retrust := false
+	if sources[0].Scope == SourceProject {
+		if retrust, err = projectTrusted(files[0].path); err != nil {
+			return err
+		}
+	}
+	if err := os.WriteFile(files[0].path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if retrust {
+		if err := trustPath(files[0].path); err != nil {
+			return err
+		}
+	}
+	return reload()
+}
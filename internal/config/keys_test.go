@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadConfigFile writes content to a config file and loads it. Cannot run in
+// parallel: mutates process-wide viper state.
+func loadConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	t.Cleanup(viper.Reset)
+	require.NoError(t, loadConfig(path))
+	return path
+}
+
+const twoBlockConfig = `# lstk configuration file
+
+[[containers]]
+type = "aws"     # Emulator type
+tag  = "latest"  # Docker image tag
+port = "4566"
+
+[[containers]]
+type = "snowflake"
+port = "4567"
+`
+
+func TestSetValue_IndexedKeyKeepsCommentsAndFormatting(t *testing.T) {
+	path := loadConfigFile(t, twoBlockConfig)
+
+	require.NoError(t, SetValue("containers[0].tag", "2026.4"))
+	require.NoError(t, SetValue("containers[1].tag", "latest"))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `# lstk configuration file
+
+[[containers]]
+type = "aws"     # Emulator type
+tag  = '2026.4'  # Docker image tag
+port = "4566"
+
+[[containers]]
+type = "snowflake"
+port = "4567"
+tag = 'latest'
+`, string(got))
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.Equal(t, "2026.4", cfg.Containers[0].Tag)
+}
+
+func TestSetValue_AddsMissingKeysAndTables(t *testing.T) {
+	path := loadConfigFile(t, twoBlockConfig)
+
+	require.NoError(t, SetValue("runtime", "podman"))
+	require.NoError(t, SetValue("env.debug.DEBUG", "1"))
+	require.NoError(t, SetValue("containers[0].env", `["debug"]`))
+	require.NoError(t, SetValue("containers[1].expose_ports", `[53, "5354:5353/udp"]`))
+	require.NoError(t, SetValue("containers[0].autosave", "true"))
+	require.NoError(t, SetValue("containers[0].autosave_keep", "5"))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "# lstk configuration file\n\nruntime = 'podman'\n\n[[containers]]")
+	assert.Contains(t, string(got), "[env.debug]\nDEBUG = '1'\n")
+	assert.Contains(t, string(got), "autosave = true\nautosave_keep = 5\n")
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.Equal(t, "podman", cfg.Runtime)
+	assert.Equal(t, []string{"debug"}, cfg.Containers[0].Env)
+	assert.Equal(t, []string{"53", "5354:5353/udp"}, cfg.Containers[1].ExposePorts)
+	assert.True(t, cfg.Containers[0].Autosave)
+	assert.Equal(t, 5, cfg.Containers[0].AutosaveKeep)
+	assert.Equal(t, map[string]string{"debug": "1"}, cfg.Env["debug"])
+}
+
+func TestSetValue_Rejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{name: "unknown key", key: "containers[0].colour", value: "blue", wantErr: `unknown config key "containers[0].colour"`},
+		{name: "missing block", key: "containers[2].tag", value: "latest", wantErr: "containers[2] is not defined in the config file"},
+		{name: "list of blocks", key: "containers", value: "[]", wantErr: "containers is a list of blocks"},
+		{name: "table", key: "containers[0]", value: "x", wantErr: "containers[0] is a table"},
+		{name: "list without brackets", key: "containers[0].volumes", value: "./data:/data", wantErr: "write the value as a TOML array"},
+		{name: "invalid result", key: "containers[1].port", value: "4566", wantErr: "setting containers[1].port would make the config invalid: [[containers]] blocks 1 and 2 both use port 4566"},
+		{name: "not a boolean", key: "containers[0].autosave", value: "yes", wantErr: "containers[0].autosave is a boolean"},
+		{name: "not a number", key: "containers[0].autosave_keep", value: "three", wantErr: "containers[0].autosave_keep is a number"},
+		{name: "autosave off AWS", key: "containers[1].autosave", value: "true", wantErr: "autosave is only supported for the AWS emulator"},
+		{name: "negative autosave_keep", key: "containers[0].autosave_keep", value: "-1", wantErr: "autosave_keep must be zero or more, got -1"},
+		{name: "bad index", key: "containers[x].tag", value: "latest", wantErr: "is not a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := loadConfigFile(t, twoBlockConfig)
+
+			err := SetValue(tt.key, tt.value)
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			got, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, twoBlockConfig, string(got), "a refused change must leave the file untouched")
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	loadConfigFile(t, twoBlockConfig+`
+[env.debug]
+DEBUG = "1"
+`)
+
+	v, err := Value("containers[1].port")
+	require.NoError(t, err)
+	assert.Equal(t, "4567", v)
+
+	v, err = Value("env.debug.DEBUG")
+	require.NoError(t, err)
+	assert.Equal(t, "1", v)
+
+	_, err = Value("containers[1].tag")
+	assert.EqualError(t, err, "containers[1].tag is not set")
+
+	_, err = Value("containers[0].colour")
+	assert.EqualError(t, err, `unknown config key "containers[0].colour"`)
+}
+
+func TestValue_KeepsEnvKeyCase(t *testing.T) {
+	loadConfigFile(t, twoBlockConfig+`
+[env.debug]
+DEBUG = "1"
+Ls_Log = "trace"
+`)
+
+	v, err := Value("env.debug")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"DEBUG": "1", "Ls_Log": "trace"}, v)
+	formatted, err := FormatValue("env.debug", v)
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG = '1'\nLs_Log = 'trace'", formatted)
+
+	v, err = Value("env.debug.Ls_Log")
+	require.NoError(t, err)
+	assert.Equal(t, "trace", v)
+
+	v, err = Value("env.debug.ls_log")
+	require.NoError(t, err)
+	assert.Equal(t, "trace", v, "keys match in any case, as before")
+}
+
+func TestFormatValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		key   string
+		value any
+		want  string
+	}{
+		{name: "scalar", key: "containers[0].port", value: "4566", want: "4566"},
+		{name: "list", key: "containers[0].env", value: []any{"debug", "ci"}, want: "['debug', 'ci']"},
+		{name: "table", key: "env.debug", value: map[string]any{"DEBUG": "1"}, want: "DEBUG = '1'"},
+		{name: "blocks", key: "containers", value: []any{map[string]any{"type": "aws"}}, want: "[[containers]]\ntype = 'aws'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := FormatValue(tt.key, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,10 +8,11 @@ import (
 )
 
 const (
-	localConfigDir = ".lstk"
-	configName     = "config"
-	configType     = "toml"
-	configFileName = configName + "." + configType
+	localConfigDir        = ".lstk"
+	projectConfigFileName = "lstk.toml"
+	configName            = "config"
+	configType            = "toml"
+	configFileName        = configName + "." + configType
 )
 
 func ConfigFilePath() (string, error) {
@@ -23,17 +24,13 @@ func ConfigFilePath() (string, error) {
 		return absResolved, nil
 	}
 
-	existingPath, found, err := firstExistingConfigPath()
+	// Side-effect-free resolution for commands that skip Init (e.g. `lstk config path`).
+	sources, err := discoverSources()
 	if err != nil {
 		return "", err
 	}
-	if found {
-		// Side-effect-free resolution for commands that skip Init (e.g. `lstk config path`).
-		absPath, err := filepath.Abs(existingPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to resolve absolute config path: %w", err)
-		}
-		return absPath, nil
+	if len(sources) > 0 {
+		return sources[0].Path, nil
 	}
 
 	creationDir, err := configCreationDir()
@@ -116,9 +113,9 @@ func osConfigDir() (string, error) {
 	return filepath.Join(configHome, "lstk"), nil
 }
 
-// configSearchDirs returns directories to search for config.toml, in priority order:
-// project-local (.lstk/), XDG-style home config, OS-specific fallback.
-func configSearchDirs() ([]string, error) {
+// globalConfigDirs returns the directories searched for the user's global
+// config.toml, in priority order: XDG-style home config, OS-specific fallback.
+func globalConfigDirs() ([]string, error) {
 	xdgDir, err := xdgConfigDir()
 	if err != nil {
 		return nil, err
@@ -129,11 +126,7 @@ func configSearchDirs() ([]string, error) {
 		return nil, err
 	}
 
-	return []string{
-		filepath.Join(".", localConfigDir),
-		xdgDir,
-		osDir,
-	}, nil
+	return []string{xdgDir, osDir}, nil
 }
 
 func configCreationDir() (string, error) {
@@ -167,20 +160,109 @@ func LicenseFilePath() (string, error) {
 	return filepath.Join(cacheDir, "lstk", "license.json"), nil
 }
 
-func firstExistingConfigPath() (string, bool, error) {
-	dirs, err := configSearchDirs()
+// SessionLogDir returns the directory where lstk saves the logs of the named
+// emulator container, one file per session, so they outlive the container.
+func SessionLogDir(containerName string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
 	if err != nil {
-		return "", false, err
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "lstk", "logs", containerName), nil
+}
+
+// SnapshotLibraryDir returns the directory of the local snapshot library, where
+// `lstk snapshot save local:NAME` keeps its entries. Snapshots are data rather
+// than config, so it follows XDG_DATA_HOME, defaulting to ~/.local/share.
+func SnapshotLibraryDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "lstk", "snapshots"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
 	}
+	return filepath.Join(homeDir, ".local", "share", "lstk", "snapshots"), nil
+}
 
+// discoverSources finds the config files Load layers, most specific first: the
+// nearest project file, then the global config.toml. Both are optional.
+func discoverSources() ([]Source, error) {
+	var sources []Source
+	project, found, err := findProjectConfig()
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		sources = append(sources, Source{Path: project, Scope: SourceProject})
+	}
+	global, found, err := findGlobalConfig()
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		sources = append(sources, Source{Path: global, Scope: SourceGlobal})
+	}
+	return sources, nil
+}
+
+// findProjectConfig walks up from the working directory to the nearest directory
+// holding an lstk.toml or a .lstk/config.toml (lstk.toml wins if both exist). The
+// walk ends at the home directory, once it has been checked: ~/.lstk/config.toml
+// is where a config used from home was read before project configs existed, and
+// nothing above home belongs to the user.
+func findProjectConfig() (string, bool, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	home, _ := os.UserHomeDir()
+	for {
+		for _, candidate := range []string{
+			filepath.Join(dir, projectConfigFileName),
+			filepath.Join(dir, localConfigDir, configFileName),
+		} {
+			if found, err := fileExists(candidate); err != nil || found {
+				return candidate, found, err
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || (home != "" && dir == filepath.Clean(home)) {
+			return "", false, nil
+		}
+		dir = parent
+	}
+}
+
+// findGlobalConfig returns the first config.toml found in globalConfigDirs. A
+// config.yaml left there by an old lstk version is reported rather than ignored.
+func findGlobalConfig() (string, bool, error) {
+	dirs, err := globalConfigDirs()
+	if err != nil {
+		return "", false, err
+	}
 	for _, dir := range dirs {
 		path := filepath.Join(dir, configFileName)
-		if _, err := os.Stat(path); err == nil {
-			return path, true, nil
-		} else if !os.IsNotExist(err) {
-			return "", false, fmt.Errorf("failed to inspect config path %s: %w", path, err)
+		if found, err := fileExists(path); err != nil || found {
+			abs, absErr := filepath.Abs(path)
+			if absErr != nil {
+				return "", false, fmt.Errorf("failed to resolve absolute config path: %w", absErr)
+			}
+			return abs, found, err
+		}
+		for _, legacy := range []string{"config.yaml", "config.yml"} {
+			if found, _ := fileExists(filepath.Join(dir, legacy)); found {
+				return "", false, fmt.Errorf("%s is from an old lstk version; lstk now uses TOML format — remove it or replace it with a config.toml file", filepath.Join(dir, legacy))
+			}
 		}
 	}
-
 	return "", false, nil
 }
+
+func fileExists(path string) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return true, nil
+	} else if !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to inspect config path %s: %w", path, err)
+	}
+	return false, nil
+}
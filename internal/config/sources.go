@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Source scopes, as reported by Source.Scope.
+const (
+	// SourceProject is an lstk.toml or .lstk/config.toml found by walking up from
+	// the working directory. It is layered over the global config.
+	SourceProject = "project"
+	// SourceGlobal is the user's config.toml in the lstk config directory.
+	SourceGlobal = "global"
+	// SourceExplicit is the file given with --config, which is used on its own.
+	SourceExplicit = "explicit"
+)
+
+// Source is one config file behind the loaded config.
+type Source struct {
+	Path  string
+	Scope string
+}
+
+// Sources lists the config files behind the loaded config, most specific first:
+// the project file, then the global config, or just the --config file. Before a
+// config is loaded it reports the files Load would use, without side effects.
+//
+// Viper only records the most specific file, so the layering is rediscovered: the
+// discovered files are the loaded ones when the first of them is viper's config
+// file, and any other file in use was named with --config.
+func Sources() ([]Source, error) {
+	discovered, err := discoverSources()
+	used := resolvedConfigPath()
+	if used == "" {
+		return discovered, err
+	}
+	if err == nil && len(discovered) > 0 && discovered[0].Path == used {
+		return discovered, nil
+	}
+	return []Source{{Path: used, Scope: SourceExplicit}}, nil
+}
+
+// loadSources reads sources (most specific first) into viper. A single file is
+// read as-is. Several are merged, the more specific file winning: tables such as
+// [env.*] merge key by key, while a file's [[containers]] replace those of the
+// files below it, so a project runs the emulators it declares and no others. Writes
+// (config set, emulator selection) go to the most specific file, which is what
+// viper reports as the config file in use and what relative paths resolve against.
+func loadSources(sources []Source) error {
+	viper.Reset()
+	setDefaults()
+	viper.SetConfigType(configType)
+	// Set up front so a file that fails to parse can still be reported (e.g. by
+	// `lstk config validate`) alongside the others.
+	viper.SetConfigFile(sources[0].Path)
+	if len(sources) == 1 {
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+		return nil
+	}
+
+	merged := map[string]any{}
+	for i := len(sources) - 1; i >= 0; i-- {
+		v := viper.New()
+		v.SetConfigType(configType)
+		v.SetConfigFile(sources[i].Path)
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", sources[i].Path, err)
+		}
+		merged = mergeSettings(merged, v.AllSettings())
+	}
+	if err := viper.MergeConfigMap(merged); err != nil {
+		return fmt.Errorf("failed to merge config files: %w", err)
+	}
+	return nil
+}
+
+// reload re-reads the loaded config files, e.g. after one of them was edited.
+func reload() error {
+	sources, err := Sources()
+	if err != nil {
+		return err
+	}
+	return loadSources(sources)
+}
+
+// userConfigPath is the file user-level state such as cli.* is written to: the
+// global config when one is loaded, else the config file in use.
+func userConfigPath() string {
+	sources, err := Sources()
+	if err != nil {
+		return resolvedConfigPath()
+	}
+	for _, s := range sources {
+		if s.Scope == SourceGlobal {
+			return s.Path
+		}
+	}
+	return resolvedConfigPath()
+}
+
+// mergeSettings merges over into base: nested tables recursively, and any other
+// value, [[containers]] included, replaced wholesale.
+func mergeSettings(base, over map[string]any) map[string]any {
+	out := make(map[string]any, len(base)+len(over))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range over {
+		baseMap, baseOK := out[k].(map[string]any)
+		overMap, overOK := v.(map[string]any)
+		if baseOK && overOK {
+			out[k] = mergeSettings(baseMap, overMap)
+		} else {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// containersFile returns the config file the loaded [[containers]] come from,
+// or false when they are lstk's defaults. With a project file layered over the
+// global config, that is the global config when the project declares no
+// containers of its own.
+func containersFile() (Source, bool, error) {
+	sources, err := Sources()
+	if err != nil || len(sources) == 0 {
+		return Source{}, false, nil
+	}
+	if len(sources) == 1 {
+		return sources[0], true, nil
+	}
+	settings := make([]map[string]any, len(sources))
+	for i, s := range sources {
+		v := viper.New()
+		v.SetConfigType(configType)
+		v.SetConfigFile(s.Path)
+		if err := v.ReadInConfig(); err != nil {
+			return Source{}, false, fmt.Errorf("failed to read config file %s: %w", s.Path, err)
+		}
+		settings[i] = v.AllSettings()
+	}
+	if i := containersSource(settings); i >= 0 {
+		return sources[i], true, nil
+	}
+	return Source{}, false, nil
+}
+
+// containersSource returns the index of the file the merged [[containers]] come
+// from: the most specific one that declares any, or -1.
+func containersSource(settings []map[string]any) int {
+	for i, s := range settings {
+		if _, ok := s["containers"]; ok {
+			return i
+		}
+	}
+	return -1
+}
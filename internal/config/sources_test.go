@@ -0,0 +1,210 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectSetup writes a global config.toml under a fake HOME and a project
+// directory holding lstk.toml, and changes into a subdirectory of the project.
+// Cannot run in parallel: mutates process-wide cwd, HOME env and viper state.
+func projectSetup(t *testing.T, global, project string) (globalPath, projectPath string) {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	home := filepath.Join(root, "home")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	globalPath = filepath.Join(home, ".config", "lstk", configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(globalPath), 0755))
+	require.NoError(t, os.WriteFile(globalPath, []byte(global), 0644))
+
+	projectDir := filepath.Join(root, "work", "app")
+	projectPath = filepath.Join(projectDir, projectConfigFileName)
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "src"), 0755))
+	require.NoError(t, os.WriteFile(projectPath, []byte(project), 0644))
+	t.Chdir(filepath.Join(projectDir, "src"))
+	return globalPath, projectPath
+}
+
+func TestLoad_LayersProjectConfigOverGlobal(t *testing.T) {
+	globalPath, projectPath := projectSetup(t, `runtime = "docker"
+
+[[containers]]
+type = "aws"
+tag = "latest"
+port = "4566"
+env = ["debug"]
+
+[[containers]]
+type = "snowflake"
+port = "4567"
+
+[env.debug]
+DEBUG = "1"
+LS_LOG = "info"
+`, `[[containers]]
+type = "aws"
+tag = "2026.4"
+port = "4566"
+env = ["debug"]
+
+[[containers]]
+type = "azure"
+port = "4568"
+
+[env.debug]
+LS_LOG = "trace"
+`)
+
+	firstRun, err := Load()
+	require.NoError(t, err)
+	assert.False(t, firstRun)
+
+	sources, err := Sources()
+	require.NoError(t, err)
+	assert.Equal(t, []Source{{Path: projectPath, Scope: SourceProject}, {Path: globalPath, Scope: SourceGlobal}}, sources)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.Equal(t, "docker", cfg.Runtime)
+	// The project's [[containers]] replace the global ones: the global
+	// Snowflake emulator does not start in this project.
+	require.Len(t, cfg.Containers, 2)
+	assert.Equal(t, EmulatorAWS, cfg.Containers[0].Type)
+	assert.Equal(t, "2026.4", cfg.Containers[0].Tag)
+	assert.Equal(t, "4566", cfg.Containers[0].Port)
+	assert.Equal(t, []string{"debug"}, cfg.Containers[0].Env)
+	assert.Equal(t, EmulatorAzure, cfg.Containers[1].Type)
+	assert.Equal(t, map[string]string{"debug": "1", "ls_log": "trace"}, cfg.Env["debug"])
+}
+
+func TestSources_ReportsConfigFlagFileAlone(t *testing.T) {
+	_, projectPath := projectSetup(t, "", "")
+	explicit := filepath.Join(filepath.Dir(projectPath), "other.toml")
+	require.NoError(t, os.WriteFile(explicit, []byte("[[containers]]\ntype = \"aws\"\nport = \"4566\"\n"), 0644))
+
+	require.NoError(t, InitFromPath(explicit))
+
+	sources, err := Sources()
+	require.NoError(t, err)
+	assert.Equal(t, []Source{{Path: explicit, Scope: SourceExplicit}}, sources)
+}
+
+func TestLoad_ProjectConfigInDotLstkDir(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	projectPath := filepath.Join(root, "app", localConfigDir, configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(projectPath), 0755))
+	require.NoError(t, os.WriteFile(projectPath, []byte("[[containers]]\ntype = \"aws\"\nport = \"4566\"\n"), 0644))
+	t.Chdir(filepath.Join(root, "app"))
+
+	firstRun, err := Load()
+	require.NoError(t, err)
+	assert.False(t, firstRun)
+	sources, err := Sources()
+	require.NoError(t, err)
+	assert.Equal(t, []Source{{Path: projectPath, Scope: SourceProject}}, sources)
+}
+
+func TestSetValue_WritesToProjectConfigAndSetToGlobal(t *testing.T) {
+	globalPath, projectPath := projectSetup(t, "[[containers]]\ntype = \"aws\"\nport = \"4566\"\n", "[[containers]]\ntype = \"aws\"\nport = \"4566\"\n")
+	_, err := Load()
+	require.NoError(t, err)
+
+	require.NoError(t, SetValue("containers[0].tag", "2026.4"))
+	require.NoError(t, Set("cli.update_skipped_version", "v1.2.3"))
+
+	project, err := os.ReadFile(projectPath)
+	require.NoError(t, err)
+	assert.Equal(t, "[[containers]]\ntype = \"aws\"\nport = \"4566\"\ntag = '2026.4'\n", string(project))
+	global, err := os.ReadFile(globalPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(global), "update_skipped_version")
+	assert.NotContains(t, string(project), "update_skipped_version")
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.Equal(t, "2026.4", cfg.Containers[0].Tag)
+	assert.Equal(t, "4566", cfg.Containers[0].Port)
+}
+
+func TestLoad_KeepsGlobalContainersWhenProjectDeclaresNone(t *testing.T) {
+	projectSetup(t, "[[containers]]\ntype = \"snowflake\"\nport = \"4567\"\n", "[env.debug]\nDEBUG = \"1\"\n")
+
+	_, err := Load()
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Len(t, cfg.Containers, 1)
+	assert.Equal(t, EmulatorSnowflake, cfg.Containers[0].Type)
+	assert.Equal(t, map[string]string{"debug": "1"}, cfg.Env["debug"])
+}
+
+func TestGet_ResolvesRelativePathsAgainstTheFileDeclaringTheContainers(t *testing.T) {
+	globalPath, _ := projectSetup(t, `[[containers]]
+type = "aws"
+port = "4566"
+volumes = ["./data:/var/lib/localstack"]
+
+[[containers.init]]
+shell = "./seed.sh"
+`, "[env.debug]\nDEBUG = \"1\"\n")
+	_, err := Load()
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	require.Len(t, cfg.Containers, 1)
+	globalDir := filepath.Dir(globalPath)
+	assert.Equal(t, globalDir, cfg.Containers[0].InitDir())
+	volumeDir, err := cfg.Containers[0].VolumeDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(globalDir, "data"), volumeDir)
+}
+
+func TestLoad_ProjectConfigInHome(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	home := filepath.Join(root, "home")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, projectConfigFileName), []byte("runtime = \"podman\"\n"), 0644))
+	projectPath := filepath.Join(home, localConfigDir, configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "work"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(projectPath), 0755))
+	require.NoError(t, os.WriteFile(projectPath, []byte("[[containers]]\ntype = \"aws\"\nport = \"4566\"\n"), 0644))
+
+	for _, dir := range []string{home, filepath.Join(home, "work")} {
+		t.Chdir(dir)
+		_, err = Load()
+		require.NoError(t, err)
+		sources, err := Sources()
+		require.NoError(t, err)
+		assert.Equal(t, []Source{{Path: projectPath, Scope: SourceProject}}, sources, "~/.lstk/config.toml is found from %s", dir)
+	}
+
+	require.NoError(t, os.Remove(projectPath))
+	_, err = Load()
+	require.NoError(t, err)
+	sources, err := Sources()
+	require.NoError(t, err)
+	assert.Empty(t, sources, "the walk ends at home")
+}
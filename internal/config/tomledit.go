@@ -0,0 +1,261 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// tomlDocument indexes the tables and key/value pairs of a TOML file by their key
+// path, so a single value can be located (for error positions) or replaced in place
+// without re-serialising the file, keeping the user's comments and formatting.
+//
+// Key paths use the same form as `lstk config get/set`: dotted keys, with an index
+// selecting one block of an array of tables, e.g. "containers[1].init[0].shell".
+type tomlDocument struct {
+	data    []byte
+	tables  []tomlTable // tables[0] is the root table, whose key is ""
+	entries []tomlEntry
+	// values is the decoded file, with its keys as written (viper lowercases them).
+	values map[string]any
+}
+
+type tomlTable struct {
+	key  string
+	line int
+	// start is the offset a new table would be inserted before: the start of the
+	// header line, or of the comment lines directly above it.
+	start int
+	// end is the offset just past the last line belonging to the table — its last
+	// key/value pair, or its header — where a new key is inserted. -1 for a root
+	// table without keys.
+	end int
+}
+
+type tomlEntry struct {
+	key                  string
+	line                 int
+	valueStart, valueEnd int
+}
+
+// tomlSyntaxError is a TOML error in the config file, located by its 1-based line.
+type tomlSyntaxError struct {
+	line int
+	msg  string
+}
+
+func (e *tomlSyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.msg)
+}
+
+func parseTOMLDocument(data []byte) (*tomlDocument, error) {
+	// The decoder also catches semantic errors (e.g. duplicate keys) the bare
+	// parser below accepts, so it runs first to report those with their position.
+	var probe map[string]any
+	if err := toml.Unmarshal(data, &probe); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, _ := decodeErr.Position()
+			return nil, &tomlSyntaxError{line: row, msg: decodeErr.Error()}
+		}
+		return nil, err
+	}
+
+	doc := &tomlDocument{data: data, tables: []tomlTable{{end: -1}}, values: probe}
+	arrays := map[string]int{} // array-of-tables key -> number of blocks so far
+	current := 0
+	var p unstable.Parser
+	p.Reset(data)
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			parts, first, last := keyParts(&p, expr.Key())
+			key := ""
+			for i, part := range parts {
+				key = joinKey(key, part)
+				if i == len(parts)-1 && expr.Kind == unstable.ArrayTable {
+					n := arrays[key]
+					arrays[key] = n + 1
+					key += fmt.Sprintf("[%d]", n)
+				} else if n := arrays[key]; n > 0 {
+					key += fmt.Sprintf("[%d]", n-1)
+				}
+			}
+			headerStart := doc.lineStart(first)
+			doc.tables = append(doc.tables, tomlTable{
+				key:   key,
+				line:  doc.lineOf(first),
+				start: doc.commentsAbove(headerStart),
+				end:   doc.lineEnd(last),
+			})
+			current = len(doc.tables) - 1
+		case unstable.KeyValue:
+			parts, first, last := keyParts(&p, expr.Key())
+			key := doc.tables[current].key
+			for _, part := range parts {
+				key = joinKey(key, part)
+			}
+			valueEnd := int(expr.Raw.Offset + expr.Raw.Length)
+			valueStart := last + bytes.IndexByte(data[last:], '=') + 1
+			for valueStart < valueEnd && (data[valueStart] == ' ' || data[valueStart] == '\t') {
+				valueStart++
+			}
+			doc.entries = append(doc.entries, tomlEntry{key: key, line: doc.lineOf(first), valueStart: valueStart, valueEnd: valueEnd})
+			doc.tables[current].end = doc.lineEnd(valueEnd)
+		}
+	}
+	if err := p.Error(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// keyParts returns the parts of a key and the offsets where the key starts and ends.
+func keyParts(p *unstable.Parser, it unstable.Iterator) (parts []string, start, end int) {
+	start = -1
+	for it.Next() {
+		n := it.Node()
+		parts = append(parts, string(n.Data))
+		if start < 0 {
+			start = int(n.Raw.Offset)
+		}
+		end = int(n.Raw.Offset + n.Raw.Length)
+	}
+	return parts, start, end
+}
+
+func joinKey(prefix, part string) string {
+	if prefix == "" {
+		return part
+	}
+	return prefix + "." + part
+}
+
+func (d *tomlDocument) lineOf(offset int) int {
+	return bytes.Count(d.data[:offset], []byte("\n")) + 1
+}
+
+func (d *tomlDocument) lineStart(offset int) int {
+	return bytes.LastIndexByte(d.data[:offset], '\n') + 1
+}
+
+func (d *tomlDocument) lineEnd(offset int) int {
+	if i := bytes.IndexByte(d.data[offset:], '\n'); i >= 0 {
+		return offset + i + 1
+	}
+	return len(d.data)
+}
+
+// commentsAbove moves offset, the start of a line, up over the comment lines
+// directly above it.
+func (d *tomlDocument) commentsAbove(offset int) int {
+	for offset > 0 {
+		prev := d.lineStart(offset - 1)
+		if !strings.HasPrefix(strings.TrimSpace(string(d.data[prev:offset])), "#") {
+			break
+		}
+		offset = prev
+	}
+	return offset
+}
+
+func (d *tomlDocument) entry(key string) (tomlEntry, bool) {
+	for _, e := range d.entries {
+		if strings.EqualFold(e.key, key) {
+			return e, true
+		}
+	}
+	return tomlEntry{}, false
+}
+
+func (d *tomlDocument) table(key string) (tomlTable, bool) {
+	for _, t := range d.tables {
+		if strings.EqualFold(t.key, key) {
+			return t, true
+		}
+	}
+	return tomlTable{}, false
+}
+
+// line returns the line defining key, or else the closest enclosing table or key
+// that is written in the file; 0 when there is none.
+func (d *tomlDocument) line(key string) int {
+	for key != "" {
+		if e, ok := d.entry(key); ok {
+			return e.line
+		}
+		if t, ok := d.table(key); ok {
+			return t.line
+		}
+		key, _ = splitLastKey(key)
+	}
+	return 0
+}
+
+// set returns the document's content with key assigned value, an encoded TOML
+// value. An existing value is replaced in place; a missing key is added to the end
+// of its table, which is created at the end of the file when it does not exist.
+// Blocks of an array of tables are never created: their index must exist.
+func (d *tomlDocument) set(key, value string) ([]byte, error) {
+	if e, ok := d.entry(key); ok {
+		return splice(d.data, e.valueStart, e.valueEnd, value), nil
+	}
+	tableKey, field := splitLastKey(key)
+	assignment := field + " = " + value + "\n"
+	t, ok := d.table(tableKey)
+	switch {
+	case ok && t.end >= 0:
+		if t.end > 0 && d.data[t.end-1] != '\n' {
+			assignment = "\n" + assignment
+		}
+		return splice(d.data, t.end, t.end, assignment), nil
+	case ok && len(d.tables) > 1:
+		// A root table without keys: put the key above the first table.
+		return splice(d.data, d.tables[1].start, d.tables[1].start, assignment+"\n"), nil
+	case strings.Contains(tableKey, "["):
+		return nil, fmt.Errorf("%s is not defined in the config file", tableKey)
+	}
+	if !ok {
+		assignment = "\n[" + tableKey + "]\n" + assignment
+	}
+	trimmed := len(bytes.TrimRight(d.data, "\n"))
+	if trimmed == 0 {
+		return []byte(strings.TrimPrefix(assignment, "\n")), nil
+	}
+	return splice(d.data, trimmed, len(d.data), "\n"+assignment), nil
+}
+
+func splice(data []byte, start, end int, insert string) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(insert))
+	out = append(out, data[:start]...)
+	out = append(out, insert...)
+	return append(out, data[end:]...)
+}
+
+// splitLastKey splits a key path into its parent and its last part, e.g.
+// "containers[0].tag" into "containers[0]" and "tag".
+func splitLastKey(key string) (parent, last string) {
+	i := strings.LastIndexByte(key, '.')
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// encodeTOMLValue encodes value as a TOML value, quoted the way go-toml quotes it.
+func encodeTOMLValue(value any) (string, error) {
+	type wrapper struct {
+		V any `toml:"v"`
+	}
+	enc, err := toml.Marshal(wrapper{V: value})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+	line := strings.TrimSpace(string(enc))
+	return strings.TrimSpace(line[strings.IndexByte(line, '=')+1:]), nil
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// trustFile lists the project config files the user trusted, one
+// "<sha256> <path>" line each. It is kept next to the global config, out of
+// reach of the projects themselves.
+const trustFile = "trusted_projects"
+
+// noticeFile lists the .lstk/config.toml files LegacyProjectNotice has
+// already reported, one path per line, next to trustFile.
+const noticeFile = "noticed_projects"
+
+// UntrustedProjectError is returned for what lstk only takes from a project
+// config file once the user has trusted it: init steps that run code on the
+// host and host volumes. A project file comes with a repository, so anyone who
+// can commit to it could otherwise run commands or mount files on the machine
+// of whoever runs lstk there.
+type UntrustedProjectError struct {
+	Path string
+	What string
+}
+
+func (e *UntrustedProjectError) Error() string {
+	return fmt.Sprintf("%s declares %s, which lstk only uses from a trusted project config; review the file, then run `lstk config trust`", e.Path, e.What)
+}
+
+// TrustProject records the loaded project config file, with its current
+// content, as trusted, and returns its path. Any later change to the file
+// needs trusting again.
+func TrustProject() (string, error) {
+	sources, err := Sources()
+	if err != nil {
+		return "", err
+	}
+	for _, s := range sources {
+		if s.Scope == SourceProject {
+			return s.Path, trustPath(s.Path)
+		}
+	}
+	return "", fmt.Errorf("no project config file (%s or %s) found in the working directory or a parent of it", projectConfigFileName, filepath.Join(localConfigDir, configFileName))
+}
+
+// LegacyProjectNotice returns a note for an untrusted .lstk/config.toml the
+// first time it is loaded. Such a file used to be a complete config read in
+// place of the global one; it is now a project config layered over it, whose
+// volumes and init steps are refused until trusted. ok is false for any other
+// file, and once the note has been returned for this one.
+func LegacyProjectNotice() (note string, ok bool, err error) {
+	sources, err := Sources()
+	if err != nil {
+		return "", false, err
+	}
+	var path string
+	for _, s := range sources {
+		if s.Scope == SourceProject && filepath.Base(filepath.Dir(s.Path)) == localConfigDir {
+			path = s.Path
+		}
+	}
+	if path == "" {
+		return "", false, nil
+	}
+	if trusted, err := projectTrusted(path); err != nil || trusted {
+		return "", false, err
+	}
+	dir, err := trustDir()
+	if err != nil {
+		return "", false, err
+	}
+	noticed, err := os.ReadFile(filepath.Join(dir, noticeFile))
+	if err != nil && !os.IsNotExist(err) {
+		return "", false, fmt.Errorf("failed to read noticed projects: %w", err)
+	}
+	if slices.Contains(strings.Split(string(noticed), "\n"), path) {
+		return "", false, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", false, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, noticeFile), append(noticed, path+"\n"...), 0600); err != nil {
+		return "", false, fmt.Errorf("failed to record noticed project: %w", err)
+	}
+	return fmt.Sprintf("%s is now a project config, layered over your global config. lstk only uses its volumes and init steps once you review it and run `lstk config trust`.", path), true, nil
+}
+
+func trustPath(path string) error {
+	sum, err := fileSum(path)
+	if err != nil {
+		return err
+	}
+	dir, err := trustDir()
+	if err != nil {
+		return err
+	}
+	trusted, err := readTrusted(dir)
+	if err != nil {
+		return err
+	}
+	trusted[path] = sum
+
+	var buf bytes.Buffer
+	for _, p := range slices.Sorted(maps.Keys(trusted)) {
+		fmt.Fprintf(&buf, "%s %s\n", trusted[p], p)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, trustFile), buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to record trusted project: %w", err)
+	}
+	return nil
+}
+
+// projectTrusted reports whether the project config file at path was trusted
+// with its current content.
+func projectTrusted(path string) (bool, error) {
+	sum, err := fileSum(path)
+	if err != nil {
+		return false, err
+	}
+	dir, err := trustDir()
+	if err != nil {
+		return false, err
+	}
+	trusted, err := readTrusted(dir)
+	if err != nil {
+		return false, err
+	}
+	return trusted[path] == sum, nil
+}
+
+// trustDir is the directory of the global config, or where it would be created.
+func trustDir() (string, error) {
+	sources, err := Sources()
+	if err != nil {
+		return "", err
+	}
+	for _, s := range sources {
+		if s.Scope == SourceGlobal {
+			return filepath.Dir(s.Path), nil
+		}
+	}
+	return configCreationDir()
+}
+
+func readTrusted(dir string) (map[string]string, error) {
+	trusted := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(dir, trustFile))
+	if os.IsNotExist(err) {
+		return trusted, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted projects: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if sum, path, ok := strings.Cut(scanner.Text(), " "); ok {
+			trusted[path] = sum
+		}
+	}
+	return trusted, nil
+}
+
+func fileSum(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const untrustedProject = `[[containers]]
+type = "aws"
+port = "4566"
+volumes = ["./data:/var/lib/localstack", "./hooks:/etc/hooks"]
+
+[[containers.init]]
+shell = "./seed.sh"
+`
+
+func TestGet_RefusesHostCodeFromAnUntrustedProject(t *testing.T) {
+	_, projectPath := projectSetup(t, "", untrustedProject)
+	_, err := Load()
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	c := cfg.Containers[0]
+	var untrusted *UntrustedProjectError
+	_, err = c.VolumeDir()
+	require.ErrorAs(t, err, &untrusted)
+	assert.Equal(t, projectPath, untrusted.Path)
+	_, err = c.ExtraVolumes()
+	require.ErrorAs(t, err, &untrusted)
+	err = c.CheckInitTrusted()
+	require.ErrorAs(t, err, &untrusted)
+	assert.Contains(t, err.Error(), "lstk config trust")
+
+	path, err := TrustProject()
+	require.NoError(t, err)
+	assert.Equal(t, projectPath, path)
+	cfg, err = Get()
+	require.NoError(t, err)
+	c = cfg.Containers[0]
+	_, err = c.VolumeDir()
+	assert.NoError(t, err)
+	_, err = c.ExtraVolumes()
+	assert.NoError(t, err)
+	assert.NoError(t, c.CheckInitTrusted())
+
+	// A change to the file needs trusting again.
+	require.NoError(t, os.WriteFile(projectPath, []byte(untrustedProject+"\n[[containers.init]]\nshell = \"./more.sh\"\n"), 0644))
+	_, err = Load()
+	require.NoError(t, err)
+	cfg, err = Get()
+	require.NoError(t, err)
+	assert.ErrorAs(t, cfg.Containers[0].CheckInitTrusted(), &untrusted)
+}
+
+func TestGet_RefusesAWSInitStepsFromAnUntrustedProject(t *testing.T) {
+	projectSetup(t, "", "[[containers]]\ntype = \"aws\"\nport = \"4566\"\n\n[[containers.init]]\naws = \"s3 mb s3://seed --endpoint-url https://example.com\"\n")
+	_, err := Load()
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	var untrusted *UntrustedProjectError
+	err = cfg.Containers[0].CheckInitTrusted()
+	require.ErrorAs(t, err, &untrusted)
+	assert.Equal(t, "aws init steps", untrusted.What)
+}
+
+func TestGet_TrustsContainersFromTheGlobalConfig(t *testing.T) {
+	projectSetup(t, untrustedProject, "[env.debug]\nDEBUG = \"1\"\n")
+	_, err := Load()
+	require.NoError(t, err)
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.NoError(t, cfg.Containers[0].CheckInitTrusted())
+	_, err = cfg.Containers[0].ExtraVolumes()
+	assert.NoError(t, err)
+}
+
+func TestSetValue_KeepsATrustedProjectTrusted(t *testing.T) {
+	globalPath, _ := projectSetup(t, "", untrustedProject)
+	_, err := Load()
+	require.NoError(t, err)
+	_, err = TrustProject()
+	require.NoError(t, err)
+
+	require.NoError(t, SetValue("containers[0].tag", "2026.4"))
+
+	cfg, err := Get()
+	require.NoError(t, err)
+	assert.NoError(t, cfg.Containers[0].CheckInitTrusted())
+	_, err = os.Stat(filepath.Join(filepath.Dir(globalPath), trustFile))
+	assert.NoError(t, err, "the trust is recorded next to the global config")
+}
+
+func TestTrustProject_WithoutProjectFile(t *testing.T) {
+	globalPath, projectPath := projectSetup(t, "", "")
+	require.NoError(t, os.Remove(projectPath))
+	_, err := Load()
+	require.NoError(t, err)
+
+	_, err = TrustProject()
+	assert.ErrorContains(t, err, "no project config file")
+	_, err = os.Stat(filepath.Join(filepath.Dir(globalPath), trustFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLegacyProjectNotice(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	projectPath := filepath.Join(root, "app", localConfigDir, configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(projectPath), 0755))
+	require.NoError(t, os.WriteFile(projectPath, []byte(untrustedProject), 0644))
+	t.Chdir(filepath.Join(root, "app"))
+	_, err = Load()
+	require.NoError(t, err)
+
+	note, ok, err := LegacyProjectNotice()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Contains(t, note, projectPath)
+	assert.Contains(t, note, "lstk config trust")
+
+	_, ok, err = LegacyProjectNotice()
+	require.NoError(t, err)
+	assert.False(t, ok, "the note is shown once per file")
+
+	otherPath := filepath.Join(root, "other", localConfigDir, configFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(otherPath), 0755))
+	require.NoError(t, os.WriteFile(otherPath, []byte(untrustedProject), 0644))
+	t.Chdir(filepath.Join(root, "other"))
+	_, err = Load()
+	require.NoError(t, err)
+	_, err = TrustProject()
+	require.NoError(t, err)
+	_, ok, err = LegacyProjectNotice()
+	require.NoError(t, err)
+	assert.False(t, ok, "a trusted file needs no note")
+}
+
+func TestLegacyProjectNotice_NotForLstkToml(t *testing.T) {
+	projectSetup(t, "", untrustedProject)
+	_, err := Load()
+	require.NoError(t, err)
+
+	_, ok, err := LegacyProjectNotice()
+	require.NoError(t, err)
+	assert.False(t, ok, "lstk.toml was never a complete config")
+}
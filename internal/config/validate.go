@@ -0,0 +1,281 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/spf13/viper"
+)
+
+// Problem is one issue `lstk config validate` found in a config file.
+type Problem struct {
+	Path string
+	// Line is the 1-based line the problem is on, or 0 when it is not tied to a
+	// line written in the file.
+	Line    int
+	Message string
+}
+
+// Validate checks the config made of sources (most specific first, as returned by
+// Sources) with every check Get applies, plus references to [env.*] profiles that
+// are otherwise only resolved at start time. Unlike Get it reports every problem,
+// ordered by file and line, each in the file that defines the offending key. TOML
+// syntax errors are reported on their own, since nothing past them can be checked.
+func Validate(sources []Source) ([]Problem, error) {
+	files, err := readConfigFiles(sources)
+	if err != nil {
+		return nil, err
+	}
+	return validateFiles(files)
+}
+
+// SourcesForValidation loads the config like any other command, from
+// explicitPath when --config gave one, but tolerates a file that fails to parse,
+// since reporting that is the point of validating it, and returns the files
+// behind it. It is empty when there is no config file yet.
+func SourcesForValidation(explicitPath string) ([]Source, error) {
+	var loadErr error
+	if explicitPath != "" {
+		loadErr = InitFromPath(explicitPath)
+	} else {
+		_, loadErr = Load()
+	}
+	sources, err := Sources()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sources {
+		if _, statErr := os.Stat(s.Path); statErr != nil && loadErr != nil {
+			return nil, loadErr
+		}
+	}
+	return sources, nil
+}
+
+// ReportProblems validates the config made of sources, emitting a
+// ConfigProblemsEvent with every problem found, and fails if there are any.
+func ReportProblems(sink output.Sink, sources []Source) error {
+	problems, err := Validate(sources)
+	if err != nil {
+		return err
+	}
+	event := output.ConfigProblemsEvent{Paths: make([]string, len(sources))}
+	for i, s := range sources {
+		event.Paths[i] = s.Path
+	}
+	for _, p := range problems {
+		event.Problems = append(event.Problems, output.ConfigProblem{Path: p.Path, Line: p.Line, Message: p.Message})
+	}
+	sink.Emit(event)
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("found 1 problem in the configuration")
+	default:
+		return fmt.Errorf("found %d problems in the configuration", len(problems))
+	}
+}
+
+// configFile is the content of one config source, most specific first in a slice.
+type configFile struct {
+	path     string
+	data     []byte
+	doc      *tomlDocument
+	settings map[string]any
+}
+
+func readConfigFiles(sources []Source) ([]configFile, error) {
+	files := make([]configFile, len(sources))
+	for i, s := range sources {
+		data, err := os.ReadFile(s.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		files[i] = configFile{path: s.Path, data: data}
+	}
+	return files, nil
+}
+
+func validateFiles(files []configFile) ([]Problem, error) {
+	var problems []Problem
+	for i := range files {
+		doc, err := parseTOMLDocument(files[i].data)
+		if err != nil {
+			var syntaxErr *tomlSyntaxError
+			if errors.As(err, &syntaxErr) {
+				problems = append(problems, Problem{Path: files[i].path, Line: syntaxErr.line, Message: syntaxErr.msg})
+			} else {
+				problems = append(problems, Problem{Path: files[i].path, Message: err.Error()})
+			}
+			continue
+		}
+		// A private viper instance decodes the file the way Load does (lowercased
+		// keys) without disturbing the loaded config.
+		v := viper.New()
+		v.SetConfigType(configType)
+		if err := v.ReadConfig(bytes.NewReader(files[i].data)); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", files[i].path, err)
+		}
+		files[i].doc = doc
+		files[i].settings = v.AllSettings()
+	}
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	merged := map[string]any{}
+	for i := len(files) - 1; i >= 0; i-- {
+		merged = mergeSettings(merged, files[i].settings)
+	}
+	v := viper.New()
+	if err := v.MergeConfigMap(merged); err != nil {
+		return nil, fmt.Errorf("failed to merge config files: %w", err)
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return []Problem{{Path: files[0].path, Message: err.Error()}}, nil
+	}
+
+	origins, src := blockOrigins(files)
+	if src >= 0 {
+		for i := range cfg.Containers {
+			cfg.Containers[i].configDir = filepath.Dir(files[src].path)
+		}
+	}
+	add := func(key string, err error) {
+		path, line := locateKey(files, origins, key)
+		problems = append(problems, Problem{Path: path, Line: line, Message: err.Error()})
+	}
+	for i, c := range cfg.Containers {
+		block := fmt.Sprintf("containers[%d].", i)
+		for _, fe := range c.fieldErrors() {
+			add(block+fe.key, fe.err)
+		}
+		for _, name := range c.Env {
+			if _, ok := cfg.Env[name]; !ok {
+				add(block+"env", fmt.Errorf("environment %q referenced in container config not found; define it in an [env.%s] section", name, name))
+			}
+		}
+	}
+	for _, fe := range containerSetErrors(cfg.Containers) {
+		add(fe.key, fe.err)
+	}
+	for _, fe := range namedEnvErrors(cfg.Env) {
+		add(fe.key, fe.err)
+	}
+
+	order := make(map[string]int, len(files))
+	for i, f := range files {
+		order[f.path] = i
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Path != problems[j].Path {
+			return order[problems[i].Path] < order[problems[j].Path]
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	return problems, nil
+}
+
+// blockOrigins maps each merged [[containers]] block to its index in every file
+// (-1 where the file does not define it). The blocks all come from one file,
+// whose index it returns too, or -1 when none declares any.
+func blockOrigins(files []configFile) ([][]int, int) {
+	settings := make([]map[string]any, len(files))
+	for f := range files {
+		settings[f] = files[f].settings
+	}
+	src := containersSource(settings)
+	if src < 0 {
+		return nil, -1
+	}
+	origins := make([][]int, len(toBlocks(files[src].settings["containers"])))
+	for i := range origins {
+		origins[i] = make([]int, len(files))
+		for f := range origins[i] {
+			origins[i][f] = -1
+		}
+		origins[i][src] = i
+	}
+	return origins, src
+}
+
+func toBlocks(v any) []map[string]any {
+	switch list := v.(type) {
+	case []map[string]any:
+		return list
+	case []any:
+		blocks := make([]map[string]any, 0, len(list))
+		for _, item := range list {
+			if block, ok := item.(map[string]any); ok {
+				blocks = append(blocks, block)
+			}
+		}
+		return blocks
+	}
+	return nil
+}
+
+// locateKey finds the file and line defining a key of the merged config: the most
+// specific file that writes the key itself, or else the closest enclosing table in
+// the most specific file that has one.
+func locateKey(files []configFile, origins [][]int, key string) (string, int) {
+	keys := make([]string, len(files))
+	for f := range files {
+		keys[f] = key
+		if rest, ok := strings.CutPrefix(key, "containers["); ok {
+			end := strings.IndexByte(rest, ']')
+			i, _ := strconv.Atoi(rest[:end])
+			if i >= len(origins) || origins[i][f] < 0 {
+				keys[f] = ""
+				continue
+			}
+			keys[f] = fmt.Sprintf("containers[%d]", origins[i][f]) + rest[end+1:]
+		}
+	}
+	for f, file := range files {
+		if keys[f] == "" {
+			continue
+		}
+		if e, ok := file.doc.entry(keys[f]); ok {
+			return file.path, e.line
+		}
+		if t, ok := file.doc.table(keys[f]); ok {
+			return file.path, t.line
+		}
+	}
+	for f, file := range files {
+		if keys[f] == "" {
+			continue
+		}
+		if line := file.doc.line(keys[f]); line > 0 {
+			return file.path, line
+		}
+	}
+	return files[0].path, 0
+}
+
+// newProblems returns the problems in after that were not already in before.
+func newProblems(before, after []Problem) []Problem {
+	seen := map[string]int{}
+	for _, p := range before {
+		seen[p.Message]++
+	}
+	var introduced []Problem
+	for _, p := range after {
+		if seen[p.Message] > 0 {
+			seen[p.Message]--
+			continue
+		}
+		introduced = append(introduced, p)
+	}
+	return introduced
+}
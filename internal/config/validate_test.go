@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_ReportsEveryProblemWithItsLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`[[containers]]
+type = "aws"
+port = "4566"
+env = ["debug", "missing"]
+
+[[containers]]
+type = "snowflake"
+port = "4566"
+expose_ports = ["99999"]
+
+[[containers.init]]
+shell = "./seed.sh"
+terraform = "./infra"
+
+[env.debug]
+DEBUG = "1"
+"BAD-NAME" = "x"
+`), 0644))
+
+	problems, err := Validate([]Source{{Path: path, Scope: SourceExplicit}})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{Path: path, Line: 4, Message: `environment "missing" referenced in container config not found; define it in an [env.missing] section`},
+		{Path: path, Line: 8, Message: "[[containers]] blocks 1 and 2 both use port 4566; each emulator needs its own port"},
+		{Path: path, Line: 9, Message: `invalid expose_ports entry "99999": port 99999 is out of range (must be 1–65535)`},
+		{Path: path, Line: 11, Message: "init step 1: each init step needs exactly one of shell, aws or terraform"},
+		{Path: path, Line: 17, Message: `invalid variable in [env.debug]: env key "bad-name" contains invalid characters`},
+	}, problems)
+}
+
+func TestValidate_ReportsSyntaxErrorLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(path, []byte("[[containers]]\ntype = \"aws\"\nport = 4566\"\n"), 0644))
+
+	problems, err := Validate([]Source{{Path: path, Scope: SourceExplicit}})
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, 3, problems[0].Line)
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(path, []byte(defaultConfigTemplate), 0644))
+
+	problems, err := Validate([]Source{{Path: path, Scope: SourceExplicit}})
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestValidate_ReportsProblemsInTheFileThatCausesThem(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, configFileName)
+	require.NoError(t, os.WriteFile(global, []byte(`[[containers]]
+type = "aws"
+port = "99999"
+
+[env.debug]
+"BAD-NAME" = "x"
+`), 0644))
+	project := filepath.Join(dir, projectConfigFileName)
+	require.NoError(t, os.WriteFile(project, []byte(`# project overrides
+[[containers]]
+type = "aws"
+port = "70000"
+env = ["debug"]
+`), 0644))
+
+	problems, err := Validate([]Source{{Path: project, Scope: SourceProject}, {Path: global, Scope: SourceGlobal}})
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{
+		{Path: project, Line: 4, Message: "port 70000 is out of range (must be 1–65535)"},
+		{Path: global, Line: 6, Message: `invalid variable in [env.debug]: env key "bad-name" contains invalid characters`},
+	}, problems)
+}
+
+type captureSink struct {
+	events []output.Event
+}
+
+func (s *captureSink) Emit(e output.Event) {
+	s.events = append(s.events, e)
+}
+
+func TestReportProblems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFileName)
+	require.NoError(t, os.WriteFile(path, []byte("[[containers]]\ntype = \"aws\"\nport = \"70000\"\n"), 0644))
+
+	sink := &captureSink{}
+	err := ReportProblems(sink, []Source{{Path: path, Scope: SourceExplicit}})
+	assert.EqualError(t, err, "found 1 problem in the configuration")
+	assert.Equal(t, []output.Event{output.ConfigProblemsEvent{
+		Paths:    []string{path},
+		Problems: []output.ConfigProblem{{Path: path, Line: 3, Message: "port 70000 is out of range (must be 1–65535)"}},
+	}}, sink.events)
+}
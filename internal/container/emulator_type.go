@@ -21,6 +21,8 @@ import (
 //     image is a hard error — it pins a specific product that cannot be
 //     reinterpreted under a different emulator type. A non-default tag or any
 //     volume mounts are kept with a warning, since they are often product-specific.
+//   - Several blocks: start only the block of the requested type; the file is
+//     never rewritten.
 //
 // Messages are emitted through sink; configPath is the friendly config path used
 // in those messages so a switch against a checked-in file is visible.
@@ -70,15 +72,18 @@ func ApplyEmulatorType(ctx context.Context, rt runtime.Runtime, sink output.Sink
 		return nil, output.NewSilentError(err)
 	}
 
-	// Reject a multi-block config before touching the file: only one block can
-	// start (checkSingleContainer enforces this on the start path), and rewriting
-	// one block's type while the start is doomed to fail would leave a confusing
-	// half-changed config.
-	if err := checkSingleContainer(containers); err != nil {
+	// With several blocks, --type selects one of them to start instead of
+	// rewriting a type: there is no telling which block the user meant to
+	// change, so refuse before touching the file when none matches.
+	if len(containers) > 1 {
+		if matched, err := config.FilterByType(containers, requested); err == nil {
+			return matched, nil
+		}
+		err := fmt.Errorf("found %d [[containers]] blocks in your config and none is %s; --type can only switch a config with one", len(containers), requested.ShortName())
 		sink.Emit(output.ErrorEvent{
-			Title:   "Unsupported configuration",
+			Title:   "Cannot switch emulator type",
 			Summary: err.Error(),
-			Actions: []output.ErrorAction{{Label: "Edit your config file so only one [[containers]] block is enabled:", Value: "lstk config path"}},
+			Actions: []output.ErrorAction{{Label: "Add a [[containers]] block for it, or change the type of one in your config file:", Value: "lstk config path"}},
 		})
 		return nil, output.NewSilentError(err)
 	}
@@ -96,6 +96,22 @@ func TestApplyEmulatorType_ErrorsWhenImageSet(t *testing.T) {
 // guard against a container-less config. config.Get() normally injects a default
 // container, so we pass an explicitly empty slice to reach the branch that would
 // otherwise panic on containers[0]; it must surface a clear, silent error.
+func TestApplyEmulatorType_SelectsMatchingBlockAmongSeveral(t *testing.T) {
+	content := "[[containers]]\ntype = \"aws\"\nport = \"4566\"\n\n[[containers]]\ntype = \"snowflake\"\nport = \"4567\"\n"
+	path := loadTempConfig(t, content)
+	cfg, err := config.Get()
+	require.NoError(t, err)
+
+	containers, err := ApplyEmulatorType(context.Background(), mockRuntimeNothingRunning(t), output.NewPlainSink(&bytes.Buffer{}), config.EmulatorSnowflake, cfg.Containers, false, path)
+	require.NoError(t, err)
+
+	require.Len(t, containers, 1)
+	assert.Equal(t, config.EmulatorSnowflake, containers[0].Type)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
+
 func TestApplyEmulatorType_ErrorsWhenNoContainersBlock(t *testing.T) {
 	path := loadTempConfig(t, "[[containers]]\ntype = \"aws\"\nport = \"4566\"\n")
 
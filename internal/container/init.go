@@ -0,0 +1,178 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	stdruntime "runtime"
+	"time"
+
+	"github.com/localstack/lstk/internal/awscli"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/endpoint"
+	tfcli "github.com/localstack/lstk/internal/iac/terraform/cli"
+	"github.com/localstack/lstk/internal/log"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/proc"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// Terraform init steps encode a fixed region and account into the generated
+// override, matching the defaults `lstk terraform` falls back to.
+const (
+	initTerraformRegion  = "us-east-1"
+	initTerraformAccount = "test"
+)
+
+// runInitSteps runs the [[containers.init]] steps of every emulator this start
+// brought up, block by block in config order, once all of them are healthy.
+// Emulators that were already running are skipped: they were seeded when they
+// started. The first failing step stops the sequence and fails the start; the
+// emulator itself keeps running.
+func runInitSteps(ctx context.Context, sink output.Sink, logger log.Logger, configs []config.ContainerConfig, started []runtime.ContainerConfig, localStackHost string) error {
+	startedNames := make(map[string]bool, len(started))
+	for _, c := range started {
+		startedNames[c.Name] = true
+	}
+	for _, c := range configs {
+		if len(c.Init) == 0 || !startedNames[c.Name()] {
+			continue
+		}
+		host, _ := endpoint.ResolveHost(ctx, c.Port, localStackHost)
+		endpointURL := "http://" + host
+		for i, step := range c.Init {
+			stepStart := time.Now()
+			sink.Emit(output.SpinnerStart(fmt.Sprintf("Running init step %d/%d: %s", i+1, len(c.Init), step.Label())))
+			err := runInitStep(ctx, sink, logger, c, step, endpointURL)
+			sink.Emit(output.SpinnerStop())
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return err
+				}
+				return initStepFailed(sink, c, i, step, err)
+			}
+			sink.Emit(output.InitStepEvent{
+				Type:      string(c.Type),
+				Container: c.Name(),
+				Step:      i + 1,
+				Total:     len(c.Init),
+				Label:     step.Label(),
+				Kind:      step.Kind(),
+				Duration:  time.Since(stepStart),
+			})
+		}
+	}
+	return nil
+}
+
+// runInitStep runs a single step against endpointURL, streaming the output of
+// every command it runs through sink as init log lines.
+func runInitStep(ctx context.Context, sink output.Sink, logger log.Logger, c config.ContainerConfig, step config.InitStep, endpointURL string) error {
+	out := output.NewLogLineWriter(sink, output.LogSourceInit)
+	defer out.Flush()
+
+	switch step.Kind() {
+	case config.InitKindShell:
+		name, flag := "sh", "-c"
+		if stdruntime.GOOS == "windows" {
+			name, flag = "cmd", "/C"
+		}
+		cmd := exec.CommandContext(ctx, name, flag, step.Shell)
+		cmd.Dir = c.InitDir()
+		cmd.Env = initEnv(c, endpointURL)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		return proc.Run(cmd)
+	case config.InitKindAWS:
+		cmds, err := step.AWSCommands()
+		if err != nil {
+			return err
+		}
+		for _, args := range cmds {
+			if err := awscli.Exec(ctx, awscli.ExecOptions{EndpointURL: endpointURL, Dir: c.InitDir(), NoStdin: true}, out, out, args); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		dir, err := step.TerraformDir(c.InitDir())
+		if err != nil {
+			return err
+		}
+		// Errors terraform support renders itself (e.g. a missing binary) are
+		// folded into the step's own failure instead of being shown twice.
+		tfSink := &capturingErrorSink{Sink: sink}
+		for _, args := range [][]string{
+			{"-chdir=" + dir, "init", "-input=false"},
+			{"-chdir=" + dir, "apply", "-auto-approve", "-input=false"},
+		} {
+			if err := tfcli.RunWithOutput(ctx, endpointURL, initTerraformRegion, initTerraformAccount, dir, out, out, tfSink, logger, args); err != nil {
+				if tfSink.captured != nil {
+					return &renderedStepError{event: *tfSink.captured, err: err}
+				}
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// initEnv is the environment of a shell step: the host environment plus, for
+// the AWS emulator, LocalStack credentials and AWS_ENDPOINT_URL, so the AWS CLI
+// and SDKs in the script reach the emulator without extra flags.
+func initEnv(c config.ContainerConfig, endpointURL string) []string {
+	env := os.Environ()
+	if c.Type != config.EmulatorAWS {
+		return env
+	}
+	env = awscli.BuildEnv(env, "")
+	return append(env, "AWS_ENDPOINT_URL="+endpointURL)
+}
+
+// initStepFailed reports a failed step, naming the step and its emulator, and
+// returns the error that fails the start.
+func initStepFailed(sink output.Sink, c config.ContainerConfig, i int, step config.InitStep, err error) error {
+	event := output.ErrorEvent{
+		Title:   fmt.Sprintf("Init step %d/%d failed for %s: %s", i+1, len(c.Init), c.DisplayName(), step.Label()),
+		Summary: err.Error(),
+		Actions: []output.ErrorAction{{Label: "Rerun the init steps on a fresh emulator:", Value: "lstk restart"}},
+		Code:    output.ErrInitStepFailed,
+	}
+	var rendered *renderedStepError
+	switch {
+	case errors.As(err, &rendered):
+		event.Summary = rendered.event.Title
+		event.Actions = append(rendered.event.Actions, event.Actions...)
+	case errors.Is(err, awscli.ErrNotInstalled):
+		event.Actions = append([]output.ErrorAction{{Label: "Install AWS CLI:", Value: awscli.InstallURL}}, event.Actions...)
+	}
+	sink.Emit(event)
+	return output.NewSilentError(fmt.Errorf("init step %d of %s failed: %w", i+1, c.Name(), err))
+}
+
+// capturingErrorSink forwards every event to Sink except ErrorEvents, the last
+// of which it keeps for the caller to fold into its own error.
+type capturingErrorSink struct {
+	output.Sink
+	captured *output.ErrorEvent
+}
+
+func (s *capturingErrorSink) Emit(event output.Event) {
+	if e, ok := event.(output.ErrorEvent); ok {
+		s.captured = &e
+		return
+	}
+	s.Sink.Emit(event)
+}
+
+// renderedStepError is a step failure that already came with an ErrorEvent.
+type renderedStepError struct {
+	event output.ErrorEvent
+	err   error
+}
+
+func (e *renderedStepError) Error() string { return e.err.Error() }
+
+func (e *renderedStepError) Unwrap() error { return e.err }
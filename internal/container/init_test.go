@@ -0,0 +1,161 @@
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	stdruntime "runtime"
+	"testing"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/log"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skipWithoutSh(t *testing.T) {
+	t.Helper()
+	if stdruntime.GOOS == "windows" {
+		t.Skip("shell init steps run through cmd /C on Windows")
+	}
+}
+
+// useConfigDir points the config at a file in a fresh directory, which init
+// steps run in. Cannot run in parallel: mutates process-wide viper state.
+func useConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(filepath.Join(dir, "config.toml"))
+}
+
+func initEvents(sink *recordingSink) (steps []output.InitStepEvent, lines []string, errs []output.ErrorEvent) {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	for _, e := range sink.events {
+		switch ev := e.(type) {
+		case output.InitStepEvent:
+			steps = append(steps, ev)
+		case output.LogLineEvent:
+			lines = append(lines, ev.Line)
+		case output.ErrorEvent:
+			errs = append(errs, ev)
+		}
+	}
+	return steps, lines, errs
+}
+
+func TestRunInitSteps_RunsStepsInOrderAndStreamsOutput(t *testing.T) {
+	skipWithoutSh(t)
+	useConfigDir(t)
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{
+		{Name: "endpoint", Shell: `echo "$AWS_ENDPOINT_URL"`},
+		{Shell: "echo second"},
+	}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, []runtime.ContainerConfig{{Name: c.Name()}}, "127.0.0.1:4566")
+	require.NoError(t, err)
+
+	steps, lines, errs := initEvents(sink)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"http://127.0.0.1:4566", "second"}, lines)
+	require.Len(t, steps, 2)
+	assert.Equal(t, "endpoint", steps[0].Label)
+	assert.Equal(t, "echo second", steps[1].Label)
+	assert.Equal(t, 2, steps[1].Step)
+	assert.Equal(t, 2, steps[1].Total)
+	assert.Equal(t, config.InitKindShell, steps[1].Kind)
+	assert.Equal(t, "localstack-aws", steps[1].Container)
+}
+
+func TestRunInitSteps_SkipsEmulatorsThatWereAlreadyRunning(t *testing.T) {
+	skipWithoutSh(t)
+	useConfigDir(t)
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{{Shell: "echo seeded"}}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, nil, "127.0.0.1:4566")
+	require.NoError(t, err)
+
+	steps, lines, _ := initEvents(sink)
+	assert.Empty(t, steps)
+	assert.Empty(t, lines)
+}
+
+func TestRunInitSteps_FailingStepStopsTheSequence(t *testing.T) {
+	skipWithoutSh(t)
+	useConfigDir(t)
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{
+		{Shell: "echo first"},
+		{Name: "broken", Shell: "echo oops >&2; exit 3"},
+		{Shell: "echo never"},
+	}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, []runtime.ContainerConfig{{Name: c.Name()}}, "127.0.0.1:4566")
+	require.Error(t, err)
+	assert.True(t, output.IsSilent(err))
+
+	steps, lines, errs := initEvents(sink)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, []string{"first", "oops"}, lines)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Init step 2/3 failed for LocalStack AWS Emulator: broken", errs[0].Title)
+	assert.Equal(t, "exit status 3", errs[0].Summary)
+	assert.Equal(t, output.ErrInitStepFailed, errs[0].Code)
+}
+
+func TestRunInitSteps_AWSStepRunsInTheConfigDirWithoutStdin(t *testing.T) {
+	skipWithoutSh(t)
+	useConfigDir(t)
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "aws"), []byte("#!/bin/sh\npwd\nread answer || echo no input\n"), 0o755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{{AWS: []string{"s3 cp file://seed.json s3://assets"}}}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, []runtime.ContainerConfig{{Name: c.Name()}}, "127.0.0.1:4566")
+	require.NoError(t, err)
+
+	_, lines, errs := initEvents(sink)
+	assert.Empty(t, errs)
+	dir, err := filepath.EvalSymlinks(c.InitDir())
+	require.NoError(t, err)
+	assert.Equal(t, []string{dir, "no input"}, lines)
+}
+
+func TestRunInitSteps_MissingAWSCLISuggestsInstall(t *testing.T) {
+	useConfigDir(t)
+	t.Setenv("PATH", t.TempDir())
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{{AWS: []string{"s3 mb s3://assets"}}}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, []runtime.ContainerConfig{{Name: c.Name()}}, "127.0.0.1:4566")
+	require.Error(t, err)
+
+	_, _, errs := initEvents(sink)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "aws CLI not found in PATH", errs[0].Summary)
+	require.NotEmpty(t, errs[0].Actions)
+	assert.Equal(t, "Install AWS CLI:", errs[0].Actions[0].Label)
+}
+
+func TestRunInitSteps_MissingTerraformIsReportedOnce(t *testing.T) {
+	useConfigDir(t)
+	t.Setenv("PATH", t.TempDir())
+	c := config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Init: []config.InitStep{{Terraform: t.TempDir()}}}
+	sink := &recordingSink{}
+
+	err := runInitSteps(context.Background(), sink, log.Nop(), []config.ContainerConfig{c}, []runtime.ContainerConfig{{Name: c.Name()}}, "127.0.0.1:4566")
+	require.Error(t, err)
+
+	_, _, errs := initEvents(sink)
+	require.Len(t, errs, 1)
+	assert.Equal(t, output.ErrInitStepFailed, errs[0].Code)
+	assert.Contains(t, errs[0].Summary, "not found in PATH")
+}
@@ -1,11 +1,137 @@
 package container
 
 import (
+	"fmt"
+	"path"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/localstack/lstk/internal/output"
 )
 
+// LogFilter selects which emulator log lines lstk prints.
+type LogFilter struct {
+	// Verbose disables the default filter that hides noisy internal loggers.
+	Verbose bool
+	// Level, when set, hides lines below that severity.
+	Level output.LogLevel
+	// Logger, when set, is a glob (e.g. "l.s.lambda*") the logger name must match.
+	Logger string
+	// Since and Until, when set, bound the line timestamps.
+	Since, Until time.Time
+	// Grep, when set, must match the line.
+	Grep *regexp.Regexp
+	// accept, when set, must accept the record of every line, continuation
+	// lines included. It lets views built on the log, such as Requests, make
+	// --tail count only the lines they show.
+	accept func(logRecord) bool
+}
+
+// keepsAll reports whether the filter lets every line through, in which case
+// the runtime's own --tail is already exact.
+func (f LogFilter) keepsAll() bool {
+	return f.Verbose && !f.selectsRecords() && f.Grep == nil && f.accept == nil
+}
+
+// selectsRecords reports whether the filter looks at the parsed header of a
+// log record (level, logger, timestamp) rather than only the raw line.
+func (f LogFilter) selectsRecords() bool {
+	return f.Level != output.LogLevelUnknown || f.Logger != "" || !f.Since.IsZero() || !f.Until.IsZero()
+}
+
+// ParseLogLevel parses a --level value.
+func ParseLogLevel(s string) (output.LogLevel, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return output.LogLevelDebug, nil
+	case "info":
+		return output.LogLevelInfo, nil
+	case "warn", "warning":
+		return output.LogLevelWarn, nil
+	case "error":
+		return output.LogLevelError, nil
+	}
+	return output.LogLevelUnknown, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", s)
+}
+
+// ValidateLoggerPattern reports whether pattern is a well-formed --logger glob.
+func ValidateLoggerPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid logger pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// logMatcher applies a LogFilter to the lines of one log stream. A record's
+// continuation lines (e.g. a traceback) carry no header of their own, so they
+// follow the verdict of the header line before them.
+type logMatcher struct {
+	filter LogFilter
+	keep   bool
+}
+
+func newLogMatcher(filter LogFilter) *logMatcher {
+	// Continuation lines at the very start of a stream belong to a record
+	// whose header was not read, so they only pass when no header is checked.
+	return &logMatcher{filter: filter, keep: !filter.selectsRecords()}
+}
+
+// match reports whether line passes the filter, and the record it parses to.
+func (m *logMatcher) match(line string) (logRecord, bool) {
+	if !m.filter.Verbose && shouldFilter(line) {
+		return logRecord{}, false
+	}
+	rec := parseLogRecord(line)
+	if rec.level != output.LogLevelUnknown || rec.logger != "" {
+		m.keep = m.matchesRecord(rec)
+	}
+	if !m.keep {
+		return rec, false
+	}
+	if m.filter.accept != nil && !m.filter.accept(rec) {
+		return rec, false
+	}
+	if m.filter.Grep != nil && !m.filter.Grep.MatchString(line) {
+		return rec, false
+	}
+	return rec, true
+}
+
+func (m *logMatcher) matchesRecord(rec logRecord) bool {
+	f := m.filter
+	if f.Level != output.LogLevelUnknown && rec.level < f.Level {
+		return false
+	}
+	if f.Logger != "" {
+		if ok, _ := path.Match(f.Logger, rec.logger); !ok {
+			return false
+		}
+	}
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		if rec.time.IsZero() || (!f.Since.IsZero() && rec.time.Before(f.Since)) || (!f.Until.IsZero() && rec.time.After(f.Until)) {
+			return false
+		}
+	}
+	return true
+}
+
+// logTimeLayout is the timestamp LocalStack starts each log record with. It
+// carries no zone; the emulator logs in UTC.
+const logTimeLayout = "2006-01-02T15:04:05.000"
+
+// parseLogTime extracts the timestamp a log record starts with.
+func parseLogTime(line string) (time.Time, bool) {
+	if len(line) < len(logTimeLayout) {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(logTimeLayout, line[:len(logTimeLayout)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
 func shouldFilter(line string) bool {
 	if strings.Contains(line, "Docker not available") {
 		return true
@@ -22,38 +148,57 @@ func shouldFilter(line string) bool {
 	return false
 }
 
-// Expected format: 2026-03-16T17:56:00.810  INFO --- [  MainThread] l.p.c.extensions.plugins   : message
+// logRecord is a log line split into the fields of LocalStack's log format.
+// A line that does not start a record (e.g. part of a traceback) has only
+// its message set.
+type logRecord struct {
+	time    time.Time
+	level   output.LogLevel
+	thread  string
+	logger  string
+	message string
+}
+
 func parseLogLine(line string) (output.LogLevel, string) {
+	rec := parseLogRecord(line)
+	return rec.level, rec.logger
+}
+
+// Expected format: 2026-03-16T17:56:00.810  INFO --- [  MainThread] l.p.c.extensions.plugins   : message
+func parseLogRecord(line string) logRecord {
+	rec := logRecord{message: line}
 	sepIdx := strings.Index(line, " --- [")
 	if sepIdx < 0 {
-		return output.LogLevelUnknown, ""
+		return rec
 	}
 
 	prefix := strings.TrimSpace(line[:sepIdx])
-	level := output.LogLevelUnknown
 	if spIdx := strings.LastIndex(prefix, " "); spIdx >= 0 {
 		switch prefix[spIdx+1:] {
 		case "DEBUG":
-			level = output.LogLevelDebug
+			rec.level = output.LogLevelDebug
 		case "INFO":
-			level = output.LogLevelInfo
+			rec.level = output.LogLevelInfo
 		case "WARN":
-			level = output.LogLevelWarn
+			rec.level = output.LogLevelWarn
 		case "ERROR":
-			level = output.LogLevelError
+			rec.level = output.LogLevelError
 		}
 	}
+	rec.time, _ = parseLogTime(line)
 
 	rest := line[sepIdx+6:] // skip " --- ["
 	bracketEnd := strings.Index(rest, "]")
 	if bracketEnd < 0 {
-		return level, ""
+		return rec
 	}
+	rec.thread = strings.TrimSpace(rest[:bracketEnd])
 	afterBracket := strings.TrimSpace(rest[bracketEnd+1:])
 	colonIdx := strings.Index(afterBracket, " : ")
 	if colonIdx < 0 {
-		return level, ""
+		return rec
 	}
-	logger := strings.TrimSpace(afterBracket[:colonIdx])
-	return level, logger
+	rec.logger = strings.TrimSpace(afterBracket[:colonIdx])
+	rec.message = afterBracket[colonIdx+3:]
+	return rec
 }
@@ -1,10 +1,13 @@
 package container
 
 import (
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/localstack/lstk/internal/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseLogLine(t *testing.T) {
@@ -76,6 +79,21 @@ func TestParseLogLine(t *testing.T) {
 	}
 }
 
+func TestParseLogRecord(t *testing.T) {
+	t.Parallel()
+
+	rec := parseLogRecord("2026-03-16T17:56:00.810  INFO --- [  MainThread] l.p.c.extensions.plugins   : loaded 0 extensions : done")
+	assert.Equal(t, logRecord{
+		time:    time.Date(2026, 3, 16, 17, 56, 0, 810_000_000, time.UTC),
+		level:   output.LogLevelInfo,
+		thread:  "MainThread",
+		logger:  "l.p.c.extensions.plugins",
+		message: "loaded 0 extensions : done",
+	}, rec)
+
+	assert.Equal(t, logRecord{message: "  at handler.py line 3"}, parseLogRecord("  at handler.py line 3"))
+}
+
 func TestShouldFilter(t *testing.T) {
 	t.Parallel()
 
@@ -133,3 +151,73 @@ func TestShouldFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestLogMatcher(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"2026-03-16T17:56:00.810 DEBUG --- [  MainThread] l.p.c.extensions.plugins : loading extensions",
+		"2026-03-16T17:56:43.472  INFO --- [et.reactor-0] localstack.request.aws   : AWS lambda.Invoke => 200",
+		"2026-03-16T17:57:10.002 ERROR --- [   Thread-12] l.s.lambda.invocation    : Invocation failed",
+		"Traceback (most recent call last):",
+		"  ResourceNotFoundException: function not found",
+		"2026-03-16T17:58:00.100  WARN --- [et.reactor-1] l.s.sqs.provider_utils   : queue is empty",
+	}
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   []int
+	}{
+		{name: "no query", filter: LogFilter{}, want: []int{0, 1, 2, 3, 4, 5}},
+		{name: "level keeps the traceback of a kept record", filter: LogFilter{Level: output.LogLevelWarn}, want: []int{2, 3, 4, 5}},
+		{name: "logger glob", filter: LogFilter{Logger: "l.s.lambda*"}, want: []int{2, 3, 4}},
+		{name: "logger glob dropping a record drops its traceback", filter: LogFilter{Logger: "l.s.sqs*"}, want: []int{5}},
+		{name: "since", filter: LogFilter{Since: time.Date(2026, 3, 16, 17, 57, 0, 0, time.UTC)}, want: []int{2, 3, 4, 5}},
+		{name: "until", filter: LogFilter{Until: time.Date(2026, 3, 16, 17, 57, 0, 0, time.UTC)}, want: []int{0, 1}},
+		{name: "grep matches single lines", filter: LogFilter{Grep: regexp.MustCompile("ResourceNotFound")}, want: []int{4}},
+		{name: "grep within a level", filter: LogFilter{Level: output.LogLevelError, Grep: regexp.MustCompile("(?i)lambda|function")}, want: []int{2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newLogMatcher(tt.filter)
+			var got []int
+			for i, line := range lines {
+				if _, ok := m.match(line); ok {
+					got = append(got, i)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Continuation lines at the start of a stream have no header to judge them
+// by, so a query that checks headers drops them.
+func TestLogMatcher_LeadingContinuationLines(t *testing.T) {
+	t.Parallel()
+	line := "  File \"handler.py\", line 3"
+
+	_, ok := newLogMatcher(LogFilter{}).match(line)
+	assert.True(t, ok)
+	_, ok = newLogMatcher(LogFilter{Level: output.LogLevelError}).match(line)
+	assert.False(t, ok)
+}
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]output.LogLevel{
+		"debug":   output.LogLevelDebug,
+		"INFO":    output.LogLevelInfo,
+		"warn":    output.LogLevelWarn,
+		"warning": output.LogLevelWarn,
+		"error":   output.LogLevelError,
+	} {
+		got, err := ParseLogLevel(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseLogLevel("fatal")
+	assert.ErrorContains(t, err, `invalid log level "fatal"`)
+}
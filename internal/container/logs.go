@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 
 	"github.com/localstack/lstk/internal/config"
 	"github.com/localstack/lstk/internal/output"
@@ -38,7 +39,14 @@ const backlogFetchFactor = 8
 // whole history rather than growing the request further.
 const maxBacklogFetch = 100_000
 
-func Logs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, follow bool, tail string, verbose bool) error {
+func Logs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, follow bool, tail string, filter LogFilter) error {
+	return streamLogs(ctx, rt, sink, containers, follow, tail, filter, func(e output.LogLineEvent) { sink.Emit(e) })
+}
+
+// streamLogs passes the log lines of the running emulators among containers
+// that survive filter to emit, tagged with their source and, when several
+// emulators are interleaved, their emulator. Calls to emit are serialized.
+func streamLogs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, follow bool, tail string, filter LogFilter, emit func(output.LogLineEvent)) error {
 	if err := rt.IsHealthy(ctx); err != nil {
 		rt.EmitUnhealthyError(sink, err)
 		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
@@ -48,33 +56,94 @@ func Logs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers
 		return fmt.Errorf("no containers configured")
 	}
 
-	// TODO: handle logs per container
-	c := containers[0]
+	type runningEmulator struct {
+		name   string
+		prefix string // names the emulator on each line when several are interleaved
+	}
+	var running []runningEmulator
+	for _, c := range containers {
+		name, err := ResolveRunningContainerName(ctx, rt, c)
+		if err != nil {
+			return fmt.Errorf("checking %s running: %w", c.Name(), err)
+		}
+		if name == "" {
+			if len(containers) > 1 {
+				sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: fmt.Sprintf("%s is not running", c.DisplayName())})
+				continue
+			}
+			return HandleNoRunningContainer(sink, c)
+		}
+		prefix := string(c.Type)
+		if c.Instance != "" {
+			prefix = c.Instance
+		}
+		running = append(running, runningEmulator{name: name, prefix: prefix})
+	}
+	if len(running) == 0 {
+		return handleNoRunningEmulators(sink)
+	}
 
-	name, err := ResolveRunningContainerName(ctx, rt, c)
-	if err != nil {
-		return fmt.Errorf("checking %s running: %w", c.Name(), err)
+	if len(running) == 1 {
+		r := running[0]
+		emitLine := func(e output.LogLineEvent) {
+			e.Source = output.LogSourceEmulator
+			emit(e)
+		}
+		return containerLogs(ctx, rt, r.name, follow, tail, filter, emitLine)
 	}
-	if name == "" {
-		return HandleNoRunningContainer(sink, c)
+
+	// Several emulators: tag every line with its emulator and serialize emits,
+	// since the followers below run concurrently and sinks (the TUI's log
+	// printer in particular) must not be called from two goroutines at once.
+	var mu sync.Mutex
+	emitFor := func(prefix string) func(output.LogLineEvent) {
+		return func(e output.LogLineEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			e.Source, e.Emulator = output.LogSourceEmulator, prefix
+			emit(e)
+		}
 	}
 
-	emit := func(line string, level output.LogLevel) {
-		sink.Emit(output.LogLineEvent{Source: output.LogSourceEmulator, Line: line, Level: level})
+	// Print each emulator's backlog as one block, then follow them all.
+	for _, r := range running {
+		if err := containerLogs(ctx, rt, r.name, false, tail, filter, emitFor(r.prefix)); err != nil {
+			return err
+		}
+	}
+	if !follow {
+		return nil
+	}
+	errCh := make(chan error, len(running))
+	for _, r := range running {
+		go func() {
+			_, err := forEachLogLine(ctx, rt, r.name, true, "0", filter, emitFor(r.prefix))
+			errCh <- err
+		}()
 	}
+	var firstErr error
+	for range running {
+		if err := <-errCh; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
 
+// containerLogs prints the logs of the named container through emit.
+func containerLogs(ctx context.Context, rt runtime.Runtime, name string, follow bool, tail string, filter LogFilter, emit func(output.LogLineEvent)) error {
 	// A --tail limit counts the lines lstk prints, not the raw container lines.
 	// Letting the runtime apply the limit would count lines that the filter
 	// then drops, so `--tail 1` prints nothing whenever the newest raw line
-	// happens to be a filtered one. Verbose mode prints every line, so there
-	// the runtime's own tail is already exact (and far cheaper).
+	// happens to be a filtered one. Verbose mode without a query prints every
+	// line, so there the runtime's own tail is already exact (and far cheaper).
 	limit, hasLimit := parseTailLimit(tail)
-	if !hasLimit || verbose {
-		_, err := forEachLogLine(ctx, rt, name, follow, tail, verbose, emit)
+	if !hasLimit || filter.keepsAll() {
+		_, err := forEachLogLine(ctx, rt, name, follow, tail, filter, emit)
 		return err
 	}
 
-	if err := emitFilteredBacklog(ctx, rt, name, limit, emit); err != nil {
+	if err := emitFilteredBacklog(ctx, rt, name, limit, filter, emit); err != nil {
 		return err
 	}
 	if !follow {
@@ -82,7 +151,7 @@ func Logs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers
 	}
 	// The backlog is already printed, so stream only what arrives from here on.
 	// Lines written in the gap between the two calls are not shown.
-	_, err = forEachLogLine(ctx, rt, name, true, "0", verbose, emit)
+	_, err := forEachLogLine(ctx, rt, name, true, "0", filter, emit)
 	return err
 }
 
@@ -99,7 +168,7 @@ func parseTailLimit(tail string) (int, bool) {
 }
 
 // emitFilteredBacklog emits the last limit lines that survive filtering.
-func emitFilteredBacklog(ctx context.Context, rt runtime.Runtime, name string, limit int, emit func(string, output.LogLevel)) error {
+func emitFilteredBacklog(ctx context.Context, rt runtime.Runtime, name string, limit int, filter LogFilter, emit func(output.LogLineEvent)) error {
 	if limit == 0 {
 		return nil
 	}
@@ -117,7 +186,7 @@ func emitFilteredBacklog(ctx context.Context, rt runtime.Runtime, name string, l
 		}
 
 		ring.reset()
-		raw, err := forEachLogLine(ctx, rt, name, false, tailArg, false, ring.add)
+		raw, err := forEachLogLine(ctx, rt, name, false, tailArg, filter, ring.add)
 		if err != nil {
 			return err
 		}
@@ -132,7 +201,7 @@ func emitFilteredBacklog(ctx context.Context, rt runtime.Runtime, name string, l
 
 // forEachLogLine streams the container's logs, calling fn for every line that
 // survives filtering, and reports how many raw lines it read.
-func forEachLogLine(ctx context.Context, rt runtime.Runtime, name string, follow bool, tail string, verbose bool, fn func(string, output.LogLevel)) (int, error) {
+func forEachLogLine(ctx context.Context, rt runtime.Runtime, name string, follow bool, tail string, filter LogFilter, fn func(output.LogLineEvent)) (int, error) {
 	pr, pw := io.Pipe()
 	errCh := make(chan error, 1)
 	go func() {
@@ -148,18 +217,38 @@ func forEachLogLine(ctx context.Context, rt runtime.Runtime, name string, follow
 		err = rt.StreamLogs(ctx, name, pw, follow, tail)
 	}()
 
+	raw, err := scanLogLines(ctx, pr, filter, fn)
+	if err != nil {
+		return raw, err
+	}
+	return raw, <-errCh
+}
+
+// scanLogLines reads log lines from r, calling fn for every line that survives
+// filtering, and reports how many lines it read. A read cut short by ctx being
+// canceled is not an error.
+func scanLogLines(ctx context.Context, r io.Reader, filter LogFilter, fn func(output.LogLineEvent)) (int, error) {
 	raw := 0
-	reader := bufio.NewReaderSize(pr, logReaderBufferSize)
+	matcher := newLogMatcher(filter)
+	reader := bufio.NewReaderSize(r, logReaderBufferSize)
 	for {
 		line, truncated, ok, err := readBoundedLine(reader, maxLogLineBytes)
 		if ok {
 			raw++
-			if verbose || !shouldFilter(line) {
-				level, _ := parseLogLine(line)
+			if rec, ok := matcher.match(line); ok {
+				e := output.LogLineEvent{
+					Line:      line,
+					Level:     rec.level,
+					Time:      rec.time,
+					Thread:    rec.thread,
+					Logger:    rec.logger,
+					Message:   rec.message,
+					Truncated: truncated > 0,
+				}
 				if truncated > 0 {
-					line = fmt.Sprintf("%s … (%d more bytes truncated)", line, truncated)
+					e.Line = fmt.Sprintf("%s … (%d more bytes truncated)", line, truncated)
 				}
-				fn(line, level)
+				fn(e)
 			}
 		}
 		if err != nil {
@@ -172,28 +261,23 @@ func forEachLogLine(ctx context.Context, rt runtime.Runtime, name string, follow
 			return raw, err
 		}
 	}
-	return raw, <-errCh
+	return raw, nil
 }
 
 // lineRing keeps the most recent n log lines, overwriting the oldest.
 type lineRing struct {
-	buf  []logLine
+	buf  []output.LogLineEvent
 	next int
 	full bool
 }
 
-type logLine struct {
-	text  string
-	level output.LogLevel
-}
-
-func newLineRing(n int) *lineRing { return &lineRing{buf: make([]logLine, n)} }
+func newLineRing(n int) *lineRing { return &lineRing{buf: make([]output.LogLineEvent, n)} }
 
-func (r *lineRing) add(text string, level output.LogLevel) {
+func (r *lineRing) add(e output.LogLineEvent) {
 	if len(r.buf) == 0 {
 		return
 	}
-	r.buf[r.next] = logLine{text: text, level: level}
+	r.buf[r.next] = e
 	r.next = (r.next + 1) % len(r.buf)
 	if r.next == 0 {
 		r.full = true
@@ -211,14 +295,13 @@ func (r *lineRing) reset() {
 	r.next, r.full = 0, false
 }
 
-func (r *lineRing) emit(fn func(string, output.LogLevel)) {
+func (r *lineRing) emit(fn func(output.LogLineEvent)) {
 	start := 0
 	if r.full {
 		start = r.next
 	}
 	for i := range r.len() {
-		l := r.buf[(start+i)%len(r.buf)]
-		fn(l.text, l.level)
+		fn(r.buf[(start+i)%len(r.buf)])
 	}
 }
 
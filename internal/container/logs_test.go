@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/localstack/lstk/internal/config"
 	"github.com/localstack/lstk/internal/output"
@@ -31,7 +32,7 @@ func (s *captureSink) Emit(e output.Event) {
 	}
 }
 
-func runLogs(t *testing.T, content string, verbose bool) *captureSink {
+func runLogs(t *testing.T, content string, filter LogFilter) *captureSink {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	mockRT := runtime.NewMockRuntime(ctrl)
@@ -46,7 +47,7 @@ func runLogs(t *testing.T, content string, verbose bool) *captureSink {
 
 	sink := &captureSink{}
 	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
-	err := Logs(context.Background(), mockRT, sink, containers, false, "all", verbose)
+	err := Logs(context.Background(), mockRT, sink, containers, false, "all", filter)
 	require.NoError(t, err)
 	return sink
 }
@@ -59,13 +60,15 @@ func TestLogs_LongLineIsTruncatedNotErrored(t *testing.T) {
 	huge := strings.Repeat("x", maxLogLineBytes+50_000)
 	line := "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : " + huge + "\n"
 
-	sink := runLogs(t, line, false)
+	sink := runLogs(t, line, LogFilter{})
 
 	require.Len(t, sink.lines, 1)
 	emitted := sink.lines[0].Line
 	assert.LessOrEqual(t, len(emitted), maxLogLineBytes+64, "emitted line must be bounded near the cap")
 	assert.Contains(t, emitted, "truncated")
 	assert.Equal(t, output.LogLevelInfo, sink.lines[0].Level, "level still parsed from the prefix")
+	assert.True(t, sink.lines[0].Truncated)
+	assert.NotContains(t, sink.lines[0].Message, "truncated", "the truncation marker is not part of the message")
 }
 
 func TestLogs_NormalLinesPassThrough(t *testing.T) {
@@ -73,7 +76,7 @@ func TestLogs_NormalLinesPassThrough(t *testing.T) {
 	content := "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : hello\n" +
 		"2026-07-07T10:05:12.240  WARN --- [  MainThread] l.bar : world\n"
 
-	sink := runLogs(t, content, false)
+	sink := runLogs(t, content, LogFilter{})
 
 	require.Len(t, sink.lines, 2)
 	assert.Equal(t, "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : hello", sink.lines[0].Line)
@@ -81,10 +84,29 @@ func TestLogs_NormalLinesPassThrough(t *testing.T) {
 	assert.Equal(t, output.LogLevelWarn, sink.lines[1].Level)
 }
 
+// Every emitted line carries the fields of the record it parses to, which
+// `logs --json` writes out.
+func TestLogs_LineFieldsParsed(t *testing.T) {
+	t.Parallel()
+	content := "2026-07-07T10:05:11.240 ERROR --- [   Thread-12] l.s.lambda.invocation : Invocation failed\n" +
+		"Traceback (most recent call last):\n"
+
+	sink := runLogs(t, content, LogFilter{})
+
+	require.Len(t, sink.lines, 2)
+	record := sink.lines[0]
+	assert.Equal(t, time.Date(2026, 7, 7, 10, 5, 11, 240_000_000, time.UTC), record.Time)
+	assert.Equal(t, "Thread-12", record.Thread)
+	assert.Equal(t, "l.s.lambda.invocation", record.Logger)
+	assert.Equal(t, "Invocation failed", record.Message)
+	assert.False(t, record.Truncated)
+	assert.Equal(t, output.LogLineEvent{Source: output.LogSourceEmulator, Line: "Traceback (most recent call last):", Message: "Traceback (most recent call last):"}, sink.lines[1])
+}
+
 // A final line without a trailing newline must still be emitted.
 func TestLogs_NoTrailingNewline(t *testing.T) {
 	t.Parallel()
-	sink := runLogs(t, "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : tail", false)
+	sink := runLogs(t, "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : tail", LogFilter{})
 
 	require.Len(t, sink.lines, 1)
 	assert.Equal(t, "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : tail", sink.lines[0].Line)
@@ -95,7 +117,7 @@ func TestLogs_FilteredLinesDropped(t *testing.T) {
 	content := "2026-07-07T10:05:11.240  INFO --- [  MainThread] localstack.request.http : noise\n" +
 		"2026-07-07T10:05:12.240  INFO --- [  MainThread] l.foo : keep\n"
 
-	sink := runLogs(t, content, false)
+	sink := runLogs(t, content, LogFilter{})
 
 	require.Len(t, sink.lines, 1)
 	assert.Contains(t, sink.lines[0].Line, "keep")
@@ -122,7 +144,7 @@ func dockerTail(content, tail string) string {
 	return strings.Join(lines[len(lines)-n:], "\n") + "\n"
 }
 
-func runLogsWithTail(t *testing.T, content, tail string) *captureSink {
+func runLogsWithTail(t *testing.T, content, tail string, filter LogFilter) *captureSink {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	mockRT := runtime.NewMockRuntime(ctrl)
@@ -137,7 +159,7 @@ func runLogsWithTail(t *testing.T, content, tail string) *captureSink {
 
 	sink := &captureSink{}
 	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
-	err := Logs(context.Background(), mockRT, sink, containers, false, tail, false)
+	err := Logs(context.Background(), mockRT, sink, containers, false, tail, filter)
 	require.NoError(t, err)
 	return sink
 }
@@ -157,7 +179,7 @@ func TestLogs_TailCountsVisibleLinesNotFilteredOnes(t *testing.T) {
 	t.Parallel()
 	content := "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : keep\n" + filteredLines(5)
 
-	sink := runLogsWithTail(t, content, "1")
+	sink := runLogsWithTail(t, content, "1", LogFilter{})
 
 	require.Len(t, sink.lines, 1, "the newest visible line must survive a --tail smaller than the filtered burst")
 	assert.Contains(t, sink.lines[0].Line, "keep")
@@ -169,7 +191,7 @@ func TestLogs_TailGrowsFetchPastLongFilteredRun(t *testing.T) {
 	t.Parallel()
 	content := "2026-07-07T10:05:11.240  INFO --- [  MainThread] l.foo : keep\n" + filteredLines(20)
 
-	sink := runLogsWithTail(t, content, "1")
+	sink := runLogsWithTail(t, content, "1", LogFilter{})
 
 	require.Len(t, sink.lines, 1)
 	assert.Contains(t, sink.lines[0].Line, "keep")
@@ -184,16 +206,29 @@ func TestLogs_TailKeepsOrderAndCapsAtVisibleCount(t *testing.T) {
 		"2026-07-07T10:05:12.240  INFO --- [  MainThread] l.foo : second\n" +
 		"2026-07-07T10:05:13.240  INFO --- [  MainThread] l.foo : third\n"
 
-	sink := runLogsWithTail(t, content, "2")
+	sink := runLogsWithTail(t, content, "2", LogFilter{})
 	require.Len(t, sink.lines, 2)
 	assert.Contains(t, sink.lines[0].Line, "second")
 	assert.Contains(t, sink.lines[1].Line, "third")
 
-	all := runLogsWithTail(t, content, "10")
+	all := runLogsWithTail(t, content, "10", LogFilter{})
 	require.Len(t, all.lines, 3, "a limit above the visible count shows every visible line")
 	assert.Contains(t, all.lines[0].Line, "first")
 }
 
+// A query makes --tail count the lines that match it, even in verbose mode,
+// so the runtime's tail cannot be used.
+func TestLogs_TailCountsLinesMatchingQuery(t *testing.T) {
+	t.Parallel()
+	content := "2026-07-07T10:05:11.240 ERROR --- [  MainThread] l.s.lambda.invocation : failed\n" + filteredLines(20)
+
+	sink := runLogsWithTail(t, content, "1", LogFilter{Verbose: true, Level: output.LogLevelError})
+
+	require.Len(t, sink.lines, 1)
+	assert.Contains(t, sink.lines[0].Line, "failed")
+	assert.Equal(t, output.LogLevelError, sink.lines[0].Level)
+}
+
 // --tail 0 shows nothing and must not read the container's history to find out.
 func TestLogs_TailZeroEmitsNothing(t *testing.T) {
 	t.Parallel()
@@ -204,7 +239,7 @@ func TestLogs_TailZeroEmitsNothing(t *testing.T) {
 
 	sink := &captureSink{}
 	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
-	require.NoError(t, Logs(context.Background(), mockRT, sink, containers, false, "0", false))
+	require.NoError(t, Logs(context.Background(), mockRT, sink, containers, false, "0", LogFilter{}))
 	assert.Empty(t, sink.lines)
 }
 
@@ -225,7 +260,7 @@ func TestLogs_VerboseDelegatesTailToRuntime(t *testing.T) {
 
 	sink := &captureSink{}
 	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
-	require.NoError(t, Logs(context.Background(), mockRT, sink, containers, false, "2", true))
+	require.NoError(t, Logs(context.Background(), mockRT, sink, containers, false, "2", LogFilter{Verbose: true}))
 	assert.Len(t, sink.lines, 2, "verbose keeps filtered lines, so the runtime tail is exact")
 }
 
@@ -243,7 +278,7 @@ func TestLogs_EmulatorNotRunning_ReturnsSilentError(t *testing.T) {
 
 	sink := &captureSink{}
 	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
-	err := Logs(context.Background(), mockRT, sink, containers, false, "all", false)
+	err := Logs(context.Background(), mockRT, sink, containers, false, "all", LogFilter{})
 
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err), "error must be silent since the sink already surfaced a 'not running' message to the user")
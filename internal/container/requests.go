@@ -0,0 +1,198 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// requestLoggers are the loggers LocalStack logs every request it serves to,
+// one line each:
+//
+//	AWS s3.GetObject => 404 (NoSuchKey)
+//	GET /_localstack/health => 200
+//
+// In trace mode (LS_LOG=trace) the line goes on with "; account/region; "
+// and the request and response. Requests LocalStack makes to itself, e.g. a
+// Lambda function's runtime reading from S3, go to the internal loggers, whose
+// names LocalStack shortens for being over 30 characters.
+var requestLoggers = map[string]requestLogger{
+	"localstack.request.aws":  {aws: true},
+	"localstack.request.http": {},
+	"l.request.internal.aws":  {aws: true, internal: true},
+	"l.request.internal.http": {internal: true},
+}
+
+type requestLogger struct {
+	aws      bool
+	internal bool
+}
+
+// internalPathPrefix is where LocalStack serves its own endpoints, polled by
+// lstk itself among others.
+const internalPathPrefix = "/_localstack/"
+
+// RequestFilter selects which requests `lstk logs requests` shows.
+type RequestFilter struct {
+	// Verbose also shows the requests LocalStack makes to itself and those
+	// to its /_localstack endpoints.
+	Verbose bool
+	// Services, when set, are the AWS services whose requests are shown.
+	Services []string
+	// Operation, when set, is a glob the AWS operation, or the path of any
+	// other request, must match.
+	Operation string
+	// Statuses, when set, are the status codes shown.
+	Statuses []StatusPattern
+	// ErrorsOnly shows only the requests that failed.
+	ErrorsOnly bool
+	// Since and Until, when set, bound the request times.
+	Since, Until time.Time
+}
+
+// StatusPattern matches a status code, or a class of them such as 4xx.
+type StatusPattern struct {
+	min, max int
+}
+
+// ParseStatusPattern parses a --status value: a status code such as 404, or
+// a class such as 4xx.
+func ParseStatusPattern(s string) (StatusPattern, error) {
+	if len(s) == 3 && strings.EqualFold(s[1:], "xx") && s[0] >= '1' && s[0] <= '5' {
+		base := int(s[0]-'0') * 100
+		return StatusPattern{min: base, max: base + 99}, nil
+	}
+	if code, err := strconv.Atoi(s); err == nil && code >= 100 && code <= 599 {
+		return StatusPattern{min: code, max: code}, nil
+	}
+	return StatusPattern{}, fmt.Errorf("invalid status %q: expected a status code such as 404 or a class such as 4xx", s)
+}
+
+func (p StatusPattern) matches(status int) bool {
+	return status >= p.min && status <= p.max
+}
+
+// ValidateOperationPattern reports whether pattern is a well-formed
+// --operation glob.
+func ValidateOperationPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid operation pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// logFilter is the log query that yields exactly the requests f shows.
+func (f RequestFilter) logFilter() LogFilter {
+	return LogFilter{
+		// The request loggers are among those hidden by default.
+		Verbose: true,
+		Since:   f.Since,
+		Until:   f.Until,
+		accept: func(rec logRecord) bool {
+			req, internal, ok := parseRequest(rec.logger, rec.message)
+			return ok && f.matches(req, internal)
+		},
+	}
+}
+
+func (f RequestFilter) matches(req output.RequestEvent, internal bool) bool {
+	if !f.Verbose && (internal || strings.HasPrefix(req.Path, internalPathPrefix)) {
+		return false
+	}
+	if len(f.Services) > 0 && !slices.Contains(f.Services, req.Service) {
+		return false
+	}
+	if f.Operation != "" {
+		name := req.Operation
+		if req.Service == "" {
+			name = req.Path
+		}
+		if ok, _ := path.Match(f.Operation, name); !ok {
+			return false
+		}
+	}
+	if f.ErrorsOnly && !req.Failed() {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.ContainsFunc(f.Statuses, func(p StatusPattern) bool { return p.matches(req.Status) }) {
+		return false
+	}
+	return true
+}
+
+// Requests shows the requests the emulators in containers served, read from
+// their request logs, then a summary of the error rate of each operation.
+// With follow, the summary is shown once ctx is canceled, which is how the
+// user stops following.
+func Requests(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, follow bool, tail string, filter RequestFilter) error {
+	var summary output.RequestSummaryEvent
+	err := streamLogs(ctx, rt, sink, containers, follow, tail, filter.logFilter(), func(e output.LogLineEvent) {
+		req, _, ok := parseRequest(e.Logger, e.Message)
+		if !ok {
+			return
+		}
+		req.Time, req.Emulator = e.Time, e.Emulator
+		summary.Add(req)
+		sink.Emit(req)
+	})
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	if len(summary.Operations) == 0 {
+		sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: "No requests found"})
+	}
+	sink.Emit(summary)
+	return nil
+}
+
+// parseRequest parses the message of a request log line, and reports whether
+// the request was one LocalStack made to itself.
+func parseRequest(logger, message string) (req output.RequestEvent, internal bool, ok bool) {
+	source, found := requestLoggers[logger]
+	if !found {
+		return req, false, false
+	}
+
+	line, trace, _ := strings.Cut(message, "; ")
+	call, result, found := strings.Cut(line, " => ")
+	if !found {
+		return req, false, false
+	}
+
+	statusText, errorCode, _ := strings.Cut(result, " ")
+	status, err := strconv.Atoi(statusText)
+	if err != nil {
+		return req, false, false
+	}
+	req.Status = status
+	req.ErrorCode = strings.TrimSuffix(strings.TrimPrefix(errorCode, "("), ")")
+
+	if source.aws {
+		operation, found := strings.CutPrefix(call, "AWS ")
+		if !found {
+			return req, false, false
+		}
+		if req.Service, req.Operation, found = strings.Cut(operation, "."); !found {
+			return req, false, false
+		}
+	} else if req.Method, req.Path, found = strings.Cut(call, " "); !found {
+		return req, false, false
+	}
+
+	if scope, _, _ := strings.Cut(trace, "; "); !strings.Contains(scope, " ") {
+		req.Account, req.Region, _ = strings.Cut(scope, "/")
+		if req.Region == "" {
+			req.Account = ""
+		}
+	}
+	return req, source.internal, true
+}
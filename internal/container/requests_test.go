@@ -0,0 +1,232 @@
+package container
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const requestLog = "2026-07-07T10:05:11.240  INFO --- [et.reactor-0] localstack.request.aws     : AWS s3.CreateBucket => 200\n" +
+	"2026-07-07T10:05:11.300  INFO --- [et.reactor-1] localstack.request.http    : GET /_localstack/health => 200\n" +
+	"2026-07-07T10:05:11.412  INFO --- [  MainThread] l.s.lambda.provider        : Creating lambda function\n" +
+	"2026-07-07T10:05:12.240  INFO --- [et.reactor-0] localstack.request.aws     : AWS s3.GetObject => 404 (NoSuchKey)\n" +
+	"2026-07-07T10:05:12.500  INFO --- [et.reactor-2] l.request.internal.aws     : AWS s3.GetObject => 200\n" +
+	"2026-07-07T10:05:13.100  INFO --- [et.reactor-1] localstack.request.http    : POST /hooks/deploy => 502\n" +
+	"2026-07-07T10:05:13.240  INFO --- [et.reactor-0] localstack.request.aws     : AWS sqs.SendMessage => 400 (InvalidParameterValue)\n"
+
+// captureRequests records the requests and the summary Requests emits.
+type captureRequests struct {
+	requests []output.RequestEvent
+	summary  *output.RequestSummaryEvent
+}
+
+func (s *captureRequests) Emit(e output.Event) {
+	switch e := e.(type) {
+	case output.RequestEvent:
+		s.requests = append(s.requests, e)
+	case output.RequestSummaryEvent:
+		s.summary = &e
+	}
+}
+
+func (s *captureRequests) targets() []string {
+	targets := make([]string, len(s.requests))
+	for i, r := range s.requests {
+		targets[i] = r.Target()
+	}
+	return targets
+}
+
+func runRequests(t *testing.T, content, tail string, filter RequestFilter) *captureRequests {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+	mockRT.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil)
+	mockRT.EXPECT().
+		StreamLogs(gomock.Any(), "localstack-aws", gomock.Any(), false, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, out io.Writer, _ bool, tail string) error {
+			_, err := io.WriteString(out, dockerTail(content, tail))
+			return err
+		}).AnyTimes()
+
+	sink := &captureRequests{}
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
+	err := Requests(context.Background(), mockRT, sink, containers, false, tail, filter)
+	require.NoError(t, err)
+	return sink
+}
+
+func TestRequests_ShowsRequestsAndSummary(t *testing.T) {
+	t.Parallel()
+	sink := runRequests(t, requestLog, "all", RequestFilter{})
+
+	assert.Equal(t, []string{"s3.CreateBucket", "s3.GetObject", "POST /hooks/deploy", "sqs.SendMessage"}, sink.targets())
+	assert.Equal(t, output.RequestEvent{
+		Time:      time.Date(2026, 7, 7, 10, 5, 12, 240_000_000, time.UTC),
+		Service:   "s3",
+		Operation: "GetObject",
+		Status:    404,
+		ErrorCode: "NoSuchKey",
+	}, sink.requests[1])
+
+	require.NotNil(t, sink.summary)
+	assert.Equal(t, []output.OperationStats{
+		{Operation: "POST /hooks/deploy", Requests: 1, Errors: 1},
+		{Service: "s3", Operation: "CreateBucket", Requests: 1},
+		{Service: "s3", Operation: "GetObject", Requests: 1, Errors: 1},
+		{Service: "sqs", Operation: "SendMessage", Requests: 1, Errors: 1},
+	}, sink.summary.Operations)
+}
+
+func TestRequests_VerboseShowsInternalRequests(t *testing.T) {
+	t.Parallel()
+	sink := runRequests(t, requestLog, "all", RequestFilter{Verbose: true})
+
+	assert.Equal(t, []string{
+		"s3.CreateBucket",
+		"GET /_localstack/health",
+		"s3.GetObject",
+		"s3.GetObject",
+		"POST /hooks/deploy",
+		"sqs.SendMessage",
+	}, sink.targets())
+}
+
+func TestRequests_Filters(t *testing.T) {
+	t.Parallel()
+	class4xx, err := ParseStatusPattern("4xx")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		filter RequestFilter
+		want   []string
+	}{
+		{"service", RequestFilter{Services: []string{"sqs"}}, []string{"sqs.SendMessage"}},
+		{"operation glob", RequestFilter{Operation: "Get*"}, []string{"s3.GetObject"}},
+		{"operation matches http path", RequestFilter{Operation: "/hooks/*"}, []string{"POST /hooks/deploy"}},
+		{"status class", RequestFilter{Statuses: []StatusPattern{class4xx}}, []string{"s3.GetObject", "sqs.SendMessage"}},
+		{"errors", RequestFilter{ErrorsOnly: true}, []string{"s3.GetObject", "POST /hooks/deploy", "sqs.SendMessage"}},
+		{"since", RequestFilter{Since: time.Date(2026, 7, 7, 10, 5, 13, 0, time.UTC)}, []string{"POST /hooks/deploy", "sqs.SendMessage"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, runRequests(t, requestLog, "all", tc.filter).targets())
+		})
+	}
+}
+
+// --tail counts requests, not the log lines around them.
+func TestRequests_TailCountsRequests(t *testing.T) {
+	t.Parallel()
+	content := requestLog +
+		"2026-07-07T10:05:14.000  INFO --- [  MainThread] l.foo : unrelated\n" +
+		"2026-07-07T10:05:14.100  INFO --- [et.reactor-1] localstack.request.http : GET /_localstack/health => 200\n"
+
+	sink := runRequests(t, content, "2", RequestFilter{})
+
+	assert.Equal(t, []string{"POST /hooks/deploy", "sqs.SendMessage"}, sink.targets())
+	require.NotNil(t, sink.summary)
+	assert.Len(t, sink.summary.Operations, 2, "the summary counts only the requests shown")
+}
+
+func TestRequests_NoneFoundStillSummarizes(t *testing.T) {
+	t.Parallel()
+	sink := runRequests(t, "2026-07-07T10:05:14.000  INFO --- [  MainThread] l.foo : unrelated\n", "all", RequestFilter{})
+
+	assert.Empty(t, sink.requests)
+	require.NotNil(t, sink.summary)
+	assert.Empty(t, sink.summary.Operations)
+}
+
+func TestParseRequest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		logger       string
+		message      string
+		want         output.RequestEvent
+		wantInternal bool
+		wantOK       bool
+	}{
+		{
+			name:    "aws",
+			logger:  "localstack.request.aws",
+			message: "AWS dynamodb.PutItem => 200",
+			want:    output.RequestEvent{Service: "dynamodb", Operation: "PutItem", Status: 200},
+			wantOK:  true,
+		},
+		{
+			name:    "aws error",
+			logger:  "localstack.request.aws",
+			message: "AWS s3.GetObject => 404 (NoSuchKey)",
+			want:    output.RequestEvent{Service: "s3", Operation: "GetObject", Status: 404, ErrorCode: "NoSuchKey"},
+			wantOK:  true,
+		},
+		{
+			name:    "trace mode",
+			logger:  "localstack.request.aws",
+			message: "AWS s3.GetObject => 404 (NoSuchKey); 000000000000/eu-west-1; GetObjectRequest({'Bucket': 'b'}, headers={}); {'Error': {}}, headers={}",
+			want:    output.RequestEvent{Service: "s3", Operation: "GetObject", Status: 404, ErrorCode: "NoSuchKey", Account: "000000000000", Region: "eu-west-1"},
+			wantOK:  true,
+		},
+		{
+			name:    "http",
+			logger:  "localstack.request.http",
+			message: "OPTIONS /restapis/abc/dev => 204",
+			want:    output.RequestEvent{Method: "OPTIONS", Path: "/restapis/abc/dev", Status: 204},
+			wantOK:  true,
+		},
+		{
+			name:         "internal",
+			logger:       "l.request.internal.aws",
+			message:      "AWS sts.AssumeRole => 200",
+			want:         output.RequestEvent{Service: "sts", Operation: "AssumeRole", Status: 200},
+			wantInternal: true,
+			wantOK:       true,
+		},
+		{name: "other logger", logger: "l.s.lambda.provider", message: "AWS s3.GetObject => 200"},
+		{name: "no status", logger: "localstack.request.aws", message: "AWS s3.GetObject"},
+		{name: "no operation", logger: "localstack.request.aws", message: "AWS s3 => 200"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, internal, ok := parseRequest(tc.logger, tc.message)
+			require.Equal(t, tc.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantInternal, internal)
+		})
+	}
+}
+
+func TestParseStatusPattern(t *testing.T) {
+	t.Parallel()
+	p, err := ParseStatusPattern("5XX")
+	require.NoError(t, err)
+	assert.True(t, p.matches(503))
+	assert.False(t, p.matches(404))
+
+	p, err = ParseStatusPattern("404")
+	require.NoError(t, err)
+	assert.True(t, p.matches(404))
+	assert.False(t, p.matches(400))
+
+	for _, bad := range []string{"4x", "6xx", "99", "abc", ""} {
+		_, err := ParseStatusPattern(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -53,7 +53,9 @@ func ResolveRunningContainerName(ctx context.Context, rt runtime.Runtime, c conf
 	if err != nil {
 		return "", fmt.Errorf("failed to scan for running containers: %w", err)
 	}
-	if found != nil {
+	// A container started as another named instance of this type is not this
+	// block's emulator, even though it matches by image.
+	if found != nil && found.Instance == c.Instance {
 		return found.Name, nil
 	}
 
@@ -68,6 +70,7 @@ func ResolveRunningContainerName(ctx context.Context, rt runtime.Runtime, c conf
 func HandleNoRunningContainer(sink output.Sink, c config.ContainerConfig) error {
 	sink.Emit(output.ErrorEvent{
 		Title: fmt.Sprintf("%s is not running", c.DisplayName()),
+		Code:  output.ErrEmulatorNotRunning,
 		Actions: []output.ErrorAction{
 			{Label: "Start LocalStack:", Value: "lstk"},
 			{Label: "See help:", Value: "lstk -h"},
@@ -75,3 +78,17 @@ func HandleNoRunningContainer(sink output.Sink, c config.ContainerConfig) error
 	})
 	return output.NewSilentError(fmt.Errorf("%s is not running", c.Name()))
 }
+
+// handleNoRunningEmulators is HandleNoRunningContainer for a config with
+// several emulators, none of which is running.
+func handleNoRunningEmulators(sink output.Sink) error {
+	sink.Emit(output.ErrorEvent{
+		Title: "No emulator is running",
+		Code:  output.ErrEmulatorNotRunning,
+		Actions: []output.ErrorAction{
+			{Label: "Start LocalStack:", Value: "lstk"},
+			{Label: "See help:", Value: "lstk -h"},
+		},
+	})
+	return output.NewSilentError(fmt.Errorf("no emulator is running"))
+}
@@ -0,0 +1,38 @@
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResolveRunningContainerName_IgnoresOtherInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws-pristine").Return(false, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), "4566/tcp").
+		Return(&runtime.RunningContainer{Name: "localstack-aws-scratch", Image: "localstack/localstack-pro:latest", BoundPort: "4567", Instance: "scratch"}, nil)
+
+	name, err := ResolveRunningContainerName(context.Background(), mockRT, config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566", Instance: "pristine"})
+
+	require.NoError(t, err)
+	assert.Empty(t, name, "a running scratch instance must not be mistaken for the pristine one")
+}
+
+func TestResolveRunningContainerName_FindsUnlabelledContainerForDefaultInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(false, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), "4566/tcp").
+		Return(&runtime.RunningContainer{Name: "external-container", Image: "localstack/localstack-pro:latest", BoundPort: "4566"}, nil)
+
+	name, err := ResolveRunningContainerName(context.Background(), mockRT, config.ContainerConfig{Type: config.EmulatorAWS, Port: "4566"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "external-container", name)
+}
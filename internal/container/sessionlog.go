@@ -0,0 +1,350 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// SaveLogsCommandName is the argv[1] sentinel for the detached process that
+// keeps saving a container's logs once `lstk start` has returned. Like the
+// telemetry flusher, cmd.Execute short-circuits on it.
+const SaveLogsCommandName = "__save-logs"
+
+// maxSessionLogBytes caps a session log file. Past it, the file is rotated to
+// a ".1" sibling (replacing an older one), so a session keeps at most twice
+// this much of its most recent output.
+const maxSessionLogBytes = 20 << 20
+
+// maxSessionLogs is how many sessions are kept per container; starting a new
+// one removes the oldest beyond it.
+const maxSessionLogs = 5
+
+// sessionLogExt is the extension of session log files, which are named after
+// the time the session started and the container's short ID so that they
+// sort chronologically.
+const sessionLogExt = ".log"
+
+// SessionLogs saves the logs of the containers lstk starts to a file per
+// session, so that they outlive the container: it is started with AutoRemove,
+// and removed along with its logs the moment it exits.
+type SessionLogs struct {
+	// Dir returns the directory of the sessions of the named container.
+	Dir func(containerName string) (string, error)
+	// Follow keeps appending the container's logs to the session log at path
+	// after lstk exits, skipping the first skip lines, which lstk start saved
+	// already. It typically spawns a detached lstk running SaveLogs.
+	Follow func(containerID, path string, skip int) error
+}
+
+// newSessionLog creates the log of a session of the container started at
+// startTime, removing the oldest sessions beyond maxSessionLogs.
+func (s *SessionLogs) newSessionLog(containerName, containerID string, startTime time.Time) (*sessionLog, error) {
+	dir, err := s.Dir(containerName)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	sessions, err := listSessionLogs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for len(sessions) >= maxSessionLogs {
+		_ = os.Remove(sessions[0])
+		_ = os.Remove(sessions[0] + ".1")
+		sessions = sessions[1:]
+	}
+	name := startTime.UTC().Format("20060102T150405Z") + "-" + shortID(containerID) + sessionLogExt
+	return openSessionLog(filepath.Join(dir, name))
+}
+
+// listSessionLogs lists the session logs in dir, oldest first.
+func listSessionLogs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var sessions []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), sessionLogExt) {
+			sessions = append(sessions, filepath.Join(dir, e.Name()))
+		}
+	}
+	slices.Sort(sessions)
+	return sessions, nil
+}
+
+// sessionContainerID is the short ID of the container a session log belongs to.
+func sessionContainerID(path string) string {
+	_, id, _ := strings.Cut(strings.TrimSuffix(filepath.Base(path), sessionLogExt), "-")
+	return id
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// sessionLog appends whole lines of a container's log stream to a session log
+// file, rotating it past maxSessionLogBytes. Like logTail, its Write never
+// errors, so saving the logs cannot fail what follows them; a line that cannot
+// be written is lost.
+type sessionLog struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	partial []byte
+	lines   int
+}
+
+func openSessionLog(path string) (*sessionLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return &sessionLog{path: path, file: f, size: info.Size()}, nil
+}
+
+// Write saves the complete lines in p, holding back a trailing partial line
+// until the rest of it arrives.
+func (l *sessionLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return len(p), nil
+	}
+	data := p
+	if len(l.partial) > 0 {
+		data = append(l.partial, p...)
+		l.partial = nil
+	}
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		l.writeLine(data[:i+1])
+		data = data[i+1:]
+	}
+	l.partial = append(l.partial, data...)
+	return len(p), nil
+}
+
+func (l *sessionLog) writeLine(line []byte) {
+	if l.size > 0 && l.size+int64(len(line)) > maxSessionLogBytes {
+		l.rotate()
+	}
+	if l.file == nil {
+		return
+	}
+	n, _ := l.file.Write(line)
+	l.size += int64(n)
+	l.lines++
+}
+
+func (l *sessionLog) rotate() {
+	_ = l.file.Close()
+	l.file = nil
+	if err := os.Rename(l.path, l.path+".1"); err != nil {
+		return
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	l.file, l.size = f, 0
+}
+
+// Close saves a trailing partial line and closes the file.
+func (l *sessionLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	if len(l.partial) > 0 {
+		l.partial = append(l.partial, '\n')
+		l.writeLine(l.partial)
+		l.partial = nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// handOver closes the file without a trailing partial line and reports how
+// many lines were saved, for SessionLogs.Follow to carry on after them: the
+// partial line is saved in full by whoever continues.
+func (l *sessionLog) handOver() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file != nil {
+		_ = l.file.Close()
+		l.file = nil
+	}
+	l.partial = nil
+	return l.lines
+}
+
+// SaveLogs appends the logs of the container to the session log at path,
+// skipping their first skip lines, until the container exits. It is what the
+// detached process SessionLogs.Follow spawns runs.
+func SaveLogs(ctx context.Context, rt runtime.Runtime, containerID, path string, skip int) error {
+	log, err := openSessionLog(path)
+	if err != nil {
+		return err
+	}
+	err = rt.StreamLogs(ctx, containerID, &lineSkipper{w: log, skip: skip}, true, tailAll)
+	if closeErr := log.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// lineSkipper drops the first skip lines written to it and passes the rest
+// on to w.
+type lineSkipper struct {
+	w    io.Writer
+	skip int
+}
+
+func (s *lineSkipper) Write(p []byte) (int, error) {
+	rest := p
+	for s.skip > 0 {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		rest = rest[i+1:]
+		s.skip--
+	}
+	if len(rest) > 0 {
+		if _, err := s.w.Write(rest); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// PreviousLogs prints the logs lstk saved of the last session of each of the
+// emulators in containers that has ended, i.e. whose container is no longer
+// running. sessionDir is as SessionLogs.Dir.
+func PreviousLogs(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, tail string, filter LogFilter, sessionDir func(containerName string) (string, error)) error {
+	if len(containers) == 0 {
+		return fmt.Errorf("no containers configured")
+	}
+
+	type savedSession struct {
+		path   string
+		prefix string
+	}
+	var saved []savedSession
+	for _, c := range containers {
+		dir, err := sessionDir(c.Name())
+		if err != nil {
+			return err
+		}
+		path, err := previousSessionLog(ctx, rt, dir)
+		if err != nil {
+			return err
+		}
+		if path == "" {
+			if len(containers) > 1 {
+				sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: fmt.Sprintf("No saved logs of a previous %s session", c.DisplayName())})
+				continue
+			}
+			return fmt.Errorf("no saved logs of a previous %s session: lstk saves them for emulators it starts", c.DisplayName())
+		}
+		prefix := string(c.Type)
+		if c.Instance != "" {
+			prefix = c.Instance
+		}
+		saved = append(saved, savedSession{path: path, prefix: prefix})
+	}
+
+	for _, s := range saved {
+		emit := func(e output.LogLineEvent) {
+			e.Source = output.LogSourceEmulator
+			if len(saved) > 1 {
+				e.Emulator = s.prefix
+			}
+			sink.Emit(e)
+		}
+		if err := sessionLogLines(ctx, s.path, tail, filter, emit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousSessionLog finds the newest session log in dir whose container is
+// not running, or "" when there is none.
+func previousSessionLog(ctx context.Context, rt runtime.Runtime, dir string) (string, error) {
+	sessions, err := listSessionLogs(dir)
+	if err != nil {
+		return "", err
+	}
+	for i := len(sessions) - 1; i >= 0; i-- {
+		if id := sessionContainerID(sessions[i]); id != "" {
+			if running, err := rt.IsRunning(ctx, id); err == nil && running {
+				continue
+			}
+		}
+		return sessions[i], nil
+	}
+	return "", nil
+}
+
+// sessionLogLines emits the lines of the session log at path, its rotated
+// older part first, that survive filter, keeping only the last of them under
+// a numeric tail.
+func sessionLogLines(ctx context.Context, path, tail string, filter LogFilter, emit func(output.LogLineEvent)) error {
+	var readers []io.Reader
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		readers = append(readers, f)
+	}
+
+	limit, hasLimit := parseTailLimit(tail)
+	if !hasLimit {
+		_, err := scanLogLines(ctx, io.MultiReader(readers...), filter, emit)
+		return err
+	}
+	ring := newLineRing(limit)
+	if _, err := scanLogLines(ctx, io.MultiReader(readers...), filter, ring.add); err != nil {
+		return err
+	}
+	ring.emit(emit)
+	return nil
+}
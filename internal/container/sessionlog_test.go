@@ -0,0 +1,162 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestSessionLog_SavesWholeLines(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.log")
+	log, err := openSessionLog(path)
+	require.NoError(t, err)
+
+	_, _ = io.WriteString(log, "first\nsec")
+	_, _ = io.WriteString(log, "ond\nthi")
+	assert.Equal(t, "first\nsecond\n", readFile(t, path), "a partial line is held back")
+
+	require.NoError(t, log.Close())
+	assert.Equal(t, "first\nsecond\nthi\n", readFile(t, path), "Close saves the partial line")
+}
+
+func TestSessionLog_HandOverDropsPartialLine(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.log")
+	log, err := openSessionLog(path)
+	require.NoError(t, err)
+
+	_, _ = io.WriteString(log, "one\ntwo\nthr")
+	assert.Equal(t, 2, log.handOver())
+	assert.Equal(t, "one\ntwo\n", readFile(t, path))
+
+	// Whoever carries on streams the logs from the start, skipping what was saved.
+	cont, err := openSessionLog(path)
+	require.NoError(t, err)
+	_, _ = io.WriteString(&lineSkipper{w: cont, skip: 2}, "one\ntwo\nthree\nfour\n")
+	require.NoError(t, cont.Close())
+	assert.Equal(t, "one\ntwo\nthree\nfour\n", readFile(t, path))
+}
+
+func TestSessionLog_Rotates(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "session.log")
+	log, err := openSessionLog(path)
+	require.NoError(t, err)
+
+	line := strings.Repeat("x", maxSessionLogBytes/2-1) + "\n"
+	for range 3 {
+		_, _ = io.WriteString(log, line)
+	}
+	_, _ = io.WriteString(log, "last\n")
+	require.NoError(t, log.Close())
+
+	assert.Equal(t, line+line, readFile(t, path+".1"))
+	assert.Equal(t, line+"last\n", readFile(t, path))
+}
+
+func TestLineSkipper(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	s := &lineSkipper{w: &buf, skip: 2}
+
+	for _, chunk := range []string{"a", "\nb\nc", "\nd\n"} {
+		n, err := io.WriteString(s, chunk)
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	assert.Equal(t, "c\nd\n", buf.String())
+}
+
+func TestNewSessionLog_PrunesOldSessions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	logs := &SessionLogs{Dir: func(string) (string, error) { return dir, nil }}
+
+	start := time.Date(2026, 7, 7, 10, 0, 0, 0, time.UTC)
+	for i := range maxSessionLogs + 2 {
+		log, err := logs.newSessionLog("localstack-aws", "abcdef0123456789", start.Add(time.Duration(i)*time.Minute))
+		require.NoError(t, err)
+		require.NoError(t, log.Close())
+	}
+
+	sessions, err := listSessionLogs(dir)
+	require.NoError(t, err)
+	require.Len(t, sessions, maxSessionLogs)
+	assert.Equal(t, "20260707T100200Z-abcdef012345.log", filepath.Base(sessions[0]))
+	assert.Equal(t, "abcdef012345", sessionContainerID(sessions[0]))
+}
+
+func writeSession(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestPreviousLogs_SkipsRunningSession(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeSession(t, dir, "20260707T100000Z-aaaaaaaaaaaa.log", "old session\n")
+	writeSession(t, dir, "20260707T110000Z-bbbbbbbbbbbb.log", "ended session\n")
+	writeSession(t, dir, "20260707T120000Z-cccccccccccc.log", "running session\n")
+
+	mockRT := runtime.NewMockRuntime(gomock.NewController(t))
+	mockRT.EXPECT().IsRunning(gomock.Any(), "cccccccccccc").Return(true, nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "bbbbbbbbbbbb").Return(false, nil)
+
+	sink := &captureSink{}
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
+	err := PreviousLogs(context.Background(), mockRT, sink, containers, "all", LogFilter{}, func(string) (string, error) { return dir, nil })
+	require.NoError(t, err)
+
+	require.Len(t, sink.lines, 1)
+	assert.Equal(t, "ended session", sink.lines[0].Line)
+}
+
+func TestPreviousLogs_TailReadsRotatedPart(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	writeSession(t, dir, "20260707T100000Z-aaaaaaaaaaaa.log.1", "one\ntwo\n")
+	writeSession(t, dir, "20260707T100000Z-aaaaaaaaaaaa.log", "three\n")
+
+	mockRT := runtime.NewMockRuntime(gomock.NewController(t))
+	mockRT.EXPECT().IsRunning(gomock.Any(), "aaaaaaaaaaaa").Return(false, nil)
+
+	sink := &captureSink{}
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
+	err := PreviousLogs(context.Background(), mockRT, sink, containers, "2", LogFilter{}, func(string) (string, error) { return dir, nil })
+	require.NoError(t, err)
+
+	var lines []string
+	for _, l := range sink.lines {
+		lines = append(lines, l.Line)
+	}
+	assert.Equal(t, []string{"two", "three"}, lines)
+}
+
+func TestPreviousLogs_NoSession(t *testing.T) {
+	t.Parallel()
+	mockRT := runtime.NewMockRuntime(gomock.NewController(t))
+
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS}}
+	err := PreviousLogs(context.Background(), mockRT, &captureSink{}, containers, "all", LogFilter{}, func(string) (string, error) { return t.TempDir(), nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no saved logs of a previous")
+}
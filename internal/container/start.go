@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"net/http"
 	"os"
@@ -49,21 +50,11 @@ type StartOptions struct {
 	// AuthOptions is passed through to auth.New; tests use it to inject a fake
 	// browser opener so a re-login flow never opens a real tab.
 	AuthOptions []auth.Option
+	// SessionLogs, when set, saves the logs of every container started.
+	SessionLogs *SessionLogs
 }
 
 func Start(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts StartOptions, interactive bool) (string, error) {
-	// Fail fast on unsupported multi-container configs before any health/auth
-	// checks or image pulls, so we don't leave a partial startup that later dies
-	// on container-name conflicts or shared port collisions.
-	if err := checkSingleContainer(opts.Containers); err != nil {
-		sink.Emit(output.ErrorEvent{
-			Title:   "Unsupported configuration",
-			Summary: err.Error(),
-			Actions: []output.ErrorAction{{Label: "Edit your config file so only one [[containers]] block is enabled:", Value: "lstk config path"}},
-		})
-		return "", output.NewSilentError(err)
-	}
-
 	if err := rt.IsHealthy(ctx); err != nil {
 		rt.EmitUnhealthyError(sink, err)
 		return "", output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
@@ -156,6 +147,7 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 		sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: text})
 	}
 	agentEnvVars := agentEnv(caller.New().Classify())
+	sharedPortsOwner := sharedPortsOwner(opts.Containers)
 
 	containers := make([]runtime.ContainerConfig, len(opts.Containers))
 	for i, c := range opts.Containers {
@@ -181,6 +173,10 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 		if err != nil {
 			return "", err
 		}
+		// Refused before anything starts rather than once the emulator is up.
+		if err := c.CheckInitTrusted(); err != nil {
+			return "", err
+		}
 
 		// GATEWAY_LISTEN is configurable via the [env.*] profiles. It controls
 		// which ports the gateway listens on inside the container and, through
@@ -217,8 +213,8 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 		// The Python Snowflake emulator routes all S3 access through a single
 		// SF_S3_ENDPOINT (defaulting to port 4566), so on a custom port internal
 		// stages (e.g. COPY INTO) fail. Set it to match the configured port unless
-		// the user already provided their own value. AWS + Snowflake multi-emulator
-		// setups are not supported by this flow yet.
+		// the user already provided their own value. This also keeps a Snowflake
+		// emulator running next to an AWS one on 4566 pointed at its own S3.
 		if c.Type == config.EmulatorSnowflake && !envHasKey(resolvedEnv, "SF_S3_ENDPOINT") {
 			env = append(env, "SF_S3_ENDPOINT="+snowflake.S3Endpoint(c.Port))
 		}
@@ -261,9 +257,18 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 
 		// The primary edge port is published via the configured host port (c.Port);
 		// any further gateway ports (443, and e.g. 8443) plus the service port range
-		// are published host-port == container-port.
+		// are published host-port == container-port. Those are the same host ports
+		// for every emulator, so when several blocks start together only one of them
+		// claims the service range and the default 443; the others are reachable on
+		// their own edge port. A GATEWAY_LISTEN the user set is still published.
 		primaryPort, _, _ := strings.Cut(containerPort, "/")
-		extraPorts := append(gateway.extraGatewayPorts(primaryPort, gatewayDefaulted), servicePortRange()...)
+		var extraPorts []runtime.PortMapping
+		if i == sharedPortsOwner || !gatewayDefaulted {
+			extraPorts = gateway.extraGatewayPorts(primaryPort, gatewayDefaulted)
+		}
+		if i == sharedPortsOwner {
+			extraPorts = append(extraPorts, servicePortRange()...)
+		}
 
 		// Ports the user asked for explicitly (e.g. 53 for DNS) are published on top.
 		exposed, err := c.ExposedPorts()
@@ -285,6 +290,7 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 			ProductName:   productName,
 			Binds:         binds,
 			ExtraPorts:    extraPorts,
+			Instance:      c.Instance,
 		}
 	}
 
@@ -326,6 +332,10 @@ func startOnce(ctx context.Context, rt runtime.Runtime, sink output.Sink, opts S
 		return "", err
 	}
 
+	if err := runInitSteps(ctx, sink, opts.Logger, opts.Containers, containers, opts.LocalStackHost); err != nil {
+		return "", err
+	}
+
 	// Maps emulator types to their post-start setup functions.
 	// Add an entry here to run setup for a new emulator type (e.g. Azure, Snowflake).
 	setups := map[config.EmulatorType]postStartSetupFunc{
@@ -364,12 +374,29 @@ func runPostStartSetups(ctx context.Context, rt runtime.Runtime, sink output.Sin
 				return err
 			}
 		}
-		emitPostStartPointers(sink, t, resolvedHost, webAppURL, isPersistenceEnabled(ctx, rt, c.Name()))
+		containerPort, _ := c.ContainerPort()
+		emitPostStartPointers(sink, t, endpointHost(rt, sink, c.Name(), containerPort, resolvedHost), webAppURL, isPersistenceEnabled(ctx, rt, c.Name()))
 	}
 	return nil
 }
 
-func emitAlreadyRunning(ctx context.Context, sink output.Sink, c runtime.ContainerConfig, localStackHost, webAppURL string, persist bool) {
+// endpointHost is where the emulator is reached once lstk returns:
+// resolvedHost, unless the runtime reaches it locally only while lstk runs.
+func endpointHost(rt runtime.Runtime, sink output.Sink, containerName, containerPort, resolvedHost string) string {
+	addresser, ok := rt.(runtime.InClusterAddresser)
+	if !ok {
+		return resolvedHost
+	}
+	sink.Emit(output.MessageEvent{
+		Severity: output.SeverityNote,
+		Text:     fmt.Sprintf("%s is forwarded to the emulator only while an lstk command runs; use the in-cluster endpoint below once it returns.", resolvedHost),
+	})
+	return addresser.InClusterAddress(containerName, containerPort)
+}
+
+// emitAlreadyRunning reports c as already running in the container called
+// containerName.
+func emitAlreadyRunning(ctx context.Context, rt runtime.Runtime, sink output.Sink, c runtime.ContainerConfig, containerName, localStackHost, webAppURL string, persist bool) {
 	name := c.EmulatorType.DisplayName()
 	if info, err := fetchLocalStackInfo(ctx, c.Port); err == nil && info.Version != "" {
 		// /_localstack/info may report a build suffix (e.g. "2026.5.3:04ddfd3a0");
@@ -382,7 +409,7 @@ func emitAlreadyRunning(ctx context.Context, sink output.Sink, c runtime.Contain
 	if !dnsOK {
 		sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: endpoint.DNSRebindNote})
 	}
-	emitPostStartPointers(sink, c.EmulatorType, resolvedHost, webAppURL, persist)
+	emitPostStartPointers(sink, c.EmulatorType, endpointHost(rt, sink, containerName, c.ContainerPort, resolvedHost), webAppURL, persist)
 }
 
 func isPersistenceEnabled(ctx context.Context, rt runtime.Runtime, containerName string) bool {
@@ -592,6 +619,12 @@ func validateLicensesFromImages(ctx context.Context, rt runtime.Runtime, sink ou
 		}
 
 		v, err := rt.GetImageVersion(ctx, c.Image)
+		if errors.Is(err, runtime.ErrImageInspectUnsupported) {
+			// The runtime never sees the image (e.g. a cluster node pulls it), so
+			// there is no version to validate against: the container validates
+			// the license at startup, as it does for a local pinned image.
+			continue
+		}
 		if err != nil {
 			return "", false, fmt.Errorf("could not resolve version from image %s: %w", c.Image, err)
 		}
@@ -621,7 +654,7 @@ func startWithLicenseRetry(ctx context.Context, rt runtime.Runtime, sink output.
 	licenseMounted := mountCachedLicense(containers, licenseFilePath)
 	retryCandidate := licenseMounted && !licenseRefreshed
 
-	err := startContainers(ctx, rt, sink, opts.Telemetry, containers, pulled, opts.StartupTimeout, interactive, retryCandidate)
+	err := startContainers(ctx, rt, sink, opts.Telemetry, containers, pulled, opts.StartupTimeout, interactive, retryCandidate, opts.SessionLogs)
 	if err == nil {
 		return nil
 	}
@@ -643,15 +676,45 @@ func startWithLicenseRetry(ctx context.Context, rt runtime.Runtime, sink output.
 	}
 	stripLicenseMount(containers)
 	mountCachedLicense(containers, licenseFilePath)
-	return startContainers(ctx, rt, sink, opts.Telemetry, containers, pulled, opts.StartupTimeout, interactive, false)
+	return startContainers(ctx, rt, sink, opts.Telemetry, containers, pulled, opts.StartupTimeout, interactive, false, opts.SessionLogs)
 }
 
-func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink, tel *telemetry.Client, containers []runtime.ContainerConfig, pulled map[string]bool, startupTimeout time.Duration, interactive bool, licenseRetryCandidate bool) error {
+func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink, tel *telemetry.Client, containers []runtime.ContainerConfig, pulled map[string]bool, startupTimeout time.Duration, interactive bool, licenseRetryCandidate bool, sessionLogs *SessionLogs) (retErr error) {
 	monitor := newStartupMonitor(rt, sink, tel, startupTimeout, interactive)
+
+	// Start every container before waiting on any of them, so emulators configured
+	// side by side boot concurrently and share one readiness wait instead of
+	// queueing behind each other's startup.
+	started := make([]*startedContainer, 0, len(containers))
+	defer func() {
+		for _, s := range started {
+			s.stopLogTail()
+			s.closeSessionLog()
+		}
+	}()
+	// When one emulator fails to start, stop the ones started alongside it
+	// rather than leave part of the config running. The failed one is left to
+	// the failure handling, and a cancelled start leaves them all running,
+	// detached.
+	var failed *startedContainer
+	defer func() {
+		if retErr == nil || errors.Is(retErr, context.Canceled) || errors.Is(retErr, context.DeadlineExceeded) {
+			return
+		}
+		for _, s := range started {
+			if s == failed {
+				continue
+			}
+			if err := rt.Stop(ctx, s.config.Name); err != nil {
+				sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: fmt.Sprintf("Could not stop %s after the failed start: %v", s.config.Name, err)})
+				continue
+			}
+			sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: fmt.Sprintf("Stopped %s, started alongside the emulator that failed", s.config.Name)})
+		}
+	}()
 	for _, c := range containers {
-		startTime := time.Now()
-		sink.Emit(output.SpinnerStart("Starting LocalStack"))
-		containerID, exitCh, err := startWithOptionalPortFallback(ctx, rt, sink, c)
+		sink.Emit(output.SpinnerStart(startingText(c, len(containers))))
+		s, err := startContainer(ctx, rt, sink, c, sessionLogs)
 		if err != nil {
 			sink.Emit(output.SpinnerStop())
 			tel.EmitEmulatorLifecycleEvent(ctx, telemetry.LifecycleEvent{
@@ -661,31 +724,22 @@ func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink,
 				ErrorCode: telemetry.ErrCodeStartFailed,
 				ErrorMsg:  err.Error(),
 			})
-			return fmt.Errorf("failed to start LocalStack: %w", err)
+			return fmt.Errorf("failed to start %s: %w", startedName(c, len(containers)), err)
 		}
+		started = append(started, s)
+	}
 
-		// Follow the container's logs into a bounded buffer from the moment it
-		// starts. With AutoRemove (--rm) the container is removed the instant it
-		// exits, so a post-hoc log fetch would race the removal; buffering as it
-		// runs keeps the startup logs available to explain a crash.
-		startupLogs := newLogTail(maxStartupLogBytes)
-		logCtx, stopLogTail := context.WithCancel(ctx)
-		logDone := make(chan struct{})
-		go func() {
-			defer close(logDone)
-			_ = rt.StreamLogs(logCtx, containerID, startupLogs, true, "all")
-		}()
-
+	for _, s := range started {
+		c := s.config
+		sink.Emit(output.SpinnerStart(startingText(c, len(containers))))
 		healthURL := fmt.Sprintf("http://localhost:%s%s", c.Port, c.HealthPath)
-		err = monitor.await(ctx, containerID, healthURL, exitCh)
+		err := monitor.await(ctx, s.id, healthURL, s.exitCh)
 		// Stop following and let the goroutine return before continuing, so it does
-		// not outlive the start. Bounded so a slow stream teardown can't hang start.
-		stopLogTail()
-		select {
-		case <-logDone:
-		case <-time.After(2 * time.Second):
-		}
+		// not outlive the start.
+		s.stopLogTail()
 		if err != nil {
+			failed = s
+			s.closeSessionLog()
 			sink.Emit(output.SpinnerStop())
 			// A cancelled context (e.g. Ctrl+C) is a deliberate abort, not a
 			// startup failure: propagate it without a styled error. The container
@@ -705,9 +759,9 @@ func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink,
 			// Read the logs only now, after the follow-goroutine's final flush, so
 			// the tail is complete. Fall back to a direct fetch if nothing was
 			// streamed (unlikely once the container ran).
-			logs := startupLogs.String()
+			logs := s.logs.String()
 			if logs == "" {
-				if direct, derr := rt.Logs(ctx, containerID, 20); derr == nil {
+				if direct, derr := rt.Logs(ctx, s.id, 20); derr == nil {
 					logs = direct
 				}
 			}
@@ -726,21 +780,22 @@ func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink,
 					ErrorCode: telemetry.ErrCodeLicenseInvalid,
 					ErrorMsg:  err.Error(),
 				})
-				return &licenseStartupError{name: "LocalStack", logs: logs}
+				return &licenseStartupError{name: startedName(c, len(containers)), logs: logs}
 			}
-			return monitor.handleFailure(ctx, c, err, logs)
+			return monitor.handleFailure(ctx, c, err, logs, s.sessionLogPath())
 		}
 		sink.Emit(output.SpinnerStop())
+		s.followSessionLog(sessionLogs)
 
-		sink.Emit(output.ContainerStatusEvent{Phase: "ready", Container: c.Name, Detail: fmt.Sprintf("containerId: %s", containerID[:12])})
+		sink.Emit(output.ContainerStatusEvent{Phase: "ready", Container: c.Name, Detail: fmt.Sprintf("containerId: %s", s.id[:12])})
 
 		lsInfo, _ := fetchLocalStackInfo(ctx, c.Port)
 		tel.EmitEmulatorLifecycleEvent(ctx, telemetry.LifecycleEvent{
 			EventType:      telemetry.LifecycleStartSuccess,
 			Emulator:       c.EmulatorType,
 			Image:          c.Image,
-			ContainerID:    containerID[:12],
-			DurationMS:     time.Since(startTime).Milliseconds(),
+			ContainerID:    s.id[:12],
+			DurationMS:     time.Since(s.startTime).Milliseconds(),
 			Pulled:         pulled[c.Name],
 			LocalStackInfo: lsInfo,
 		})
@@ -748,11 +803,112 @@ func startContainers(ctx context.Context, rt runtime.Runtime, sink output.Sink,
 	return nil
 }
 
+// startedContainer is a container that startContainers has started but not yet
+// seen become ready, together with the log follower buffering its output.
+type startedContainer struct {
+	config    runtime.ContainerConfig
+	id        string
+	exitCh    <-chan runtime.ExitResult
+	startTime time.Time
+	logs      *logTail
+	session   *sessionLog // nil when session logs are not saved
+	cancel    context.CancelFunc
+	logDone   chan struct{}
+}
+
+// startContainer starts c and begins following its logs into a bounded buffer
+// from the moment it starts. With AutoRemove (--rm) the container is removed
+// the instant it exits, so a post-hoc log fetch would race the removal;
+// buffering as it runs keeps the startup logs available to explain a crash.
+//
+// With sessionLogs, the logs are also saved to the session log of the
+// container as they arrive, so a crash during startup leaves them on disk.
+func startContainer(ctx context.Context, rt runtime.Runtime, sink output.Sink, c runtime.ContainerConfig, sessionLogs *SessionLogs) (*startedContainer, error) {
+	startTime := time.Now()
+	containerID, exitCh, err := startWithOptionalPortFallback(ctx, rt, sink, c)
+	if err != nil {
+		return nil, err
+	}
+	s := &startedContainer{
+		config:    c,
+		id:        containerID,
+		exitCh:    exitCh,
+		startTime: startTime,
+		logs:      newLogTail(maxStartupLogBytes),
+		logDone:   make(chan struct{}),
+	}
+	var out io.Writer = s.logs
+	if sessionLogs != nil {
+		// Saving logs is best effort: failing to must not fail the start.
+		if session, err := sessionLogs.newSessionLog(c.Name, containerID, startTime); err == nil {
+			s.session = session
+			out = io.MultiWriter(s.logs, session)
+		}
+	}
+	var logCtx context.Context
+	logCtx, s.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(s.logDone)
+		_ = rt.StreamLogs(logCtx, containerID, out, true, "all")
+	}()
+	return s, nil
+}
+
+// sessionLogPath is where the container's logs are saved, or "" if they are not.
+func (s *startedContainer) sessionLogPath() string {
+	if s.session == nil {
+		return ""
+	}
+	return s.session.path
+}
+
+// followSessionLog hands the session log over to sessionLogs.Follow, which
+// keeps saving the logs of the now ready container after lstk exits.
+func (s *startedContainer) followSessionLog(sessionLogs *SessionLogs) {
+	if s.session == nil || sessionLogs.Follow == nil {
+		return
+	}
+	_ = sessionLogs.Follow(s.id, s.session.path, s.session.handOver())
+}
+
+// stopLogTail stops following the container's logs and waits for the follower
+// to return. Bounded so a slow stream teardown can't hang start; safe to call
+// more than once.
+func (s *startedContainer) stopLogTail() {
+	s.cancel()
+	select {
+	case <-s.logDone:
+	case <-time.After(2 * time.Second):
+	}
+}
+
+// closeSessionLog stops saving the container's logs, e.g. because it failed
+// to start. Safe to call more than once, and after followSessionLog.
+func (s *startedContainer) closeSessionLog() {
+	if s.session != nil {
+		_ = s.session.Close()
+	}
+}
+
+// startingText is the spinner text while c boots. A lone emulator keeps the
+// familiar "Starting LocalStack"; side by side, each names its emulator.
+func startingText(c runtime.ContainerConfig, total int) string {
+	return "Starting " + startedName(c, total)
+}
+
+func startedName(c runtime.ContainerConfig, total int) string {
+	if total > 1 {
+		return config.InstanceDisplayName(c.EmulatorType, c.Instance)
+	}
+	return "LocalStack"
+}
+
 // handleFailure classifies an await failure for container c, emits the
 // matching ErrorEvent + lifecycle telemetry, and returns a silent error (so the
 // top-level handler does not re-print it). logs is the container's buffered
-// startup output, read after the follow-goroutine's final flush.
-func (m *startupMonitor) handleFailure(ctx context.Context, c runtime.ContainerConfig, err error, logs string) error {
+// startup output, read after the follow-goroutine's final flush, and savedLogs
+// the session log holding all of it, if it was saved.
+func (m *startupMonitor) handleFailure(ctx context.Context, c runtime.ContainerConfig, err error, logs, savedLogs string) error {
 	errCode := telemetry.ErrCodeStartFailed
 
 	switch {
@@ -785,6 +941,9 @@ func (m *startupMonitor) handleFailure(ctx context.Context, c runtime.ContainerC
 				{Label: "Try again:", Value: "lstk start"},
 				{Label: "Allow more time on a slow machine:", Value: "LSTK_STARTUP_TIMEOUT=5m lstk start"},
 			}
+			if savedLogs != "" {
+				actions = append(actions, output.ErrorAction{Label: "Full logs saved to:", Value: savedLogs})
+			}
 		}
 		if tail := lastLogLines(logs, 15); tail != "" {
 			summary += "\nLast container output:\n" + tail
@@ -801,12 +960,16 @@ func (m *startupMonitor) handleFailure(ctx context.Context, c runtime.ContainerC
 		if tail := lastLogLines(logs, 15); tail != "" {
 			summary = "Last container output:\n" + tail
 		}
+		actions := []output.ErrorAction{
+			{Label: "Check your configuration and try again:", Value: "lstk start"},
+		}
+		if savedLogs != "" {
+			actions = append(actions, output.ErrorAction{Label: "Full logs saved to:", Value: savedLogs})
+		}
 		m.sink.Emit(output.ErrorEvent{
 			Title:   err.Error(),
 			Summary: summary,
-			Actions: []output.ErrorAction{
-				{Label: "Check your configuration and try again:", Value: "lstk start"},
-			},
+			Actions: actions,
 		})
 	}
 
@@ -826,6 +989,11 @@ func isStartupTimeout(err error) bool {
 }
 
 func selectContainersToStart(ctx context.Context, rt runtime.Runtime, sink output.Sink, tel *telemetry.Client, containers []runtime.ContainerConfig, localStackHost, webAppURL string) ([]runtime.ContainerConfig, error) {
+	configured := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		configured[c.Name] = true
+	}
+
 	var filtered []runtime.ContainerConfig
 	for _, c := range containers {
 		brief, err := rt.InspectBrief(ctx, c.Name)
@@ -833,7 +1001,14 @@ func selectContainersToStart(ctx context.Context, rt runtime.Runtime, sink outpu
 			return nil, fmt.Errorf("failed to check container status: %w", err)
 		}
 		if brief.Running {
-			emitAlreadyRunning(ctx, sink, c, localStackHost, webAppURL, isPersistenceEnabled(ctx, rt, c.Name))
+			emitAlreadyRunning(ctx, rt, sink, c, c.Name, localStackHost, webAppURL, isPersistenceEnabled(ctx, rt, c.Name))
+			continue
+		}
+		if brief.Starting {
+			sink.Emit(output.MessageEvent{
+				Severity: output.SeverityNote,
+				Text:     fmt.Sprintf("%s is already starting; run lstk wait to block until it is ready", c.EmulatorType.DisplayName()),
+			})
 			continue
 		}
 		if brief.Exists {
@@ -846,6 +1021,12 @@ func selectContainersToStart(ctx context.Context, rt runtime.Runtime, sink outpu
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan for running containers: %w", err)
 		}
+		// Another block of this config that is already up, or another named
+		// instance, is a sibling, not a conflict: it runs on its own port
+		// (validated at config load).
+		if found != nil && found.Name != c.Name && (configured[found.Name] || found.Instance != c.Instance) {
+			found = nil
+		}
 		if found != nil {
 			foundType := config.EmulatorTypeForImage(found.Image)
 			if foundType != "" && foundType != c.EmulatorType {
@@ -882,7 +1063,7 @@ func selectContainersToStart(ctx context.Context, rt runtime.Runtime, sink outpu
 				})
 				return nil, output.NewSilentError(fmt.Errorf("LocalStack already running on port %s", found.BoundPort))
 			}
-			emitAlreadyRunning(ctx, sink, c, localStackHost, webAppURL, isPersistenceEnabled(ctx, rt, found.Name))
+			emitAlreadyRunning(ctx, rt, sink, c, found.Name, localStackHost, webAppURL, isPersistenceEnabled(ctx, rt, found.Name))
 			continue
 		}
 
@@ -1559,19 +1740,8 @@ func (m *startupMonitor) await(ctx context.Context, containerID, healthURL strin
 			return false, exitedError(exitCh)
 		}
 
-		resp, gerr := client.Get(healthURL)
-		if gerr == nil && resp.StatusCode == http.StatusOK {
-			if cerr := resp.Body.Close(); cerr != nil {
-				m.sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: fmt.Sprintf("failed to close response body: %v", cerr)})
-			}
-			return true, nil
-		}
-		if resp != nil {
-			if cerr := resp.Body.Close(); cerr != nil {
-				m.sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: fmt.Sprintf("failed to close response body: %v", cerr)})
-			}
-		}
-		return false, nil
+		_, healthy := probeHealth(ctx, client, healthURL)
+		return healthy, nil
 	}
 
 	// Probe once before the first tick so a fast start is caught promptly.
@@ -1752,15 +1922,17 @@ func agentEnv(cl caller.Classification) []string {
 	return env
 }
 
-// checkSingleContainer rejects configs that enable more than one [[containers]]
-// block. Only one emulator is supported at a time; running several together
-// (e.g. AWS and Snowflake) is not supported yet and would collide on container
-// names and shared ports during startup.
-func checkSingleContainer(containers []config.ContainerConfig) error {
-	if len(containers) > 1 {
-		return fmt.Errorf("found %d [[containers]] blocks in your config, but only one is supported at a time", len(containers))
+// sharedPortsOwner picks the block that publishes the host ports every emulator
+// would otherwise claim identically (the 4510-4559 service range and the default
+// 443): the first AWS block, since those ports exist for AWS service endpoints,
+// or the first block when none is AWS.
+func sharedPortsOwner(containers []config.ContainerConfig) int {
+	for i, c := range containers {
+		if c.Type == config.EmulatorAWS {
+			return i
+		}
 	}
-	return nil
+	return 0
 }
 
 // servicePortRange returns the external service ports LocalStack opens for
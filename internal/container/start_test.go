@@ -32,37 +32,13 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func TestStart_RejectsMultipleContainersBeforeHealthCheck(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	// No IsHealthy expectation: the guard must fire before the runtime is touched.
-	mockRT := runtime.NewMockRuntime(ctrl)
-
-	sink := output.NewPlainSink(io.Discard)
-	opts := StartOptions{
-		Logger: log.Nop(),
-		Containers: []config.ContainerConfig{
-			{Type: config.EmulatorAWS, Port: "4566"},
-			{Type: config.EmulatorSnowflake, Port: "4567"},
-		},
-	}
-
-	_, err := Start(context.Background(), mockRT, sink, opts, false)
-
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "only one is supported at a time")
-	assert.True(t, output.IsSilent(err), "error should be silent since it was already emitted")
-}
-
-func TestCheckSingleContainer(t *testing.T) {
-	assert.NoError(t, checkSingleContainer(nil))
-	assert.NoError(t, checkSingleContainer([]config.ContainerConfig{{Type: config.EmulatorAWS}}))
-
-	err := checkSingleContainer([]config.ContainerConfig{
-		{Type: config.EmulatorAWS},
+func TestSharedPortsOwner(t *testing.T) {
+	assert.Equal(t, 0, sharedPortsOwner(nil))
+	assert.Equal(t, 0, sharedPortsOwner([]config.ContainerConfig{{Type: config.EmulatorSnowflake}}))
+	assert.Equal(t, 1, sharedPortsOwner([]config.ContainerConfig{
 		{Type: config.EmulatorSnowflake},
-	})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "only one is supported at a time")
+		{Type: config.EmulatorAWS},
+	}))
 }
 
 func TestResolvedPinnedVersion(t *testing.T) {
@@ -204,20 +180,42 @@ func TestEmitAlreadyRunning_IncludesRunningVersion(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
-	emitAlreadyRunning(context.Background(), sink, runtime.ContainerConfig{EmulatorType: config.EmulatorAWS, Port: port}, "", "", false)
+	emitAlreadyRunning(context.Background(), nil, sink, runtime.ContainerConfig{EmulatorType: config.EmulatorAWS, Port: port}, "", "", "", false)
 
 	got := out.String()
 	assert.Contains(t, got, "2026.5.3 is already running")
 	assert.NotContains(t, got, "04ddfd3a0", "build suffix should be stripped from the version")
 }
 
+// clusterRuntime is a runtime whose emulators have an in-cluster address.
+type clusterRuntime struct {
+	*runtime.MockRuntime
+}
+
+func (clusterRuntime) InClusterAddress(containerName, containerPort string) string {
+	return containerName + ".ci.svc:" + strings.TrimSuffix(containerPort, "/tcp")
+}
+
+func TestEmitAlreadyRunning_PointsAtInClusterAddress(t *testing.T) {
+	var out bytes.Buffer
+	sink := output.NewPlainSink(&out)
+	rt := clusterRuntime{runtime.NewMockRuntime(gomock.NewController(t))}
+
+	c := runtime.ContainerConfig{EmulatorType: config.EmulatorAWS, Port: "0", ContainerPort: "4566/tcp"}
+	emitAlreadyRunning(context.Background(), rt, sink, c, "localstack-aws", "", "", false)
+
+	got := out.String()
+	assert.Contains(t, got, "only while an lstk command runs")
+	assert.Contains(t, got, "Endpoint: localstack-aws.ci.svc:4566")
+}
+
 func TestEmitAlreadyRunning_FallsBackWhenVersionUnavailable(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
 	// Nothing is listening on this port, so the version lookup fails and we
 	// fall back to the bare note.
-	emitAlreadyRunning(context.Background(), sink, runtime.ContainerConfig{EmulatorType: config.EmulatorAWS, Port: "0"}, "", "", false)
+	emitAlreadyRunning(context.Background(), nil, sink, runtime.ContainerConfig{EmulatorType: config.EmulatorAWS, Port: "0"}, "", "", "", false)
 
 	got := out.String()
 	assert.Contains(t, got, "is already running")
@@ -291,6 +289,31 @@ func TestSelectContainersToStart_AttachesWhenExternalContainerOnConfiguredPort(t
 	assert.NotContains(t, out.String(), "config specifies")
 }
 
+func TestSelectContainersToStart_LeavesStartingContainerAlone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+
+	c := runtime.ContainerConfig{
+		Image:         "localstack/localstack-pro:latest",
+		Name:          "localstack-aws",
+		EmulatorType:  config.EmulatorAWS,
+		Port:          "4566",
+		ContainerPort: "4566/tcp",
+	}
+
+	// No Remove: a pod still pulling its image is not a leftover to heal.
+	mockRT.EXPECT().InspectBrief(gomock.Any(), c.Name).Return(runtime.ContainerBrief{Exists: true, Starting: true, Managed: true}, nil)
+
+	var out bytes.Buffer
+	sink := output.NewPlainSink(&out)
+
+	result, err := selectContainersToStart(context.Background(), mockRT, sink, nil, []runtime.ContainerConfig{c}, "", "")
+
+	require.NoError(t, err)
+	assert.Empty(t, result)
+	assert.Contains(t, out.String(), "already starting")
+}
+
 func TestSelectContainersToStart_AttachesWhenExternalContainerVersionDiffers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRT := runtime.NewMockRuntime(ctrl)
@@ -705,7 +728,7 @@ func TestStartContainers_SnowflakeLicenseError(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
-	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 0, false, false)
+	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 0, false, false, nil)
 	tel.Close()
 
 	require.Error(t, err)
@@ -727,6 +750,42 @@ func TestStartContainers_SnowflakeLicenseError(t *testing.T) {
 	}
 }
 
+func TestStartContainers_StopsStartedContainersWhenALaterOneFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+
+	aws := runtime.ContainerConfig{
+		Image:         "localstack/localstack-pro:latest",
+		Name:          "localstack-aws",
+		EmulatorType:  config.EmulatorAWS,
+		Port:          "4566",
+		ContainerPort: "4566/tcp",
+		HealthPath:    "/_localstack/health",
+	}
+	snowflake := runtime.ContainerConfig{
+		Image:         "localstack/snowflake:latest",
+		Name:          "localstack-snowflake",
+		EmulatorType:  config.EmulatorSnowflake,
+		Port:          "4567",
+		ContainerPort: "4566/tcp",
+		HealthPath:    "/_localstack/health",
+	}
+	const containerID = "abc123"
+	mockRT.EXPECT().Start(gomock.Any(), aws).Return(containerID, exitResultChan(runtime.ExitResult{ExitCode: 0}), nil)
+	mockRT.EXPECT().StreamLogs(gomock.Any(), containerID, gomock.Any(), true, "all").Return(nil)
+	mockRT.EXPECT().Start(gomock.Any(), snowflake).Return("", nil, errors.New("port is already allocated"))
+	mockRT.EXPECT().Stop(gomock.Any(), aws.Name).Return(nil)
+
+	tel, _ := newCapturingTelClient(t)
+	defer tel.Close()
+
+	var out bytes.Buffer
+	err := startContainers(context.Background(), mockRT, output.NewPlainSink(&out), tel, []runtime.ContainerConfig{aws, snowflake}, map[string]bool{}, 0, false, false, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, out.String(), "Stopped localstack-aws")
+}
+
 func TestStartContainers_AzureLicenseError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRT := runtime.NewMockRuntime(ctrl)
@@ -752,7 +811,7 @@ func TestStartContainers_AzureLicenseError(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
-	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 0, false, false)
+	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 0, false, false, nil)
 	tel.Close()
 
 	require.Error(t, err)
@@ -1006,7 +1065,7 @@ func TestStartContainers_ExitedEmitsErrorAndTelemetry(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
-	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, time.Minute, false, false)
+	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, time.Minute, false, false, nil)
 	tel.Close()
 
 	require.Error(t, err)
@@ -1051,7 +1110,7 @@ func TestStartContainers_TimeoutEmitsErrorAndTelemetry(t *testing.T) {
 	var out bytes.Buffer
 	sink := output.NewPlainSink(&out)
 
-	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 50*time.Millisecond, false, false)
+	err := startContainers(context.Background(), mockRT, sink, tel, []runtime.ContainerConfig{c}, map[string]bool{}, 50*time.Millisecond, false, false, nil)
 	tel.Close()
 
 	require.Error(t, err)
@@ -1743,3 +1802,46 @@ func TestPromptRelogin_OffersAnAdvertisedDeclineKey(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectContainersToStart_IgnoresRunningSiblingBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	freePort := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	aws := runtime.ContainerConfig{
+		Image:         "localstack/localstack-pro:latest",
+		Name:          "localstack-aws",
+		EmulatorType:  config.EmulatorAWS,
+		Tag:           "latest",
+		Port:          "4566",
+		ContainerPort: "4566/tcp",
+	}
+	snowflake := runtime.ContainerConfig{
+		Image:         "localstack/snowflake:latest",
+		Name:          "localstack-snowflake",
+		EmulatorType:  config.EmulatorSnowflake,
+		Tag:           "latest",
+		Port:          strconv.Itoa(freePort),
+		ContainerPort: "4566/tcp",
+	}
+
+	mockRT.EXPECT().InspectBrief(gomock.Any(), aws.Name).Return(runtime.ContainerBrief{Exists: true, Running: true}, nil)
+	mockRT.EXPECT().ContainerEnv(gomock.Any(), aws.Name).Return(nil, nil).AnyTimes()
+	mockRT.EXPECT().InspectBrief(gomock.Any(), snowflake.Name).Return(runtime.ContainerBrief{}, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), "4566/tcp").
+		Return(&runtime.RunningContainer{Name: aws.Name, Image: aws.Image, BoundPort: "4566"}, nil)
+	mockRT.EXPECT().Flavor().Return(runtime.FlavorDockerDesktop).AnyTimes()
+
+	var out bytes.Buffer
+	sink := output.NewPlainSink(&out)
+
+	result, err := selectContainersToStart(context.Background(), mockRT, sink, nil, []runtime.ContainerConfig{aws, snowflake}, "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []runtime.ContainerConfig{snowflake}, result, "the running AWS block is a sibling, not a conflict")
+	assert.NotContains(t, out.String(), "is running on port")
+}
@@ -3,6 +3,8 @@ package container
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/localstack/lstk/internal/config"
@@ -24,30 +26,22 @@ func Status(ctx context.Context, rt runtime.Runtime, containers []config.Contain
 	ctx, cancel := context.WithTimeout(ctx, statusTimeout)
 	defer cancel()
 
+	running := 0
 	for _, c := range containers {
 		name, err := ResolveRunningContainerName(ctx, rt, c)
 		if err != nil {
 			return fmt.Errorf("checking %s running: %w", c.Name(), err)
 		}
 		if name == "" {
+			if len(containers) > 1 {
+				sink.Emit(output.EmulatorNotRunningEvent{Type: string(c.Type), DisplayName: c.DisplayName()})
+				continue
+			}
 			return HandleNoRunningContainer(sink, c)
 		}
+		running++
 
-		// status makes direct HTTP calls to LocalStack, so it needs the actual host port.
-		// Ask Docker rather than trusting the config: the user may have changed the config
-		// port while the container still runs on the old one.
-		port := c.Port
-		if containerPort, err := c.ContainerPort(); err == nil {
-			if actualPort, err := rt.GetBoundPort(ctx, name, containerPort); err == nil {
-				port = actualPort
-			}
-		}
-		host, _ := endpoint.ResolveHost(ctx, port, localStackHost)
-		if c.Type == config.EmulatorSnowflake {
-			if h := snowflake.Hostname(host); h != "" {
-				host = h
-			}
-		}
+		host := statusHost(ctx, rt, c, name, localStackHost)
 
 		var uptime time.Duration
 		if startedAt, err := rt.ContainerStartedAt(ctx, name); err == nil {
@@ -56,6 +50,7 @@ func Status(ctx context.Context, rt runtime.Runtime, containers []config.Contain
 
 		var version string
 		var rows []emulator.Resource
+		services := fetchServiceStates(ctx, "http://"+host)
 		if client, ok := clients[c.Type]; ok {
 			baseURL := "http://" + host
 			sink.Emit(output.SpinnerStart("Fetching LocalStack status"))
@@ -74,12 +69,14 @@ func Status(ctx context.Context, rt runtime.Runtime, containers []config.Contain
 		}
 
 		sink.Emit(output.InstanceInfoEvent{
+			Type:          string(c.Type),
 			EmulatorName:  c.DisplayName(),
 			Version:       version,
 			Host:          host,
 			ContainerName: name,
 			Uptime:        uptime,
 			Persistence:   c.Type == config.EmulatorAWS && isPersistenceEnabled(ctx, rt, name),
+			Services:      services,
 		})
 
 		if c.Type == config.EmulatorAWS {
@@ -87,9 +84,32 @@ func Status(ctx context.Context, rt runtime.Runtime, containers []config.Contain
 		}
 	}
 
+	if running == 0 && len(containers) > 1 {
+		return handleNoRunningEmulators(sink)
+	}
 	return nil
 }
 
+// statusHost returns the host:port status reaches the running container name
+// at. status makes direct HTTP calls to LocalStack, so it needs the actual
+// host port. Ask Docker rather than trusting the config: the user may have
+// changed the config port while the container still runs on the old one.
+func statusHost(ctx context.Context, rt runtime.Runtime, c config.ContainerConfig, name, localStackHost string) string {
+	port := c.Port
+	if containerPort, err := c.ContainerPort(); err == nil {
+		if actualPort, err := rt.GetBoundPort(ctx, name, containerPort); err == nil {
+			port = actualPort
+		}
+	}
+	host, _ := endpoint.ResolveHost(ctx, port, localStackHost)
+	if c.Type == config.EmulatorSnowflake {
+		if h := snowflake.Hostname(host); h != "" {
+			host = h
+		}
+	}
+	return host
+}
+
 // StatusExternal renders status for an externally-managed endpoint
 // (--endpoint-url/LSTK_ENDPOINT_URL/AWS_ENDPOINT_URL): reachability, detected
 // type, reported version, and — for an AWS-typed target — deployed resources,
@@ -102,6 +122,7 @@ func Status(ctx context.Context, rt runtime.Runtime, containers []config.Contain
 func StatusExternal(ctx context.Context, target *endpoint.Target, clients map[config.EmulatorType]emulator.Client, sink output.Sink) error {
 	var version string
 	var rows []emulator.Resource
+	services := fetchServiceStates(ctx, target.URL)
 	if client, ok := clients[target.Type]; ok {
 		sink.Emit(output.SpinnerStart("Fetching LocalStack status"))
 		if v, err := client.FetchVersion(ctx, target.URL); err != nil {
@@ -119,9 +140,11 @@ func StatusExternal(ctx context.Context, target *endpoint.Target, clients map[co
 	}
 
 	sink.Emit(output.InstanceInfoEvent{
+		Type:         string(target.Type),
 		EmulatorName: target.Type.DisplayName(),
 		Version:      version,
 		Host:         target.URL,
+		Services:     services,
 	})
 
 	if target.Type == config.EmulatorAWS {
@@ -131,6 +154,18 @@ func StatusExternal(ctx context.Context, target *endpoint.Target, clients map[co
 	return nil
 }
 
+// fetchServiceStates returns the per-service states the emulator at baseURL
+// reports in /_localstack/health, or nil when it reports none. A failure only
+// drops the services from the output: the version fetch already reports an
+// unreachable emulator.
+func fetchServiceStates(ctx context.Context, baseURL string) map[string]string {
+	health, ok := probeHealth(ctx, &http.Client{Timeout: 2 * time.Second}, strings.TrimRight(baseURL, "/")+"/_localstack/health")
+	if !ok || len(health.Services) == 0 {
+		return nil
+	}
+	return health.Services
+}
+
 func emitResources(sink output.Sink, rows []emulator.Resource) {
 	if len(rows) == 0 {
 		sink.Emit(output.MessageEvent{Severity: output.SeverityNote, Text: "No resources deployed"})
@@ -1,12 +1,14 @@
 package container
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"testing"
 
 	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/endpoint"
 	"github.com/localstack/lstk/internal/output"
 	"github.com/localstack/lstk/internal/runtime"
 	"github.com/stretchr/testify/assert"
@@ -29,21 +31,44 @@ func TestStatus_IsRunningError(t *testing.T) {
 	assert.Contains(t, err.Error(), "docker unavailable")
 }
 
-func TestStatus_MultipleContainers_StopsAtFirstNotRunning(t *testing.T) {
+func TestStatus_MultipleContainers_ErrorsWhenNoneRunning(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockRT := runtime.NewMockRuntime(ctrl)
 	mockRT.EXPECT().IsHealthy(gomock.Any()).Return(nil)
 	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(false, nil)
 	mockRT.EXPECT().FindRunningByImage(gomock.Any(), []string{"localstack/localstack-pro", "localstack/localstack"}, "4566/tcp").Return(nil, nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-snowflake").Return(false, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), "4566/tcp").Return(nil, nil)
 
 	containers := []config.ContainerConfig{
 		{Type: config.EmulatorAWS},
 		{Type: config.EmulatorSnowflake},
 	}
-	sink := output.NewPlainSink(io.Discard)
+	var out bytes.Buffer
+	sink := output.NewPlainSink(&out)
 
 	err := Status(context.Background(), mockRT, containers, "", nil, sink)
 
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
+	assert.Contains(t, out.String(), "LocalStack AWS Emulator is not running")
+	assert.Contains(t, out.String(), "No emulator is running")
+}
+
+func TestStatusExternal_ReportsServiceStates(t *testing.T) {
+	srv := healthServer(t, 0)
+	sink := &recordingSink{}
+
+	err := StatusExternal(context.Background(), &endpoint.Target{URL: srv.URL, Type: config.EmulatorAWS}, nil, sink)
+	require.NoError(t, err)
+
+	var infos []output.InstanceInfoEvent
+	for _, e := range sink.events {
+		if ev, ok := e.(output.InstanceInfoEvent); ok {
+			infos = append(infos, ev)
+		}
+	}
+	require.Len(t, infos, 1)
+	assert.Equal(t, "aws", infos[0].Type)
+	assert.Equal(t, map[string]string{"s3": "available", "sqs": "running", "kinesis": "disabled"}, infos[0].Services)
 }
@@ -0,0 +1,203 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/emulator"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// WatchStatus polls the emulators in containers every interval until ctx is
+// canceled, emitting a StatusRefreshEvent per refresh with what changed since
+// the previous one. Unlike Status, an emulator that is not running is watched
+// rather than reported as an error: it may be started while watching.
+func WatchStatus(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, localStackHost string, clients map[config.EmulatorType]emulator.Client, interval time.Duration, sink output.Sink) error {
+	if err := rt.IsHealthy(ctx); err != nil {
+		rt.EmitUnhealthyError(sink, err)
+		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
+	}
+
+	return watchStatus(ctx, interval, sink, func(ctx context.Context) []output.WatchedEmulator {
+		emulators := make([]output.WatchedEmulator, len(containers))
+		for i, c := range containers {
+			emulators[i] = inspectContainer(ctx, rt, c, localStackHost, clients[c.Type])
+		}
+		return emulators
+	})
+}
+
+// WatchStatusExternal is WatchStatus for an externally-managed endpoint. The
+// emulator counts as running while its health endpoint answers.
+func WatchStatusExternal(ctx context.Context, target *endpoint.Target, clients map[config.EmulatorType]emulator.Client, interval time.Duration, sink output.Sink) error {
+	return watchStatus(ctx, interval, sink, func(ctx context.Context) []output.WatchedEmulator {
+		em := output.WatchedEmulator{Info: output.InstanceInfoEvent{
+			Type:         string(target.Type),
+			EmulatorName: target.Type.DisplayName(),
+			Host:         target.URL,
+		}}
+		healthURL := strings.TrimRight(target.URL, "/") + "/_localstack/health"
+		if health, ok := probeHealth(ctx, &http.Client{Timeout: 2 * time.Second}, healthURL); ok {
+			em.Running = true
+			if len(health.Services) > 0 {
+				em.Info.Services = health.Services
+			}
+			inspectEmulator(ctx, &em, target.Type, clients[target.Type], target.URL)
+		}
+		return []output.WatchedEmulator{em}
+	})
+}
+
+// watchStatus runs poll every interval, diffs each emulator against the
+// previous refresh and emits the result. Canceling ctx ends the watch without
+// an error, as it is how the user stops watching.
+func watchStatus(ctx context.Context, interval time.Duration, sink output.Sink, poll func(context.Context) []output.WatchedEmulator) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous map[string]output.WatchedEmulator
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, statusTimeout)
+		emulators := poll(pollCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		seen := make(map[string]output.WatchedEmulator, len(emulators))
+		for i := range emulators {
+			key := emulators[i].Info.Type + "/" + emulators[i].Info.EmulatorName
+			if prev, ok := previous[key]; ok {
+				diffWatched(&emulators[i], prev)
+			} else {
+				emulators[i].WasRunning = emulators[i].Running
+				emulators[i].PrevErr = emulators[i].Err
+			}
+			seen[key] = emulators[i]
+		}
+		sink.Emit(output.StatusRefreshEvent{Time: time.Now(), Interval: interval, Initial: previous == nil, Emulators: emulators})
+		previous = seen
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// diffWatched fills in what changed in current since prev. While an emulator
+// is stopped or cannot be refreshed, its last known resources are carried
+// over, so a restart with persistence does not read as everything removed
+// and re-added.
+func diffWatched(current *output.WatchedEmulator, prev output.WatchedEmulator) {
+	current.PrevErr = prev.Err
+	if current.Err != "" {
+		current.Running = prev.Running
+	}
+	current.WasRunning = prev.Running
+	if !current.Running || current.Err != "" {
+		current.Resources = prev.Resources
+		return
+	}
+
+	current.Added = resourcesMissingFrom(current.Resources, prev.Resources)
+	current.Removed = resourcesMissingFrom(prev.Resources, current.Resources)
+
+	if !prev.Running || prev.Err != "" {
+		return
+	}
+	names := make([]string, 0, len(current.Info.Services))
+	for service := range current.Info.Services {
+		names = append(names, service)
+	}
+	for service := range prev.Info.Services {
+		if _, ok := current.Info.Services[service]; !ok {
+			names = append(names, service)
+		}
+	}
+	slices.Sort(names)
+	for _, service := range names {
+		from, to := prev.Info.Services[service], current.Info.Services[service]
+		if from != to {
+			current.ServiceChanges = append(current.ServiceChanges, output.ServiceStateChange{Service: service, From: from, To: to})
+		}
+	}
+}
+
+// resourcesMissingFrom returns the resources in a that are not in b, in a's order.
+func resourcesMissingFrom(a, b []output.StatusResource) []output.StatusResource {
+	inB := make(map[output.StatusResource]struct{}, len(b))
+	for _, r := range b {
+		inB[r] = struct{}{}
+	}
+	var missing []output.StatusResource
+	for _, r := range a {
+		if _, ok := inB[r]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// inspectContainer gathers what status reports about the emulator c.
+func inspectContainer(ctx context.Context, rt runtime.Runtime, c config.ContainerConfig, localStackHost string, client emulator.Client) output.WatchedEmulator {
+	em := output.WatchedEmulator{Info: output.InstanceInfoEvent{Type: string(c.Type), EmulatorName: c.DisplayName()}}
+	name, err := ResolveRunningContainerName(ctx, rt, c)
+	if err != nil {
+		em.Err = fmt.Sprintf("checking %s running: %v", c.Name(), err)
+		return em
+	}
+	if name == "" {
+		return em
+	}
+	em.Running = true
+
+	host := statusHost(ctx, rt, c, name, localStackHost)
+	em.Info.Host = host
+	em.Info.ContainerName = name
+	if startedAt, err := rt.ContainerStartedAt(ctx, name); err == nil {
+		em.Info.Uptime = time.Since(startedAt)
+	}
+	em.Info.Persistence = c.Type == config.EmulatorAWS && isPersistenceEnabled(ctx, rt, name)
+	em.Info.Services = fetchServiceStates(ctx, "http://"+host)
+	inspectEmulator(ctx, &em, c.Type, client, "http://"+host)
+	return em
+}
+
+// inspectEmulator fills in the version and, for AWS, the deployed resources
+// of the running emulator at baseURL.
+func inspectEmulator(ctx context.Context, em *output.WatchedEmulator, emulatorType config.EmulatorType, client emulator.Client, baseURL string) {
+	if client == nil {
+		return
+	}
+	version, err := client.FetchVersion(ctx, baseURL)
+	if err != nil {
+		em.Err = fmt.Sprintf("could not fetch version: %v", err)
+		return
+	}
+	em.Info.Version = version
+	if emulatorType != config.EmulatorAWS {
+		return
+	}
+	rows, err := client.FetchResources(ctx, baseURL)
+	if err != nil {
+		em.Err = fmt.Sprintf("could not fetch resources: %v", err)
+		return
+	}
+	em.Resources = make([]output.StatusResource, len(rows))
+	for i, r := range rows {
+		em.Resources[i] = statusResource(r)
+	}
+}
+
+func statusResource(r emulator.Resource) output.StatusResource {
+	return output.StatusResource{Service: r.Service, Name: r.Name, Region: r.Region, Account: r.Account}
+}
@@ -0,0 +1,117 @@
+package container
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/emulator"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changingClient reports version 4.0.0 and, on each FetchResources call, the
+// next entry of resources (repeating the last one).
+type changingClient struct {
+	resources [][]emulator.Resource
+	calls     atomic.Int32
+}
+
+func (c *changingClient) FetchVersion(context.Context, string) (string, error) {
+	return "4.0.0", nil
+}
+
+func (c *changingClient) FetchResources(context.Context, string) ([]emulator.Resource, error) {
+	i := int(c.calls.Add(1)) - 1
+	return c.resources[min(i, len(c.resources)-1)], nil
+}
+
+func TestWatchStatusExternal_ReportsWhatChangedBetweenRefreshes(t *testing.T) {
+	t.Parallel()
+	srv := healthServer(t, 1)
+	queue := emulator.Resource{Service: "SQS", Name: "orders", Region: "us-east-1", Account: "000000000000"}
+	bucket := emulator.Resource{Service: "S3", Name: "uploads", Region: "us-east-1", Account: "000000000000"}
+	client := &changingClient{resources: [][]emulator.Resource{{bucket}, {queue}}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var refreshes []output.StatusRefreshEvent
+	sink := output.SinkFunc(func(e output.Event) {
+		if ev, ok := e.(output.StatusRefreshEvent); ok {
+			refreshes = append(refreshes, ev)
+			if len(refreshes) == 2 {
+				cancel()
+			}
+		}
+	})
+
+	target := &endpoint.Target{URL: srv.URL, Type: config.EmulatorAWS}
+	clients := map[config.EmulatorType]emulator.Client{config.EmulatorAWS: client}
+	err := WatchStatusExternal(ctx, target, clients, 50*time.Millisecond, sink)
+	require.NoError(t, err)
+	require.Len(t, refreshes, 2)
+
+	first := refreshes[0]
+	assert.True(t, first.Initial)
+	require.Len(t, first.Emulators, 1)
+	assert.True(t, first.Emulators[0].Running)
+	assert.Equal(t, "4.0.0", first.Emulators[0].Info.Version)
+	assert.Equal(t, []output.StatusResource{statusResource(bucket)}, first.Emulators[0].Resources)
+	assert.False(t, first.Emulators[0].Changed())
+
+	second := refreshes[1].Emulators[0]
+	assert.False(t, refreshes[1].Initial)
+	assert.Equal(t, []output.StatusResource{statusResource(queue)}, second.Added)
+	assert.Equal(t, []output.StatusResource{statusResource(bucket)}, second.Removed)
+	assert.Equal(t, []output.ServiceStateChange{{Service: "sqs", From: "initializing", To: "running"}}, second.ServiceChanges)
+	assert.True(t, second.Changed())
+}
+
+func TestDiffWatched_KeepsResourcesWhileStopped(t *testing.T) {
+	t.Parallel()
+	bucket := output.StatusResource{Service: "S3", Name: "uploads"}
+	running := output.WatchedEmulator{
+		Info:      output.InstanceInfoEvent{Type: "aws", Services: map[string]string{"s3": "running"}},
+		Running:   true,
+		Resources: []output.StatusResource{bucket},
+	}
+
+	stopped := output.WatchedEmulator{Info: output.InstanceInfoEvent{Type: "aws"}}
+	diffWatched(&stopped, running)
+	assert.True(t, stopped.WasRunning)
+	assert.Empty(t, stopped.Removed)
+	assert.Equal(t, []output.StatusResource{bucket}, stopped.Resources)
+	assert.True(t, stopped.Changed())
+
+	restarted := output.WatchedEmulator{
+		Info:      output.InstanceInfoEvent{Type: "aws", Services: map[string]string{"s3": "available"}},
+		Running:   true,
+		Resources: []output.StatusResource{bucket},
+	}
+	diffWatched(&restarted, stopped)
+	assert.Empty(t, restarted.Added)
+	assert.Empty(t, restarted.Removed)
+	assert.Empty(t, restarted.ServiceChanges, "services are only compared between two running refreshes")
+	assert.True(t, restarted.Changed())
+}
+
+func TestDiffWatched_FailedRefreshKeepsLastState(t *testing.T) {
+	t.Parallel()
+	bucket := output.StatusResource{Service: "S3", Name: "uploads"}
+	prev := output.WatchedEmulator{Info: output.InstanceInfoEvent{Type: "aws"}, Running: true, Resources: []output.StatusResource{bucket}}
+
+	failed := output.WatchedEmulator{Info: output.InstanceInfoEvent{Type: "aws"}, Err: "could not fetch resources: boom"}
+	diffWatched(&failed, prev)
+	assert.True(t, failed.Running)
+	assert.Equal(t, []output.StatusResource{bucket}, failed.Resources)
+	assert.Empty(t, failed.Removed)
+	assert.True(t, failed.Changed())
+
+	again := output.WatchedEmulator{Info: output.InstanceInfoEvent{Type: "aws"}, Err: "could not fetch resources: boom"}
+	diffWatched(&again, failed)
+	assert.False(t, again.Changed())
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/localstack/lstk/internal/telemetry"
 )
 
-// StopOptions carries optional telemetry context for the stop command.
+// StopOptions carries optional telemetry context and hooks for the stop command.
 type StopOptions struct {
 	Telemetry *telemetry.Client
+	// BeforeStop, when set, runs for each running emulator before it is stopped,
+	// e.g. to save its state. It cannot veto the stop: it reports its own
+	// failures to the sink.
+	BeforeStop func(ctx context.Context, sink output.Sink, c config.ContainerConfig)
 }
 
 func Stop(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers []config.ContainerConfig, opts StopOptions) error {
@@ -23,12 +27,19 @@ func Stop(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers
 	}
 
 	const stopTimeout = 30 * time.Second
+	stopped := 0
 	for _, c := range containers {
 		name, err := ResolveRunningContainerName(ctx, rt, c)
 		if err != nil {
 			return err
 		}
 		if name == "" {
+			if len(containers) > 1 {
+				// With several emulators configured, one being down is not an
+				// error on its own: stop the rest and report it.
+				sink.Emit(output.EmulatorStoppedEvent{Type: string(c.Type), Name: c.Name(), DisplayName: c.DisplayName(), WasRunning: false})
+				continue
+			}
 			sink.Emit(output.ErrorEvent{
 				Title: fmt.Sprintf("%s is not running", c.DisplayName()),
 				Code:  output.ErrEmulatorNotRunning,
@@ -39,20 +50,29 @@ func Stop(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers
 		// Fetch localstack info before stopping so it can be included in telemetry.
 		lsInfo, _ := fetchLocalStackInfo(ctx, c.Port)
 
+		if opts.BeforeStop != nil {
+			opts.BeforeStop(ctx, sink, c)
+		}
+
 		stopStart := time.Now()
 
-		sink.Emit(output.SpinnerStart("Stopping LocalStack..."))
+		label := "LocalStack"
+		if len(containers) > 1 {
+			label = c.DisplayName()
+		}
+		sink.Emit(output.SpinnerStart(fmt.Sprintf("Stopping %s...", label)))
 		stopCtx, stopCancel := context.WithTimeout(ctx, stopTimeout)
 		if err := rt.Stop(stopCtx, name); err != nil {
 			stopCancel()
 			sink.Emit(output.SpinnerStop())
-			wrapped := fmt.Errorf("failed to stop LocalStack: %w", err)
+			wrapped := fmt.Errorf("failed to stop %s: %w", label, err)
 			sink.Emit(output.ErrorEvent{Title: wrapped.Error(), Code: output.ErrRuntimeUnavailable})
 			return output.NewSilentError(wrapped)
 		}
 		stopCancel()
 		sink.Emit(output.SpinnerStop())
 		sink.Emit(output.EmulatorStoppedEvent{Type: string(c.Type), Name: name, DisplayName: c.DisplayName(), WasRunning: true})
+		stopped++
 
 		opts.Telemetry.EmitEmulatorLifecycleEvent(ctx, telemetry.LifecycleEvent{
 			EventType:      telemetry.LifecycleStop,
@@ -62,5 +82,12 @@ func Stop(ctx context.Context, rt runtime.Runtime, sink output.Sink, containers
 		})
 	}
 
+	if stopped == 0 && len(containers) > 1 {
+		sink.Emit(output.ErrorEvent{
+			Title: "No emulator is running",
+			Code:  output.ErrEmulatorNotRunning,
+		})
+		return output.NewSilentError(fmt.Errorf("no emulator is running"))
+	}
 	return nil
 }
@@ -0,0 +1,43 @@
+package container
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStop_BeforeStopRunsBeforeEachRunningEmulatorStops(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+	mockRT.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-snowflake").Return(false, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	var hooked []string
+	mockRT.EXPECT().Stop(gomock.Any(), "localstack-aws").DoAndReturn(func(context.Context, string) error {
+		assert.Equal(t, []string{"localstack-aws"}, hooked, "the hook must run before the container is stopped")
+		return nil
+	})
+
+	containers := []config.ContainerConfig{
+		{Type: config.EmulatorAWS, Port: "4566"},
+		{Type: config.EmulatorSnowflake, Port: "4567"},
+	}
+	err := Stop(context.Background(), mockRT, output.NewPlainSink(io.Discard), containers, StopOptions{
+		Telemetry: telemetry.New("", true),
+		BeforeStop: func(_ context.Context, _ output.Sink, c config.ContainerConfig) {
+			hooked = append(hooked, c.Name())
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localstack-aws"}, hooked, "an emulator that is not running is not hooked")
+}
@@ -0,0 +1,242 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// WaitOptions configures Wait and WaitExternal.
+type WaitOptions struct {
+	// Timeout bounds the whole wait, across every emulator waited for. Zero uses
+	// the non-interactive startup timeout, as `lstk start` does in CI.
+	Timeout time.Duration
+	// Services lists services that must report "available" or "running" in
+	// /_localstack/health. When empty, a healthy emulator is enough.
+	Services []string
+}
+
+// Wait blocks until every emulator in containers is running and healthy, and
+// every service in opts.Services is ready. An emulator that is not running yet
+// is waited for too, so Wait can follow a `lstk start` sent to the background.
+func Wait(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, localStackHost string, opts WaitOptions, sink output.Sink) error {
+	if err := rt.IsHealthy(ctx); err != nil {
+		rt.EmitUnhealthyError(sink, err)
+		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
+	}
+
+	timeout := resolveStartupTimeout(opts.Timeout, false)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	started := time.Now()
+
+	for _, c := range containers {
+		var name string
+		locate := func(ctx context.Context) (string, error) {
+			var err error
+			if name, err = ResolveRunningContainerName(ctx, rt, c); err != nil || name == "" {
+				return "", err
+			}
+			// Ask the runtime for the bound port, as status does: the config may
+			// have changed while the container kept running on the old one.
+			port := c.Port
+			if containerPort, err := c.ContainerPort(); err == nil {
+				if actualPort, err := rt.GetBoundPort(ctx, name, containerPort); err == nil {
+					port = actualPort
+				}
+			}
+			host, _ := endpoint.ResolveHost(ctx, port, localStackHost)
+			return "http://" + host, nil
+		}
+
+		sink.Emit(output.SpinnerStart(fmt.Sprintf("Waiting for %s", c.DisplayName())))
+		baseURL, health, err := pollReady(waitCtx, locate, opts.Services)
+		sink.Emit(output.SpinnerStop())
+		if err != nil {
+			return waitFailed(ctx, sink, c.DisplayName(), timeout, err, true)
+		}
+		sink.Emit(output.EmulatorReadyEvent{
+			Type:        string(c.Type),
+			Name:        name,
+			DisplayName: c.DisplayName(),
+			URL:         baseURL,
+			Services:    serviceStates(health, opts.Services),
+			Waited:      time.Since(started),
+		})
+	}
+	return nil
+}
+
+// WaitExternal is Wait for an externally-managed emulator given by
+// --endpoint-url (or LSTK_ENDPOINT_URL/AWS_ENDPOINT_URL). endpointURL is only
+// validated, not probed, since the emulator may not be up yet; it is probed for
+// its type once it answers.
+func WaitExternal(ctx context.Context, endpointURL string, opts WaitOptions, sink output.Sink) error {
+	timeout := resolveStartupTimeout(opts.Timeout, false)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	started := time.Now()
+
+	locate := func(context.Context) (string, error) { return endpointURL, nil }
+	sink.Emit(output.SpinnerStart(fmt.Sprintf("Waiting for %s", endpointURL)))
+	_, health, err := pollReady(waitCtx, locate, opts.Services)
+	sink.Emit(output.SpinnerStop())
+	if err != nil {
+		return waitFailed(ctx, sink, "The emulator at "+endpointURL, timeout, err, false)
+	}
+
+	target, err := endpoint.Probe(ctx, endpointURL)
+	if err != nil {
+		sink.Emit(output.ErrorEvent{Title: err.Error(), Code: output.ErrEmulatorNotReady})
+		return output.NewSilentError(err)
+	}
+	sink.Emit(output.EmulatorReadyEvent{
+		Type:        string(target.Type),
+		DisplayName: target.Type.DisplayName(),
+		URL:         target.URL,
+		Services:    serviceStates(health, opts.Services),
+		Waited:      time.Since(started),
+	})
+	return nil
+}
+
+// notReadyError is returned by pollReady when ctx expires first. pending says
+// what was still missing at the last poll.
+type notReadyError struct {
+	pending string
+}
+
+func (e *notReadyError) Error() string {
+	return "not ready: " + e.pending
+}
+
+// unknownServiceError is returned by pollReady when a requested service is not
+// in the emulator's health report at all, which no amount of waiting fixes.
+type unknownServiceError struct {
+	service string
+}
+
+func (e *unknownServiceError) Error() string {
+	return fmt.Sprintf("the emulator does not provide a service named %q", e.service)
+}
+
+// pollReady polls the emulator located by locate (an empty URL meaning it is
+// not running yet) once a second until its health endpoint answers and every
+// service in services is ready, returning the emulator's URL and last health
+// report. It fails with *notReadyError when ctx expires first.
+func pollReady(ctx context.Context, locate func(context.Context) (string, error), services []string) (string, *endpoint.Health, error) {
+	client := &http.Client{Timeout: 2 * time.Second}
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	pending := "the emulator is not running"
+	for {
+		baseURL, err := locate(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				return "", nil, err
+			}
+		case baseURL == "":
+			pending = "the emulator is not running"
+		default:
+			health, ok := probeHealth(ctx, client, baseURL+"/_localstack/health")
+			if !ok {
+				pending = fmt.Sprintf("%s/_localstack/health is not responding yet", baseURL)
+				break
+			}
+			var waiting []string
+			for _, s := range services {
+				state, known := health.Services[s]
+				if !known {
+					return "", nil, &unknownServiceError{service: s}
+				}
+				if !endpoint.ServiceReady(state) {
+					waiting = append(waiting, fmt.Sprintf("%s (%s)", s, state))
+				}
+			}
+			if len(waiting) == 0 {
+				return baseURL, health, nil
+			}
+			pending = "waiting for " + strings.Join(waiting, ", ")
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", nil, &notReadyError{pending: pending}
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeHealth GETs healthURL and reports whether the emulator answered 200,
+// which is what readiness means to both `lstk start` and `lstk wait`. The
+// returned Health carries the per-service states when the body has them.
+func probeHealth(ctx context.Context, client *http.Client, healthURL string) (*endpoint.Health, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return nil, false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+	health := &endpoint.Health{}
+	_ = json.NewDecoder(resp.Body).Decode(health)
+	return health, true
+}
+
+// serviceStates picks the requested services out of a health report.
+func serviceStates(health *endpoint.Health, services []string) map[string]string {
+	if len(services) == 0 {
+		return nil
+	}
+	states := make(map[string]string, len(services))
+	for _, s := range services {
+		states[s] = health.Services[s]
+	}
+	return states
+}
+
+// waitFailed reports a failed wait for the emulator named label. A canceled
+// parent ctx (Ctrl+C) is returned as-is rather than reported as a timeout.
+func waitFailed(ctx context.Context, sink output.Sink, label string, timeout time.Duration, err error, local bool) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var unknown *unknownServiceError
+	if errors.As(err, &unknown) {
+		sink.Emit(output.ErrorEvent{
+			Title: fmt.Sprintf("%s does not provide a service named %q", label, unknown.service),
+			Code:  output.ErrValidationError,
+		})
+		return output.NewSilentError(err)
+	}
+	var notReady *notReadyError
+	if !errors.As(err, &notReady) {
+		return err
+	}
+	event := output.ErrorEvent{
+		Title:   fmt.Sprintf("%s is not ready after %s", label, timeout),
+		Summary: notReady.pending,
+		Code:    output.ErrEmulatorNotReady,
+	}
+	if local {
+		event.Actions = []output.ErrorAction{{Label: "See what the emulator is doing:", Value: "lstk logs"}}
+	}
+	sink.Emit(event)
+	return output.NewSilentError(fmt.Errorf("%s is not ready after %s: %s", label, timeout, notReady.pending))
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthServer serves an AWS-shaped /_localstack/health whose sqs state moves
+// from "initializing" to "running" after the given number of polls.
+func healthServer(t *testing.T, readyAfter int32) *httptest.Server {
+	t.Helper()
+	var polls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sqs := "initializing"
+		if polls.Add(1) > readyAfter {
+			sqs = "running"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "4.0.0", "services": {"s3": "available", "sqs": "` + sqs + `", "kinesis": "disabled"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWaitExternal_WaitsForRequestedServices(t *testing.T) {
+	t.Parallel()
+	srv := healthServer(t, 1)
+	sink := &recordingSink{}
+
+	err := WaitExternal(context.Background(), srv.URL, WaitOptions{Timeout: 10 * time.Second, Services: []string{"s3", "sqs"}}, sink)
+	require.NoError(t, err)
+
+	var ready []output.EmulatorReadyEvent
+	for _, e := range sink.events {
+		if ev, ok := e.(output.EmulatorReadyEvent); ok {
+			ready = append(ready, ev)
+		}
+	}
+	require.Len(t, ready, 1)
+	assert.Equal(t, "aws", ready[0].Type)
+	assert.Equal(t, srv.URL, ready[0].URL)
+	assert.Equal(t, map[string]string{"s3": "available", "sqs": "running"}, ready[0].Services)
+}
+
+func TestWaitExternal_TimesOutNamingWhatIsPending(t *testing.T) {
+	t.Parallel()
+	srv := healthServer(t, 1000)
+	sink := &recordingSink{}
+
+	err := WaitExternal(context.Background(), srv.URL, WaitOptions{Timeout: 1500 * time.Millisecond, Services: []string{"sqs", "kinesis"}}, sink)
+	require.Error(t, err)
+	assert.True(t, output.IsSilent(err))
+
+	_, _, errs := initEvents(sink)
+	require.Len(t, errs, 1)
+	assert.Equal(t, output.ErrEmulatorNotReady, errs[0].Code)
+	assert.Equal(t, "The emulator at "+srv.URL+" is not ready after 1.5s", errs[0].Title)
+	assert.Equal(t, "waiting for sqs (initializing), kinesis (disabled)", errs[0].Summary)
+}
+
+func TestWaitExternal_UnknownServiceFailsWithoutWaiting(t *testing.T) {
+	t.Parallel()
+	srv := healthServer(t, 0)
+	sink := &recordingSink{}
+
+	started := time.Now()
+	err := WaitExternal(context.Background(), srv.URL, WaitOptions{Timeout: time.Minute, Services: []string{"s4"}}, sink)
+	require.Error(t, err)
+	assert.Less(t, time.Since(started), 10*time.Second)
+
+	_, _, errs := initEvents(sink)
+	require.Len(t, errs, 1)
+	assert.Equal(t, output.ErrValidationError, errs[0].Code)
+	assert.Contains(t, errs[0].Title, `does not provide a service named "s4"`)
+}
+
+func TestWaitExternal_UnreachableEndpointTimesOut(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	sink := &recordingSink{}
+
+	err := WaitExternal(context.Background(), url, WaitOptions{Timeout: time.Second}, sink)
+	require.Error(t, err)
+
+	_, _, errs := initEvents(sink)
+	require.Len(t, errs, 1)
+	assert.Equal(t, output.ErrEmulatorNotReady, errs[0].Code)
+	assert.Equal(t, url+"/_localstack/health is not responding yet", errs[0].Summary)
+	assert.Empty(t, errs[0].Actions)
+}
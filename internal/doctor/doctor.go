@@ -0,0 +1,283 @@
+// Package doctor runs the checks behind `lstk doctor`: the pre-flights lstk
+// makes before starting an emulator or running a tool against it, reported
+// together so that a broken setup can be diagnosed in one go.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	stdruntime "runtime"
+	"strings"
+
+	"github.com/localstack/lstk/internal/api"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/version"
+)
+
+// LicenseClient requests licenses from the LocalStack platform.
+type LicenseClient interface {
+	GetLicense(ctx context.Context, req *api.LicenseRequest) (*api.LicenseResponse, error)
+}
+
+// Options is what the checks inspect.
+type Options struct {
+	// Runtime is the container runtime, nil when creating it failed with
+	// RuntimeErr. RuntimeBackend is the backend it was created for.
+	Runtime        runtime.Runtime
+	RuntimeErr     error
+	RuntimeBackend string
+
+	// ConfigSources are the config files behind Containers, most specific
+	// first. ConfigErr is set when they could not be loaded, which leaves the
+	// checks of the emulators out.
+	ConfigSources []config.Source
+	ConfigErr     error
+	Containers    []config.ContainerConfig
+
+	LocalStackHost string
+
+	// AuthToken is the resolved auth token, and AuthTokenSource where it was
+	// found, e.g. "LOCALSTACK_AUTH_TOKEN".
+	AuthToken       string
+	AuthTokenSource string
+	Platform        LicenseClient
+
+	// ResolveHost is endpoint.ResolveHost.
+	ResolveHost func(ctx context.Context, port, override string) (host string, dnsOK bool)
+	// ToolVersion runs a tool to print its version, returning what it
+	// printed, or an error wrapping exec.ErrNotFound when it is not installed.
+	ToolVersion func(ctx context.Context, command string) (string, error)
+}
+
+// Run runs every check, emitting a DoctorCheckEvent for each, then a
+// DoctorReportEvent. Failed checks are reported, not returned: the error is
+// only for the context being canceled.
+func Run(ctx context.Context, sink output.Sink, opts Options) error {
+	report := output.DoctorReportEvent{
+		LstkVersion: version.Version(),
+		OS:          stdruntime.GOOS,
+		Arch:        stdruntime.GOARCH,
+		Runtime:     opts.RuntimeBackend,
+	}
+	emit := func(check output.DoctorCheckEvent) {
+		switch check.Status {
+		case output.CheckPass:
+			report.Passed++
+		case output.CheckWarn:
+			report.Warnings++
+		case output.CheckFail:
+			report.Failures++
+		default:
+			report.Skipped++
+		}
+		sink.Emit(check)
+	}
+
+	// The tools can take seconds to print their versions, so they run while
+	// the other checks do.
+	toolChecks := startToolChecks(ctx, opts.ToolVersion)
+
+	emit(checkConfig(opts))
+
+	runtimeCheck := checkRuntime(ctx, opts)
+	emit(runtimeCheck)
+	runtimeOK := runtimeCheck.Status == output.CheckPass
+	if opts.Runtime != nil {
+		report.RuntimeFlavor = opts.Runtime.Flavor()
+	}
+	if report.RuntimeFlavor == "" {
+		report.RuntimeFlavor = runtime.DetectInstalledFlavor()
+	}
+
+	sharedPortsChecked := false
+	for i := range opts.Containers {
+		c := &opts.Containers[i]
+		// The service port range is published by one emulator only.
+		servicePorts := c.Type == config.EmulatorAWS && !sharedPortsChecked
+		sharedPortsChecked = sharedPortsChecked || servicePorts
+		emit(checkPorts(ctx, opts, c, runtimeOK, servicePorts))
+	}
+
+	emit(checkDNS(ctx, opts))
+	emit(checkAuthToken(opts))
+	for i := range opts.Containers {
+		emit(checkLicense(ctx, opts, &opts.Containers[i], runtimeOK))
+	}
+	emit(checkAWSProfile(ctx, opts))
+
+	for _, check := range toolChecks {
+		select {
+		case result := <-check:
+			emit(result)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for _, s := range opts.ConfigSources {
+		report.ConfigFiles = append(report.ConfigFiles, output.ConfigSource{Path: s.Path, Scope: s.Scope})
+	}
+	for i := range opts.Containers {
+		c := &opts.Containers[i]
+		image, _ := c.Image()
+		report.Emulators = append(report.Emulators, output.DoctorEmulator{Type: string(c.Type), Name: c.Name(), Image: image, Port: c.Port})
+	}
+	sink.Emit(report)
+	return ctx.Err()
+}
+
+func checkConfig(opts Options) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "Config"}
+	validateAction := []output.ErrorAction{{Label: "See every problem:", Value: "lstk config validate"}}
+	if len(opts.ConfigSources) == 0 {
+		if opts.ConfigErr != nil {
+			check.Status, check.Detail = output.CheckFail, opts.ConfigErr.Error()
+			return check
+		}
+		check.Status, check.Detail = output.CheckPass, "no config file yet; lstk uses its defaults"
+		return check
+	}
+
+	// Validate finds what keeps the config from loading too, with the line
+	// it is on.
+	problems, err := config.Validate(opts.ConfigSources)
+	if err != nil {
+		check.Status, check.Detail = output.CheckFail, err.Error()
+		return check
+	}
+	if len(problems) > 0 {
+		p := problems[0]
+		location := p.Path
+		if p.Line > 0 {
+			location = fmt.Sprintf("%s:%d", p.Path, p.Line)
+		}
+		check.Status, check.Detail, check.Actions = output.CheckFail, location+": "+p.Message, validateAction
+		if len(problems) > 1 {
+			check.Detail += fmt.Sprintf(" (and %d more)", len(problems)-1)
+		}
+		return check
+	}
+	if opts.ConfigErr != nil {
+		check.Status, check.Detail = output.CheckFail, opts.ConfigErr.Error()
+		return check
+	}
+	paths := make([]string, len(opts.ConfigSources))
+	for i, s := range opts.ConfigSources {
+		paths[i] = s.Path
+	}
+	check.Status, check.Detail = output.CheckPass, strings.Join(paths, ", ")
+	return check
+}
+
+func checkRuntime(ctx context.Context, opts Options) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "Container runtime"}
+	if opts.Runtime == nil {
+		check.Status, check.Detail = output.CheckFail, opts.RuntimeErr.Error()
+		return check
+	}
+	if err := opts.Runtime.IsHealthy(ctx); err != nil {
+		// The runtime knows best how to tell the user to fix it.
+		var captured captureError
+		opts.Runtime.EmitUnhealthyError(&captured, err)
+		check.Status, check.Detail, check.Actions = output.CheckFail, err.Error(), captured.event.Actions
+		if captured.event.Title != "" {
+			check.Detail = captured.event.Title
+			if captured.event.Summary != "" {
+				check.Detail += ": " + captured.event.Summary
+			}
+		}
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, "reachable"
+	if flavor := opts.Runtime.Flavor(); flavor != "" {
+		check.Detail += " (" + flavor + ")"
+	}
+	return check
+}
+
+// captureError keeps the ErrorEvent emitted to it.
+type captureError struct {
+	event output.ErrorEvent
+}
+
+func (c *captureError) Emit(e output.Event) {
+	if ev, ok := e.(output.ErrorEvent); ok {
+		c.event = ev
+	}
+}
+
+// licenseLoginAction is how a missing or rejected auth token is fixed.
+var licenseLoginAction = []output.ErrorAction{{Label: "Log in:", Value: "lstk login"}}
+
+func checkAuthToken(opts Options) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "Auth token"}
+	if opts.AuthToken == "" {
+		check.Status, check.Detail, check.Actions = output.CheckFail, "not found; starting an emulator needs one", licenseLoginAction
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, "found"
+	if opts.AuthTokenSource != "" {
+		check.Detail += " in " + opts.AuthTokenSource
+	}
+	return check
+}
+
+func checkLicense(ctx context.Context, opts Options, c *config.ContainerConfig, runtimeOK bool) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "License (" + c.DisplayName() + ")"}
+	if c.Type.SelfValidatesLicense() {
+		check.Status, check.Detail = output.CheckSkip, "the emulator validates its license when it starts"
+		return check
+	}
+	if opts.AuthToken == "" {
+		check.Status, check.Detail = output.CheckSkip, "no auth token"
+		return check
+	}
+	productName, err := c.ProductName()
+	if err != nil {
+		check.Status, check.Detail = output.CheckSkip, err.Error()
+		return check
+	}
+
+	// Like lstk start, resolve a floating tag to the version of the image
+	// it names, which can only be done once it has been pulled.
+	tag := c.Tag
+	if tag == "" || tag == "latest" {
+		image, _ := c.Image()
+		tag = ""
+		if runtimeOK {
+			tag, _ = opts.Runtime.GetImageVersion(ctx, image)
+		}
+		if tag == "" {
+			check.Status, check.Detail = output.CheckSkip, fmt.Sprintf("lstk checks the license for %s once it has pulled it", image)
+			return check
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	resp, err := opts.Platform.GetLicense(ctx, &api.LicenseRequest{
+		Product:     api.ProductInfo{Name: productName, Version: config.NormalizeTag(tag)},
+		Credentials: api.CredentialsInfo{Token: opts.AuthToken},
+		Machine:     api.MachineInfo{Hostname: hostname, Platform: stdruntime.GOOS, PlatformRelease: stdruntime.GOARCH},
+	})
+	if err != nil {
+		var licErr *api.LicenseError
+		switch {
+		case !errors.As(err, &licErr):
+			check.Status, check.Detail = output.CheckWarn, "could not reach the license server: "+err.Error()
+		case licErr.IsUnsupportedTag:
+			check.Status, check.Detail = output.CheckWarn, fmt.Sprintf("the license server does not support tag %q; the emulator validates the license when it starts", tag)
+		default:
+			check.Status, check.Detail, check.Actions = output.CheckFail, licErr.Message, licenseLoginAction
+		}
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, fmt.Sprintf("valid for %s %s", productName, tag)
+	if plan := resp.PlanDisplayName(); plan != "" {
+		check.Detail = fmt.Sprintf("LocalStack %s, valid for %s %s", plan, productName, tag)
+	}
+	return check
+}
@@ -0,0 +1,192 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/api"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type captureSink struct {
+	events []output.Event
+}
+
+func (s *captureSink) Emit(e output.Event) {
+	s.events = append(s.events, e)
+}
+
+type fakeLicenseClient struct {
+	resp *api.LicenseResponse
+	err  error
+	req  *api.LicenseRequest
+}
+
+func (f *fakeLicenseClient) GetLicense(_ context.Context, req *api.LicenseRequest) (*api.LicenseResponse, error) {
+	f.req = req
+	return f.resp, f.err
+}
+
+func TestCheckTool(t *testing.T) {
+	t.Parallel()
+	cdk := tools()[2]
+
+	tests := []struct {
+		name       string
+		tool       tool
+		out        string
+		err        error
+		wantStatus output.CheckStatus
+		wantDetail string
+	}{
+		{name: "pass", tool: tool{name: "AWS CLI", command: "aws"}, out: "aws-cli/2.17.0 Python/3.11.9", wantStatus: output.CheckPass, wantDetail: "aws 2.17.0"},
+		{name: "not installed", tool: tool{name: "Azure CLI", command: "az"}, err: fmt.Errorf("exec: %w", exec.ErrNotFound), wantStatus: output.CheckSkip, wantDetail: "az is not installed"},
+		{name: "error", tool: tool{name: "AWS CLI", command: "aws"}, err: errors.New("exit status 1"), wantStatus: output.CheckWarn, wantDetail: "could not determine the version of aws: exit status 1"},
+		{name: "too old", tool: cdk, out: "1.0.0 (build 1234)", wantStatus: output.CheckFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			check := checkTool(tt.tool, tt.out, tt.err)
+			assert.Equal(t, tt.tool.name, check.Name)
+			assert.Equal(t, tt.wantStatus, check.Status)
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, check.Detail)
+			}
+		})
+	}
+}
+
+func TestCheckConfig_InvalidFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[[containers]\ntype = \"aws\"\n"), 0o600))
+
+	check := checkConfig(Options{ConfigSources: []config.Source{{Path: path, Scope: "project"}}})
+	assert.Equal(t, output.CheckFail, check.Status)
+	assert.Contains(t, check.Detail, path)
+}
+
+func TestCheckConfig_NoFile(t *testing.T) {
+	t.Parallel()
+	check := checkConfig(Options{})
+	assert.Equal(t, output.CheckPass, check.Status)
+}
+
+func TestCheckRuntime_Unhealthy(t *testing.T) {
+	t.Parallel()
+	mockRT := runtime.NewMockRuntime(gomock.NewController(t))
+	healthErr := errors.New("cannot connect to the Docker daemon")
+	mockRT.EXPECT().IsHealthy(gomock.Any()).Return(healthErr)
+	mockRT.EXPECT().EmitUnhealthyError(gomock.Any(), healthErr).Do(func(sink output.Sink, _ error) {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Docker is not running",
+			Summary: "cannot connect to the Docker daemon",
+			Actions: []output.ErrorAction{{Label: "Start Docker:", Value: "open -a Docker"}},
+		})
+	})
+
+	check := checkRuntime(context.Background(), Options{Runtime: mockRT})
+	assert.Equal(t, output.CheckFail, check.Status)
+	assert.Equal(t, "Docker is not running: cannot connect to the Docker daemon", check.Detail)
+	assert.Equal(t, []output.ErrorAction{{Label: "Start Docker:", Value: "open -a Docker"}}, check.Actions)
+}
+
+func TestCheckPorts_Busy(t *testing.T) {
+	t.Parallel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+
+	c := &config.ContainerConfig{Type: config.EmulatorAWS, Port: port}
+	check := checkPorts(context.Background(), Options{}, c, false, false)
+	assert.Equal(t, output.CheckFail, check.Status)
+	assert.Contains(t, check.Detail, "port "+port+" is in use")
+}
+
+func TestCheckLicense(t *testing.T) {
+	t.Parallel()
+	aws := &config.ContainerConfig{Type: config.EmulatorAWS, Tag: "4.0.0", Port: "4566"}
+
+	tests := []struct {
+		name       string
+		client     *fakeLicenseClient
+		wantStatus output.CheckStatus
+		wantDetail string
+	}{
+		{name: "valid", client: &fakeLicenseClient{resp: &api.LicenseResponse{LicenseType: "hobby"}}, wantStatus: output.CheckPass, wantDetail: "LocalStack Hobby, valid for"},
+		{name: "rejected", client: &fakeLicenseClient{err: &api.LicenseError{Status: 403, Message: "invalid auth token"}}, wantStatus: output.CheckFail, wantDetail: "invalid auth token"},
+		{name: "unsupported tag", client: &fakeLicenseClient{err: &api.LicenseError{Status: 400, IsUnsupportedTag: true}}, wantStatus: output.CheckWarn, wantDetail: "does not support tag"},
+		{name: "unreachable", client: &fakeLicenseClient{err: errors.New("dial tcp: connection refused")}, wantStatus: output.CheckWarn, wantDetail: "could not reach the license server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			check := checkLicense(context.Background(), Options{AuthToken: "ls-token", Platform: tt.client}, aws, false)
+			assert.Equal(t, tt.wantStatus, check.Status)
+			assert.Contains(t, check.Detail, tt.wantDetail)
+			require.NotNil(t, tt.client.req)
+			assert.Equal(t, "ls-token", tt.client.req.Credentials.Token)
+		})
+	}
+}
+
+func TestCheckLicense_LatestWithoutRuntimeIsSkipped(t *testing.T) {
+	t.Parallel()
+	client := &fakeLicenseClient{}
+	c := &config.ContainerConfig{Type: config.EmulatorAWS, Tag: "latest", Port: "4566"}
+
+	check := checkLicense(context.Background(), Options{AuthToken: "ls-token", Platform: client}, c, false)
+	assert.Equal(t, output.CheckSkip, check.Status)
+	assert.Nil(t, client.req, "no license request without a version to ask for")
+}
+
+func TestRun_ReportsEveryCheck(t *testing.T) {
+	t.Parallel()
+	sink := &captureSink{}
+	opts := Options{
+		RuntimeErr:     errors.New("no container runtime found"),
+		RuntimeBackend: "auto",
+		ResolveHost: func(context.Context, string, string) (string, bool) {
+			return "localhost.localstack.cloud:4566", true
+		},
+		ToolVersion: func(context.Context, string) (string, error) {
+			return "", exec.ErrNotFound
+		},
+	}
+
+	require.NoError(t, Run(context.Background(), sink, opts))
+
+	var checks []output.DoctorCheckEvent
+	var report *output.DoctorReportEvent
+	for _, e := range sink.events {
+		switch ev := e.(type) {
+		case output.DoctorCheckEvent:
+			checks = append(checks, ev)
+		case output.DoctorReportEvent:
+			report = &ev
+		}
+	}
+	require.NotNil(t, report)
+	assert.IsType(t, output.DoctorReportEvent{}, sink.events[len(sink.events)-1], "the report comes last")
+	assert.Equal(t, len(checks), report.Passed+report.Warnings+report.Failures+report.Skipped)
+	// Config and DNS pass; the runtime and the auth token fail; the AWS
+	// profile and the five tools are skipped.
+	assert.Equal(t, 2, report.Passed)
+	assert.Equal(t, 2, report.Failures)
+	assert.Equal(t, 6, report.Skipped)
+	assert.Equal(t, "auto", report.Runtime)
+}
@@ -0,0 +1,109 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/localstack/lstk/internal/awsconfig"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/endpoint"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/ports"
+)
+
+// servicePorts is the range of per-service ports LocalStack publishes.
+const servicePorts = "4510-4559"
+
+// defaultPort is the port the host checks resolve for when no emulator is
+// configured.
+const defaultPort = "4566"
+
+// checkPorts checks that the host ports c publishes are free, unless c is
+// running and holds them itself. servicePorts adds the service port range.
+func checkPorts(ctx context.Context, opts Options, c *config.ContainerConfig, runtimeOK, withServicePorts bool) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "Ports (" + c.DisplayName() + ")"}
+	if runtimeOK {
+		if running, err := opts.Runtime.IsRunning(ctx, c.Name()); err == nil && running {
+			check.Status, check.Detail = output.CheckPass, fmt.Sprintf("in use by %s, which is running", c.Name())
+			return check
+		}
+	}
+
+	specs := []string{c.Port}
+	if withServicePorts {
+		specs = append(specs, servicePorts)
+	}
+	// A TCP dial says nothing about a UDP port, as in lstk start.
+	exposed, _ := c.ExposedPorts()
+	for _, p := range exposed {
+		if p.Protocol != "udp" {
+			specs = append(specs, p.HostPort)
+		}
+	}
+
+	busy, err := ports.CheckAvailable(specs...)
+	if err != nil {
+		check.Status = output.CheckFail
+		check.Detail = fmt.Sprintf("port %s is in use by another process; free it before starting %s", busy, c.DisplayName())
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, "free: "+strings.Join(specs, ", ")
+	return check
+}
+
+// checkDNS checks that localhost.localstack.cloud resolves to the local
+// machine, which the emulators' endpoints are advertised under.
+func checkDNS(ctx context.Context, opts Options) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "DNS"}
+	if opts.LocalStackHost != "" {
+		check.Status, check.Detail = output.CheckSkip, fmt.Sprintf("LOCALSTACK_HOST is set to %s", opts.LocalStackHost)
+		return check
+	}
+	if _, ok := opts.ResolveHost(ctx, firstPort(opts.Containers), ""); !ok {
+		check.Status = output.CheckWarn
+		check.Detail = endpoint.Hostname + " does not resolve to 127.0.0.1, e.g. because of DNS rebind protection; lstk uses 127.0.0.1 instead"
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, endpoint.Hostname+" resolves to 127.0.0.1"
+	return check
+}
+
+// checkAWSProfile checks that the localstack profile in ~/.aws points at the
+// AWS emulator.
+func checkAWSProfile(ctx context.Context, opts Options) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: "AWS profile"}
+	var aws *config.ContainerConfig
+	for i := range opts.Containers {
+		if opts.Containers[i].Type == config.EmulatorAWS {
+			aws = &opts.Containers[i]
+			break
+		}
+	}
+	if aws == nil {
+		check.Status, check.Detail = output.CheckSkip, "no AWS emulator configured"
+		return check
+	}
+
+	host, _ := opts.ResolveHost(ctx, aws.Port, opts.LocalStackHost)
+	status, err := awsconfig.CheckProfileStatus(host)
+	if err != nil {
+		check.Status, check.Detail = output.CheckWarn, err.Error()
+		return check
+	}
+	if status.AnyNeeded() {
+		check.Status = output.CheckWarn
+		check.Detail = fmt.Sprintf("the %s profile is missing or does not point at http://%s", awsconfig.ProfileName, host)
+		check.Actions = []output.ErrorAction{{Label: "Set it up:", Value: "lstk setup aws"}}
+		return check
+	}
+	check.Status, check.Detail = output.CheckPass, fmt.Sprintf("the %s profile points at http://%s", awsconfig.ProfileName, host)
+	return check
+}
+
+func firstPort(containers []config.ContainerConfig) string {
+	if len(containers) > 0 && containers[0].Port != "" {
+		return containers[0].Port
+	}
+	return defaultPort
+}
@@ -0,0 +1,101 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	cdkcli "github.com/localstack/lstk/internal/iac/cdk/cli"
+	samcli "github.com/localstack/lstk/internal/iac/sam/cli"
+	tfcli "github.com/localstack/lstk/internal/iac/terraform/cli"
+	"github.com/localstack/lstk/internal/output"
+)
+
+// toolVersionTimeout bounds how long a tool may take to print its version;
+// the AWS CDK CLI, a Node program, can take several seconds.
+const toolVersionTimeout = 30 * time.Second
+
+// versionRe matches the MAJOR.MINOR.PATCH a tool prints as its version, e.g.
+// "aws-cli/2.17.0 Python/3.11.9" or "Terraform v1.9.5".
+var versionRe = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// tool is a tool lstk runs against the emulators.
+type tool struct {
+	name    string
+	command string
+	// checkVersion checks the output of `<command> --version` against the
+	// minimum version lstk supports, nil when it has none.
+	checkVersion func(out string) error
+}
+
+func tools() []tool {
+	return []tool{
+		{name: "AWS CLI", command: "aws"},
+		{name: "Terraform", command: tfcli.Command()},
+		{name: "AWS CDK", command: cdkcli.Command(), checkVersion: cdkcli.CheckVersionOutput},
+		{name: "AWS SAM CLI", command: samcli.Command(), checkVersion: samcli.CheckVersionOutput},
+		{name: "Azure CLI", command: "az"},
+	}
+}
+
+// startToolChecks checks every tool concurrently, returning a channel per
+// tool, in order, that receives its check.
+func startToolChecks(ctx context.Context, toolVersion func(ctx context.Context, command string) (string, error)) []<-chan output.DoctorCheckEvent {
+	var checks []<-chan output.DoctorCheckEvent
+	for _, t := range tools() {
+		ch := make(chan output.DoctorCheckEvent, 1)
+		checks = append(checks, ch)
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, toolVersionTimeout)
+			defer cancel()
+			out, err := toolVersion(ctx, t.command)
+			ch <- checkTool(t, out, err)
+		}()
+	}
+	return checks
+}
+
+func checkTool(t tool, out string, err error) output.DoctorCheckEvent {
+	check := output.DoctorCheckEvent{Name: t.name}
+	if errors.Is(err, exec.ErrNotFound) {
+		check.Status, check.Detail = output.CheckSkip, t.command+" is not installed"
+		return check
+	}
+	if err != nil {
+		check.Status, check.Detail = output.CheckWarn, fmt.Sprintf("could not determine the version of %s: %v", t.command, err)
+		return check
+	}
+	if t.checkVersion != nil {
+		if err := t.checkVersion(out); err != nil {
+			check.Status, check.Detail = output.CheckFail, err.Error()
+			return check
+		}
+	}
+	check.Status, check.Detail = output.CheckPass, t.command
+	if v := versionRe.FindString(out); v != "" {
+		check.Detail += " " + v
+	}
+	return check
+}
+
+// ToolVersion runs `<command> --version`, returning what it printed to
+// stdout and stderr: some versions of the AWS CLI print their version to the
+// latter.
+func ToolVersion(ctx context.Context, command string) (string, error) {
+	bin, err := exec.LookPath(command)
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.CommandContext(ctx, bin, "--version").CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
@@ -25,6 +25,10 @@ type Client struct {
 	// s3BucketURLTemplate builds the URL for an S3 bucket existence check; it
 	// contains a single %s for the bucket name. Overridable in tests.
 	s3BucketURLTemplate string
+	// s3ObjectURLTemplate builds the base URL of an S3 bucket that lstk reads
+	// and writes objects in; it contains a %s for the bucket name, then one for
+	// its region. Overridable in tests.
+	s3ObjectURLTemplate string
 }
 
 // isFeatureUnavailableResponse reports whether a non-2xx response from a
@@ -77,6 +81,7 @@ func NewClient() *Client {
 			),
 		},
 		s3BucketURLTemplate: "https://%s.s3.amazonaws.com/",
+		s3ObjectURLTemplate: "https://%s.s3.%s.amazonaws.com",
 	}
 }
 
@@ -162,6 +167,8 @@ func (c *Client) FetchResources(ctx context.Context, baseURL string) ([]emulator
 					Name:    extractResourceName(e.ID),
 					Region:  e.RegionName,
 					Account: e.AccountID,
+					Type:    resourceType,
+					ID:      e.ID,
 				})
 			}
 		}
@@ -200,6 +207,30 @@ func (c *Client) ResetState(ctx context.Context, baseURL string) error {
 	return nil
 }
 
+// ResetServiceState wipes the running instance's state for a single service.
+func (c *Client) ResetServiceState(ctx context.Context, baseURL, service string) error {
+	url := strings.TrimRight(baseURL, "/") + "/_localstack/state/" + service + "/reset"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("connect to LocalStack: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if isFeatureUnavailableResponse(resp.StatusCode, body) {
+			return snapshot.ErrSnapshotFeatureUnavailable
+		}
+		return emulatorStatusError(fmt.Sprintf("LocalStack returned status %d", resp.StatusCode), body)
+	}
+	return nil
+}
+
 // ExportState streams the running instance's state into dst as a zip. services,
 // when non-empty, limits the export to that subset of services. It returns the
 // services actually captured, reported by LocalStack via a response header.
@@ -355,8 +386,14 @@ func isPodVersionNotFoundMsg(msg string) bool {
 	return strings.Contains(strings.ToLower(msg), "maximum version available")
 }
 
-func (c *Client) LoadPodSnapshot(ctx context.Context, baseURL, podName string, version int, authToken, strategy string) ([]string, error) {
-	return c.doPodLoad(ctx, baseURL, podName, version, authToken, strategy, []byte("{}"))
+// LoadPodSnapshot loads a platform-hosted pod with the given merge strategy.
+// services, when non-empty, limits the load to that subset of services.
+func (c *Client) LoadPodSnapshot(ctx context.Context, baseURL, podName string, version int, authToken, strategy string, services []string) ([]string, error) {
+	body, err := marshalPodBody("", nil, services)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	return c.doPodLoad(ctx, baseURL, podName, version, authToken, strategy, body)
 }
 
 func (c *Client) DiffPodSnapshot(ctx context.Context, baseURL, podName string, version int, authToken string) (snapshot.DiffResult, error) {
@@ -84,6 +84,8 @@ func TestFetchResources(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, rows, 1)
 		assert.Equal(t, "my-topic", rows[0].Name)
+		assert.Equal(t, "AWS::SNS::Topic", rows[0].Type)
+		assert.Equal(t, "arn:aws:sns:us-east-1:000000000000:my-topic", rows[0].ID)
 	})
 
 	t.Run("returns empty slice when no resources", func(t *testing.T) {
@@ -368,6 +370,37 @@ func TestResetState(t *testing.T) {
 // the Cloud Pods license, so the empty-body-404 translation is asserted for all
 // of them at once. A method missing from this table is a method that would still
 // surface the raw "status 404" error (DEVX-1009).
+func TestResetServiceState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("posts to the service's state reset endpoint on 200", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/_localstack/state/s3/reset", r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		c := NewClient()
+		err := c.ResetServiceState(context.Background(), srv.URL, "s3")
+		require.NoError(t, err)
+	})
+
+	t.Run("returns error on 500", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		c := NewClient()
+		err := c.ResetServiceState(context.Background(), srv.URL, "s3")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+	})
+}
+
 func snapshotOps() map[string]func(context.Context, *Client, string) error {
 	return map[string]func(context.Context, *Client, string) error{
 		"ExportState": func(ctx context.Context, c *Client, host string) error {
@@ -380,6 +413,9 @@ func snapshotOps() map[string]func(context.Context, *Client, string) error {
 		"ResetState": func(ctx context.Context, c *Client, host string) error {
 			return c.ResetState(ctx, host)
 		},
+		"ResetServiceState": func(ctx context.Context, c *Client, host string) error {
+			return c.ResetServiceState(ctx, host, "s3")
+		},
 		"DiffPodSnapshot": func(ctx context.Context, c *Client, host string) error {
 			_, err := c.DiffPodSnapshot(ctx, host, "pod", 0, "tok")
 			return err
@@ -392,7 +428,7 @@ func snapshotOps() map[string]func(context.Context, *Client, string) error {
 			return err
 		},
 		"LoadPodSnapshot": func(ctx context.Context, c *Client, host string) error {
-			_, err := c.LoadPodSnapshot(ctx, host, "pod", 0, "tok", "")
+			_, err := c.LoadPodSnapshot(ctx, host, "pod", 0, "tok", "", nil)
 			return err
 		},
 		"RegisterRemote": func(ctx context.Context, c *Client, host string) error {
@@ -407,7 +443,7 @@ func snapshotOps() map[string]func(context.Context, *Client, string) error {
 			return err
 		},
 		"LoadPodRemote": func(ctx context.Context, c *Client, host string) error {
-			_, err := c.LoadPodRemote(ctx, host, "pod", "remote", nil, "tok", "")
+			_, err := c.LoadPodRemote(ctx, host, "pod", "remote", nil, "tok", "", nil)
 			return err
 		},
 	}
@@ -22,7 +22,7 @@ type remotePayload struct {
 	RemoteParams map[string]string `json:"remote_params,omitempty"`
 }
 
-// podAttributes carries pod-save request options that aren't part of the
+// podAttributes carries pod save/load request options that aren't part of the
 // remote targeting, e.g. a services filter.
 type podAttributes struct {
 	Services []string `json:"services,omitempty"`
@@ -37,7 +37,7 @@ type podRequestBody struct {
 
 // marshalPodBody builds the request body for a pod operation. When remoteName is
 // empty it returns "{}" (the platform default remote). services, when non-empty,
-// limits a save to that subset of services.
+// limits a save or load to that subset of services.
 func marshalPodBody(remoteName string, params map[string]string, services []string) ([]byte, error) {
 	body := podRequestBody{}
 	if remoteName != "" {
@@ -125,9 +125,10 @@ func (c *Client) SavePodRemote(ctx context.Context, baseURL, podName, remoteName
 }
 
 // LoadPodRemote loads podName from the named remote with the given merge strategy.
-// S3 remotes have no version addressing, so version 0 is always passed.
-func (c *Client) LoadPodRemote(ctx context.Context, baseURL, podName, remoteName string, params map[string]string, authToken, strategy string) ([]string, error) {
-	body, err := marshalPodBody(remoteName, params, nil)
+// S3 remotes have no version addressing, so version 0 is always passed. services,
+// when non-empty, limits the load to that subset of services.
+func (c *Client) LoadPodRemote(ctx context.Context, baseURL, podName, remoteName string, params map[string]string, authToken, strategy string, services []string) ([]string, error) {
+	body, err := marshalPodBody(remoteName, params, services)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
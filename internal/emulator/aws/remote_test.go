@@ -114,6 +114,47 @@ func TestSavePodSnapshot_SendsServicesFilter(t *testing.T) {
 	assert.Equal(t, []string{"s3", "lambda"}, gotBody.Attributes.Services)
 }
 
+func TestLoadPodRemote_SendsServicesFilter(t *testing.T) {
+	t.Parallel()
+	var gotBody podRequestBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &gotBody))
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = fmt.Fprintln(w, `{"event": "service", "service": "s3", "status": "ok"}`)
+		_, _ = fmt.Fprintln(w, `{"event": "completion", "status": "ok"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	params := map[string]string{"access_key_id": "AKIA", "secret_access_key": "shh"}
+	services, err := c.LoadPodRemote(context.Background(), server.URL, "my-pod", "lstk-s3-abc", params, "", "", []string{"s3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3"}, services)
+	require.NotNil(t, gotBody.Remote)
+	require.NotNil(t, gotBody.Attributes)
+	assert.Equal(t, []string{"s3"}, gotBody.Attributes.Services)
+}
+
+func TestLoadPodSnapshot_SendsServicesFilter(t *testing.T) {
+	t.Parallel()
+	var gotBody podRequestBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &gotBody))
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = fmt.Fprintln(w, `{"event": "completion", "status": "ok"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient()
+	_, err := c.LoadPodSnapshot(context.Background(), server.URL, "my-pod", 0, "the-token", "", []string{"s3", "dynamodb"})
+	require.NoError(t, err)
+	assert.Nil(t, gotBody.Remote, "platform pod load must not include a remote payload")
+	require.NotNil(t, gotBody.Attributes)
+	assert.Equal(t, []string{"s3", "dynamodb"}, gotBody.Attributes.Services)
+}
+
 func TestS3BucketExists(t *testing.T) {
 	t.Parallel()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -7,6 +7,11 @@ type Resource struct {
 	Name    string
 	Region  string
 	Account string
+	// Type is the CloudFormation-style resource type, e.g. "AWS::S3::Bucket".
+	Type string
+	// ID is the identifier the emulator reports, often an ARN; Name is the
+	// short form of it shown in tables.
+	ID string
 }
 
 type Client interface {
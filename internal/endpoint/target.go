@@ -117,22 +117,37 @@ func (e *IndeterminateTypeError) Error() string {
 // cmd may be nil (some call sites don't have a *cobra.Command handy), in
 // which case only the two environment variables are consulted.
 func Resolve(ctx context.Context, cmd *cobra.Command) (*Target, error) {
+	normalized, ok, err := ResolveURL(cmd)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return Probe(ctx, normalized)
+}
+
+// ResolveURL is the first half of Resolve: it applies the same source
+// precedence and validation, but does not probe the endpoint. It is for a
+// caller that polls the endpoint itself and must tolerate an emulator that is
+// not up yet (e.g. `lstk wait`). ok is false when no endpoint URL was given.
+func ResolveURL(cmd *cobra.Command) (normalized string, ok bool, err error) {
 	raw, ok := rawURL(cmd)
 	if !ok {
-		return nil, nil
+		return "", false, nil
 	}
-
-	normalized, err := validateURL(raw)
+	normalized, err = validateURL(raw)
 	if err != nil {
-		return nil, err
+		return "", false, err
 	}
+	return normalized, true, nil
+}
 
-	emulatorType, err := probeType(ctx, normalized)
+// Probe is the second half of Resolve: it checks that endpointURL, as returned
+// by ResolveURL, answers as a LocalStack emulator and determines its type.
+func Probe(ctx context.Context, endpointURL string) (*Target, error) {
+	emulatorType, err := probeType(ctx, endpointURL)
 	if err != nil {
 		return nil, err
 	}
-
-	return &Target{URL: normalized, Type: emulatorType}, nil
+	return &Target{URL: endpointURL, Type: emulatorType}, nil
 }
 
 // rawURL applies the source precedence: --endpoint-url flag, LSTK_ENDPOINT_URL,
@@ -180,15 +195,23 @@ func validateURL(raw string) (string, error) {
 	return strings.TrimRight(u.String(), "/"), nil
 }
 
-// healthResponse mirrors the shape of GET /_localstack/health. AWS and
-// Snowflake both populate Version; Azure's health response omits it (see
+// Health mirrors the shape of GET /_localstack/health. AWS and Snowflake both
+// populate Version; Azure's health response omits it (see
 // internal/emulator/azure/client.go), which is the signal to fall back to
-// /_localstack/info.
-type healthResponse struct {
+// /_localstack/info. Services maps each service to its state, e.g.
+// "available", "running" or "disabled".
+type Health struct {
 	Version  string            `json:"version"`
 	Services map[string]string `json:"services"`
 }
 
+// ServiceReady reports whether a service state from Health.Services means the
+// service can take requests: "available" (not started yet, but starts on the
+// first request) or "running".
+func ServiceReady(state string) bool {
+	return state == "available" || state == "running"
+}
+
 // infoResponse mirrors the shape of GET /_localstack/info.
 type infoResponse struct {
 	Version string `json:"version"`
@@ -210,7 +233,7 @@ var awsSignatureServices = []string{"s3", "sqs", "sts", "iam", "lambda", "dynamo
 // licensed emulator to inspect, which wasn't available to verify this
 // against. See design.md's Open Questions for add-endpoint-url-flag.
 func probeType(ctx context.Context, endpointURL string) (config.EmulatorType, error) {
-	health, err := fetchJSON[healthResponse](ctx, endpointURL+"/_localstack/health")
+	health, err := fetchJSON[Health](ctx, endpointURL+"/_localstack/health")
 	if err != nil {
 		return "", unreachable(ctx, endpointURL, err)
 	}
@@ -248,7 +271,7 @@ func unreachable(ctx context.Context, endpointURL string, cause error) error {
 	if !ok {
 		return base
 	}
-	if _, err := fetchJSON[healthResponse](ctx, alt+"/_localstack/health"); err != nil {
+	if _, err := fetchJSON[Health](ctx, alt+"/_localstack/health"); err != nil {
 		return base
 	}
 	return &SchemeMismatchError{AltURL: alt, Unreachable: base}
@@ -12,6 +12,9 @@ type Env struct {
 	AuthToken      string
 	LocalStackHost string
 	DockerHost     string
+	// Runtime is the LSTK_RUNTIME override of the config's `runtime` key,
+	// which config.RuntimeBackend reads.
+	Runtime        string
 	DisableEvents  bool
 	TracesEnabled  bool
 	StartupTimeout time.Duration
@@ -25,6 +28,13 @@ type Env struct {
 	JSON           bool
 	GitHubToken    string
 	MergeStrategy  string
+
+	// SnapshotPassphrase encrypts local snapshot saves made with --encrypt and
+	// decrypts passphrase-encrypted snapshots on load.
+	SnapshotPassphrase string
+	// SnapshotIdentity is the path of an age identity file used to decrypt
+	// snapshots when --identity is not passed.
+	SnapshotIdentity string
 }
 
 // Init initializes environment variable configuration and returns the result.
@@ -42,6 +52,7 @@ func Init() *Env {
 		AuthToken:         os.Getenv("LOCALSTACK_AUTH_TOKEN"),
 		LocalStackHost:    os.Getenv("LOCALSTACK_HOST"),
 		DockerHost:        os.Getenv("DOCKER_HOST"),
+		Runtime:           os.Getenv("LSTK_RUNTIME"),
 		DisableEvents:     os.Getenv("LOCALSTACK_DISABLE_EVENTS") == "1",
 		TracesEnabled:     viper.GetBool("otel"),
 		StartupTimeout:    viper.GetDuration("startup_timeout"),
@@ -51,6 +62,9 @@ func Init() *Env {
 		AnalyticsEndpoint: viper.GetString("analytics_endpoint"),
 		GitHubToken:       viper.GetString("github_token"),
 		MergeStrategy:     viper.GetString("merge_strategy"),
+
+		SnapshotPassphrase: viper.GetString("snapshot_passphrase"),
+		SnapshotIdentity:   viper.GetString("snapshot_identity"),
 	}
 
 }
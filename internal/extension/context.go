@@ -39,9 +39,10 @@ const envPrefix = "LSTK_EXT_"
 
 // Emulator describes one running LocalStack emulator in the context payload.
 type Emulator struct {
-	Type     string `json:"type"`     // emulator type, e.g. "aws", "snowflake", "azure"
-	Endpoint string `json:"endpoint"` // full URL, e.g. "http://localhost:4566"
-	Port     string `json:"port"`     // resolved host port, e.g. "4566"
+	Type     string `json:"type"`               // emulator type, e.g. "aws", "snowflake", "azure"
+	Instance string `json:"instance,omitempty"` // config instance name, e.g. "scratch"; omitted for the default instance
+	Endpoint string `json:"endpoint"`           // full URL, e.g. "http://localhost:4566"
+	Port     string `json:"port"`               // resolved host port, e.g. "4566"
 }
 
 // Context is the resolved runtime context lstk conveys to an extension, rendered
@@ -185,6 +185,7 @@ func TestEnvironRendersJSONContext(t *testing.T) {
 		Emulators: []Emulator{
 			{Type: "aws", Endpoint: "http://localhost:4566", Port: "4566"},
 			{Type: "snowflake", Endpoint: "http://localhost:4566", Port: "4566"},
+			{Type: "aws", Instance: "scratch", Endpoint: "http://localhost:4567", Port: "4567"},
 		},
 	}, nil)
 
@@ -204,12 +205,19 @@ func TestEnvironRendersJSONContext(t *testing.T) {
 	if c.EndpointURL != "http://localhost:4566" {
 		t.Errorf("endpointUrl = %q, want the conveyed endpoint target", c.EndpointURL)
 	}
-	if len(c.Emulators) != 2 || c.Emulators[0].Type != "aws" || c.Emulators[1].Type != "snowflake" {
+	if len(c.Emulators) != 3 || c.Emulators[0].Type != "aws" || c.Emulators[1].Type != "snowflake" {
 		t.Errorf("emulators wrong: %+v", c.Emulators)
 	}
 	if c.Emulators[0].Endpoint != "http://localhost:4566" || c.Emulators[0].Port != "4566" {
 		t.Errorf("emulator[0] fields wrong: %+v", c.Emulators[0])
 	}
+	if c.Emulators[2].Instance != "scratch" || c.Emulators[2].Port != "4567" {
+		t.Errorf("emulator[2] fields wrong: %+v", c.Emulators[2])
+	}
+	// The default instance carries no instance key, so extensions detect the field by presence.
+	if strings.Count(env[EnvContext], `"instance"`) != 1 {
+		t.Errorf("instance must be omitted for the default instance, got: %s", env[EnvContext])
+	}
 }
 
 func TestEnvironOmitsAbsentValues(t *testing.T) {
@@ -6,9 +6,9 @@ import "os"
 // lstk config, so reading them here (the domain boundary for cdk) is consistent
 // with the rule that domain code must not call config.Get().
 
-// cdkCmd returns the CDK binary name to invoke, honoring LSTK_CDK_CMD and
+// Command returns the CDK binary name to invoke, honoring LSTK_CDK_CMD and
 // defaulting to "cdk".
-func cdkCmd() string {
+func Command() string {
 	if v := os.Getenv("LSTK_CDK_CMD"); v != "" {
 		return v
 	}
@@ -32,15 +32,15 @@ func Run(ctx context.Context, endpointURL, region string, sink output.Sink, logg
 	ctx, span := otel.Tracer("github.com/localstack/lstk/internal/iac/cdk/cli").Start(ctx, "cdk cli")
 	defer span.End()
 
-	cdkBin, err := exec.LookPath(cdkCmd())
+	cdkBin, err := exec.LookPath(Command())
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		sink.Emit(output.ErrorEvent{
-			Title:   fmt.Sprintf("%s not found in PATH", cdkCmd()),
+			Title:   fmt.Sprintf("%s not found in PATH", Command()),
 			Actions: []output.ErrorAction{{Label: "Install CDK CLI:", Value: "npm install -g aws-cdk"}},
 		})
-		return output.NewSilentError(fmt.Errorf("%s not found in PATH", cdkCmd()))
+		return output.NewSilentError(fmt.Errorf("%s not found in PATH", Command()))
 	}
 
 	if err := CheckVersion(ctx, cdkBin); err != nil {
@@ -22,10 +22,11 @@ func CheckVersion(ctx context.Context, cdkBin string) error {
 	if err != nil {
 		return fmt.Errorf("could not determine cdk version (run `%s --version`): %w", cdkBin, err)
 	}
-	return checkVersionString(string(out))
+	return CheckVersionOutput(string(out))
 }
 
-func checkVersionString(out string) error {
+// CheckVersionOutput is CheckVersion for the output of `cdk --version`.
+func CheckVersionOutput(out string) error {
 	m := versionRe.FindStringSubmatch(out)
 	if m == nil {
 		return fmt.Errorf("could not parse cdk version from %q; lstk requires AWS CDK %s or newer", out, minCDKVersionString)
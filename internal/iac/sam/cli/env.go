@@ -9,9 +9,9 @@ import (
 // lstk config, so reading them here (the domain boundary for sam) is consistent
 // with the rule that domain code must not call config.Get().
 
-// samCmd returns the SAM CLI binary name to invoke, honoring LSTK_SAM_CMD and
+// Command returns the SAM CLI binary name to invoke, honoring LSTK_SAM_CMD and
 // defaulting to "sam".
-func samCmd() string {
+func Command() string {
 	if v := os.Getenv("LSTK_SAM_CMD"); v != "" {
 		return v
 	}
@@ -36,15 +36,15 @@ func Run(ctx context.Context, endpointURL, account, region string, regionSelecte
 	ctx, span := otel.Tracer("github.com/localstack/lstk/internal/iac/sam/cli").Start(ctx, "sam cli")
 	defer span.End()
 
-	samBin, err := exec.LookPath(samCmd())
+	samBin, err := exec.LookPath(Command())
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		sink.Emit(output.ErrorEvent{
-			Title:   fmt.Sprintf("%s not found in PATH", samCmd()),
+			Title:   fmt.Sprintf("%s not found in PATH", Command()),
 			Actions: []output.ErrorAction{{Label: "Install AWS SAM CLI:", Value: installDocsURL}},
 		})
-		return output.NewSilentError(fmt.Errorf("%s not found in PATH", samCmd()))
+		return output.NewSilentError(fmt.Errorf("%s not found in PATH", Command()))
 	}
 
 	if err := CheckVersion(ctx, samBin); err != nil {
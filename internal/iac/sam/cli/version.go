@@ -22,10 +22,11 @@ func CheckVersion(ctx context.Context, samBin string) error {
 	if err != nil {
 		return fmt.Errorf("could not determine sam version (run `%s --version`): %w", samBin, err)
 	}
-	return checkVersionString(string(out))
+	return CheckVersionOutput(string(out))
 }
 
-func checkVersionString(out string) error {
+// CheckVersionOutput is CheckVersion for the output of `sam --version`.
+func CheckVersionOutput(out string) error {
 	m := versionRe.FindStringSubmatch(out)
 	if m == nil {
 		return fmt.Errorf("could not parse sam version from %q; lstk requires AWS SAM CLI %s or newer", out, minSAMVersionString)
@@ -20,7 +20,7 @@ func TestCheckVersionString(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := checkVersionString(tt.out)
+			err := CheckVersionOutput(tt.out)
 			if tt.wantErr && err == nil {
 				t.Fatalf("expected error for %q, got nil", tt.out)
 			}
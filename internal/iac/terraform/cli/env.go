@@ -9,9 +9,9 @@ import (
 // lstk config, so reading them here (the domain boundary for terraform) is
 // consistent with the rule that domain code must not call config.Get().
 
-// tfCmd returns the terraform binary name to invoke, honoring LSTK_TF_CMD (e.g.
+// Command returns the terraform binary name to invoke, honoring LSTK_TF_CMD (e.g.
 // "tofu") and defaulting to "terraform".
-func tfCmd() string {
+func Command() string {
 	if v := os.Getenv("LSTK_TF_CMD"); v != "" {
 		return v
 	}
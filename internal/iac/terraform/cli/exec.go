@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -43,27 +44,44 @@ import (
 // discovery, override generation, cleanup) to that directory rather than the
 // process working directory, mirroring the switch terraform itself makes.
 func Run(ctx context.Context, endpointURL, region, account, chdir string, sink output.Sink, logger log.Logger, args []string) error {
+	return run(ctx, endpointURL, region, account, chdir, stdio{in: os.Stdin, out: os.Stdout, err: os.Stderr}, sink, logger, args)
+}
+
+// RunWithOutput is Run for non-interactive callers, such as the init steps run
+// by `lstk start`: terraform gets no stdin, and its stdout and stderr go to the
+// given writers instead of lstk's own.
+func RunWithOutput(ctx context.Context, endpointURL, region, account, chdir string, stdout, stderr io.Writer, sink output.Sink, logger log.Logger, args []string) error {
+	return run(ctx, endpointURL, region, account, chdir, stdio{out: stdout, err: stderr}, sink, logger, args)
+}
+
+// stdio is the set of streams terraform is wired to.
+type stdio struct {
+	in       io.Reader
+	out, err io.Writer
+}
+
+func run(ctx context.Context, endpointURL, region, account, chdir string, streams stdio, sink output.Sink, logger log.Logger, args []string) error {
 	ctx, span := otel.Tracer("github.com/localstack/lstk/internal/iac/terraform/cli").Start(ctx, "terraform cli")
 	defer span.End()
 
-	tfBin, err := exec.LookPath(tfCmd())
+	tfBin, err := exec.LookPath(Command())
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		installLabel, installURL := "Install Terraform CLI:", "https://developer.hashicorp.com/terraform/cli"
-		if tfCmd() == "tofu" {
+		if Command() == "tofu" {
 			installLabel, installURL = "Install OpenTofu CLI:", "https://opentofu.org/docs/intro/install/"
 		}
 		sink.Emit(output.ErrorEvent{
-			Title:   fmt.Sprintf("%s not found in PATH", tfCmd()),
+			Title:   fmt.Sprintf("%s not found in PATH", Command()),
 			Actions: []output.ErrorAction{{Label: installLabel, Value: installURL}},
 		})
-		return output.NewSilentError(fmt.Errorf("%s not found in PATH", tfCmd()))
+		return output.NewSilentError(fmt.Errorf("%s not found in PATH", Command()))
 	}
 	span.SetAttributes(attribute.StringSlice("terraform.args", args), attribute.Bool("terraform.unproxied", IsUnproxied(args)))
 
 	if IsUnproxied(args) {
-		return runTerraform(ctx, span, tfBin, args)
+		return runTerraform(ctx, span, tfBin, streams, args)
 	}
 
 	workdir, err := os.Getwd()
@@ -85,7 +103,7 @@ func Run(ctx context.Context, endpointURL, region, account, chdir string, sink o
 
 	// init without an S3 backend passes through to bootstrap the provider.
 	if isInit && backend == nil {
-		return runTerraform(ctx, span, tfBin, args)
+		return runTerraform(ctx, span, tfBin, streams, args)
 	}
 
 	// endpointURL is already fully resolved by the command boundary
@@ -111,7 +129,7 @@ func Run(ctx context.Context, endpointURL, region, account, chdir string, sink o
 			if errors.Is(err, ErrInitRequired) {
 				sink.Emit(output.ErrorEvent{
 					Title:   "Terraform AWS provider is not installed",
-					Actions: []output.ErrorAction{{Label: "Initialize the project:", Value: tfCmd() + " init"}},
+					Actions: []output.ErrorAction{{Label: "Initialize the project:", Value: Command() + " init"}},
 				})
 				return output.NewSilentError(err)
 			}
@@ -172,7 +190,7 @@ func Run(ctx context.Context, endpointURL, region, account, chdir string, sink o
 		}
 	}
 
-	return runTerraform(ctx, span, tfBin, args)
+	return runTerraform(ctx, span, tfBin, streams, args)
 }
 
 // discoverEndpointKeys probes the installed provider schema for the AWS endpoint
@@ -220,14 +238,14 @@ func ResolveChdir(getwd, chdir string) string {
 	return filepath.Join(getwd, chdir)
 }
 
-// runTerraform executes terraform with stdio wired through to the user and
+// runTerraform executes terraform with its stdio wired to streams and
 // propagates the exit code. A non-zero exit is wrapped as a silent error so
 // lstk does not print an additional error line over terraform's own output.
-func runTerraform(ctx context.Context, span trace.Span, tfBin string, args []string) error {
+func runTerraform(ctx context.Context, span trace.Span, tfBin string, streams stdio, args []string) error {
 	cmd := exec.CommandContext(ctx, tfBin, args...)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdin = streams.in
+	cmd.Stdout = streams.out
+	cmd.Stderr = streams.err
 
 	if err := proc.Run(cmd); err != nil {
 		var exitErr *exec.ExitError
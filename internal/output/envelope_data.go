@@ -26,3 +26,180 @@ type JsonStoppedEmulator struct {
 }
 
 func (JsonStoppedEmulator) sealedEmulatorEntry() {}
+
+// JsonStatusEmulator is the per-emulator entry in `status`'s data.emulators.
+// The details are absent for an emulator that is not running.
+type JsonStatusEmulator struct {
+	Type    string `json:"type"`
+	Name    string `json:"name,omitempty"`
+	Running bool   `json:"running"`
+	*JsonStatusDetails
+}
+
+func (JsonStatusEmulator) sealedEmulatorEntry() {}
+
+// JsonStatusDetails describes a running emulator in `status`. Services maps
+// each service to its state in /_localstack/health.
+type JsonStatusDetails struct {
+	Version         string               `json:"version,omitempty"`
+	Host            string               `json:"host"`
+	UptimeSeconds   int64                `json:"uptimeSeconds,omitempty"`
+	Persistence     bool                 `json:"persistence"`
+	Services        map[string]string    `json:"services"`
+	ResourceSummary *JsonResourceSummary `json:"resourceSummary,omitempty"`
+}
+
+// JsonResourceSummary counts the resources deployed in an AWS emulator.
+type JsonResourceSummary struct {
+	Resources int `json:"resources"`
+	Services  int `json:"services"`
+}
+
+// JsonSnapshotEmulator is an entry in the data.emulators of the "snapshot"
+// line `status --watch` streams first: the full state, as in `status`'s
+// envelope, plus the deployed resources themselves.
+type JsonSnapshotEmulator struct {
+	JsonStatusEmulator
+	Resources []JsonStatusResource `json:"resources"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// JsonDiffEmulator is an entry in the data.emulators of a "diff" line of
+// `status --watch`: only what changed since the previous line.
+// ServiceChanges is keyed by service name.
+type JsonDiffEmulator struct {
+	Type           string                            `json:"type"`
+	Name           string                            `json:"name,omitempty"`
+	Running        bool                              `json:"running"`
+	Added          []JsonStatusResource              `json:"added,omitempty"`
+	Removed        []JsonStatusResource              `json:"removed,omitempty"`
+	ServiceChanges map[string]JsonServiceStateChange `json:"serviceChanges,omitempty"`
+	Error          string                            `json:"error,omitempty"`
+}
+
+// JsonStatusResource is a resource deployed in an emulator.
+type JsonStatusResource struct {
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Account string `json:"account"`
+}
+
+// JsonServiceStateChange is a service's move between two health states. From
+// is empty for a service that just appeared.
+type JsonServiceStateChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// JsonLogLine is the data of a "log" line `logs --json` streams: one emulator
+// log line, split into the fields of LocalStack's log format. A line that
+// continues a record, such as part of a traceback, has only Message.
+// Emulator names the emulator when the logs of several are interleaved.
+type JsonLogLine struct {
+	Emulator  string `json:"emulator,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Thread    string `json:"thread,omitempty"`
+	Logger    string `json:"logger,omitempty"`
+	Message   string `json:"message"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// JsonRequest is the data of a "request" line `logs requests --json`
+// streams: one request an emulator served. An AWS request has service and
+// operation, plus errorCode when it failed; any other request has method and
+// path. account and region are only known in LocalStack's trace mode.
+type JsonRequest struct {
+	Emulator  string `json:"emulator,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Service   string `json:"service,omitempty"`
+	Operation string `json:"operation,omitempty"`
+	Method    string `json:"method,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Status    int    `json:"status"`
+	ErrorCode string `json:"errorCode,omitempty"`
+	Account   string `json:"account,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// JsonRequestSummary is the data of the "summary" line that ends
+// `logs requests --json`: the requests streamed, per operation.
+type JsonRequestSummary struct {
+	Operations []JsonOperationStats `json:"operations"`
+}
+
+// JsonOperationStats is one operation in a JsonRequestSummary. errorRate is
+// the share of requests that failed, between 0 and 1.
+type JsonOperationStats struct {
+	Service   string  `json:"service,omitempty"`
+	Operation string  `json:"operation"`
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// JsonReadyEmulator is the per-emulator entry in `wait`'s data.emulators.
+// Services is absent when only the emulator itself was waited for.
+type JsonReadyEmulator struct {
+	JsonEmulatorRef
+	URL      string            `json:"url"`
+	Services map[string]string `json:"services,omitempty"`
+	WaitedMS int64             `json:"waitedMs"`
+}
+
+func (JsonReadyEmulator) sealedEmulatorEntry() {}
+
+// JsonInitStep is an entry in `start`'s data.init: a [[containers.init]] step
+// that completed against a freshly started emulator.
+type JsonInitStep struct {
+	Emulator   JsonEmulatorRef `json:"emulator"`
+	Step       int             `json:"step"`
+	Name       string          `json:"name"`
+	Kind       string          `json:"kind"`
+	DurationMS int64           `json:"durationMs"`
+}
+
+// JsonConfigSource is an entry in `config path`'s data.sources: a config file
+// layered into the loaded config, most specific first.
+type JsonConfigSource struct {
+	Path  string `json:"path"`
+	Scope string `json:"scope"`
+}
+
+// JsonDoctorCheck is an entry in `doctor`'s data.checks: the outcome of one
+// check, with the suggested fixes of one that did not pass.
+type JsonDoctorCheck struct {
+	Name    string           `json:"name"`
+	Status  CheckStatus      `json:"status"`
+	Detail  string           `json:"detail,omitempty"`
+	Actions []EnvelopeAction `json:"actions,omitempty"`
+}
+
+// JsonDoctorSummary is `doctor`'s data.summary: how many checks had each
+// outcome.
+type JsonDoctorSummary struct {
+	Passed   int `json:"passed"`
+	Warnings int `json:"warnings"`
+	Failures int `json:"failures"`
+	Skipped  int `json:"skipped"`
+}
+
+// JsonDoctorEnvironment is `doctor`'s data.environment: what lstk ran on,
+// for a support request.
+type JsonDoctorEnvironment struct {
+	LstkVersion   string               `json:"lstkVersion"`
+	OS            string               `json:"os"`
+	Arch          string               `json:"arch"`
+	Runtime       string               `json:"runtime"`
+	RuntimeFlavor string               `json:"runtimeFlavor,omitempty"`
+	ConfigFiles   []JsonConfigSource   `json:"configFiles"`
+	Emulators     []JsonDoctorEmulator `json:"emulators"`
+}
+
+// JsonDoctorEmulator is a configured emulator in JsonDoctorEnvironment.
+type JsonDoctorEmulator struct {
+	JsonEmulatorRef
+	Image string `json:"image,omitempty"`
+	Port  string `json:"port"`
+}
@@ -40,6 +40,56 @@ func (s *EnvelopeSink) Emit(event Event) {
 			JsonEmulatorRef: JsonEmulatorRef{Type: e.Type, Name: e.Name},
 			WasRunning:      e.WasRunning,
 		})
+	case InstanceInfoEvent:
+		services := e.Services
+		if services == nil {
+			services = map[string]string{}
+		}
+		s.appendEmulator(JsonStatusEmulator{
+			Type:    e.Type,
+			Name:    e.ContainerName,
+			Running: true,
+			JsonStatusDetails: &JsonStatusDetails{
+				Version:       e.Version,
+				Host:          e.Host,
+				UptimeSeconds: int64(e.Uptime.Seconds()),
+				Persistence:   e.Persistence,
+				Services:      services,
+			},
+		})
+	case EmulatorNotRunningEvent:
+		s.appendEmulator(JsonStatusEmulator{Type: e.Type})
+	case ResourceSummaryEvent:
+		// Follows the InstanceInfoEvent of the emulator it summarises.
+		list, _ := s.data["emulators"].([]JsonEmulatorEntry)
+		if n := len(list); n > 0 {
+			if entry, ok := list[n-1].(JsonStatusEmulator); ok && entry.JsonStatusDetails != nil {
+				entry.ResourceSummary = &JsonResourceSummary{Resources: e.Resources, Services: e.Services}
+			}
+		}
+	case EmulatorReadyEvent:
+		s.appendEmulator(JsonReadyEmulator{
+			JsonEmulatorRef: JsonEmulatorRef{Type: e.Type, Name: e.Name},
+			URL:             e.URL,
+			Services:        e.Services,
+			WaitedMS:        e.Waited.Milliseconds(),
+		})
+	case InitStepEvent:
+		steps, _ := s.data["init"].([]JsonInitStep)
+		s.data["init"] = append(steps, JsonInitStep{
+			Emulator:   JsonEmulatorRef{Type: e.Type, Name: e.Container},
+			Step:       e.Step,
+			Name:       e.Label,
+			Kind:       e.Kind,
+			DurationMS: e.Duration.Milliseconds(),
+		})
+	case ConfigPathEvent:
+		sources := make([]JsonConfigSource, len(e.Sources))
+		for i, src := range e.Sources {
+			sources[i] = JsonConfigSource(src)
+		}
+		s.data["path"] = e.Path
+		s.data["sources"] = sources
 	case EmulatorResetEvent:
 		s.data["emulator"] = JsonEmulatorRef(e)
 		s.data["reset"] = true
@@ -47,6 +97,33 @@ func (s *EnvelopeSink) Emit(event Event) {
 		s.data["currentVersion"] = e.CurrentVersion
 		s.data["latestVersion"] = e.LatestVersion
 		s.data["updateAvailable"] = e.Available
+	case DoctorCheckEvent:
+		checks, _ := s.data["checks"].([]JsonDoctorCheck)
+		s.data["checks"] = append(checks, JsonDoctorCheck{
+			Name:    e.Name,
+			Status:  e.Status,
+			Detail:  e.Detail,
+			Actions: envelopeActions(e.Actions),
+		})
+	case DoctorReportEvent:
+		s.data["summary"] = JsonDoctorSummary{Passed: e.Passed, Warnings: e.Warnings, Failures: e.Failures, Skipped: e.Skipped}
+		configFiles := make([]JsonConfigSource, len(e.ConfigFiles))
+		for i, src := range e.ConfigFiles {
+			configFiles[i] = JsonConfigSource(src)
+		}
+		emulators := make([]JsonDoctorEmulator, len(e.Emulators))
+		for i, em := range e.Emulators {
+			emulators[i] = JsonDoctorEmulator{JsonEmulatorRef: JsonEmulatorRef{Type: em.Type, Name: em.Name}, Image: em.Image, Port: em.Port}
+		}
+		s.data["environment"] = JsonDoctorEnvironment{
+			LstkVersion:   e.LstkVersion,
+			OS:            e.OS,
+			Arch:          e.Arch,
+			Runtime:       e.Runtime,
+			RuntimeFlavor: e.RuntimeFlavor,
+			ConfigFiles:   configFiles,
+			Emulators:     emulators,
+		}
 	case UpdateAppliedEvent:
 		// An UpdateCheckedEvent always precedes this on the apply path (Check
 		// fires it unconditionally now, for the plain-text "Update available"
@@ -88,22 +165,32 @@ func errorDetails(e ErrorEvent) map[string]any {
 }
 
 func (s *EnvelopeSink) setError(e ErrorEvent) {
+	s.err = newEnvelopeError(e)
+}
+
+// newEnvelopeError classifies e as the EnvelopeError a JSON consumer sees,
+// falling back to ErrInternal for an ErrorEvent without a Code.
+func newEnvelopeError(e ErrorEvent) *EnvelopeError {
 	code := e.Code
 	if code == "" {
 		code = ErrInternal
 	}
-	var actions []EnvelopeAction
-	for _, a := range e.Actions {
-		actions = append(actions, EnvelopeAction{ID: slugify(a.Label), Command: a.Value})
-	}
-	s.err = &EnvelopeError{
+	return &EnvelopeError{
 		Code:      code,
 		Category:  code.Category(),
 		Message:   e.Title,
 		Retryable: code.Retryable(),
 		Details:   errorDetails(e),
-		Actions:   actions,
+		Actions:   envelopeActions(e.Actions),
+	}
+}
+
+func envelopeActions(actions []ErrorAction) []EnvelopeAction {
+	var out []EnvelopeAction
+	for _, a := range actions {
+		out = append(out, EnvelopeAction{ID: slugify(a.Label), Command: a.Value})
 	}
+	return out
 }
 
 // Result builds the final Envelope for command, given the error RunE
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/localstack/lstk/internal/snap"
 	"github.com/stretchr/testify/require"
@@ -53,6 +54,117 @@ func TestEnvelopeSink_EmulatorStoppedEventAccumulates(t *testing.T) {
 	}, entries)
 }
 
+func TestEnvelopeSink_InitStepEventAccumulates(t *testing.T) {
+	t.Parallel()
+
+	sink := NewEnvelopeSink(FormatJSON)
+	sink.Emit(InitStepEvent{Type: "aws", Container: "localstack-aws", Step: 1, Total: 2, Label: "buckets", Kind: "aws", Duration: 1500 * time.Millisecond})
+	sink.Emit(InitStepEvent{Type: "aws", Container: "localstack-aws", Step: 2, Total: 2, Label: "./seed.sh", Kind: "shell", Duration: 20 * time.Millisecond})
+
+	envelope := sink.Result("start", nil)
+	data, ok := envelope.Data.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, []JsonInitStep{
+		{Emulator: JsonEmulatorRef{Type: "aws", Name: "localstack-aws"}, Step: 1, Name: "buckets", Kind: "aws", DurationMS: 1500},
+		{Emulator: JsonEmulatorRef{Type: "aws", Name: "localstack-aws"}, Step: 2, Name: "./seed.sh", Kind: "shell", DurationMS: 20},
+	}, data["init"])
+}
+
+func TestEnvelopeSink_EmulatorReadyEventAccumulates(t *testing.T) {
+	t.Parallel()
+
+	sink := NewEnvelopeSink(FormatJSON)
+	sink.Emit(EmulatorReadyEvent{Type: "aws", Name: "localstack-aws", URL: "http://localhost:4566", Services: map[string]string{"s3": "available"}, Waited: 2500 * time.Millisecond})
+
+	envelope := sink.Result("wait", nil)
+	data, ok := envelope.Data.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, []JsonEmulatorEntry{
+		JsonReadyEmulator{
+			JsonEmulatorRef: JsonEmulatorRef{Type: "aws", Name: "localstack-aws"},
+			URL:             "http://localhost:4566",
+			Services:        map[string]string{"s3": "available"},
+			WaitedMS:        2500,
+		},
+	}, data["emulators"])
+}
+
+func TestEnvelopeSink_StatusEventsAccumulate(t *testing.T) {
+	t.Parallel()
+
+	sink := NewEnvelopeSink(FormatJSON)
+	sink.Emit(InstanceInfoEvent{
+		Type:          "aws",
+		EmulatorName:  "LocalStack AWS Emulator",
+		Version:       "4.14.1",
+		Host:          "localhost.localstack.cloud:4566",
+		ContainerName: "localstack-aws",
+		Uptime:        90 * time.Second,
+		Services:      map[string]string{"s3": "running", "lambda": "error"},
+	})
+	sink.Emit(ResourceSummaryEvent{Resources: 3, Services: 1})
+	sink.Emit(TableEvent{Headers: []string{"Service"}, Rows: [][]string{{"s3"}}})
+	sink.Emit(EmulatorNotRunningEvent{Type: "snowflake", DisplayName: "LocalStack Snowflake Emulator"})
+
+	envelope := sink.Result("status", nil)
+	raw, err := json.Marshal(envelope.Data)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"emulators": [
+		{"type": "aws", "name": "localstack-aws", "running": true, "version": "4.14.1", "host": "localhost.localstack.cloud:4566",
+		 "uptimeSeconds": 90, "persistence": false, "services": {"s3": "running", "lambda": "error"},
+		 "resourceSummary": {"resources": 3, "services": 1}},
+		{"type": "snowflake", "running": false}
+	]}`, string(raw))
+}
+
+func TestEnvelopeSink_ConfigPathEvent(t *testing.T) {
+	t.Parallel()
+
+	sink := NewEnvelopeSink(FormatJSON)
+	sink.Emit(ConfigPathEvent{Path: "/work/app/lstk.toml", Sources: []ConfigSource{
+		{Path: "/work/app/lstk.toml", Scope: "project"},
+		{Path: "/home/u/.config/lstk/config.toml", Scope: "global"},
+	}})
+
+	envelope := sink.Result("config path", nil)
+	data, ok := envelope.Data.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "/work/app/lstk.toml", data["path"])
+	require.Equal(t, []JsonConfigSource{
+		{Path: "/work/app/lstk.toml", Scope: "project"},
+		{Path: "/home/u/.config/lstk/config.toml", Scope: "global"},
+	}, data["sources"])
+}
+
+func TestEnvelopeSink_DoctorEvents(t *testing.T) {
+	t.Parallel()
+
+	sink := NewEnvelopeSink(FormatJSON)
+	sink.Emit(DoctorCheckEvent{Name: "DNS", Status: CheckPass, Detail: "resolves"})
+	sink.Emit(DoctorCheckEvent{Name: "Auth token", Status: CheckFail, Detail: "not found", Actions: []ErrorAction{{Label: "Log in:", Value: "lstk login"}}})
+	sink.Emit(DoctorReportEvent{
+		Passed: 1, Failures: 1,
+		LstkVersion: "1.2.0", OS: "linux", Arch: "amd64", Runtime: "auto", RuntimeFlavor: "docker",
+		ConfigFiles: []ConfigSource{{Path: "/work/app/lstk.toml", Scope: "project"}},
+		Emulators:   []DoctorEmulator{{Type: "aws", Name: "localstack-aws", Image: "localstack/localstack-pro:latest", Port: "4566"}},
+	})
+
+	raw, err := json.Marshal(sink.Result("doctor", nil))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"schemaVersion": 1, "command": "doctor", "status": "ok", "data": {
+		"checks": [
+			{"name": "DNS", "status": "pass", "detail": "resolves"},
+			{"name": "Auth token", "status": "fail", "detail": "not found", "actions": [{"id": "log-in", "command": "lstk login"}]}
+		],
+		"summary": {"passed": 1, "warnings": 0, "failures": 1, "skipped": 0},
+		"environment": {
+			"lstkVersion": "1.2.0", "os": "linux", "arch": "amd64", "runtime": "auto", "runtimeFlavor": "docker",
+			"configFiles": [{"path": "/work/app/lstk.toml", "scope": "project"}],
+			"emulators": [{"type": "aws", "name": "localstack-aws", "image": "localstack/localstack-pro:latest", "port": "4566"}]
+		}
+	}, "warnings": [], "error": null}`, string(raw))
+}
+
 func TestEnvelopeSink_EmulatorResetEvent(t *testing.T) {
 	t.Parallel()
 
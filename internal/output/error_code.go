@@ -13,6 +13,8 @@ const (
 	ErrEmulatorWrongType      ErrorCode = "EMULATOR_WRONG_TYPE"
 	ErrEmulatorNotConfigured  ErrorCode = "EMULATOR_NOT_CONFIGURED"
 	ErrEmulatorStartFailed    ErrorCode = "EMULATOR_START_FAILED"
+	ErrEmulatorNotReady       ErrorCode = "EMULATOR_NOT_READY"
+	ErrInitStepFailed         ErrorCode = "INIT_STEP_FAILED"
 	ErrAuthRequired           ErrorCode = "AUTH_REQUIRED"
 	ErrAuthLoginFailed        ErrorCode = "AUTH_LOGIN_FAILED"
 	ErrCredentialsMissing     ErrorCode = "CREDENTIALS_MISSING"
@@ -43,6 +45,7 @@ var retryableCodes = map[ErrorCode]bool{
 	ErrRuntimeUnavailable:  true,
 	ErrImagePullFailed:     true,
 	ErrEmulatorStartFailed: true,
+	ErrEmulatorNotReady:    true,
 	ErrAuthLoginFailed:     true,
 	ErrSnapshotRemoteError: true,
 	ErrNetworkError:        true,
@@ -97,6 +100,8 @@ var allErrorCodes = []ErrorCode{
 	ErrEmulatorWrongType,
 	ErrEmulatorNotConfigured,
 	ErrEmulatorStartFailed,
+	ErrEmulatorNotReady,
+	ErrInitStepFailed,
 	ErrAuthRequired,
 	ErrAuthLoginFailed,
 	ErrCredentialsMissing,
@@ -134,6 +139,8 @@ var categoryByCode = map[ErrorCode]ErrorCategory{
 	ErrEmulatorWrongType:      CategoryEmulator,
 	ErrEmulatorNotConfigured:  CategoryEmulator,
 	ErrEmulatorStartFailed:    CategoryEmulator,
+	ErrEmulatorNotReady:       CategoryEmulator,
+	ErrInitStepFailed:         CategoryEmulator,
 	ErrAuthRequired:           CategoryAuth,
 	ErrAuthLoginFailed:        CategoryAuth,
 	ErrCredentialsMissing:     CategoryAuth,
@@ -15,7 +15,11 @@
 //   - Use for errors that need more than a single line
 package output
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 type MessageSeverity int
 
@@ -63,12 +67,25 @@ type AuthEvent struct {
 }
 
 type InstanceInfoEvent struct {
+	Type          string // emulator type, e.g. "aws"
 	EmulatorName  string
 	Version       string
 	Host          string
 	ContainerName string
 	Uptime        time.Duration
 	Persistence   bool
+	// Services maps each service in /_localstack/health to its state
+	// ("available", "running", "error", "disabled", ...). Empty when the
+	// emulator reports none.
+	Services map[string]string
+}
+
+// EmulatorNotRunningEvent reports a configured emulator that status found not
+// running while it reports on others; with a single emulator, status fails
+// instead.
+type EmulatorNotRunningEvent struct {
+	Type        string
+	DisplayName string
 }
 
 type TableEvent struct {
@@ -117,12 +134,26 @@ type DeferredEvent struct {
 type SnapshotLoadedEvent struct {
 	Source   string   // display source shown to the user (e.g. "./snap.snapshot" or "pod:my-baseline")
 	Services []string // services restored
+	Skipped  []string // services the snapshot holds that a --services filter left out
 }
 
 type PodSnapshotRemovedEvent struct {
 	PodName string
 }
 
+// LocalSnapshotsPrunedEvent reports the outcome of pruning the local snapshot
+// library: how many entries were deleted and the disk space that freed.
+type LocalSnapshotsPrunedEvent struct {
+	Removed int
+	Size    int64
+}
+
+// ResourceManifestExportedEvent reports a resource manifest written to a file.
+type ResourceManifestExportedEvent struct {
+	Path      string
+	Resources int
+}
+
 // SnapshotResourceCount is a count of one resource kind, e.g. {Count: 3, Noun: "buckets"}.
 type SnapshotResourceCount struct {
 	Count int
@@ -135,24 +166,29 @@ type SnapshotResourceLine struct {
 	Counts  []SnapshotResourceCount
 }
 
-// SnapshotShownEvent reports the metadata of a single cloud snapshot for the
+// SnapshotShownEvent reports the metadata of a single snapshot for the
 // `snapshot show` command. Created is nil and Resources is empty when the
-// platform has no value for them; the formatter omits those sections.
+// platform has no value for them; the formatter omits those sections. Path is
+// set only for a snapshot in the local library. Accounts and Regions are set
+// only for local snapshots, read from the archive.
 type SnapshotShownEvent struct {
 	Name              string
+	Path              string
 	Version           int
 	Created           *time.Time
 	Size              int64
 	LocalStackVersion string
 	Message           string
 	Services          []string
+	Accounts          []string
+	Regions           []string
 	Resources         []SnapshotResourceLine
 }
 
-// EmulatorStoppedEvent reports that a configured emulator was running and has
-// been stopped. WasRunning is always true today (Stop returns an error before
-// reaching this point otherwise) but is carried explicitly for JSON shape
-// stability. DisplayName is precomputed by the caller (e.g. "LocalStack AWS
+// EmulatorStoppedEvent reports the outcome of stopping one configured emulator.
+// WasRunning is false only when several emulators are configured and this one
+// was already down; with a single emulator Stop returns an error instead.
+// DisplayName is precomputed by the caller (e.g. "LocalStack AWS
 // Emulator"), matching InstanceInfoEvent.EmulatorName's existing precedent, so
 // internal/output never needs to know how to derive it from Type.
 type EmulatorStoppedEvent struct {
@@ -162,6 +198,65 @@ type EmulatorStoppedEvent struct {
 	WasRunning  bool
 }
 
+// EmulatorReadyEvent reports that `lstk wait` saw an emulator become ready.
+// Services holds the state of each service the caller waited for (empty when
+// it only waited for the emulator itself), and Waited how long that took.
+type EmulatorReadyEvent struct {
+	Type        string
+	Name        string
+	DisplayName string
+	URL         string
+	Services    map[string]string
+	Waited      time.Duration
+}
+
+// StatusRefreshEvent is one refresh of `lstk status --watch`: the state of
+// each watched emulator and what changed since the previous refresh. Initial
+// marks the first refresh, which has nothing to compare against.
+type StatusRefreshEvent struct {
+	Time      time.Time
+	Interval  time.Duration
+	Initial   bool
+	Emulators []WatchedEmulator
+}
+
+// WatchedEmulator is one emulator in a StatusRefreshEvent. Info only carries
+// Type and EmulatorName while the emulator is not running. Err is set when
+// this refresh failed, in which case Resources are those seen last; WasRunning
+// and PrevErr are the previous refresh's Running and Err.
+type WatchedEmulator struct {
+	Info           InstanceInfoEvent
+	Running        bool
+	WasRunning     bool
+	Resources      []StatusResource
+	Added          []StatusResource
+	Removed        []StatusResource
+	ServiceChanges []ServiceStateChange
+	Err            string
+	PrevErr        string
+}
+
+// Changed reports whether anything differs from the previous refresh.
+func (e WatchedEmulator) Changed() bool {
+	return e.Running != e.WasRunning || e.Err != e.PrevErr || len(e.Added) > 0 || len(e.Removed) > 0 || len(e.ServiceChanges) > 0
+}
+
+// StatusResource is a resource deployed in an emulator, as listed by status.
+type StatusResource struct {
+	Service string
+	Name    string
+	Region  string
+	Account string
+}
+
+// ServiceStateChange is a service whose /_localstack/health state moved
+// between two refreshes. From is empty for a service that just appeared.
+type ServiceStateChange struct {
+	Service string
+	From    string
+	To      string
+}
+
 // EmulatorResetEvent reports that the named emulator's in-memory state was reset.
 type EmulatorResetEvent struct {
 	Type string
@@ -189,6 +284,105 @@ type UpdateAppliedEvent struct {
 
 type AuthCompleteEvent struct{}
 
+// InitStepEvent reports a [[containers.init]] step that ran to completion
+// against a freshly started emulator. A failing step is reported through an
+// ErrorEvent instead, since it fails the start.
+type InitStepEvent struct {
+	Type      string // emulator type, e.g. "aws"
+	Container string // container name of the emulator the step ran against
+	Step      int    // 1-based position of the step in the block's init list
+	Total     int
+	Label     string // the step's name, or a description of its command
+	Kind      string // "shell", "aws" or "terraform"
+	Duration  time.Duration
+}
+
+// ConfigPathEvent reports where the config lives: Path is the file lstk reads and
+// edits first (or would create, when Sources is empty), and Sources every config
+// file layered into the loaded config, most specific first.
+type ConfigPathEvent struct {
+	Path    string
+	Sources []ConfigSource
+}
+
+// ConfigSource is one config file behind the loaded config.
+type ConfigSource struct {
+	Path  string
+	Scope string // "project", "global" or "explicit"
+}
+
+// ConfigValueEvent reports the value of a config key for `lstk config get`,
+// already rendered: scalars as-is, lists and tables as TOML.
+type ConfigValueEvent struct {
+	Key   string
+	Value string
+}
+
+// ConfigProblemsEvent reports what `lstk config validate` found in the config
+// files Paths, most specific first. No Problems means the config is valid, and
+// no Paths that there is no config file, so lstk uses its defaults.
+type ConfigProblemsEvent struct {
+	Paths    []string
+	Problems []ConfigProblem
+}
+
+// ConfigProblem is one problem in a config file. Line is 1-based, or 0 when the
+// problem is not tied to a line written in the file.
+type ConfigProblem struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+// CheckStatus is the outcome of a check `lstk doctor` runs.
+type CheckStatus string
+
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+	// CheckSkip is a check that does not apply, e.g. to a tool that is not
+	// installed, or that an earlier failure keeps from running.
+	CheckSkip CheckStatus = "skip"
+)
+
+// DoctorCheckEvent is the outcome of one check `lstk doctor` runs. Actions
+// say how to fix a check that did not pass.
+type DoctorCheckEvent struct {
+	Name    string
+	Status  CheckStatus
+	Detail  string
+	Actions []ErrorAction
+}
+
+// DoctorReportEvent ends `lstk doctor`: how many checks had each outcome, and
+// the environment they ran in, which `lstk doctor --json` puts in its support
+// bundle along with the checks.
+type DoctorReportEvent struct {
+	Passed   int
+	Warnings int
+	Failures int
+	Skipped  int
+
+	LstkVersion string
+	OS          string
+	Arch        string
+	// Runtime is the container runtime backend, and RuntimeFlavor what backs
+	// its daemon connection, e.g. "colima"; empty when unknown.
+	Runtime       string
+	RuntimeFlavor string
+	ConfigFiles   []ConfigSource
+	Emulators     []DoctorEmulator
+}
+
+// DoctorEmulator is a configured emulator in a DoctorReportEvent.
+type DoctorEmulator struct {
+	Type  string
+	Name  string
+	Image string
+	Port  string
+}
+
 type SnapshotDiffServiceResult struct {
 	Additions     int
 	Modifications int
@@ -196,10 +390,14 @@ type SnapshotDiffServiceResult struct {
 
 // SnapshotDiffEvent reports a dry-run diff between a cloud snapshot and the
 // running state. Version is the pinned snapshot version, or 0 for the latest.
+// A local diff, computed by lstk from the archives themselves, leaves PodName
+// empty and names its two sides in Base and Target instead.
 type SnapshotDiffEvent struct {
 	PodName  string
 	Version  int
 	Strategy string
+	Base     string
+	Target   string
 	Services map[string]SnapshotDiffServiceResult
 }
 
@@ -207,32 +405,45 @@ type SnapshotDiffEvent struct {
 // so Sink.Emit rejects unknown types at compile time.
 type Event interface{ sealedEvent() }
 
-func (MessageEvent) sealedEvent()             {}
-func (SpinnerEvent) sealedEvent()             {}
-func (ErrorEvent) sealedEvent()               {}
-func (AuthEvent) sealedEvent()                {}
-func (AuthCompleteEvent) sealedEvent()        {}
-func (InstanceInfoEvent) sealedEvent()        {}
-func (TableEvent) sealedEvent()               {}
-func (ResourceSummaryEvent) sealedEvent()     {}
-func (PodSnapshotSavedEvent) sealedEvent()    {}
-func (LocalSnapshotSavedEvent) sealedEvent()  {}
-func (RemoteSnapshotSavedEvent) sealedEvent() {}
-func (DeferredEvent) sealedEvent()            {}
-func (SnapshotLoadedEvent) sealedEvent()      {}
-func (SnapshotDiffEvent) sealedEvent()        {}
-func (PodSnapshotRemovedEvent) sealedEvent()  {}
-func (SnapshotShownEvent) sealedEvent()       {}
-func (EmulatorStoppedEvent) sealedEvent()     {}
-func (EmulatorResetEvent) sealedEvent()       {}
-func (UpdateCheckedEvent) sealedEvent()       {}
-func (UpdateAppliedEvent) sealedEvent()       {}
-func (ContainerStatusEvent) sealedEvent()     {}
-func (ProgressEvent) sealedEvent()            {}
-func (UserInputRequestEvent) sealedEvent()    {}
-func (UserInputDismissEvent) sealedEvent()    {}
-func (PullSkippableEvent) sealedEvent()       {}
-func (LogLineEvent) sealedEvent()             {}
+func (MessageEvent) sealedEvent()                  {}
+func (SpinnerEvent) sealedEvent()                  {}
+func (ErrorEvent) sealedEvent()                    {}
+func (AuthEvent) sealedEvent()                     {}
+func (AuthCompleteEvent) sealedEvent()             {}
+func (InstanceInfoEvent) sealedEvent()             {}
+func (EmulatorNotRunningEvent) sealedEvent()       {}
+func (TableEvent) sealedEvent()                    {}
+func (ResourceSummaryEvent) sealedEvent()          {}
+func (PodSnapshotSavedEvent) sealedEvent()         {}
+func (LocalSnapshotSavedEvent) sealedEvent()       {}
+func (RemoteSnapshotSavedEvent) sealedEvent()      {}
+func (DeferredEvent) sealedEvent()                 {}
+func (SnapshotLoadedEvent) sealedEvent()           {}
+func (SnapshotDiffEvent) sealedEvent()             {}
+func (InitStepEvent) sealedEvent()                 {}
+func (ConfigPathEvent) sealedEvent()               {}
+func (ConfigValueEvent) sealedEvent()              {}
+func (ConfigProblemsEvent) sealedEvent()           {}
+func (PodSnapshotRemovedEvent) sealedEvent()       {}
+func (LocalSnapshotsPrunedEvent) sealedEvent()     {}
+func (ResourceManifestExportedEvent) sealedEvent() {}
+func (SnapshotShownEvent) sealedEvent()            {}
+func (EmulatorStoppedEvent) sealedEvent()          {}
+func (EmulatorReadyEvent) sealedEvent()            {}
+func (StatusRefreshEvent) sealedEvent()            {}
+func (EmulatorResetEvent) sealedEvent()            {}
+func (UpdateCheckedEvent) sealedEvent()            {}
+func (UpdateAppliedEvent) sealedEvent()            {}
+func (ContainerStatusEvent) sealedEvent()          {}
+func (ProgressEvent) sealedEvent()                 {}
+func (UserInputRequestEvent) sealedEvent()         {}
+func (UserInputDismissEvent) sealedEvent()         {}
+func (PullSkippableEvent) sealedEvent()            {}
+func (LogLineEvent) sealedEvent()                  {}
+func (RequestEvent) sealedEvent()                  {}
+func (RequestSummaryEvent) sealedEvent()           {}
+func (DoctorCheckEvent) sealedEvent()              {}
+func (DoctorReportEvent) sealedEvent()             {}
 
 type Sink interface {
 	Emit(event Event)
@@ -317,6 +528,7 @@ const (
 	LogSourceEmulator = "emulator"
 	LogSourceBrew     = "brew"
 	LogSourceNPM      = "npm"
+	LogSourceInit     = "init"
 )
 
 type LogLevel int
@@ -331,8 +543,99 @@ const (
 
 type LogLineEvent struct {
 	Source string
-	Line   string
-	Level  LogLevel
+	// Emulator names the emulator the line came from (its type, e.g. "aws", or
+	// its instance name) when logs of several emulators are interleaved; empty
+	// otherwise.
+	Emulator string
+	Line     string
+	Level    LogLevel
+	// Time, Thread, Logger and Message are the fields of an emulator log
+	// record, parsed from Line; continuation lines such as a traceback only
+	// have Message set.
+	Time    time.Time
+	Thread  string
+	Logger  string
+	Message string
+	// Truncated reports that Line was clipped for being too long.
+	Truncated bool
+}
+
+// RequestEvent is one request an emulator served, read from its request log
+// by `lstk logs requests`. AWS requests carry Service and Operation, plus
+// ErrorCode when the operation failed; other HTTP requests carry Method and
+// Path instead. Account and Region are only logged in LocalStack's trace mode
+// (LS_LOG=trace). The log records no latency, so neither does the event.
+type RequestEvent struct {
+	Time time.Time
+	// Emulator names the emulator, as in LogLineEvent.
+	Emulator  string
+	Service   string
+	Operation string
+	Method    string
+	Path      string
+	Status    int
+	ErrorCode string
+	Account   string
+	Region    string
+}
+
+// Failed reports whether the emulator answered with an error status.
+func (e RequestEvent) Failed() bool {
+	return e.Status >= 400
+}
+
+// Target names what was called: "s3.GetObject", or "GET /path" for a request
+// that is not an AWS operation.
+func (e RequestEvent) Target() string {
+	if e.Service == "" {
+		return e.Method + " " + e.Path
+	}
+	return e.Service + "." + e.Operation
+}
+
+// RequestSummaryEvent tallies the requests `lstk logs requests` showed, per
+// operation, sorted by service and operation.
+type RequestSummaryEvent struct {
+	Operations []OperationStats
+}
+
+// OperationStats counts the requests to one operation, and how many of them
+// failed. Service is empty for a request that is not an AWS operation, whose
+// Operation is then its method and path.
+type OperationStats struct {
+	Service   string
+	Operation string
+	Requests  int
+	Errors    int
+}
+
+// ErrorRate is the share of requests that failed, between 0 and 1.
+func (s OperationStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// Add counts e in the summary.
+func (s *RequestSummaryEvent) Add(e RequestEvent) {
+	service, operation := e.Service, e.Operation
+	if service == "" {
+		operation = e.Method + " " + e.Path
+	}
+	i, found := slices.BinarySearchFunc(s.Operations, OperationStats{Service: service, Operation: operation}, func(a, b OperationStats) int {
+		if c := strings.Compare(a.Service, b.Service); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Operation, b.Operation)
+	})
+	if !found {
+		s.Operations = slices.Insert(s.Operations, i, OperationStats{Service: service, Operation: operation})
+	}
+	s.Operations[i].Requests++
+	if e.Failed() {
+		s.Operations[i].Errors++
+	}
 }
 
 const DefaultSpinnerMinDuration = 400 * time.Millisecond
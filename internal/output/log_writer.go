@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LogLineWriter adapts a Sink into an io.Writer, emitting each complete line
+// as a LogLineEvent from source. Partial writes are buffered until a newline
+// arrives. Used to stream the output of a child process through the sink.
+type LogLineWriter struct {
+	mu     sync.Mutex
+	sink   Sink
+	source string
+	buf    []byte
+}
+
+func NewLogLineWriter(sink Sink, source string) *LogLineWriter {
+	return &LogLineWriter{sink: sink, source: source}
+}
+
+func (w *LogLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+		if line != "" {
+			w.sink.Emit(LogLineEvent{Source: w.source, Line: line, Level: LogLevelUnknown})
+		}
+	}
+	return len(p), nil
+}
+
+// Flush emits any remaining buffered content that didn't end with a newline.
+func (w *LogLineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.sink.Emit(LogLineEvent{Source: w.source, Line: string(w.buf), Level: LogLevelUnknown})
+		w.buf = nil
+	}
+}
@@ -0,0 +1,212 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Stream line types. See "Streaming output" in docs/structured-output.md.
+const (
+	StreamTypeSnapshot = "snapshot"
+	StreamTypeDiff     = "diff"
+	StreamTypeError    = "error"
+	StreamTypeLog      = "log"
+	StreamTypeRequest  = "request"
+	StreamTypeSummary  = "summary"
+)
+
+// StreamLine is one line of an NDJSON stream. It mirrors an Envelope, with
+// Type in place of Status, for commands whose output has no natural end.
+type StreamLine struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Command       string `json:"command"`
+	Type          string `json:"type"`
+	Data          any    `json:"data"`
+}
+
+// NDJSONSink implements Sink for unbounded commands by writing each event
+// worth reporting as its own compact JSON line as soon as it happens, instead
+// of accumulating a single Envelope. Presentational events are dropped, as in
+// EnvelopeSink.
+type NDJSONSink struct {
+	out     io.Writer
+	command string
+	err     error
+}
+
+func NewNDJSONSink(out io.Writer, command string) *NDJSONSink {
+	return &NDJSONSink{out: out, command: command}
+}
+
+// Err returns the first write error encountered, if any.
+func (s *NDJSONSink) Err() error {
+	return s.err
+}
+
+func (s *NDJSONSink) Emit(event Event) {
+	switch e := event.(type) {
+	case DeferredEvent:
+		s.Emit(e.Inner)
+	case ErrorEvent:
+		s.write(StreamTypeError, newEnvelopeError(e))
+	case StatusRefreshEvent:
+		s.emitStatusRefresh(e)
+	case LogLineEvent:
+		s.write(StreamTypeLog, jsonLogLine(e))
+	case RequestEvent:
+		s.write(StreamTypeRequest, jsonRequest(e))
+	case RequestSummaryEvent:
+		s.write(StreamTypeSummary, jsonRequestSummary(e))
+	}
+}
+
+// logTimestampLayout keeps the millisecond precision of the emulator's log
+// timestamps.
+const logTimestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+func jsonLogLine(e LogLineEvent) JsonLogLine {
+	line := JsonLogLine{
+		Emulator:  e.Emulator,
+		Level:     logLevelName(e.Level),
+		Thread:    e.Thread,
+		Logger:    e.Logger,
+		Message:   e.Message,
+		Truncated: e.Truncated,
+	}
+	if !e.Time.IsZero() {
+		line.Timestamp = e.Time.UTC().Format(logTimestampLayout)
+	}
+	return line
+}
+
+func jsonRequest(e RequestEvent) JsonRequest {
+	req := JsonRequest{
+		Emulator:  e.Emulator,
+		Service:   e.Service,
+		Operation: e.Operation,
+		Method:    e.Method,
+		Path:      e.Path,
+		Status:    e.Status,
+		ErrorCode: e.ErrorCode,
+		Account:   e.Account,
+		Region:    e.Region,
+	}
+	if !e.Time.IsZero() {
+		req.Timestamp = e.Time.UTC().Format(logTimestampLayout)
+	}
+	return req
+}
+
+func jsonRequestSummary(e RequestSummaryEvent) JsonRequestSummary {
+	operations := make([]JsonOperationStats, len(e.Operations))
+	for i, op := range e.Operations {
+		operations[i] = JsonOperationStats{
+			Service:   op.Service,
+			Operation: op.Operation,
+			Requests:  op.Requests,
+			Errors:    op.Errors,
+			ErrorRate: op.ErrorRate(),
+		}
+	}
+	return JsonRequestSummary{Operations: operations}
+}
+
+func logLevelName(level LogLevel) string {
+	switch level {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	}
+	return ""
+}
+
+// emitStatusRefresh writes the first refresh in full and every later one as
+// a diff, skipping refreshes where nothing changed.
+func (s *NDJSONSink) emitStatusRefresh(e StatusRefreshEvent) {
+	at := e.Time.UTC().Format(time.RFC3339)
+	if e.Initial {
+		emulators := make([]JsonSnapshotEmulator, len(e.Emulators))
+		for i, em := range e.Emulators {
+			emulators[i] = JsonSnapshotEmulator{
+				JsonStatusEmulator: watchedStatus(em),
+				Resources:          jsonResources(em.Resources),
+				Error:              em.Err,
+			}
+		}
+		s.write(StreamTypeSnapshot, map[string]any{"time": at, "emulators": emulators})
+		return
+	}
+
+	var emulators []JsonDiffEmulator
+	for _, em := range e.Emulators {
+		if !em.Changed() {
+			continue
+		}
+		var changes map[string]JsonServiceStateChange
+		if len(em.ServiceChanges) > 0 {
+			changes = make(map[string]JsonServiceStateChange, len(em.ServiceChanges))
+			for _, c := range em.ServiceChanges {
+				changes[c.Service] = JsonServiceStateChange{From: c.From, To: c.To}
+			}
+		}
+		emulators = append(emulators, JsonDiffEmulator{
+			Type:           em.Info.Type,
+			Name:           em.Info.ContainerName,
+			Running:        em.Running,
+			Added:          jsonResources(em.Added),
+			Removed:        jsonResources(em.Removed),
+			ServiceChanges: changes,
+			Error:          em.Err,
+		})
+	}
+	if len(emulators) > 0 {
+		s.write(StreamTypeDiff, map[string]any{"time": at, "emulators": emulators})
+	}
+}
+
+// watchedStatus is the `status` envelope entry for em.
+func watchedStatus(em WatchedEmulator) JsonStatusEmulator {
+	entry := JsonStatusEmulator{Type: em.Info.Type, Name: em.Info.ContainerName, Running: em.Running}
+	if !em.Running {
+		return entry
+	}
+	services := em.Info.Services
+	if services == nil {
+		services = map[string]string{}
+	}
+	entry.JsonStatusDetails = &JsonStatusDetails{
+		Version:       em.Info.Version,
+		Host:          em.Info.Host,
+		UptimeSeconds: int64(em.Info.Uptime.Seconds()),
+		Persistence:   em.Info.Persistence,
+		Services:      services,
+	}
+	return entry
+}
+
+func jsonResources(resources []StatusResource) []JsonStatusResource {
+	out := make([]JsonStatusResource, len(resources))
+	for i, r := range resources {
+		out[i] = JsonStatusResource(r)
+	}
+	return out
+}
+
+func (s *NDJSONSink) write(lineType string, data any) {
+	if s.err != nil {
+		return
+	}
+	line, err := json.Marshal(StreamLine{SchemaVersion: EnvelopeSchemaVersion, Command: s.command, Type: lineType, Data: data})
+	if err != nil {
+		s.err = err
+		return
+	}
+	_, s.err = fmt.Fprintln(s.out, string(line))
+}
@@ -0,0 +1,100 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONSink_StatusRefreshesStreamSnapshotThenDiffs(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	bucket := StatusResource{Service: "S3", Name: "uploads", Region: "us-east-1", Account: "000000000000"}
+	queue := StatusResource{Service: "SQS", Name: "orders", Region: "us-east-1", Account: "000000000000"}
+	info := InstanceInfoEvent{Type: "aws", EmulatorName: "LocalStack AWS Emulator", Version: "4.0.0", Host: "localhost.localstack.cloud:4566", ContainerName: "localstack-aws", Services: map[string]string{"s3": "running"}}
+
+	var out bytes.Buffer
+	sink := NewNDJSONSink(&out, "status")
+	sink.Emit(StatusRefreshEvent{Time: at, Initial: true, Emulators: []WatchedEmulator{
+		{Info: info, Running: true, WasRunning: true, Resources: []StatusResource{bucket}},
+		{Info: InstanceInfoEvent{Type: "snowflake", EmulatorName: "LocalStack Snowflake Emulator"}},
+	}})
+	sink.Emit(StatusRefreshEvent{Time: at.Add(3 * time.Second), Emulators: []WatchedEmulator{
+		{Info: info, Running: true, WasRunning: true, Resources: []StatusResource{bucket}},
+	}})
+	sink.Emit(StatusRefreshEvent{Time: at.Add(6 * time.Second), Emulators: []WatchedEmulator{
+		{Info: info, Running: true, WasRunning: true, Resources: []StatusResource{queue}, Added: []StatusResource{queue}, Removed: []StatusResource{bucket},
+			ServiceChanges: []ServiceStateChange{{Service: "sqs", To: "running"}}},
+		{Info: InstanceInfoEvent{Type: "snowflake", EmulatorName: "LocalStack Snowflake Emulator"}},
+	}})
+	sink.Emit(ErrorEvent{Title: "runtime not healthy", Code: ErrRuntimeUnavailable})
+	require.NoError(t, sink.Err())
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 3, "a refresh where nothing changed writes no line")
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"status","type":"snapshot","data":{"time":"2026-05-04T10:00:00Z","emulators":[
+		{"type":"aws","name":"localstack-aws","running":true,"version":"4.0.0","host":"localhost.localstack.cloud:4566","persistence":false,"services":{"s3":"running"},
+		 "resources":[{"service":"S3","name":"uploads","region":"us-east-1","account":"000000000000"}]},
+		{"type":"snowflake","running":false,"resources":[]}]}}`, lines[0])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"status","type":"diff","data":{"time":"2026-05-04T10:00:06Z","emulators":[
+		{"type":"aws","name":"localstack-aws","running":true,
+		 "added":[{"service":"SQS","name":"orders","region":"us-east-1","account":"000000000000"}],
+		 "removed":[{"service":"S3","name":"uploads","region":"us-east-1","account":"000000000000"}],
+		 "serviceChanges":{"sqs":{"from":"","to":"running"}}}]}}`, lines[1])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"status","type":"error","data":{"code":"RUNTIME_UNAVAILABLE","category":"RUNTIME","message":"runtime not healthy","retryable":true}}`, lines[2])
+}
+
+func TestNDJSONSink_LogLines(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	sink := NewNDJSONSink(&out, "logs")
+	sink.Emit(LogLineEvent{
+		Source:    LogSourceEmulator,
+		Line:      "2026-07-07T10:05:11.240 ERROR --- [   Thread-12] l.s.lambda.invocation : Invocation failed … (10 more bytes truncated)",
+		Level:     LogLevelError,
+		Time:      time.Date(2026, 7, 7, 10, 5, 11, 240_000_000, time.UTC),
+		Thread:    "Thread-12",
+		Logger:    "l.s.lambda.invocation",
+		Message:   "Invocation failed",
+		Truncated: true,
+	})
+	sink.Emit(LogLineEvent{Source: LogSourceEmulator, Emulator: "aws", Line: "Traceback (most recent call last):", Message: "Traceback (most recent call last):"})
+	sink.Emit(MessageEvent{Severity: SeverityNote, Text: "snowflake is not running"})
+	require.NoError(t, sink.Err())
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2, "notes are presentational and not streamed")
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs","type":"log","data":{"timestamp":"2026-07-07T10:05:11.240Z","level":"error","thread":"Thread-12","logger":"l.s.lambda.invocation","message":"Invocation failed","truncated":true}}`, lines[0])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs","type":"log","data":{"emulator":"aws","message":"Traceback (most recent call last):"}}`, lines[1])
+}
+
+func TestNDJSONSink_Requests(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	sink := NewNDJSONSink(&out, "logs requests")
+	var summary RequestSummaryEvent
+	for _, req := range []RequestEvent{
+		{Time: time.Date(2026, 7, 7, 10, 5, 12, 240_000_000, time.UTC), Service: "s3", Operation: "GetObject", Status: 404, ErrorCode: "NoSuchKey"},
+		{Emulator: "aws", Method: "POST", Path: "/hooks/deploy", Status: 200, Account: "000000000000", Region: "us-east-1"},
+		{Service: "s3", Operation: "GetObject", Status: 200},
+	} {
+		summary.Add(req)
+		sink.Emit(req)
+	}
+	sink.Emit(MessageEvent{Severity: SeverityNote, Text: "No requests found"})
+	sink.Emit(summary)
+	require.NoError(t, sink.Err())
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"request","data":{"timestamp":"2026-07-07T10:05:12.240Z","service":"s3","operation":"GetObject","status":404,"errorCode":"NoSuchKey"}}`, lines[0])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"request","data":{"emulator":"aws","method":"POST","path":"/hooks/deploy","status":200,"account":"000000000000","region":"us-east-1"}}`, lines[1])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"summary","data":{"operations":[{"operation":"POST /hooks/deploy","requests":1,"errors":0,"errorRate":0},{"service":"s3","operation":"GetObject","requests":2,"errors":1,"errorRate":0.5}]}}`, lines[3])
+}
@@ -41,7 +41,14 @@ func FormatEventLine(event Event) (string, bool) {
 		// pulls never emit it, and PlainSink cannot bind the ESC key.
 		return "", false
 	case LogLineEvent:
+		if e.Emulator != "" {
+			return e.Emulator + " | " + e.Line, true
+		}
 		return e.Line, true
+	case RequestEvent:
+		return formatRequest(e), true
+	case RequestSummaryEvent:
+		return formatRequestSummary(e)
 	case InstanceInfoEvent:
 		return formatInstanceInfo(e), true
 	case TableEvent:
@@ -60,20 +67,44 @@ func FormatEventLine(event Event) (string, bool) {
 		return FormatEventLine(e.Inner)
 	case PodSnapshotRemovedEvent:
 		return formatPodSnapshotRemoved(e), true
+	case LocalSnapshotsPrunedEvent:
+		return formatLocalSnapshotsPruned(e), true
+	case ResourceManifestExportedEvent:
+		return formatResourceManifestExported(e), true
 	case SnapshotShownEvent:
 		return formatSnapshotShown(e), true
 	case SnapshotDiffEvent:
 		return formatSnapshotDiff(e), true
+	case InitStepEvent:
+		return formatInitStep(e), true
+	case ConfigPathEvent:
+		return formatConfigPath(e), true
+	case ConfigValueEvent:
+		return e.Value, true
+	case ConfigProblemsEvent:
+		return formatConfigProblems(e), true
 	case AuthCompleteEvent:
 		return "", false
 	case EmulatorStoppedEvent:
 		return formatEmulatorStopped(e), true
+	case EmulatorNotRunningEvent:
+		return fmt.Sprintf("> Note: %s is not running", e.DisplayName), true
+	case EmulatorReadyEvent:
+		return formatEmulatorReady(e), true
+	case StatusRefreshEvent:
+		// Rendered by the TUI as a live view, or as NDJSON lines by
+		// NDJSONSink; a plain-text stream of full refreshes reads as noise.
+		return "", false
 	case EmulatorResetEvent:
 		return formatEmulatorReset(e), true
 	case UpdateCheckedEvent:
 		return formatUpdateChecked(e), true
 	case UpdateAppliedEvent:
 		return formatUpdateApplied(e), true
+	case DoctorCheckEvent:
+		return formatDoctorCheck(e), true
+	case DoctorReportEvent:
+		return formatDoctorReport(e), true
 	default:
 		return "", false
 	}
@@ -199,9 +230,78 @@ func formatMessageEvent(e MessageEvent) string {
 }
 
 func formatEmulatorStopped(e EmulatorStoppedEvent) string {
+	if !e.WasRunning {
+		return fmt.Sprintf("> Note: %s was not running", e.DisplayName)
+	}
 	return SuccessMarker() + " " + fmt.Sprintf("%s stopped", e.DisplayName)
 }
 
+func formatEmulatorReady(e EmulatorReadyEvent) string {
+	line := SuccessMarker() + fmt.Sprintf(" %s is ready at %s", e.DisplayName, e.URL)
+	if len(e.Services) > 0 {
+		services := make([]string, 0, len(e.Services))
+		for name, state := range e.Services {
+			services = append(services, name+" "+state)
+		}
+		sort.Strings(services)
+		line += " (" + strings.Join(services, ", ") + ")"
+	}
+	return line
+}
+
+// requestTargetWidth pads the called operation so that the error codes of
+// consecutive requests line up.
+const requestTargetWidth = 36
+
+// formatRequest renders one request as a row: time, status, what was called,
+// then the error code and account/region when known.
+func formatRequest(e RequestEvent) string {
+	var sb strings.Builder
+	if e.Emulator != "" {
+		sb.WriteString(e.Emulator + " | ")
+	}
+	if !e.Time.IsZero() {
+		sb.WriteString(e.Time.Format("15:04:05.000") + "  ")
+	}
+	fmt.Fprintf(&sb, "%d  %-*s", e.Status, requestTargetWidth, e.Target())
+	if e.ErrorCode != "" {
+		sb.WriteString("  " + e.ErrorCode)
+	}
+	if e.Account != "" || e.Region != "" {
+		sb.WriteString("  " + e.Account + "/" + e.Region)
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// formatRequestSummary renders the error rate of every operation as a table,
+// under a total.
+func formatRequestSummary(e RequestSummaryEvent) (string, bool) {
+	if len(e.Operations) == 0 {
+		return "", false
+	}
+	var total OperationStats
+	rows := make([][]string, len(e.Operations))
+	for i, op := range e.Operations {
+		service := op.Service
+		if service == "" {
+			service = "-"
+		}
+		rows[i] = []string{service, op.Operation, strconv.Itoa(op.Requests), strconv.Itoa(op.Errors), formatErrorRate(op)}
+		total.Requests += op.Requests
+		total.Errors += op.Errors
+	}
+	table, _ := formatTable(TableEvent{Headers: []string{"Service", "Operation", "Requests", "Errors", "Error rate"}, Rows: rows})
+	noun := "requests"
+	if total.Requests == 1 {
+		noun = "request"
+	}
+	return fmt.Sprintf("%d %s, %d failed (%s)\n%s", total.Requests, noun, total.Errors, formatErrorRate(total), table), true
+}
+
+func formatErrorRate(s OperationStats) string {
+	return strconv.FormatFloat(s.ErrorRate()*100, 'f', 1, 64) + "%"
+}
+
 func formatEmulatorReset(e EmulatorResetEvent) string {
 	return SuccessMarker() + " Emulator state reset"
 }
@@ -260,9 +360,51 @@ func formatInstanceInfo(e InstanceInfoEvent) string {
 	if e.Uptime > 0 {
 		sb.WriteString("\n• Uptime: " + FormatUptime(e.Uptime))
 	}
+	if summary, unhealthy := FormatServiceStates(e.Services); summary != "" {
+		sb.WriteString("\n• Services: " + summary)
+		if unhealthy != "" {
+			sb.WriteString("\n" + UnhealthyServicesPrefix + unhealthy)
+		}
+	}
 	return sb.String()
 }
 
+// UnhealthyServicesPrefix starts the InstanceInfoEvent line listing services in
+// a state other than running, available or disabled, so the TUI can pick it
+// out and highlight it.
+const UnhealthyServicesPrefix = "• Not ready: "
+
+// FormatServiceStates summarises per-service health states: counts of running,
+// available and disabled services, which are normal and often numerous, and the
+// names of any others with their state (e.g. "lambda (error)"), which are what
+// a user looks at status for.
+func FormatServiceStates(services map[string]string) (summary, unhealthy string) {
+	if len(services) == 0 {
+		return "", ""
+	}
+	counts := map[string]int{}
+	var others []string
+	for name, state := range services {
+		switch state {
+		case "running", "available", "disabled":
+			counts[state]++
+		default:
+			others = append(others, fmt.Sprintf("%s (%s)", name, state))
+		}
+	}
+	var parts []string
+	for _, state := range []string{"running", "available", "disabled"} {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+	if len(others) > 0 {
+		parts = append(parts, fmt.Sprintf("%d not ready", len(others)))
+	}
+	sort.Strings(others)
+	return strings.Join(parts, ", "), strings.Join(others, ", ")
+}
+
 func FormatUptime(d time.Duration) string {
 	d = d.Round(time.Second)
 	h := int(d.Hours())
@@ -287,9 +429,16 @@ func formatSnapshotLoaded(e SnapshotLoadedEvent) string {
 	if len(e.Services) > 0 {
 		sb.WriteString("\n• Services: " + strings.Join(e.Services, ", "))
 	}
+	if len(e.Skipped) > 0 {
+		sb.WriteString("\n• Skipped: " + strings.Join(e.Skipped, ", "))
+	}
 	return sb.String()
 }
 
+func formatInitStep(e InitStepEvent) string {
+	return SuccessMarker() + fmt.Sprintf(" Init step %d/%d done: %s (%s)", e.Step, e.Total, e.Label, e.Duration.Round(100*time.Millisecond))
+}
+
 func formatPodSnapshotSaved(e PodSnapshotSavedEvent) string {
 	var sb strings.Builder
 	sb.WriteString(SuccessMarker() + fmt.Sprintf(" Snapshot saved to pod:%s", e.PodName))
@@ -300,7 +449,7 @@ func formatPodSnapshotSaved(e PodSnapshotSavedEvent) string {
 		sb.WriteString("\n• Services: " + strings.Join(e.Services, ", "))
 	}
 	if e.Size > 0 {
-		sb.WriteString("\n• Size: " + formatBytes(e.Size))
+		sb.WriteString("\n• Size: " + FormatBytes(e.Size))
 	}
 	return sb.String()
 }
@@ -312,7 +461,7 @@ func formatLocalSnapshotSaved(e LocalSnapshotSavedEvent) string {
 		sb.WriteString("\n• Services: " + strings.Join(e.Services, ", "))
 	}
 	if e.Size > 0 {
-		sb.WriteString("\n• Size: " + formatBytes(e.Size))
+		sb.WriteString("\n• Size: " + FormatBytes(e.Size))
 	}
 	return sb.String()
 }
@@ -327,7 +476,7 @@ func formatRemoteSnapshotSaved(e RemoteSnapshotSavedEvent) string {
 		sb.WriteString("\n• Services: " + strings.Join(e.Services, ", "))
 	}
 	if e.Size > 0 {
-		sb.WriteString("\n• Size: " + formatBytes(e.Size))
+		sb.WriteString("\n• Size: " + FormatBytes(e.Size))
 	}
 	return sb.String()
 }
@@ -336,6 +485,25 @@ func formatPodSnapshotRemoved(e PodSnapshotRemovedEvent) string {
 	return SuccessMarker() + fmt.Sprintf(" Cloud snapshot 'pod:%s' deleted", e.PodName)
 }
 
+func formatLocalSnapshotsPruned(e LocalSnapshotsPrunedEvent) string {
+	if e.Removed == 0 {
+		return SuccessMarker() + " Nothing to prune"
+	}
+	noun := "snapshots"
+	if e.Removed == 1 {
+		noun = "snapshot"
+	}
+	return SuccessMarker() + fmt.Sprintf(" Pruned %d local %s, freeing %s", e.Removed, noun, FormatBytes(e.Size))
+}
+
+func formatResourceManifestExported(e ResourceManifestExportedEvent) string {
+	noun := "resources"
+	if e.Resources == 1 {
+		noun = "resource"
+	}
+	return SuccessMarker() + fmt.Sprintf(" Resource manifest written to %s (%d %s)", e.Path, e.Resources, noun)
+}
+
 // snapshotShowLabelWidth is the column at which values align in the show output.
 const snapshotShowLabelWidth = 16
 
@@ -355,11 +523,12 @@ func formatSnapshotShown(e SnapshotShownEvent) string {
 	if e.Version > 0 {
 		row("Version", strconv.Itoa(e.Version))
 	}
+	row("Path", e.Path)
 	if e.Created != nil {
 		row("Created", e.Created.UTC().Format("2006-01-02 15:04 UTC"))
 	}
 	if e.Size > 0 {
-		row("Size", formatBytes(e.Size))
+		row("Size", FormatBytes(e.Size))
 	}
 	row("LocalStack", e.LocalStackVersion)
 	row("Message", e.Message)
@@ -367,6 +536,12 @@ func formatSnapshotShown(e SnapshotShownEvent) string {
 	if len(e.Services) > 0 {
 		sb.WriteString("\n\n")
 		sb.WriteString(fmt.Sprintf("%-*s%s", snapshotShowLabelWidth, "Services", strings.Join(e.Services, ", ")))
+		if len(e.Accounts) > 0 {
+			sb.WriteString(fmt.Sprintf("\n%-*s%s", snapshotShowLabelWidth, "Accounts", strings.Join(e.Accounts, ", ")))
+		}
+		if len(e.Regions) > 0 {
+			sb.WriteString(fmt.Sprintf("\n%-*s%s", snapshotShowLabelWidth, "Regions", strings.Join(e.Regions, ", ")))
+		}
 	}
 
 	if len(e.Resources) > 0 {
@@ -384,14 +559,63 @@ func formatSnapshotShown(e SnapshotShownEvent) string {
 }
 
 func formatSnapshotDiff(e SnapshotDiffEvent) string {
+	if e.PodName == "" {
+		return formatLocalSnapshotDiff(e)
+	}
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("Dry-run results for pod:%s", e.PodName))
 	if e.Version > 0 {
 		sb.WriteString(fmt.Sprintf(":%d", e.Version))
 	}
 
-	services := make([]string, 0, len(e.Services))
-	for svc := range e.Services {
+	rows, totalMods := formatSnapshotDiffRows(e.Services)
+	if len(rows) == 0 {
+		sb.WriteString("\n\n  No changes — pod state matches running state.")
+	} else {
+		sb.WriteString("\n")
+		for _, r := range rows {
+			sb.WriteString("\n")
+			sb.WriteString(r)
+		}
+	}
+
+	if totalMods > 0 {
+		noun := "modifications"
+		if totalMods == 1 {
+			noun = "modification"
+		}
+		sb.WriteString(fmt.Sprintf("\n\n> Note: %d %s will be resolved using the %s strategy.", totalMods, noun, e.Strategy))
+	}
+
+	sb.WriteString("\n\n" + SuccessMarker() + " No state was modified.")
+	return sb.String()
+}
+
+// formatLocalSnapshotDiff renders a diff lstk computed between two snapshot
+// archives (or one and the running state). Nothing is loaded, so unlike a pod
+// dry-run there is no merge strategy to resolve modifications with.
+func formatLocalSnapshotDiff(e SnapshotDiffEvent) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Changes from %s to %s", e.Base, e.Target))
+
+	rows, _ := formatSnapshotDiffRows(e.Services)
+	if len(rows) == 0 {
+		sb.WriteString(fmt.Sprintf("\n\n  No changes — %s matches %s.", e.Target, e.Base))
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	for _, r := range rows {
+		sb.WriteString("\n")
+		sb.WriteString(r)
+	}
+	return sb.String()
+}
+
+// formatSnapshotDiffRows renders one row per service with changes, sorted by
+// service, and returns the total modification count.
+func formatSnapshotDiffRows(diff map[string]SnapshotDiffServiceResult) ([]string, int) {
+	services := make([]string, 0, len(diff))
+	for svc := range diff {
 		services = append(services, svc)
 	}
 	sort.Strings(services)
@@ -404,10 +628,9 @@ func formatSnapshotDiff(e SnapshotDiffEvent) string {
 	}
 
 	var rows []string
-	hasModifications := false
 	totalMods := 0
 	for _, svc := range services {
-		counts := e.Services[svc]
+		counts := diff[svc]
 		if counts.Additions == 0 && counts.Modifications == 0 {
 			continue
 		}
@@ -424,7 +647,6 @@ func formatSnapshotDiff(e SnapshotDiffEvent) string {
 			if counts.Additions > 0 {
 				row.WriteString("   ")
 			}
-			hasModifications = true
 			totalMods += counts.Modifications
 			noun := "modifications"
 			if counts.Modifications == 1 {
@@ -434,30 +656,11 @@ func formatSnapshotDiff(e SnapshotDiffEvent) string {
 		}
 		rows = append(rows, row.String())
 	}
-
-	if len(rows) == 0 {
-		sb.WriteString("\n\n  No changes — pod state matches running state.")
-	} else {
-		sb.WriteString("\n")
-		for _, r := range rows {
-			sb.WriteString("\n")
-			sb.WriteString(r)
-		}
-	}
-
-	if hasModifications {
-		noun := "modifications"
-		if totalMods == 1 {
-			noun = "modification"
-		}
-		sb.WriteString(fmt.Sprintf("\n\n> Note: %d %s will be resolved using the %s strategy.", totalMods, noun, e.Strategy))
-	}
-
-	sb.WriteString("\n\n" + SuccessMarker() + " No state was modified.")
-	return sb.String()
+	return rows, totalMods
 }
 
-func formatBytes(b int64) string {
+// FormatBytes renders a byte count with a binary unit, e.g. "1.5 MB".
+func FormatBytes(b int64) string {
 	switch {
 	case b >= byteGB:
 		return fmt.Sprintf("%.1f GB", float64(b)/float64(byteGB))
@@ -551,3 +754,76 @@ func formatTableWidth(e TableEvent, totalWidth int) string {
 	}
 	return sb.String()
 }
+
+// formatConfigPath prints one path per line, most specific first, so the first
+// line is always the file lstk edits.
+func formatConfigPath(e ConfigPathEvent) string {
+	if len(e.Sources) == 0 {
+		return e.Path
+	}
+	paths := make([]string, len(e.Sources))
+	for i, s := range e.Sources {
+		paths[i] = s.Path
+	}
+	return strings.Join(paths, "\n")
+}
+
+func formatConfigProblems(e ConfigProblemsEvent) string {
+	if len(e.Paths) == 0 {
+		return "No config file found; lstk uses its built-in defaults."
+	}
+	if len(e.Problems) == 0 {
+		return strings.Join(e.Paths, ", ") + ": no problems found"
+	}
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		location := p.Path
+		if p.Line > 0 {
+			location = fmt.Sprintf("%s:%d", p.Path, p.Line)
+		}
+		lines[i] = location + ": " + p.Message
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CheckMarker is the marker formatDoctorCheck puts before a check with status.
+func CheckMarker(status CheckStatus) string {
+	switch status {
+	case CheckPass:
+		return SuccessMarker()
+	case CheckWarn:
+		return WarningMarker()
+	case CheckFail:
+		return FailureMarker()
+	default:
+		return "-"
+	}
+}
+
+func formatDoctorCheck(e DoctorCheckEvent) string {
+	var sb strings.Builder
+	sb.WriteString(CheckMarker(e.Status) + " " + e.Name)
+	if e.Detail != "" {
+		sb.WriteString(": " + e.Detail)
+	}
+	for _, action := range e.Actions {
+		sb.WriteString("\n  " + ErrorActionPrefix + action.Label + " " + action.Value)
+	}
+	return sb.String()
+}
+
+func formatDoctorReport(e DoctorReportEvent) string {
+	parts := []string{fmt.Sprintf("%d passed", e.Passed)}
+	if e.Warnings == 1 {
+		parts = append(parts, "1 warning")
+	} else if e.Warnings > 1 {
+		parts = append(parts, fmt.Sprintf("%d warnings", e.Warnings))
+	}
+	if e.Failures > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", e.Failures))
+	}
+	if e.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", e.Skipped))
+	}
+	return fmt.Sprintf("\nlstk %s on %s/%s: %s", e.LstkVersion, e.OS, e.Arch, strings.Join(parts, ", "))
+}
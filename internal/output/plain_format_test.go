@@ -145,6 +145,32 @@ func TestFormatEventLine(t *testing.T) {
 			want:   SuccessMarker() + " LocalStack AWS Emulator is running\n• Endpoint: 127.0.0.1:4566\n• Container: localstack-aws",
 			wantOK: true,
 		},
+		{
+			name: "instance info with service states",
+			event: InstanceInfoEvent{
+				EmulatorName: "LocalStack AWS Emulator",
+				Host:         "127.0.0.1:4566",
+				Services:     map[string]string{"s3": "running", "sqs": "available", "sns": "available", "kinesis": "disabled", "lambda": "error", "ec2": "initializing"},
+			},
+			want:   SuccessMarker() + " LocalStack AWS Emulator is running\n• Endpoint: 127.0.0.1:4566\n• Services: 1 running, 2 available, 1 disabled, 2 not ready\n• Not ready: ec2 (initializing), lambda (error)",
+			wantOK: true,
+		},
+		{
+			name: "instance info with healthy services",
+			event: InstanceInfoEvent{
+				EmulatorName: "LocalStack AWS Emulator",
+				Host:         "127.0.0.1:4566",
+				Services:     map[string]string{"s3": "running", "sqs": "available"},
+			},
+			want:   SuccessMarker() + " LocalStack AWS Emulator is running\n• Endpoint: 127.0.0.1:4566\n• Services: 1 running, 1 available",
+			wantOK: true,
+		},
+		{
+			name:   "emulator not running",
+			event:  EmulatorNotRunningEvent{Type: "snowflake", DisplayName: "LocalStack Snowflake Emulator"},
+			want:   "> Note: LocalStack Snowflake Emulator is not running",
+			wantOK: true,
+		},
 		{
 			name: "table with entries",
 			event: TableEvent{
@@ -175,6 +201,33 @@ func TestFormatEventLine(t *testing.T) {
 			want:   "Docker not available",
 			wantOK: true,
 		},
+		{
+			name:   "request event",
+			event:  RequestEvent{Time: time.Date(2026, 7, 7, 10, 5, 12, 240_000_000, time.UTC), Service: "s3", Operation: "GetObject", Status: 404, ErrorCode: "NoSuchKey", Account: "000000000000", Region: "us-east-1"},
+			want:   "10:05:12.240  404  s3.GetObject                          NoSuchKey  000000000000/us-east-1",
+			wantOK: true,
+		},
+		{
+			name:   "request event http",
+			event:  RequestEvent{Emulator: "aws", Method: "GET", Path: "/hooks", Status: 200},
+			want:   "aws | 200  GET /hooks",
+			wantOK: true,
+		},
+		{
+			name: "request summary event",
+			event: RequestSummaryEvent{Operations: []OperationStats{
+				{Operation: "GET /hooks", Requests: 1},
+				{Service: "s3", Operation: "GetObject", Requests: 4, Errors: 1},
+			}},
+			want:   "5 requests, 1 failed (20.0%)\n  SERVICE  OPERATION   REQUESTS  ERRORS  ERROR RATE\n  -        GET /hooks  1         0       0.0%\n  s3       GetObject   4         1       25.0%",
+			wantOK: true,
+		},
+		{
+			name:   "request summary event empty",
+			event:  RequestSummaryEvent{},
+			want:   "",
+			wantOK: false,
+		},
 		{
 			name:   "auth complete event",
 			event:  AuthCompleteEvent{},
@@ -187,6 +240,12 @@ func TestFormatEventLine(t *testing.T) {
 			want:   SuccessMarker() + " LocalStack AWS Emulator stopped",
 			wantOK: true,
 		},
+		{
+			name:   "emulator stopped event for an emulator that was not running",
+			event:  EmulatorStoppedEvent{Type: "snowflake", Name: "localstack-snowflake", DisplayName: "LocalStack Snowflake Emulator", WasRunning: false},
+			want:   "> Note: LocalStack Snowflake Emulator was not running",
+			wantOK: true,
+		},
 		{
 			name:   "emulator reset event",
 			event:  EmulatorResetEvent{Type: "aws", Name: "localstack-aws"},
@@ -294,6 +353,12 @@ func TestFormatEventLine(t *testing.T) {
 			want:   SuccessMarker() + " Snapshot loaded from ./my-baseline.snapshot\n• Services: s3, dynamodb",
 			wantOK: true,
 		},
+		{
+			name:   "partial snapshot load lists skipped services",
+			event:  SnapshotLoadedEvent{Source: "./my-baseline.snapshot", Services: []string{"dynamodb", "s3"}, Skipped: []string{"iam", "lambda"}},
+			want:   SuccessMarker() + " Snapshot loaded from ./my-baseline.snapshot\n• Services: dynamodb, s3\n• Skipped: iam, lambda",
+			wantOK: true,
+		},
 		{
 			name:   "snapshot loaded no services",
 			event:  SnapshotLoadedEvent{Source: "./snap.snapshot"},
@@ -313,6 +378,14 @@ func TestFormatEventLine(t *testing.T) {
 			wantOK: true,
 		},
 
+		// init step events
+		{
+			name:   "init step done",
+			event:  InitStepEvent{Type: "aws", Container: "localstack-aws", Step: 1, Total: 2, Label: "seed buckets", Kind: "aws", Duration: 1234 * time.Millisecond},
+			want:   SuccessMarker() + " Init step 1/2 done: seed buckets (1.2s)",
+			wantOK: true,
+		},
+
 		// deferred events — plain sinks render the inner event immediately
 		{
 			name:   "deferred note message",
@@ -346,6 +419,30 @@ func TestFormatEventLine(t *testing.T) {
 			want:   SuccessMarker() + " Cloud snapshot 'pod:my-baseline' deleted",
 			wantOK: true,
 		},
+		{
+			name:   "local snapshots pruned",
+			event:  LocalSnapshotsPrunedEvent{Removed: 3, Size: 3 * 1024 * 1024},
+			want:   SuccessMarker() + " Pruned 3 local snapshots, freeing 3.0 MB",
+			wantOK: true,
+		},
+		{
+			name:   "local snapshots pruned with nothing to remove",
+			event:  LocalSnapshotsPrunedEvent{},
+			want:   SuccessMarker() + " Nothing to prune",
+			wantOK: true,
+		},
+		{
+			name:   "resource manifest exported",
+			event:  ResourceManifestExportedEvent{Path: "./resources.yaml", Resources: 12},
+			want:   SuccessMarker() + " Resource manifest written to ./resources.yaml (12 resources)",
+			wantOK: true,
+		},
+		{
+			name:   "resource manifest exported with one resource",
+			event:  ResourceManifestExportedEvent{Path: "./resources.json", Resources: 1},
+			want:   SuccessMarker() + " Resource manifest written to ./resources.json (1 resource)",
+			wantOK: true,
+		},
 
 		// snapshot diff events
 		{
@@ -396,6 +493,107 @@ func TestFormatEventLine(t *testing.T) {
 			want:   "Dry-run results for pod:my-baseline:3\n\n  dynamodb  + 2 additions\n\n" + SuccessMarker() + " No state was modified.",
 			wantOK: true,
 		},
+		{
+			name: "local snapshot diff",
+			event: SnapshotDiffEvent{
+				Base:   "./a.snapshot",
+				Target: "./b.snapshot",
+				Services: map[string]SnapshotDiffServiceResult{
+					"s3":  {Additions: 1},
+					"sqs": {Modifications: 2},
+				},
+			},
+			want:   "Changes from ./a.snapshot to ./b.snapshot\n\n  s3   + 1 addition\n  sqs  ~ 2 modifications ⚠",
+			wantOK: true,
+		},
+		{
+			name: "local snapshot diff no changes",
+			event: SnapshotDiffEvent{
+				Base:     "running state",
+				Target:   "./a.snapshot",
+				Services: map[string]SnapshotDiffServiceResult{},
+			},
+			want:   "Changes from running state to ./a.snapshot\n\n  No changes — ./a.snapshot matches running state.",
+			wantOK: true,
+		},
+		{
+			name:   "emulator ready",
+			event:  EmulatorReadyEvent{Type: "aws", DisplayName: "LocalStack AWS Emulator", URL: "http://localhost:4566"},
+			want:   SuccessMarker() + " LocalStack AWS Emulator is ready at http://localhost:4566",
+			wantOK: true,
+		},
+		{
+			name:   "emulator ready with services",
+			event:  EmulatorReadyEvent{Type: "aws", DisplayName: "LocalStack AWS Emulator", URL: "http://localhost:4566", Services: map[string]string{"sqs": "running", "s3": "available"}},
+			want:   SuccessMarker() + " LocalStack AWS Emulator is ready at http://localhost:4566 (s3 available, sqs running)",
+			wantOK: true,
+		},
+		{
+			name: "config path with layered sources",
+			event: ConfigPathEvent{Path: "/work/app/lstk.toml", Sources: []ConfigSource{
+				{Path: "/work/app/lstk.toml", Scope: "project"},
+				{Path: "/home/u/.config/lstk/config.toml", Scope: "global"},
+			}},
+			want:   "/work/app/lstk.toml\n/home/u/.config/lstk/config.toml",
+			wantOK: true,
+		},
+		{
+			name:   "config path without a config file",
+			event:  ConfigPathEvent{Path: "/home/u/.config/lstk/config.toml"},
+			want:   "/home/u/.config/lstk/config.toml",
+			wantOK: true,
+		},
+		{
+			name:   "config value",
+			event:  ConfigValueEvent{Key: "containers[0].tag", Value: "latest"},
+			want:   "latest",
+			wantOK: true,
+		},
+		{
+			name: "config problems",
+			event: ConfigProblemsEvent{Paths: []string{"/work/app/lstk.toml"}, Problems: []ConfigProblem{
+				{Path: "/work/app/lstk.toml", Line: 3, Message: "port 70000 is out of range (must be 1–65535)"},
+				{Path: "/work/app/lstk.toml", Message: "[[containers]] blocks 1 and 2 both use port 4566; each emulator needs its own port"},
+			}},
+			want:   "/work/app/lstk.toml:3: port 70000 is out of range (must be 1–65535)\n/work/app/lstk.toml: [[containers]] blocks 1 and 2 both use port 4566; each emulator needs its own port",
+			wantOK: true,
+		},
+		{
+			name:   "config without problems",
+			event:  ConfigProblemsEvent{Paths: []string{"/work/app/lstk.toml", "/home/u/.config/lstk/config.toml"}},
+			want:   "/work/app/lstk.toml, /home/u/.config/lstk/config.toml: no problems found",
+			wantOK: true,
+		},
+		{
+			name:   "config problems without a config file",
+			event:  ConfigProblemsEvent{},
+			want:   "No config file found; lstk uses its built-in defaults.",
+			wantOK: true,
+		},
+		{
+			name:   "doctor check passed",
+			event:  DoctorCheckEvent{Name: "DNS", Status: CheckPass, Detail: "localhost.localstack.cloud resolves to 127.0.0.1"},
+			want:   SuccessMarker() + " DNS: localhost.localstack.cloud resolves to 127.0.0.1",
+			wantOK: true,
+		},
+		{
+			name:   "doctor check failed with action",
+			event:  DoctorCheckEvent{Name: "Auth token", Status: CheckFail, Detail: "not found", Actions: []ErrorAction{{Label: "Log in:", Value: "lstk login"}}},
+			want:   FailureMarker() + " Auth token: not found\n  ==> Log in: lstk login",
+			wantOK: true,
+		},
+		{
+			name:   "doctor check skipped",
+			event:  DoctorCheckEvent{Name: "Azure CLI", Status: CheckSkip, Detail: "az is not installed"},
+			want:   "- Azure CLI: az is not installed",
+			wantOK: true,
+		},
+		{
+			name:   "doctor report",
+			event:  DoctorReportEvent{Passed: 6, Warnings: 1, Failures: 2, Skipped: 3, LstkVersion: "1.2.0", OS: "linux", Arch: "amd64"},
+			want:   "\nlstk 1.2.0 on linux/amd64: 6 passed, 1 warning, 2 failed, 3 skipped",
+			wantOK: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -544,6 +742,50 @@ func TestFormatSnapshotShown(t *testing.T) {
 				resLabel("lambda") + "12 functions, 3 layers",
 			}, "\n"),
 		},
+		{
+			name: "local library snapshot shows its path",
+			event: SnapshotShownEvent{
+				Name:     "local:my-baseline",
+				Path:     "~/.local/share/lstk/snapshots/my-baseline/2026-04-15T14-32-00.000Z.snapshot",
+				Created:  &created,
+				Services: []string{"s3"},
+			},
+			want: strings.Join([]string{
+				label("Name") + "local:my-baseline",
+				label("Path") + "~/.local/share/lstk/snapshots/my-baseline/2026-04-15T14-32-00.000Z.snapshot",
+				label("Created") + "2026-04-15 14:32 UTC",
+				"",
+				label("Services") + "s3",
+			}, "\n"),
+		},
+		{
+			name: "local file lists accounts and regions with its services",
+			event: SnapshotShownEvent{
+				Name:              "./baseline.snapshot",
+				Size:              2048,
+				LocalStackVersion: "4.3.0",
+				Services:          []string{"s3", "sqs"},
+				Accounts:          []string{"000000000000"},
+				Regions:           []string{"eu-west-1", "us-east-1"},
+				Resources: []SnapshotResourceLine{
+					{Service: "s3", Counts: []SnapshotResourceCount{{Count: 2, Noun: "state files"}}},
+					{Service: "sqs", Counts: []SnapshotResourceCount{{Count: 1, Noun: "state file"}}},
+				},
+			},
+			want: strings.Join([]string{
+				label("Name") + "./baseline.snapshot",
+				label("Size") + "2.0 KB",
+				label("LocalStack") + "4.3.0",
+				"",
+				label("Services") + "s3, sqs",
+				label("Accounts") + "000000000000",
+				label("Regions") + "eu-west-1, us-east-1",
+				"",
+				"Resources",
+				resLabel("s3") + "2 state files",
+				resLabel("sqs") + "1 state file",
+			}, "\n"),
+		},
 		{
 			name:  "minimal omits empty fields and sections",
 			event: SnapshotShownEvent{Name: "minimal"},
@@ -585,9 +827,9 @@ func TestFormatBytes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
 			t.Parallel()
-			got := formatBytes(tt.input)
+			got := FormatBytes(tt.input)
 			if got != tt.want {
-				t.Fatalf("formatBytes(%d) = %q, want %q", tt.input, got, tt.want)
+				t.Fatalf("FormatBytes(%d) = %q, want %q", tt.input, got, tt.want)
 			}
 		})
 	}
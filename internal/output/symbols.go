@@ -7,3 +7,7 @@ func SuccessMarker() string {
 func WarningMarker() string {
 	return "⚠"
 }
+
+func FailureMarker() string {
+	return "✘"
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package proc
+
+import "syscall"
+
+// DetachedSysProcAttr lets a helper child outlive lstk. Setsid puts the child
+// in a new session so it isn't killed when the parent's controlling terminal
+// goes away.
+func DetachedSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
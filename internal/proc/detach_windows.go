@@ -1,6 +1,6 @@
 //go:build windows
 
-package telemetry
+package proc
 
 import (
 	"syscall"
@@ -8,9 +8,10 @@ import (
 	"golang.org/x/sys/windows"
 )
 
+// DetachedSysProcAttr lets a helper child outlive lstk.
 // DETACHED_PROCESS | CREATE_NEW_PROCESS_GROUP: detach from the parent's console
 // and put the child in its own process group so Ctrl+C in the parent doesn't
 // signal it.
-func detachedSysProcAttr() *syscall.SysProcAttr {
+func DetachedSysProcAttr() *syscall.SysProcAttr {
 	return &syscall.SysProcAttr{CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP}
 }
@@ -41,6 +41,10 @@ const (
 	managedLabelValue = "true"
 )
 
+// instanceLabelKey records the config instance name of a named emulator
+// instance, so discovery by image can tell two instances of one type apart.
+const instanceLabelKey = "cloud.localstack.lstk.instance"
+
 type DockerRuntime struct {
 	client *client.Client
 }
@@ -493,12 +497,17 @@ func (d *DockerRuntime) Start(ctx context.Context, config ContainerConfig) (stri
 		binds = append(binds, bind)
 	}
 
+	labels := map[string]string{managedLabelKey: managedLabelValue}
+	if config.Instance != "" {
+		labels[instanceLabelKey] = config.Instance
+	}
+
 	resp, err := d.client.ContainerCreate(ctx, client.ContainerCreateOptions{
 		Config: &container.Config{
 			Image:        config.Image,
 			ExposedPorts: exposedPorts,
 			Env:          config.Env,
-			Labels:       map[string]string{managedLabelKey: managedLabelValue},
+			Labels:       labels,
 		},
 		HostConfig: &container.HostConfig{
 			PortBindings: portBindings,
@@ -756,6 +765,7 @@ func (d *DockerRuntime) FindRunningByImage(ctx context.Context, imageRepos []str
 					Name:      name,
 					Image:     c.Image,
 					BoundPort: strconv.Itoa(int(p.PublicPort)),
+					Instance:  c.Labels[instanceLabelKey],
 				}, nil
 			}
 		}
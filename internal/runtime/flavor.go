@@ -1,6 +1,8 @@
 package runtime
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 )
@@ -43,6 +45,7 @@ const (
 	FlavorPodmanRootful  = "podman-rootful"
 	FlavorPodmanRootless = "podman-rootless"
 	FlavorDockerNative   = "docker"
+	FlavorKubernetes     = "kubernetes"
 )
 
 func (f runtimeFlavor) String() string {
@@ -125,3 +128,57 @@ func classifySocketFlavor(home, host string) runtimeFlavor {
 	}
 	return flavorUnknown
 }
+
+// isPodman reports whether f is any Podman flavor, VM-backed or native.
+func (f runtimeFlavor) isPodman() bool {
+	return f == flavorPodman || f == flavorPodmanRootful || f == flavorPodmanRootless
+}
+
+// Backend names accepted by New, as set with the top-level `runtime` config key
+// or LSTK_RUNTIME.
+const (
+	BackendAuto       = "auto"
+	BackendDocker     = "docker"
+	BackendPodman     = "podman"
+	BackendKubernetes = "kubernetes"
+)
+
+// New connects to the container runtime named by backend. BackendAuto (or an
+// empty backend) resolves the daemon the same way NewDockerRuntime does and
+// switches to the native Podman backend when that daemon's socket is a Podman
+// one; Podman serves the Docker-compatible API on the same socket, but its
+// libpod API is what knows about rootless user namespaces. BackendKubernetes is
+// never auto-detected: it runs the emulator in the current kubeconfig context.
+func New(dockerHost, backend string) (Runtime, error) {
+	switch backend {
+	case BackendDocker:
+		return NewDockerRuntime(dockerHost)
+	case BackendPodman:
+		return NewPodmanRuntime(podmanHostFromDockerHost(dockerHost))
+	case BackendKubernetes:
+		return NewKubernetesRuntime("", "")
+	case "", BackendAuto:
+		docker, err := NewDockerRuntime(dockerHost)
+		if err != nil {
+			return nil, err
+		}
+		host := docker.client.DaemonHost()
+		home, _ := os.UserHomeDir()
+		if classifySocketFlavor(home, host).isPodman() {
+			return NewPodmanRuntime(host)
+		}
+		return docker, nil
+	default:
+		return nil, fmt.Errorf("invalid runtime %q (must be one of: %s, %s, %s, %s)", backend, BackendAuto, BackendDocker, BackendPodman, BackendKubernetes)
+	}
+}
+
+// podmanHostFromDockerHost keeps an explicit unix:// DOCKER_HOST when Podman is
+// forced, since it usually points at Podman's socket in that case; anything
+// else (empty, tcp://) falls back to NewPodmanRuntime's own discovery.
+func podmanHostFromDockerHost(dockerHost string) string {
+	if strings.HasPrefix(dockerHost, "unix://") {
+		return dockerHost
+	}
+	return ""
+}
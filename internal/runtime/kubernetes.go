@@ -0,0 +1,816 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+const (
+	// kubeContainerName is the name of the emulator container inside its pod.
+	kubeContainerName = "localstack"
+	// podNameLabelKey carries the pod's own name so its Service can select it.
+	podNameLabelKey = "cloud.localstack.lstk.name"
+	// hostPortsAnnotationKey records the host port each container port was
+	// requested on ({"4566/tcp":"4566"}), since a pod has no host bindings of
+	// its own: GetBoundPort and FindRunningByImage read it back, and it is what
+	// the port-forward publishes.
+	hostPortsAnnotationKey = "cloud.localstack.lstk/host-ports"
+	// bindHostAnnotationKey records the local address the ports are forwarded on.
+	bindHostAnnotationKey = "cloud.localstack.lstk/bind-host"
+	// serviceAnnotationKey records the name of the pod's Service, which
+	// serviceName derives from the pod's.
+	serviceAnnotationKey = "cloud.localstack.lstk/service"
+
+	podPollInterval = 500 * time.Millisecond
+)
+
+// podStartFailureReasons are container waiting reasons the kubelet will not
+// recover from by itself; the pod stays Pending forever, so IsRunning reports
+// them instead of letting the start wait run into its timeout.
+var podStartFailureReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// portForwardFunc forwards ports ("local:remote") from address to the named
+// pod until ctx is done, closing ready once the local listeners are up.
+type portForwardFunc func(ctx context.Context, namespace, pod, address string, ports []string, ready chan struct{}) error
+
+// podForward is an active port-forward to one pod.
+type podForward struct {
+	cancel context.CancelFunc
+	ready  chan struct{}
+	done   chan struct{}
+	err    error // set before done is closed
+}
+
+// KubernetesRuntime runs each emulator as a single-container Pod, plus a
+// ClusterIP Service of the same name so other workloads in the namespace can
+// reach it. Pods have no host port bindings, so the ports lstk would publish
+// with Docker are port-forwarded to the local machine instead, for as long as
+// the lstk process runs.
+type KubernetesRuntime struct {
+	client    kubernetes.Interface
+	namespace string
+	forward   portForwardFunc
+
+	mu       sync.Mutex
+	forwards map[string]*podForward // by pod name
+}
+
+// NewKubernetesRuntime connects to the cluster of the current kubeconfig
+// context. kubeconfig overrides the usual KUBECONFIG/~/.kube/config lookup and
+// namespace the context's namespace; both may be empty. Inside a pod with no
+// kubeconfig, the in-cluster service account is used.
+func NewKubernetesRuntime(kubeconfig, namespace string) (*KubernetesRuntime, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{}
+	if namespace != "" {
+		overrides.Context.Namespace = namespace
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	ns, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve Kubernetes namespace: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return newKubernetesRuntime(client, ns, spdyPortForwarder(restConfig, client)), nil
+}
+
+// newKubernetesRuntime wires a runtime to an existing clientset, so tests can
+// pass a fake one together with a fake port-forwarder.
+func newKubernetesRuntime(client kubernetes.Interface, namespace string, forward portForwardFunc) *KubernetesRuntime {
+	return &KubernetesRuntime{
+		client:    client,
+		namespace: namespace,
+		forward:   forward,
+		forwards:  map[string]*podForward{},
+	}
+}
+
+// spdyPortForwarder forwards through the API server's pods/portforward
+// subresource, as `kubectl port-forward` does.
+func spdyPortForwarder(restConfig *rest.Config, client kubernetes.Interface) portForwardFunc {
+	return func(ctx context.Context, namespace, pod, address string, ports []string, ready chan struct{}) error {
+		transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+		if err != nil {
+			return err
+		}
+		req := client.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward")
+		dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+		stop := make(chan struct{})
+		go func() {
+			<-ctx.Done()
+			close(stop)
+		}()
+		fw, err := portforward.NewOnAddresses(dialer, []string{address}, ports, stop, ready, io.Discard, io.Discard)
+		if err != nil {
+			return err
+		}
+		return fw.ForwardPorts()
+	}
+}
+
+func (k *KubernetesRuntime) pods() corev1client.PodInterface {
+	return k.client.CoreV1().Pods(k.namespace)
+}
+
+func (k *KubernetesRuntime) Flavor() string {
+	return FlavorKubernetes
+}
+
+// SocketPath returns "": the cluster offers no Docker socket to mount into the
+// emulator, so Lambda and other container-spawning services need LocalStack's
+// own Kubernetes executor instead.
+func (k *KubernetesRuntime) SocketPath() string {
+	return ""
+}
+
+func (k *KubernetesRuntime) IsHealthy(ctx context.Context) error {
+	if _, err := k.pods().List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("cannot list pods in namespace %s: %w", k.namespace, err)
+	}
+	return nil
+}
+
+func (k *KubernetesRuntime) EmitUnhealthyError(sink output.Sink, err error) {
+	sink.Emit(output.ErrorEvent{
+		Title:   "Kubernetes is not available",
+		Summary: err.Error(),
+		Actions: []output.ErrorAction{
+			{Label: "Check the current context:", Value: "kubectl config current-context"},
+			{Label: "Check access to the namespace:", Value: "kubectl auth can-i create pods -n " + k.namespace},
+		},
+		Code: output.ErrRuntimeUnavailable,
+	})
+}
+
+// PullImage is a no-op: the node's kubelet pulls the image when the pod is
+// scheduled, and a pull failure surfaces through IsRunning.
+func (k *KubernetesRuntime) PullImage(_ context.Context, _ string, progress chan<- PullProgress) error {
+	if progress != nil {
+		close(progress)
+	}
+	return nil
+}
+
+// ImageExists always reports false: lstk cannot see the nodes' image caches.
+func (k *KubernetesRuntime) ImageExists(context.Context, string) (bool, error) {
+	return false, nil
+}
+
+// GetImageVersion cannot read the image without pulling it on a node.
+func (k *KubernetesRuntime) GetImageVersion(context.Context, string) (string, error) {
+	return "", ErrImageInspectUnsupported
+}
+
+// podPorts lists the ports to declare on the pod, keyed to their host port,
+// with duplicates dropped.
+func podPorts(config ContainerConfig) ([]corev1.ContainerPort, map[string]string, error) {
+	hostPorts := map[string]string{}
+	var ports []corev1.ContainerPort
+	add := func(containerPort, hostPort, proto string) error {
+		portStr, p, found := strings.Cut(containerPort, "/")
+		if found {
+			proto = p
+		}
+		if proto == "" {
+			proto = "tcp"
+		}
+		n, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid container port %q: %w", containerPort, err)
+		}
+		key := portStr + "/" + proto
+		if _, dup := hostPorts[key]; dup {
+			return nil
+		}
+		hostPorts[key] = hostPort
+		ports = append(ports, corev1.ContainerPort{
+			Name:          fmt.Sprintf("%s-%d", proto, n),
+			ContainerPort: int32(n),
+			Protocol:      corev1.Protocol(strings.ToUpper(proto)),
+		})
+		return nil
+	}
+	if err := add(config.ContainerPort, config.Port, ""); err != nil {
+		return nil, nil, err
+	}
+	for _, ep := range config.ExtraPorts {
+		if err := add(ep.ContainerPort, ep.HostPort, ep.Protocol); err != nil {
+			return nil, nil, fmt.Errorf("invalid extra port %q: %w", ep.ContainerPort, err)
+		}
+	}
+	return ports, hostPorts, nil
+}
+
+// Start creates the emulator pod and its Service. Binds become emptyDir
+// volumes at the same container paths: host directories are not visible to the
+// cluster's nodes, so the state volume and init hooks start out empty.
+func (k *KubernetesRuntime) Start(ctx context.Context, config ContainerConfig) (string, <-chan ExitResult, error) {
+	bindHost := config.BindHost
+	if bindHost == "" {
+		bindHost = "127.0.0.1"
+	}
+	ports, hostPorts, err := podPorts(config)
+	if err != nil {
+		return "", nil, err
+	}
+	hostPortsJSON, err := json.Marshal(hostPorts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	env := make([]corev1.EnvVar, 0, len(config.Env))
+	for _, kv := range config.Env {
+		name, value, _ := strings.Cut(kv, "=")
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	for i, b := range config.Binds {
+		name := fmt.Sprintf("bind-%d", i)
+		volumes = append(volumes, corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: b.ContainerPath, ReadOnly: b.ReadOnly})
+	}
+
+	svcName := serviceName(config.Name)
+	labels := map[string]string{managedLabelKey: managedLabelValue, podNameLabelKey: config.Name}
+	if config.Instance != "" {
+		labels[instanceLabelKey] = config.Instance
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   config.Name,
+			Labels: labels,
+			Annotations: map[string]string{
+				hostPortsAnnotationKey: string(hostPortsJSON),
+				bindHostAnnotationKey:  bindHost,
+				serviceAnnotationKey:   svcName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:         kubeContainerName,
+				Image:        config.Image,
+				Env:          env,
+				Ports:        ports,
+				VolumeMounts: mounts,
+			}},
+			Volumes: volumes,
+		},
+	}
+	created, err := k.pods().Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create pod %s: %w", config.Name, err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: svcName, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{podNameLabelKey: config.Name},
+		},
+	}
+	for _, p := range ports {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{Name: p.Name, Port: p.ContainerPort, Protocol: p.Protocol})
+	}
+	if _, err := k.client.CoreV1().Services(k.namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		_ = k.pods().Delete(ctx, config.Name, metav1.DeleteOptions{})
+		return "", nil, fmt.Errorf("failed to create service %s: %w", svcName, err)
+	}
+
+	return podID(created), k.watchPod(ctx, created), nil
+}
+
+// serviceName derives the name of the Service for the named pod. A pod name
+// may hold dots, as a pinned tag puts in it (localstack-aws-4.3), but a Service
+// name must be a DNS-1035 label: lowercase letters, digits and hyphens, starting
+// with a letter, at most 63 characters.
+func serviceName(podName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, podName)
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "ls-" + name
+	}
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+// podServiceName is the name of the named pod's Service: the one recorded on
+// the pod, or the one serviceName derives when the pod is gone or predates it.
+func (k *KubernetesRuntime) podServiceName(ctx context.Context, podName string) string {
+	pod, err := k.pods().Get(ctx, podName, metav1.GetOptions{})
+	if err == nil && pod.Annotations[serviceAnnotationKey] != "" {
+		return pod.Annotations[serviceAnnotationKey]
+	}
+	return serviceName(podName)
+}
+
+// InClusterAddress is the address of the emulator's Service: the port-forward
+// to localhost ends with the lstk command that opened it.
+func (k *KubernetesRuntime) InClusterAddress(containerName, containerPort string) string {
+	port, _, _ := strings.Cut(containerPort, "/")
+	return fmt.Sprintf("%s.%s.svc:%s", k.podServiceName(context.Background(), containerName), k.namespace, port)
+}
+
+// podID is the pod's UID, or its name when the API server assigned none (the
+// fake clientset does not). Long enough either way for callers that shorten
+// container IDs.
+func podID(pod *corev1.Pod) string {
+	if pod.UID != "" {
+		return string(pod.UID)
+	}
+	return pod.Name
+}
+
+// watchPod returns a channel that receives exactly one ExitResult once the pod
+// terminates or is deleted. Once the pod runs, it also starts forwarding its
+// ports, so the emulator is reachable on localhost like a Docker container.
+func (k *KubernetesRuntime) watchPod(ctx context.Context, pod *corev1.Pod) <-chan ExitResult {
+	// Buffered so the goroutine never leaks if the caller stops reading.
+	out := make(chan ExitResult, 1)
+	go func() {
+		w, err := k.pods().Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", pod.Name).String(),
+			ResourceVersion: pod.ResourceVersion,
+		})
+		if err != nil {
+			out <- ExitResult{ExitCode: -1, Err: err}
+			return
+		}
+		defer w.Stop()
+
+		// The pod may have moved on between create and watch.
+		last := pod
+		if current, err := k.pods().Get(ctx, pod.Name, metav1.GetOptions{}); err == nil {
+			last = current
+		}
+		forwarding := false
+		for {
+			if !forwarding && last.Status.Phase == corev1.PodRunning {
+				forwarding = true
+				go func(p *corev1.Pod) { _ = k.ensureForward(ctx, p) }(last)
+			}
+			if code, ok := podExitCode(last); ok {
+				out <- ExitResult{ExitCode: code}
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				out <- ExitResult{ExitCode: -1, Err: ctx.Err()}
+				return
+			case ev, ok := <-w.ResultChan():
+				if !ok {
+					out <- ExitResult{ExitCode: -1, Err: errors.New("pod watch closed")}
+					return
+				}
+				switch ev.Type {
+				case watch.Deleted:
+					code, _ := podExitCode(last)
+					if code == 0 && last.Status.Phase != corev1.PodSucceeded {
+						code = -1
+					}
+					out <- ExitResult{ExitCode: code}
+					return
+				case watch.Error:
+					out <- ExitResult{ExitCode: -1, Err: apierrors.FromObject(ev.Object)}
+					return
+				default:
+					if p, ok := ev.Object.(*corev1.Pod); ok {
+						last = p
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// podExitCode reports the emulator container's exit code once the pod has
+// terminated.
+func podExitCode(pod *corev1.Pod) (int, bool) {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return 0, false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == kubeContainerName && cs.State.Terminated != nil {
+			return int(cs.State.Terminated.ExitCode), true
+		}
+	}
+	return -1, true
+}
+
+// podHostPorts decodes the pod's host port annotation.
+func podHostPorts(pod *corev1.Pod) map[string]string {
+	var hostPorts map[string]string
+	_ = json.Unmarshal([]byte(pod.Annotations[hostPortsAnnotationKey]), &hostPorts)
+	return hostPorts
+}
+
+// forwardedPorts returns the TCP "local:remote" pairs recorded on the pod.
+// Port-forwarding carries TCP only, so UDP ports (e.g. DNS via expose_ports)
+// are reachable through the Service alone.
+func forwardedPorts(pod *corev1.Pod) []string {
+	var ports []string
+	for containerPort, hostPort := range podHostPorts(pod) {
+		port, proto, _ := strings.Cut(containerPort, "/")
+		if proto == "tcp" {
+			ports = append(ports, hostPort+":"+port)
+		}
+	}
+	return ports
+}
+
+// ensureForward starts forwarding the pod's ports unless this process already
+// does, and waits until the local listeners are up. The forward outlives ctx:
+// it runs until Stop or Remove, or the process exits. Listeners that cannot
+// bind (e.g. 443 without privileges) are skipped, like optional ports with
+// Docker; only failing to bind every port is an error.
+func (k *KubernetesRuntime) ensureForward(ctx context.Context, pod *corev1.Pod) error {
+	k.mu.Lock()
+	fw, ok := k.forwards[pod.Name]
+	if !ok {
+		fwCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		fw = &podForward{cancel: cancel, ready: make(chan struct{}), done: make(chan struct{})}
+		k.forwards[pod.Name] = fw
+		address := pod.Annotations[bindHostAnnotationKey]
+		if address == "" {
+			address = "127.0.0.1"
+		}
+		go func() {
+			fw.err = k.forward(fwCtx, k.namespace, pod.Name, address, forwardedPorts(pod), fw.ready)
+			// A dropped forward is re-established by the next caller.
+			k.mu.Lock()
+			if k.forwards[pod.Name] == fw {
+				delete(k.forwards, pod.Name)
+			}
+			k.mu.Unlock()
+			close(fw.done)
+		}()
+	}
+	k.mu.Unlock()
+
+	select {
+	case <-fw.ready:
+		return nil
+	case <-fw.done:
+		if fw.err == nil {
+			return fmt.Errorf("port-forward to pod %s ended", pod.Name)
+		}
+		return fmt.Errorf("failed to forward ports to pod %s: %w", pod.Name, fw.err)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (k *KubernetesRuntime) stopForward(podName string) {
+	k.mu.Lock()
+	fw, ok := k.forwards[podName]
+	delete(k.forwards, podName)
+	k.mu.Unlock()
+	if ok {
+		fw.cancel()
+	}
+}
+
+// getPod resolves ref, a pod name or the UID Start returned, to its pod. A
+// missing pod is a NotFound API error.
+func (k *KubernetesRuntime) getPod(ctx context.Context, ref string) (*corev1.Pod, error) {
+	pod, err := k.pods().Get(ctx, ref, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return pod, err
+	}
+	list, lerr := k.pods().List(ctx, metav1.ListOptions{LabelSelector: managedLabelKey + "=" + managedLabelValue})
+	if lerr != nil {
+		return nil, lerr
+	}
+	for i := range list.Items {
+		if string(list.Items[i].UID) == ref {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, err
+}
+
+// deletePod deletes the pod and its Service. A missing one is not an error.
+func (k *KubernetesRuntime) deletePod(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	k.stopForward(name)
+	svcName := k.podServiceName(ctx, name)
+	if err := k.pods().Delete(ctx, name, opts); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := k.client.CoreV1().Services(k.namespace).Delete(ctx, svcName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Stop deletes the pod with its normal grace period, which stops the emulator
+// the way `docker stop` does; with RestartPolicy Never nothing recreates it.
+func (k *KubernetesRuntime) Stop(ctx context.Context, containerName string) error {
+	pod, err := k.getPod(ctx, containerName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return k.deletePod(ctx, pod.Name, metav1.DeleteOptions{})
+}
+
+func (k *KubernetesRuntime) Remove(ctx context.Context, containerName string) error {
+	zero := int64(0)
+	if err := k.deletePod(ctx, containerName, metav1.DeleteOptions{GracePeriodSeconds: &zero}); err != nil {
+		return err
+	}
+	// Wait until the pod is actually gone, so a subsequent create reusing the
+	// same name does not fail with AlreadyExists.
+	ctx, cancel := context.WithTimeout(ctx, containerRemovalTimeout)
+	defer cancel()
+	for {
+		if _, err := k.pods().Get(ctx, containerName, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for pod %s to be removed", containerName)
+		case <-time.After(containerRemovalPollInterval):
+		}
+	}
+}
+
+// IsRunning reports whether the pod is pending or running: a pod still being
+// scheduled or pulling its image counts as running, so the start wait keeps
+// polling its health. A pod stuck on an unrecoverable start failure (e.g. an
+// image that cannot be pulled) is reported as an error.
+func (k *KubernetesRuntime) IsRunning(ctx context.Context, containerID string) (bool, error) {
+	pod, err := k.getPod(ctx, containerID)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if w := cs.State.Waiting; w != nil && podStartFailureReasons[w.Reason] {
+			return false, fmt.Errorf("pod %s cannot start: %s: %s", pod.Name, w.Reason, w.Message)
+		}
+	}
+	return pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning, nil
+}
+
+func (k *KubernetesRuntime) InspectBrief(ctx context.Context, containerName string) (ContainerBrief, error) {
+	pod, err := k.pods().Get(ctx, containerName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return ContainerBrief{}, nil
+	}
+	if err != nil {
+		return ContainerBrief{}, err
+	}
+	var image string
+	if len(pod.Spec.Containers) > 0 {
+		image = pod.Spec.Containers[0].Image
+	}
+	// A pending pod is one the cluster is still scheduling or pulling the
+	// image for, not a leftover of a failed start.
+	return ContainerBrief{
+		Exists:   true,
+		Running:  pod.Status.Phase == corev1.PodRunning,
+		Starting: pod.Status.Phase == corev1.PodPending,
+		Image:    image,
+		Managed:  pod.Labels[managedLabelKey] == managedLabelValue,
+	}, nil
+}
+
+func (k *KubernetesRuntime) ContainerStartedAt(ctx context.Context, containerName string) (time.Time, error) {
+	pod, err := k.getPod(ctx, containerName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get pod: %w", err)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == kubeContainerName && cs.State.Running != nil {
+			return cs.State.Running.StartedAt.Time, nil
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time, nil
+	}
+	return time.Time{}, fmt.Errorf("pod %s has not started", pod.Name)
+}
+
+func (k *KubernetesRuntime) ContainerEnv(ctx context.Context, containerName string) ([]string, error) {
+	pod, err := k.getPod(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+	var env []string
+	for _, c := range pod.Spec.Containers {
+		if c.Name != kubeContainerName {
+			continue
+		}
+		for _, e := range c.Env {
+			env = append(env, e.Name+"="+e.Value)
+		}
+	}
+	return env, nil
+}
+
+// GetBoundPort returns the host port recorded for containerPort and makes sure
+// this process forwards it, so callers can reach the emulator on localhost.
+func (k *KubernetesRuntime) GetBoundPort(ctx context.Context, containerName string, containerPort string) (string, error) {
+	pod, err := k.getPod(ctx, containerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pod: %w", err)
+	}
+	if !strings.Contains(containerPort, "/") {
+		containerPort += "/tcp"
+	}
+	hostPort, ok := podHostPorts(pod)[containerPort]
+	if !ok {
+		return "", fmt.Errorf("no binding found for port %s on pod %s", containerPort, pod.Name)
+	}
+	if err := k.ensureForward(ctx, pod); err != nil {
+		return "", err
+	}
+	return hostPort, nil
+}
+
+// openLogs opens the emulator container's log stream. A nil tailLines means
+// the whole log.
+func (k *KubernetesRuntime) openLogs(ctx context.Context, containerID string, follow bool, tailLines *int64) (io.ReadCloser, error) {
+	pod, err := k.getPod(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	return k.pods().GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: kubeContainerName,
+		Follow:    follow,
+		TailLines: tailLines,
+	}).Stream(ctx)
+}
+
+func (k *KubernetesRuntime) Logs(ctx context.Context, containerID string, tail int) (string, error) {
+	tailLines := int64(50)
+	if tail > 0 {
+		tailLines = int64(tail)
+	}
+	reader, err := k.openLogs(ctx, containerID, false, &tailLines)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("failed to close logs reader: %v", err)
+		}
+	}()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, reader); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// StreamLogs waits for the emulator container to start before opening the
+// stream: unlike Docker, the kubelet refuses to serve logs while the pod is
+// still being scheduled or pulling its image.
+func (k *KubernetesRuntime) StreamLogs(ctx context.Context, containerID string, out io.Writer, follow bool, tail string) error {
+	var tailLines *int64
+	if tail != "" && tail != "all" {
+		n, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid tail %q: %w", tail, err)
+		}
+		tailLines = &n
+	}
+	if err := k.waitForContainerStart(ctx, containerID); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("emulator is not running. Start LocalStack with `lstk`")
+		}
+		return err
+	}
+	reader, err := k.openLogs(ctx, containerID, follow, tailLines)
+	if err != nil {
+		return fmt.Errorf("failed to stream logs for %s: %w", containerID, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("failed to close logs reader: %v", err)
+		}
+	}()
+
+	_, err = io.Copy(out, reader)
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("error reading logs: %w", err)
+	}
+	return nil
+}
+
+// waitForContainerStart returns once the emulator container has left the
+// waiting state, i.e. has logs to serve.
+func (k *KubernetesRuntime) waitForContainerStart(ctx context.Context, containerID string) error {
+	for {
+		pod, err := k.getPod(ctx, containerID)
+		if err != nil {
+			return err
+		}
+		if pod.Status.Phase != corev1.PodPending {
+			return nil
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == kubeContainerName && cs.State.Waiting == nil {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(podPollInterval):
+		}
+	}
+}
+
+func (k *KubernetesRuntime) FindRunningByImage(ctx context.Context, imageRepos []string, containerPort string) (*RunningContainer, error) {
+	list, err := k.pods().List(ctx, metav1.ListOptions{
+		LabelSelector: managedLabelKey + "=" + managedLabelValue,
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodRunning)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(containerPort, "/") {
+		containerPort += "/tcp"
+	}
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		// Clusters often qualify short Docker Hub names ("docker.io/localstack/…").
+		image := strings.TrimPrefix(pod.Spec.Containers[0].Image, "docker.io/")
+		if !matchesAnyImageRepo(image, imageRepos) {
+			continue
+		}
+		hostPort, ok := podHostPorts(pod)[containerPort]
+		if !ok {
+			continue
+		}
+		return &RunningContainer{
+			Name:      pod.Name,
+			Image:     image,
+			BoundPort: hostPort,
+			Instance:  pod.Labels[instanceLabelKey],
+		}, nil
+	}
+	return nil, nil
+}
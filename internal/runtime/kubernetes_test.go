@@ -0,0 +1,343 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// recordingForwarder is a portForwardFunc that records each forward and keeps
+// it open until its context is cancelled.
+type recordingForwarder struct {
+	mu    sync.Mutex
+	calls [][]string
+	err   error
+}
+
+func (f *recordingForwarder) forward(ctx context.Context, _, _, address string, ports []string, ready chan struct{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	sorted := append([]string{address}, ports...)
+	sort.Strings(sorted[1:])
+	f.mu.Lock()
+	f.calls = append(f.calls, sorted)
+	f.mu.Unlock()
+	close(ready)
+	<-ctx.Done()
+	return nil
+}
+
+func (f *recordingForwarder) recorded() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.calls...)
+}
+
+func fakeKubernetes(t *testing.T, objects ...corev1.Pod) (*KubernetesRuntime, *fake.Clientset, *recordingForwarder) {
+	t.Helper()
+	clientset := fake.NewClientset()
+	for i := range objects {
+		_, err := clientset.CoreV1().Pods("lstk").Create(context.Background(), &objects[i], metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	fw := &recordingForwarder{}
+	return newKubernetesRuntime(clientset, "lstk", fw.forward), clientset, fw
+}
+
+func setPodStatus(t *testing.T, clientset *fake.Clientset, name string, status corev1.PodStatus) {
+	t.Helper()
+	pod, err := clientset.CoreV1().Pods("lstk").Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status = status
+	_, err = clientset.CoreV1().Pods("lstk").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+func managedPod(name, image, phase string, annotations map[string]string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{managedLabelKey: managedLabelValue},
+			Annotations: annotations,
+		},
+		Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: kubeContainerName, Image: image}}},
+		Status: corev1.PodStatus{Phase: corev1.PodPhase(phase)},
+	}
+}
+
+func TestKubernetesStart_CreatesPodAndService(t *testing.T) {
+	rt, clientset, _ := fakeKubernetes(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, _, err := rt.Start(ctx, ContainerConfig{
+		Image:         "localstack/localstack-pro:latest",
+		Name:          "localstack-aws",
+		Port:          "4567",
+		ContainerPort: "4566/tcp",
+		Env:           []string{"DEBUG=1", "GATEWAY_LISTEN=:4566,:443"},
+		Binds:         []BindMount{{HostPath: "/home/u/.cache/lstk/volume", ContainerPath: "/var/lib/localstack"}},
+		ExtraPorts: []PortMapping{
+			{ContainerPort: "443", HostPort: "443", Optional: true},
+			{ContainerPort: "53", HostPort: "53", Protocol: "udp"},
+		},
+		Instance: "scratch",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "localstack-aws", id)
+
+	pod, err := clientset.CoreV1().Pods("lstk").Get(ctx, "localstack-aws", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, managedLabelValue, pod.Labels[managedLabelKey])
+	assert.Equal(t, "scratch", pod.Labels[instanceLabelKey])
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.JSONEq(t, `{"4566/tcp":"4567","443/tcp":"443","53/udp":"53"}`, pod.Annotations[hostPortsAnnotationKey])
+
+	c := pod.Spec.Containers[0]
+	assert.Equal(t, "localstack/localstack-pro:latest", c.Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "DEBUG", Value: "1"}, {Name: "GATEWAY_LISTEN", Value: ":4566,:443"}}, c.Env)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "bind-0", MountPath: "/var/lib/localstack"}}, c.VolumeMounts)
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.NotNil(t, pod.Spec.Volumes[0].EmptyDir)
+
+	svc, err := clientset.CoreV1().Services("lstk").Get(ctx, "localstack-aws", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{podNameLabelKey: "localstack-aws"}, svc.Spec.Selector)
+	assert.Equal(t, []corev1.ServicePort{
+		{Name: "tcp-4566", Port: 4566, Protocol: corev1.ProtocolTCP},
+		{Name: "tcp-443", Port: 443, Protocol: corev1.ProtocolTCP},
+		{Name: "udp-53", Port: 53, Protocol: corev1.ProtocolUDP},
+	}, svc.Spec.Ports)
+}
+
+func TestKubernetesStart_ForwardsOnceRunningAndReportsExit(t *testing.T) {
+	rt, clientset, fw := fakeKubernetes(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, exitCh, err := rt.Start(ctx, ContainerConfig{
+		Image:         "localstack/localstack:latest",
+		Name:          "localstack-aws",
+		Port:          "4566",
+		ContainerPort: "4566/tcp",
+		ExtraPorts:    []PortMapping{{ContainerPort: "443", HostPort: "443"}},
+	})
+	require.NoError(t, err)
+
+	setPodStatus(t, clientset, "localstack-aws", corev1.PodStatus{Phase: corev1.PodRunning})
+	require.Eventually(t, func() bool { return len(fw.recorded()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"127.0.0.1", "443:443", "4566:4566"}, fw.recorded()[0])
+
+	setPodStatus(t, clientset, "localstack-aws", corev1.PodStatus{
+		Phase: corev1.PodFailed,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  kubeContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 3}},
+		}},
+	})
+	select {
+	case res := <-exitCh:
+		assert.Equal(t, ExitResult{ExitCode: 3}, res)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no exit reported")
+	}
+}
+
+func TestKubernetesGetBoundPort_ForwardsRecordedHostPort(t *testing.T) {
+	rt, _, fw := fakeKubernetes(t, managedPod("localstack-aws", "localstack/localstack:latest", "Running", map[string]string{
+		hostPortsAnnotationKey: `{"4566/tcp":"4567"}`,
+		bindHostAnnotationKey:  "0.0.0.0",
+	}))
+
+	port, err := rt.GetBoundPort(context.Background(), "localstack-aws", "4566/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, "4567", port)
+	// A second lookup reuses the forward this process already runs.
+	_, err = rt.GetBoundPort(context.Background(), "localstack-aws", "4566")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"0.0.0.0", "4567:4566"}}, fw.recorded())
+
+	require.NoError(t, rt.Stop(context.Background(), "localstack-aws"))
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	assert.Empty(t, rt.forwards)
+}
+
+func TestKubernetesGetBoundPort_ForwardFailure(t *testing.T) {
+	rt, _, fw := fakeKubernetes(t, managedPod("localstack-aws", "localstack/localstack:latest", "Running", map[string]string{
+		hostPortsAnnotationKey: `{"4566/tcp":"4566"}`,
+	}))
+	fw.err = errors.New("unable to listen on any of the requested ports")
+
+	_, err := rt.GetBoundPort(context.Background(), "localstack-aws", "4566/tcp")
+	require.ErrorContains(t, err, "failed to forward ports to pod localstack-aws")
+}
+
+func TestKubernetesStop_DeletesPodAndServiceAndToleratesMissing(t *testing.T) {
+	rt, clientset, _ := fakeKubernetes(t)
+	ctx := context.Background()
+	_, _, err := rt.Start(ctx, ContainerConfig{Image: "img", Name: "localstack-aws", Port: "4566", ContainerPort: "4566/tcp"})
+	require.NoError(t, err)
+
+	require.NoError(t, rt.Stop(ctx, "localstack-aws"))
+	_, err = clientset.CoreV1().Pods("lstk").Get(ctx, "localstack-aws", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = clientset.CoreV1().Services("lstk").Get(ctx, "localstack-aws", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	require.NoError(t, rt.Stop(ctx, "localstack-aws"))
+	require.NoError(t, rt.Remove(ctx, "localstack-aws"))
+}
+
+func TestKubernetesStart_DottedTagGetsAValidServiceName(t *testing.T) {
+	rt, clientset, _ := fakeKubernetes(t)
+	ctx := context.Background()
+	_, _, err := rt.Start(ctx, ContainerConfig{Image: "localstack/localstack:4.3", Name: "localstack-aws-4.3", Port: "4566", ContainerPort: "4566/tcp"})
+	require.NoError(t, err)
+
+	pod, err := clientset.CoreV1().Pods("lstk").Get(ctx, "localstack-aws-4.3", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "localstack-aws-4-3", pod.Annotations[serviceAnnotationKey])
+	svc, err := clientset.CoreV1().Services("lstk").Get(ctx, "localstack-aws-4-3", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, validation.IsDNS1035Label(svc.Name))
+	assert.Equal(t, map[string]string{podNameLabelKey: "localstack-aws-4.3"}, svc.Spec.Selector)
+	assert.Equal(t, "localstack-aws-4-3.lstk.svc:4566", rt.InClusterAddress("localstack-aws-4.3", "4566/tcp"))
+
+	require.NoError(t, rt.Remove(ctx, "localstack-aws-4.3"))
+	_, err = clientset.CoreV1().Services("lstk").Get(ctx, "localstack-aws-4-3", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestServiceName(t *testing.T) {
+	tests := []struct {
+		podName string
+		want    string
+	}{
+		{podName: "localstack-aws", want: "localstack-aws"},
+		{podName: "localstack-aws-2026.4", want: "localstack-aws-2026-4"},
+		{podName: "My_Emulator", want: "my-emulator"},
+		{podName: "4566-aws", want: "ls-4566-aws"},
+		{podName: strings.Repeat("a", 62) + ".b", want: strings.Repeat("a", 62)},
+	}
+	for _, tt := range tests {
+		got := serviceName(tt.podName)
+		assert.Equal(t, tt.want, got, tt.podName)
+		assert.Empty(t, validation.IsDNS1035Label(got), tt.podName)
+	}
+}
+
+func TestKubernetesInspectBrief(t *testing.T) {
+	rt, _, _ := fakeKubernetes(t, managedPod("pending", "localstack/localstack:4.0", "Pending", nil))
+
+	brief, err := rt.InspectBrief(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Equal(t, ContainerBrief{}, brief)
+
+	brief, err = rt.InspectBrief(context.Background(), "pending")
+	require.NoError(t, err)
+	assert.Equal(t, ContainerBrief{Exists: true, Starting: true, Image: "localstack/localstack:4.0", Managed: true}, brief, "a pending pod is starting, not a leftover")
+}
+
+func TestKubernetesInClusterAddress(t *testing.T) {
+	rt, _, _ := fakeKubernetes(t)
+	assert.Equal(t, "localstack-aws.lstk.svc:4566", rt.InClusterAddress("localstack-aws", "4566/tcp"))
+}
+
+func TestKubernetesIsRunning(t *testing.T) {
+	pullFailure := managedPod("bad-image", "localstack/localstack:nope", "Pending", nil)
+	pullFailure.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  kubeContainerName,
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "manifest unknown"}},
+	}}
+	rt, _, _ := fakeKubernetes(t,
+		managedPod("pending", "img", "Pending", nil),
+		managedPod("done", "img", "Succeeded", nil),
+		pullFailure,
+	)
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr string
+	}{
+		{"pending", true, ""},
+		{"done", false, ""},
+		{"missing", false, ""},
+		{"bad-image", false, "pod bad-image cannot start: ImagePullBackOff: manifest unknown"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			running, err := rt.IsRunning(context.Background(), tc.name)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, running)
+		})
+	}
+}
+
+func TestKubernetesFindRunningByImage(t *testing.T) {
+	scratch := managedPod("localstack-aws-scratch", "docker.io/localstack/localstack-pro:latest", "Running", map[string]string{
+		hostPortsAnnotationKey: `{"4566/tcp":"4567"}`,
+	})
+	scratch.Labels[instanceLabelKey] = "scratch"
+	rt, _, _ := fakeKubernetes(t,
+		managedPod("other", "nginx:latest", "Running", map[string]string{hostPortsAnnotationKey: `{"4566/tcp":"8080"}`}),
+		managedPod("stopped", "localstack/localstack-pro:latest", "Failed", map[string]string{hostPortsAnnotationKey: `{"4566/tcp":"4566"}`}),
+		scratch,
+	)
+
+	found, err := rt.FindRunningByImage(context.Background(), []string{"localstack/localstack-pro"}, "4566/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, &RunningContainer{
+		Name:      "localstack-aws-scratch",
+		Image:     "localstack/localstack-pro:latest",
+		BoundPort: "4567",
+		Instance:  "scratch",
+	}, found)
+}
+
+func TestKubernetesLogs(t *testing.T) {
+	rt, _, _ := fakeKubernetes(t, managedPod("localstack-aws", "img", "Running", nil))
+
+	logs, err := rt.Logs(context.Background(), "localstack-aws", 10)
+	require.NoError(t, err)
+	// The fake clientset serves a fixed body for every log request.
+	assert.Equal(t, "fake logs", logs)
+
+	err = rt.StreamLogs(context.Background(), "missing", io.Discard, false, "all")
+	require.EqualError(t, err, "emulator is not running. Start LocalStack with `lstk`")
+}
+
+func TestKubernetesGetImageVersion_Unsupported(t *testing.T) {
+	rt, _, _ := fakeKubernetes(t)
+	_, err := rt.GetImageVersion(context.Background(), "localstack/localstack:latest")
+	assert.ErrorIs(t, err, ErrImageInspectUnsupported)
+}
+
+func TestKubernetesEmitUnhealthyError(t *testing.T) {
+	rt, _, _ := fakeKubernetes(t)
+	sink := &captureSink{}
+	rt.EmitUnhealthyError(sink, fmt.Errorf("connection refused"))
+	errEvent := sink.errorEvent(t)
+	assert.Equal(t, "Kubernetes is not available", errEvent.Title)
+	assert.Equal(t, "kubectl auth can-i create pods -n lstk", errEvent.Actions[1].Value)
+}
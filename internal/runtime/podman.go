@@ -0,0 +1,762 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	stdruntime "runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+// libpodAPIPrefix is the versioned root of the libpod REST API. Podman serves
+// every 4.x endpoint lstk uses under this prefix on 4.x and 5.x alike.
+const libpodAPIPrefix = "/v4.0.0/libpod"
+
+// PodmanRuntime talks to Podman's native libpod REST API instead of its
+// Docker-compatible endpoints. The libpod API reports rootless mode, so bind
+// mounts can be set up for the rootless user namespace, and its pull stream is
+// what Podman itself emits rather than a Docker-shaped translation of it.
+type PodmanRuntime struct {
+	host   string // "unix://" + socket path
+	client *http.Client
+}
+
+// NewPodmanRuntime connects to the libpod API on host ("unix:///path/to.sock").
+// When host is empty it honours CONTAINER_HOST, Podman's own counterpart of
+// DOCKER_HOST, and otherwise probes the Podman sockets lstk knows about: the
+// macOS machine socket, then native rootful and rootless Podman.
+func NewPodmanRuntime(host string) (*PodmanRuntime, error) {
+	if host == "" {
+		host = os.Getenv("CONTAINER_HOST")
+	}
+	if host == "" {
+		host = "unix://" + findPodmanSocket()
+	}
+	if !strings.HasPrefix(host, "unix://") {
+		return nil, fmt.Errorf("unsupported Podman host %q: only unix:// sockets are supported", host)
+	}
+	socketPath := strings.TrimPrefix(host, "unix://")
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	return &PodmanRuntime{host: host, client: &http.Client{Transport: transport}}, nil
+}
+
+// findPodmanSocket returns the first live Podman socket, or the socket Podman
+// would most likely use when none is live, so IsHealthy reports a useful path.
+func findPodmanSocket() string {
+	home, _ := os.UserHomeDir()
+	var candidates []string
+	for _, spec := range vmSocketSpecs {
+		if spec.flavor == flavorPodman {
+			candidates = append(candidates, filepath.Join(home, spec.relPath))
+		}
+	}
+	candidates = append(candidates, nativeSocketPaths()...)
+	if sock := probeSocket(candidates...); sock != "" {
+		return sock
+	}
+	// Rootless is the more common default (see tailoredRuntimeAction).
+	native := nativeSocketPaths()
+	return native[len(native)-1]
+}
+
+// podmanAPIError is the JSON body libpod returns with every non-2xx response.
+type podmanAPIError struct {
+	StatusCode int    `json:"response"`
+	Message    string `json:"message"`
+	Cause      string `json:"cause"`
+}
+
+func (e *podmanAPIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("podman API returned status %d", e.StatusCode)
+}
+
+func isPodmanStatus(err error, status int) bool {
+	var apiErr *podmanAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// do sends a request to the libpod API. A non-2xx response is decoded into a
+// *podmanAPIError and its body closed; otherwise the caller owns resp.Body.
+func (p *PodmanRuntime) do(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u := "http://d" + libpodAPIPrefix + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
+		defer func() { _ = resp.Body.Close() }()
+		apiErr := &podmanAPIError{}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// getJSON issues a GET and decodes the response body into out.
+func (p *PodmanRuntime) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := p.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// send issues a request whose response body is not needed.
+func (p *PodmanRuntime) send(ctx context.Context, method, path string, query url.Values, body any) error {
+	resp, err := p.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+func (p *PodmanRuntime) Flavor() string {
+	home, _ := os.UserHomeDir()
+	return classifySocketFlavor(home, p.host).String()
+}
+
+// SocketPath returns the Podman socket to bind-mount into containers. Podman
+// serves the Docker-compatible API on the same socket, so nested workloads
+// such as Lambda functions can use it as a Docker socket. The macOS machine
+// socket is a forwarded view of the VM's, which the VM exposes at
+// /var/run/docker.sock.
+func (p *PodmanRuntime) SocketPath() string {
+	home, _ := os.UserHomeDir()
+	if classifySocketFlavor(home, p.host) == flavorPodman {
+		return dockerNativeSocket
+	}
+	return strings.TrimPrefix(p.host, "unix://")
+}
+
+func (p *PodmanRuntime) IsHealthy(ctx context.Context) error {
+	if err := p.send(ctx, http.MethodGet, "/_ping", nil, nil); err != nil {
+		return fmt.Errorf("cannot connect to Podman at %s: %w", p.host, err)
+	}
+	return nil
+}
+
+func (p *PodmanRuntime) EmitUnhealthyError(sink output.Sink, err error) {
+	home, _ := os.UserHomeDir()
+	p.emitUnhealthyError(sink, err, home, stdruntime.GOOS)
+}
+
+// emitUnhealthyError is EmitUnhealthyError with home and GOOS injected, like
+// DockerRuntime.emitUnhealthyError. The socket always identifies Podman here,
+// so the only question is which start command applies.
+func (p *PodmanRuntime) emitUnhealthyError(sink output.Sink, err error, home, goos string) {
+	actions := []output.ErrorAction{
+		{Label: "Install Podman:", Value: "https://podman.io/docs/installation"},
+	}
+	flavor := classifySocketFlavor(home, p.host)
+	if flavor == flavorUnknown {
+		flavor = flavorPodman
+	}
+	if tailored, ok := tailoredRuntimeAction(flavor, goos); ok {
+		actions = append([]output.ErrorAction{tailored}, actions...)
+	}
+	sink.Emit(output.ErrorEvent{
+		Title:   "Podman is not available",
+		Summary: err.Error(),
+		Actions: actions,
+		Code:    output.ErrRuntimeUnavailable,
+	})
+}
+
+// libpodPullReport is one line of libpod's image pull stream. Unlike Docker's
+// per-layer JSON progress, libpod streams the plain-text progress lines of
+// `podman pull` ("Copying blob sha256:…") and a final report carrying the
+// pulled image ID.
+type libpodPullReport struct {
+	Stream string   `json:"stream"`
+	Error  string   `json:"error"`
+	Images []string `json:"images"`
+	ID     string   `json:"id"`
+}
+
+func (p *PodmanRuntime) PullImage(ctx context.Context, imageName string, progress chan<- PullProgress) error {
+	// Close progress unconditionally, as DockerRuntime.PullImage does, so callers
+	// that wait for the progress stream to drain never hang.
+	if progress != nil {
+		defer close(progress)
+	}
+
+	resp, err := p.do(ctx, http.MethodPost, "/images/pull", url.Values{"reference": {imageName}}, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("failed to close image pull reader: %v", err)
+		}
+	}()
+
+	var layers []string
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var report libpodPullReport
+		if err := decoder.Decode(&report); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if report.Error != "" {
+			return fmt.Errorf("image pull failed: %s", report.Error)
+		}
+		if progress == nil {
+			continue
+		}
+		for _, line := range strings.Split(report.Stream, "\n") {
+			update, ok := parseLibpodPullLine(line)
+			if !ok {
+				continue
+			}
+			if update.Status == "Downloading" {
+				layers = append(layers, update.LayerID)
+			}
+			progress <- update
+		}
+		// The final report lists the pulled image: every blob still in flight
+		// has been copied by now.
+		if len(report.Images) > 0 {
+			for _, layer := range layers {
+				progress <- PullProgress{LayerID: layer, Status: "Pull complete"}
+			}
+			layers = nil
+		}
+	}
+	return nil
+}
+
+// parseLibpodPullLine maps one "Copying blob …" line of libpod's pull stream to
+// the Docker-style layer statuses the pull progress UI understands: a blob
+// starts "Downloading", and one libpod reports as already present, or done
+// without a separate copy line, is "Already exists".
+func parseLibpodPullLine(line string) (PullProgress, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), "Copying blob ")
+	if !ok {
+		return PullProgress{}, false
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return PullProgress{}, false
+	}
+	layer := strings.TrimPrefix(fields[0], "sha256:")
+	if len(layer) > 12 {
+		layer = layer[:12]
+	}
+	if strings.Contains(rest, "skipped") || strings.Contains(rest, "already exists") {
+		return PullProgress{LayerID: layer, Status: "Already exists"}, true
+	}
+	if len(fields) > 1 && fields[1] == "done" {
+		return PullProgress{LayerID: layer, Status: "Pull complete"}, true
+	}
+	return PullProgress{LayerID: layer, Status: "Downloading"}, true
+}
+
+// libpodPortMapping and libpodMount are the subset of libpod's SpecGenerator
+// fields lstk sets when creating a container.
+type libpodPortMapping struct {
+	HostIP        string `json:"host_ip,omitempty"`
+	ContainerPort uint16 `json:"container_port"`
+	HostPort      uint16 `json:"host_port"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+type libpodMount struct {
+	Destination string   `json:"destination"`
+	Source      string   `json:"source"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type libpodNamespace struct {
+	NSMode string `json:"nsmode"`
+	Value  string `json:"value,omitempty"`
+}
+
+type libpodCreateSpec struct {
+	Name         string              `json:"name"`
+	Image        string              `json:"image"`
+	Env          map[string]string   `json:"env,omitempty"`
+	Labels       map[string]string   `json:"labels"`
+	PortMappings []libpodPortMapping `json:"portmappings"`
+	Mounts       []libpodMount       `json:"mounts,omitempty"`
+	Remove       bool                `json:"remove"`
+	UserNS       *libpodNamespace    `json:"userns,omitempty"`
+}
+
+func parsePortMapping(hostIP, hostPort, containerPort, proto string) (libpodPortMapping, error) {
+	portStr, p, found := strings.Cut(containerPort, "/")
+	if found {
+		proto = p
+	}
+	if proto == "" {
+		proto = "tcp"
+	}
+	cport, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return libpodPortMapping{}, fmt.Errorf("invalid container port %q: %w", containerPort, err)
+	}
+	hport, err := strconv.ParseUint(hostPort, 10, 16)
+	if err != nil {
+		return libpodPortMapping{}, fmt.Errorf("invalid host port %q: %w", hostPort, err)
+	}
+	return libpodPortMapping{HostIP: hostIP, ContainerPort: uint16(cport), HostPort: uint16(hport), Protocol: proto}, nil
+}
+
+// rootless reports whether the Podman service runs rootless.
+func (p *PodmanRuntime) rootless(ctx context.Context) (bool, error) {
+	var info struct {
+		Host struct {
+			Security struct {
+				Rootless bool `json:"rootless"`
+			} `json:"security"`
+		} `json:"host"`
+	}
+	if err := p.getJSON(ctx, "/info", nil, &info); err != nil {
+		return false, fmt.Errorf("failed to query Podman info: %w", err)
+	}
+	return info.Host.Security.Rootless, nil
+}
+
+// bindMounts translates Binds into libpod mounts. Unlike Docker, Podman refuses
+// to start a container whose bind-mount source is missing, so missing source
+// directories are created first — as the invoking user, which under rootless
+// Podman is also who the container's root maps to.
+func bindMounts(binds []BindMount) ([]libpodMount, error) {
+	mounts := make([]libpodMount, 0, len(binds))
+	for _, b := range binds {
+		if _, err := os.Stat(b.HostPath); errors.Is(err, os.ErrNotExist) {
+			if err := os.MkdirAll(b.HostPath, 0o755); err != nil {
+				return nil, fmt.Errorf("failed to create bind mount source %s: %w", b.HostPath, err)
+			}
+		}
+		options := []string{"rbind"}
+		if b.ReadOnly {
+			options = append(options, "ro")
+		}
+		mounts = append(mounts, libpodMount{Destination: b.ContainerPath, Source: b.HostPath, Type: "bind", Options: options})
+	}
+	return mounts, nil
+}
+
+func (p *PodmanRuntime) Start(ctx context.Context, config ContainerConfig) (string, <-chan ExitResult, error) {
+	bindHost := config.BindHost
+	if bindHost == "" {
+		bindHost = "127.0.0.1"
+	}
+
+	primary, err := parsePortMapping(bindHost, config.Port, config.ContainerPort, "")
+	if err != nil {
+		return "", nil, err
+	}
+	ports := []libpodPortMapping{primary}
+	for _, ep := range config.ExtraPorts {
+		mapping, err := parsePortMapping(bindHost, ep.HostPort, ep.ContainerPort, ep.Protocol)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid extra port %q: %w", ep.ContainerPort, err)
+		}
+		ports = append(ports, mapping)
+	}
+
+	rootless, err := p.rootless(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	mounts, err := bindMounts(config.Binds)
+	if err != nil {
+		return "", nil, err
+	}
+
+	env := make(map[string]string, len(config.Env))
+	for _, kv := range config.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		env[k] = v
+	}
+
+	labels := map[string]string{managedLabelKey: managedLabelValue}
+	if config.Instance != "" {
+		labels[instanceLabelKey] = config.Instance
+	}
+
+	spec := libpodCreateSpec{
+		Name:         config.Name,
+		Image:        config.Image,
+		Env:          env,
+		Labels:       labels,
+		PortMappings: ports,
+		Mounts:       mounts,
+		Remove:       true,
+	}
+	if rootless {
+		// Map the invoking user to the container's root explicitly: the emulator
+		// runs as root, so whatever it writes to a bind mount (the volume
+		// directory, init hooks) stays owned by the user on the host instead of
+		// by a subordinate UID they cannot clean up.
+		spec.UserNS = &libpodNamespace{NSMode: "keep-id", Value: "uid=0,gid=0"}
+	}
+
+	var created struct {
+		ID string `json:"Id"`
+	}
+	resp, err := p.do(ctx, http.MethodPost, "/containers/create", nil, spec)
+	if err != nil {
+		return "", nil, err
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	_ = resp.Body.Close()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode create response: %w", err)
+	}
+
+	// Register the exit wait before starting, as DockerRuntime.Start does. Podman
+	// also keeps the exit code of an auto-removed container, so a wait that only
+	// reaches the service after the removal still reports it when addressed by ID.
+	exitCh := p.waitForExit(ctx, created.ID)
+
+	if err := p.send(ctx, http.MethodPost, "/containers/"+url.PathEscape(created.ID)+"/start", nil, nil); err != nil {
+		return "", nil, err
+	}
+	return created.ID, exitCh, nil
+}
+
+// waitForExit returns a channel that receives exactly one ExitResult for the
+// container's exit.
+func (p *PodmanRuntime) waitForExit(ctx context.Context, containerID string) <-chan ExitResult {
+	// Buffered so the goroutine never leaks if the caller stops reading.
+	out := make(chan ExitResult, 1)
+	go func() {
+		resp, err := p.do(ctx, http.MethodPost, "/containers/"+url.PathEscape(containerID)+"/wait", url.Values{"condition": {"exited"}}, nil)
+		if err != nil {
+			out <- ExitResult{ExitCode: -1, Err: err}
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var code int
+		if err := json.NewDecoder(resp.Body).Decode(&code); err != nil {
+			out <- ExitResult{ExitCode: -1, Err: err}
+			return
+		}
+		out <- ExitResult{ExitCode: code}
+	}()
+	return out
+}
+
+func (p *PodmanRuntime) Stop(ctx context.Context, containerName string) error {
+	err := p.send(ctx, http.MethodPost, "/containers/"+url.PathEscape(containerName)+"/stop", nil, nil)
+	if err != nil && !isPodmanStatus(err, http.StatusNotFound) {
+		return err
+	}
+	// Ignore conflict and not-found: container is gone, which is the goal.
+	err = p.send(ctx, http.MethodDelete, "/containers/"+url.PathEscape(containerName), url.Values{"force": {"true"}}, nil)
+	if err != nil && !isPodmanStatus(err, http.StatusNotFound) && !isPodmanStatus(err, http.StatusConflict) {
+		return err
+	}
+	return nil
+}
+
+func (p *PodmanRuntime) Remove(ctx context.Context, containerName string) error {
+	err := p.send(ctx, http.MethodDelete, "/containers/"+url.PathEscape(containerName), url.Values{"force": {"true"}}, nil)
+	if err != nil && !isPodmanStatus(err, http.StatusNotFound) && !isPodmanStatus(err, http.StatusConflict) {
+		return err
+	}
+	// Wait until the container is actually gone, so a subsequent create reusing the
+	// same name does not race an in-flight auto-removal.
+	ctx, cancel := context.WithTimeout(ctx, containerRemovalTimeout)
+	defer cancel()
+	for {
+		if err := p.send(ctx, http.MethodGet, "/containers/"+url.PathEscape(containerName)+"/exists", nil, nil); isPodmanStatus(err, http.StatusNotFound) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for container %s to be removed", containerName)
+		case <-time.After(containerRemovalPollInterval):
+		}
+	}
+}
+
+// libpodContainerInspect is the subset of libpod's container inspect response
+// lstk reads.
+type libpodContainerInspect struct {
+	State struct {
+		Status    string    `json:"Status"`
+		Running   bool      `json:"Running"`
+		StartedAt time.Time `json:"StartedAt"`
+	} `json:"State"`
+	Config struct {
+		Image  string            `json:"Image"`
+		Env    []string          `json:"Env"`
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		AutoRemove bool `json:"AutoRemove"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+func (p *PodmanRuntime) inspect(ctx context.Context, containerName string) (*libpodContainerInspect, error) {
+	var inspect libpodContainerInspect
+	if err := p.getJSON(ctx, "/containers/"+url.PathEscape(containerName)+"/json", nil, &inspect); err != nil {
+		return nil, err
+	}
+	return &inspect, nil
+}
+
+func (p *PodmanRuntime) IsRunning(ctx context.Context, containerID string) (bool, error) {
+	inspect, err := p.inspect(ctx, containerID)
+	if err != nil {
+		if isPodmanStatus(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return inspect.State.Running, nil
+}
+
+func (p *PodmanRuntime) InspectBrief(ctx context.Context, containerName string) (ContainerBrief, error) {
+	inspect, err := p.inspect(ctx, containerName)
+	if err != nil {
+		if isPodmanStatus(err, http.StatusNotFound) {
+			return ContainerBrief{}, nil
+		}
+		return ContainerBrief{}, err
+	}
+	return ContainerBrief{
+		Exists:     true,
+		Running:    inspect.State.Running,
+		Created:    inspect.State.Status == "created" || inspect.State.Status == "configured",
+		AutoRemove: inspect.HostConfig.AutoRemove,
+		Image:      inspect.Config.Image,
+		Managed:    inspect.Config.Labels[managedLabelKey] == managedLabelValue,
+	}, nil
+}
+
+func (p *PodmanRuntime) ContainerStartedAt(ctx context.Context, containerName string) (time.Time, error) {
+	inspect, err := p.inspect(ctx, containerName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return inspect.State.StartedAt, nil
+}
+
+func (p *PodmanRuntime) ContainerEnv(ctx context.Context, containerName string) ([]string, error) {
+	inspect, err := p.inspect(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	return inspect.Config.Env, nil
+}
+
+func (p *PodmanRuntime) GetBoundPort(ctx context.Context, containerName string, containerPort string) (string, error) {
+	inspect, err := p.inspect(ctx, containerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if !strings.Contains(containerPort, "/") {
+		containerPort += "/tcp"
+	}
+	bindings := inspect.NetworkSettings.Ports[containerPort]
+	if len(bindings) == 0 {
+		return "", fmt.Errorf("no binding found for port %s on container %s", containerPort, containerName)
+	}
+	return bindings[0].HostPort, nil
+}
+
+// openLogs returns libpod's log stream, which uses the same stdout/stderr
+// multiplexing header as Docker's.
+func (p *PodmanRuntime) openLogs(ctx context.Context, containerID string, follow bool, tail string) (io.ReadCloser, error) {
+	query := url.Values{
+		"stdout": {"true"},
+		"stderr": {"true"},
+		"follow": {strconv.FormatBool(follow)},
+		"tail":   {tail},
+	}
+	resp, err := p.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(containerID)+"/logs", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (p *PodmanRuntime) Logs(ctx context.Context, containerID string, tail int) (string, error) {
+	tailStr := "50"
+	if tail > 0 {
+		tailStr = strconv.Itoa(tail)
+	}
+	reader, err := p.openLogs(ctx, containerID, false, tailStr)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("failed to close logs reader: %v", err)
+		}
+	}()
+
+	var buf bytes.Buffer
+	if _, err := stdcopy.StdCopy(&buf, &buf, reader); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (p *PodmanRuntime) StreamLogs(ctx context.Context, containerID string, out io.Writer, follow bool, tail string) error {
+	if tail == "" {
+		tail = "all"
+	}
+	reader, err := p.openLogs(ctx, containerID, follow, tail)
+	if err != nil {
+		if isPodmanStatus(err, http.StatusNotFound) {
+			return fmt.Errorf("emulator is not running. Start LocalStack with `lstk`")
+		}
+		return fmt.Errorf("failed to stream logs for %s: %w", containerID, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			log.Printf("failed to close logs reader: %v", err)
+		}
+	}()
+
+	_, err = stdcopy.StdCopy(out, out, reader)
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("error reading logs: %w", err)
+	}
+	return nil
+}
+
+// libpodListedContainer is the subset of libpod's container list entries lstk
+// reads.
+type libpodListedContainer struct {
+	Names  []string          `json:"Names"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
+	Ports  []struct {
+		HostPort      uint16 `json:"host_port"`
+		ContainerPort uint16 `json:"container_port"`
+		Protocol      string `json:"protocol"`
+		Range         uint16 `json:"range"`
+	} `json:"Ports"`
+}
+
+func (p *PodmanRuntime) FindRunningByImage(ctx context.Context, imageRepos []string, containerPort string) (*RunningContainer, error) {
+	filters, err := json.Marshal(map[string][]string{"status": {"running"}})
+	if err != nil {
+		return nil, err
+	}
+	var list []libpodListedContainer
+	if err := p.getJSON(ctx, "/containers/json", url.Values{"filters": {string(filters)}}, &list); err != nil {
+		return nil, err
+	}
+
+	portStr, proto, found := strings.Cut(containerPort, "/")
+	if !found {
+		proto = "tcp"
+	}
+	privatePort, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container port %q: %w", containerPort, err)
+	}
+
+	for _, c := range list {
+		// Podman qualifies short Docker Hub names ("docker.io/localstack/…").
+		image := strings.TrimPrefix(c.Image, "docker.io/")
+		if !matchesAnyImageRepo(image, imageRepos) {
+			continue
+		}
+		for _, pm := range c.Ports {
+			// A mapping may cover a range of consecutive ports.
+			span := uint64(max(pm.Range, 1))
+			if pm.Protocol != proto || privatePort < uint64(pm.ContainerPort) || privatePort >= uint64(pm.ContainerPort)+span {
+				continue
+			}
+			name := ""
+			if len(c.Names) > 0 {
+				name = c.Names[0]
+			}
+			return &RunningContainer{
+				Name:      name,
+				Image:     image,
+				BoundPort: strconv.FormatUint(uint64(pm.HostPort)+privatePort-uint64(pm.ContainerPort), 10),
+				Instance:  c.Labels[instanceLabelKey],
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (p *PodmanRuntime) GetImageVersion(ctx context.Context, imageName string) (string, error) {
+	var inspect struct {
+		Config struct {
+			Env []string `json:"Env"`
+		} `json:"Config"`
+	}
+	if err := p.getJSON(ctx, "/images/"+url.PathEscape(imageName)+"/json", nil, &inspect); err != nil {
+		return "", fmt.Errorf("failed to inspect image: %w", err)
+	}
+	for _, env := range inspect.Config.Env {
+		if v, ok := strings.CutPrefix(env, "LOCALSTACK_BUILD_VERSION="); ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("LOCALSTACK_BUILD_VERSION not found in image environment")
+}
+
+func (p *PodmanRuntime) ImageExists(ctx context.Context, image string) (bool, error) {
+	if err := p.send(ctx, http.MethodGet, "/images/"+url.PathEscape(image)+"/exists", nil, nil); err != nil {
+		if isPodmanStatus(err, http.StatusNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	return true, nil
+}
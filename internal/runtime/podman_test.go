@@ -0,0 +1,249 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLibpod serves handler on a unix socket and returns a PodmanRuntime
+// connected to it.
+func fakeLibpod(t *testing.T, handler http.HandlerFunc) *PodmanRuntime {
+	t.Helper()
+	sock := filepath.Join(shortTempDir(t), "podman.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: handler}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	rt, err := NewPodmanRuntime("unix://" + sock)
+	require.NoError(t, err)
+	return rt
+}
+
+func TestParseLibpodPullLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   PullProgress
+		wantOK bool
+	}{
+		{"Copying blob sha256:0123456789abcdef0123", PullProgress{LayerID: "0123456789ab", Status: "Downloading"}, true},
+		{"Copying blob 0123456789ab done", PullProgress{LayerID: "0123456789ab", Status: "Pull complete"}, true},
+		{"Copying blob 0123456789ab skipped: already exists", PullProgress{LayerID: "0123456789ab", Status: "Already exists"}, true},
+		{"Copying config sha256:feedface", PullProgress{}, false},
+		{"Writing manifest to image destination", PullProgress{}, false},
+		{"", PullProgress{}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.line, func(t *testing.T) {
+			got, ok := parseLibpodPullLine(tc.line)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPodmanPullImage_MapsStreamToProgress(t *testing.T) {
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v4.0.0/libpod/images/pull", r.URL.Path)
+		assert.Equal(t, "localstack/localstack:latest", r.URL.Query().Get("reference"))
+		enc := json.NewEncoder(w)
+		_ = enc.Encode(libpodPullReport{Stream: "Trying to pull docker.io/localstack/localstack:latest...\n"})
+		_ = enc.Encode(libpodPullReport{Stream: "Copying blob sha256:aaaaaaaaaaaaaaaa\n"})
+		_ = enc.Encode(libpodPullReport{Stream: "Copying blob sha256:bbbbbbbbbbbbbbbb skipped: already exists\n"})
+		_ = enc.Encode(libpodPullReport{Images: []string{"abc"}, ID: "abc"})
+	})
+
+	progress := make(chan PullProgress, 10)
+	require.NoError(t, rt.PullImage(context.Background(), "localstack/localstack:latest", progress))
+
+	var got []PullProgress
+	for p := range progress {
+		got = append(got, p)
+	}
+	assert.Equal(t, []PullProgress{
+		{LayerID: "aaaaaaaaaaaa", Status: "Downloading"},
+		{LayerID: "bbbbbbbbbbbb", Status: "Already exists"},
+		{LayerID: "aaaaaaaaaaaa", Status: "Pull complete"},
+	}, got)
+}
+
+func TestPodmanPullImage_ReportsStreamError(t *testing.T) {
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(libpodPullReport{Error: "manifest unknown"})
+	})
+
+	progress := make(chan PullProgress, 1)
+	err := rt.PullImage(context.Background(), "localstack/localstack:nope", progress)
+	require.EqualError(t, err, "image pull failed: manifest unknown")
+	_, open := <-progress
+	assert.False(t, open, "progress must be closed on error")
+}
+
+func TestPodmanStart_RootlessMapsUserAndCreatesBindSource(t *testing.T) {
+	volume := filepath.Join(shortTempDir(t), "state")
+	var spec libpodCreateSpec
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v4.0.0/libpod/info":
+			_, _ = fmt.Fprint(w, `{"host":{"security":{"rootless":true}}}`)
+		case r.URL.Path == "/v4.0.0/libpod/containers/create":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&spec))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprint(w, `{"Id":"c0ffee"}`)
+		case strings.HasSuffix(r.URL.Path, "/wait"):
+			_, _ = fmt.Fprint(w, "0")
+		case strings.HasSuffix(r.URL.Path, "/start"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	id, exitCh, err := rt.Start(context.Background(), ContainerConfig{
+		Image:         "localstack/localstack:latest",
+		Name:          "localstack-aws",
+		Port:          "4566",
+		ContainerPort: "4566/tcp",
+		Env:           []string{"DEBUG=1"},
+		Binds:         []BindMount{{HostPath: volume, ContainerPath: "/var/lib/localstack"}},
+		Instance:      "scratch",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "c0ffee", id)
+	assert.Equal(t, ExitResult{ExitCode: 0}, <-exitCh)
+
+	assert.DirExists(t, volume)
+	assert.Equal(t, &libpodNamespace{NSMode: "keep-id", Value: "uid=0,gid=0"}, spec.UserNS)
+	assert.Equal(t, []libpodMount{{Destination: "/var/lib/localstack", Source: volume, Type: "bind", Options: []string{"rbind"}}}, spec.Mounts)
+	assert.Equal(t, []libpodPortMapping{{HostIP: "127.0.0.1", ContainerPort: 4566, HostPort: 4566, Protocol: "tcp"}}, spec.PortMappings)
+	assert.Equal(t, map[string]string{"DEBUG": "1"}, spec.Env)
+	assert.Equal(t, "scratch", spec.Labels[instanceLabelKey])
+	assert.True(t, spec.Remove)
+}
+
+func TestPodmanStart_RootfulKeepsDefaultUserNamespace(t *testing.T) {
+	var spec libpodCreateSpec
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v4.0.0/libpod/info":
+			_, _ = fmt.Fprint(w, `{"host":{"security":{"rootless":false}}}`)
+		case r.URL.Path == "/v4.0.0/libpod/containers/create":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&spec))
+			_, _ = fmt.Fprint(w, `{"Id":"c0ffee"}`)
+		case strings.HasSuffix(r.URL.Path, "/wait"):
+			_, _ = fmt.Fprint(w, "0")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	_, _, err := rt.Start(context.Background(), ContainerConfig{Image: "img", Name: "n", Port: "4566", ContainerPort: "4566/tcp"})
+	require.NoError(t, err)
+	assert.Nil(t, spec.UserNS)
+}
+
+func TestPodmanInspectBrief_MissingContainerIsNotAnError(t *testing.T) {
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"cause":"no such container","message":"no container with name or ID \"x\" found","response":404}`)
+	})
+
+	brief, err := rt.InspectBrief(context.Background(), "x")
+	require.NoError(t, err)
+	assert.Equal(t, ContainerBrief{}, brief)
+
+	running, err := rt.IsRunning(context.Background(), "x")
+	require.NoError(t, err)
+	assert.False(t, running)
+}
+
+func TestPodmanFindRunningByImage_MatchesQualifiedImageAndLabel(t *testing.T) {
+	rt := fakeLibpod(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `{"status":["running"]}`, r.URL.Query().Get("filters"))
+		_, _ = fmt.Fprint(w, `[
+			{"Names":["other"],"Image":"docker.io/library/nginx:latest","Ports":[{"host_port":8080,"container_port":4566,"protocol":"tcp"}]},
+			{"Names":["localstack-aws-scratch"],"Image":"docker.io/localstack/localstack-pro:latest",
+			 "Labels":{"cloud.localstack.lstk.instance":"scratch"},
+			 "Ports":[{"host_port":4510,"container_port":4510,"protocol":"tcp","range":50},{"host_port":4567,"container_port":4566,"protocol":"tcp"}]}
+		]`)
+	})
+
+	found, err := rt.FindRunningByImage(context.Background(), []string{"localstack/localstack-pro"}, "4566/tcp")
+	require.NoError(t, err)
+	assert.Equal(t, &RunningContainer{
+		Name:      "localstack-aws-scratch",
+		Image:     "localstack/localstack-pro:latest",
+		BoundPort: "4567",
+		Instance:  "scratch",
+	}, found)
+}
+
+func TestNewPodmanRuntime_RejectsNonUnixHost(t *testing.T) {
+	_, err := NewPodmanRuntime("tcp://127.0.0.1:8080")
+	require.Error(t, err)
+}
+
+func TestNewPodmanRuntime_HonoursContainerHost(t *testing.T) {
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/lstk-podman-test.sock")
+	rt, err := NewPodmanRuntime("")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/lstk-podman-test.sock", rt.SocketPath())
+}
+
+func TestNew_SelectsBackend(t *testing.T) {
+	t.Run("podman forced keeps a unix DOCKER_HOST", func(t *testing.T) {
+		rt, err := New("unix:///tmp/lstk-forced.sock", BackendPodman)
+		require.NoError(t, err)
+		require.IsType(t, &PodmanRuntime{}, rt)
+		assert.Equal(t, "/tmp/lstk-forced.sock", rt.SocketPath())
+	})
+
+	t.Run("docker forced stays on the Docker API", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix:///run/podman/podman.sock")
+		rt, err := New("unix:///run/podman/podman.sock", BackendDocker)
+		require.NoError(t, err)
+		assert.IsType(t, &DockerRuntime{}, rt)
+	})
+
+	t.Run("auto switches to Podman for a Podman socket", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix:///run/podman/podman.sock")
+		rt, err := New("unix:///run/podman/podman.sock", BackendAuto)
+		require.NoError(t, err)
+		assert.IsType(t, &PodmanRuntime{}, rt)
+		assert.Equal(t, FlavorPodmanRootful, rt.Flavor())
+	})
+
+	t.Run("auto keeps Docker for a Docker socket", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+		rt, err := New("unix:///var/run/docker.sock", "")
+		require.NoError(t, err)
+		assert.IsType(t, &DockerRuntime{}, rt)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := New("", "containerd")
+		require.EqualError(t, err, `invalid runtime "containerd" (must be one of: auto, docker, podman, kubernetes)`)
+	})
+}
+
+func TestPodmanEmitUnhealthyError_RootlessHint(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	rt, err := NewPodmanRuntime("unix:///run/user/1000/podman/podman.sock")
+	require.NoError(t, err)
+
+	sink := &captureSink{}
+	rt.emitUnhealthyError(sink, fmt.Errorf("connection refused"), "/home/user", "linux")
+	errEvent := sink.errorEvent(t)
+	assert.Equal(t, "Podman is not available", errEvent.Title)
+	assert.Equal(t, "systemctl --user start podman.socket", errEvent.Actions[0].Value)
+}
@@ -4,6 +4,7 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"io"
 	"time"
 
@@ -43,6 +44,7 @@ type ContainerConfig struct {
 	ProductName   string
 	Binds         []BindMount
 	ExtraPorts    []PortMapping
+	Instance      string // config instance name; empty for the default instance
 }
 
 type PullProgress struct {
@@ -56,6 +58,7 @@ type RunningContainer struct {
 	Name      string
 	Image     string // full image with tag, e.g. "localstack/localstack-pro:3.5.0"
 	BoundPort string // host port bound to the queried container port
+	Instance  string // config instance name it was started as; empty if none
 }
 
 // ContainerBrief describes an existing container for pre-start checks, so
@@ -65,11 +68,22 @@ type ContainerBrief struct {
 	Exists     bool
 	Running    bool
 	Created    bool   // state "created": created but never started
+	Starting   bool   // still being scheduled or pulling its image, e.g. a pending pod
 	AutoRemove bool   // created with --rm: removes itself once it exits
 	Image      string // full image the container was created from
 	Managed    bool   // carries the label Start stamps on every lstk container
 }
 
+// InClusterAddresser is implemented by runtimes that reach their emulators from
+// the local machine only while an lstk command runs, as the Kubernetes
+// runtime's port-forwards do. What outlives lstk is the address other
+// workloads reach the emulator at.
+type InClusterAddresser interface {
+	// InClusterAddress is the host:port of containerPort (e.g. "4566/tcp") of
+	// the named container.
+	InClusterAddress(containerName, containerPort string) string
+}
+
 // ExitResult reports a container's exit as observed by the exit wait that
 // Start registers.
 type ExitResult struct {
@@ -77,6 +91,12 @@ type ExitResult struct {
 	Err      error // wait itself failed (exit code unknown)
 }
 
+// ErrImageInspectUnsupported is returned by GetImageVersion when the runtime
+// cannot read an image before starting it, e.g. because a cluster node rather
+// than the local daemon pulls it. The emulator then validates its license at
+// startup instead.
+var ErrImageInspectUnsupported = errors.New("runtime cannot inspect images")
+
 // Runtime abstracts container runtime operations (Docker, Podman, Kubernetes, etc.)
 type Runtime interface {
 	IsHealthy(ctx context.Context) error
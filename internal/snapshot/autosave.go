@@ -0,0 +1,217 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+)
+
+// autosavePrefix marks the library names autosaves are kept under, so they
+// read apart from the user's own local: snapshots in `snapshot list --local`.
+const autosavePrefix = "autosave-"
+
+// AutosaveName returns the library name the autosaves of the named container
+// are kept under.
+func AutosaveName(containerName string) string {
+	return libraryName(autosavePrefix, containerName)
+}
+
+// AutosaveEnabled reports whether any of containers saves its state on stop:
+// autosave is an AWS emulator setting.
+func AutosaveEnabled(containers []config.ContainerConfig) bool {
+	for _, c := range containers {
+		if c.Type == config.EmulatorAWS && c.Autosave {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfiguredStartRef returns the snapshot REF the config has the AWS emulator
+// start from: the newest autosave when autosave is on and one has been saved,
+// else its configured snapshot. Returns "" when there is neither.
+func ConfiguredStartRef(containers []config.ContainerConfig, lib Library) string {
+	for _, c := range containers {
+		if c.Type != config.EmulatorAWS {
+			continue
+		}
+		if ref := latestAutosaveRef(c, lib); ref != "" {
+			return ref
+		}
+		if c.Snapshot != "" {
+			return c.Snapshot
+		}
+	}
+	return ""
+}
+
+// latestAutosaveRef returns the local: REF of c's autosaves when autosave is on
+// and one has been saved, or "". A library that can't be located or read counts
+// as having none: the start falls back to the configured snapshot rather than
+// failing.
+func latestAutosaveRef(c config.ContainerConfig, lib Library) string {
+	if !c.Autosave || lib.Dir == "" {
+		return ""
+	}
+	name := AutosaveName(c.Name())
+	if _, err := lib.Latest(name); err != nil {
+		return ""
+	}
+	return LibraryRef(name)
+}
+
+// NewAutosaveHook returns the container.StopOptions.BeforeStop hook that saves
+// the state of each AWS emulator with autosave on to lib before it is stopped.
+// baseURL resolves the emulator's address; passphrase is
+// LSTK_SNAPSHOT_PASSPHRASE, for containers that set encrypt.
+func NewAutosaveHook(exporter StateExporter, lib Library, passphrase string, baseURL func(context.Context, config.ContainerConfig) string) func(context.Context, output.Sink, config.ContainerConfig) {
+	return func(ctx context.Context, sink output.Sink, c config.ContainerConfig) {
+		if c.Type != config.EmulatorAWS || !c.Autosave {
+			return
+		}
+		name := AutosaveName(c.Name())
+		enc, err := ContainerEncryption(c, passphrase)
+		if err != nil {
+			// Never fall back to a plaintext save of state meant to be encrypted.
+			sink.Emit(output.MessageEvent{
+				Severity: output.SeverityWarning,
+				Text:     fmt.Sprintf("Could not save state to %s: %v", LibraryRef(name), err),
+			})
+			return
+		}
+		// Autosave reports its own failure; the emulator is stopped regardless.
+		_ = Autosave(ctx, exporter, baseURL(ctx, c), lib, name, c.AutosaveGenerations(), enc, sink)
+	}
+}
+
+// ContainerEncryption is the encryption c's config asks for on the saves lstk
+// makes on its own: passphrase, LSTK_SNAPSHOT_PASSPHRASE, when c sets encrypt,
+// and none otherwise.
+func ContainerEncryption(c config.ContainerConfig, passphrase string) (Encryption, error) {
+	if !c.Encrypt {
+		return Encryption{}, nil
+	}
+	if passphrase == "" {
+		return Encryption{}, errors.New("encrypt is set in the config but LSTK_SNAPSHOT_PASSPHRASE is not")
+	}
+	return Encryption{Passphrase: passphrase}, nil
+}
+
+// DefaultSaveName returns the library name `snapshot save` without a
+// destination saves the named container's state under.
+func DefaultSaveName(containerName string) string {
+	return libraryName("", containerName)
+}
+
+// libraryName joins prefix and containerName into a library snapshot name.
+// Characters a library name cannot hold become hyphens.
+func libraryName(prefix, containerName string) string {
+	return prefix + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '-'
+	}, containerName)
+}
+
+// Autosave exports the running emulator's state as a new generation of the
+// named library snapshot, then prunes the name down to its newest keep
+// generations. It runs on the stop path, so unlike SaveLibrary it does not
+// check the runtime or whether the emulator is up: the caller has just found it
+// running. The save is sealed per enc. A failed save is reported as a warning:
+// the caller is expected to stop the emulator anyway.
+func Autosave(ctx context.Context, exporter StateExporter, host string, lib Library, name string, keep int, enc Encryption, sink output.Sink) error {
+	return saveRotating(ctx, exporter, host, lib, name, nil, keep, enc, sink)
+}
+
+// saveRotating saves a new generation of the named library snapshot and prunes
+// the name down to its newest keep generations, for saves lstk makes on the
+// user's behalf rather than on request. Failures are warnings, not error
+// events: they must not end the stop or the schedule that triggered them.
+//
+// The export is written beside the library entry and only renamed into place
+// once complete, so an interrupted save (e.g. Ctrl-C) never leaves a truncated
+// generation for a later load to pick up.
+func saveRotating(ctx context.Context, exporter StateExporter, host string, lib Library, name string, services []string, keep int, enc Encryption, sink output.Sink) error {
+	dest := lib.entryPath(name, time.Now())
+	sink.Emit(output.SpinnerStart(fmt.Sprintf("Saving state to %s...", LibraryRef(name))))
+	extracted, err := writeGeneration(ctx, exporter, host, dest, services, enc)
+	sink.Emit(output.SpinnerStop())
+	if err != nil {
+		reason := err.Error()
+		if errors.Is(err, ErrSnapshotFeatureUnavailable) {
+			reason = "this LocalStack image cannot export state"
+		}
+		sink.Emit(output.MessageEvent{
+			Severity: output.SeverityWarning,
+			Text:     fmt.Sprintf("Could not save state to %s: %s", LibraryRef(name), reason),
+		})
+		return err
+	}
+
+	sink.Emit(output.LocalSnapshotSavedEvent{
+		Path:     LibraryRef(name),
+		Services: extracted,
+		Size:     fileSize(dest),
+	})
+
+	// The save itself succeeded, so failing to trim old generations is only
+	// worth a warning.
+	entries, err := lib.Entries(name)
+	if err == nil {
+		_, _, err = pruneEntries(entries, keep)
+	}
+	if err != nil {
+		sink.Emit(output.MessageEvent{
+			Severity: output.SeverityWarning,
+			Text:     fmt.Sprintf("Could not prune old generations of %s: %v", LibraryRef(name), err),
+		})
+	}
+	return nil
+}
+
+func writeGeneration(ctx context.Context, exporter StateExporter, host, dest string, services []string, enc Encryption) ([]string, error) {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return nil, fmt.Errorf("create snapshot library: %w", err)
+	}
+	// The temporary name does not end in .snapshot, so Entries skips it.
+	partial := dest + ".partial"
+	f, err := os.Create(partial)
+	if err != nil {
+		return nil, fmt.Errorf("save to %s: %w", dest, err)
+	}
+	w, err := enc.seal(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(partial)
+		return nil, err
+	}
+	extracted, err := exportWithMetadata(ctx, exporter, host, services, w)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(partial)
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("encrypt snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("save to %s: %w", dest, err)
+	}
+	if err := os.Rename(partial, dest); err != nil {
+		_ = os.Remove(partial)
+		return nil, fmt.Errorf("save to %s: %w", dest, err)
+	}
+	return extracted, nil
+}
@@ -0,0 +1,192 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAutosaveName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "autosave-localstack-aws", snapshot.AutosaveName("localstack-aws"))
+	assert.Equal(t, "autosave-ci-agent-1", snapshot.AutosaveName("ci.agent.1"))
+}
+
+func TestAutosave_KeepsNewestGenerations(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	oldest := seedLibrary(t, lib, "autosave-localstack-aws", "2026-01-01T00-00-00.000Z", nil)
+	older := seedLibrary(t, lib, "autosave-localstack-aws", "2026-02-01T00-00-00.000Z", nil)
+	other := seedLibrary(t, lib, "my-baseline", "2026-01-01T00-00-00.000Z", nil)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.Autosave(context.Background(), mockExporterReturning(t, []byte("zipdata")), "http://host", lib, "autosave-localstack-aws", 2, snapshot.Encryption{}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries("autosave-localstack-aws")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, older, entries[1].Path)
+	got, err := os.ReadFile(entries[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, "zipdata", string(got))
+	assert.NoFileExists(t, oldest)
+	assert.FileExists(t, other, "autosave only prunes its own name")
+
+	var saved []output.LocalSnapshotSavedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.LocalSnapshotSavedEvent); ok {
+			saved = append(saved, ev)
+		}
+	}
+	require.Len(t, saved, 1)
+	assert.Equal(t, "local:autosave-localstack-aws", saved[0].Path)
+}
+
+func TestAutosave_Encrypted(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	sink, _ := captureEvents(t)
+
+	err := snapshot.Autosave(context.Background(), mockExporterReturning(t, []byte("zipdata")), "http://host", lib, "autosave-localstack-aws", 1, snapshot.Encryption{Passphrase: "correct horse"}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries("autosave-localstack-aws")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	got, err := os.ReadFile(entries[0].Path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(got, []byte("age-encryption.org/v1\n")), "the autosave must be sealed with age")
+	assert.NotContains(t, string(got), "zipdata", "the plain archive must not reach disk")
+
+	var imported []byte
+	err = snapshot.LoadLibrary(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", lib, "autosave-localstack-aws", "", nil, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, "zipdata", string(imported))
+}
+
+func TestAutosave_FailureWarnsAndLeavesNoPartialGeneration(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	previous := seedLibrary(t, lib, "autosave-localstack-aws", "2026-01-01T00-00-00.000Z", nil)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.Autosave(context.Background(), mockExporterReturningError(t, errors.New("connection reset")), "http://host", lib, "autosave-localstack-aws", 1, snapshot.Encryption{}, sink)
+	require.Error(t, err)
+
+	files, err := os.ReadDir(filepath.Dir(previous))
+	require.NoError(t, err)
+	require.Len(t, files, 1, "a failed save must not leave a partial file behind")
+	assert.Equal(t, filepath.Base(previous), files[0].Name())
+
+	var warnings []string
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warnings = append(warnings, ev.Text)
+		}
+		_, saved := e.(output.LocalSnapshotSavedEvent)
+		assert.False(t, saved, "a failed save must not report success")
+	}
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Could not save state to local:autosave-localstack-aws")
+	assert.Contains(t, warnings[0], "connection reset")
+}
+
+func TestAutosaveEnabled(t *testing.T) {
+	t.Parallel()
+	assert.False(t, snapshot.AutosaveEnabled([]config.ContainerConfig{{Type: config.EmulatorAWS}}))
+	assert.False(t, snapshot.AutosaveEnabled([]config.ContainerConfig{{Type: config.EmulatorSnowflake, Autosave: true}}), "autosave is an AWS setting")
+	assert.True(t, snapshot.AutosaveEnabled([]config.ContainerConfig{{Type: config.EmulatorSnowflake}, {Type: config.EmulatorAWS, Autosave: true}}))
+}
+
+func TestConfiguredStartRef(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	aws := config.ContainerConfig{Type: config.EmulatorAWS, Tag: "latest", Snapshot: "pod:my-baseline", Autosave: true}
+
+	assert.Equal(t, "pod:my-baseline", snapshot.ConfiguredStartRef([]config.ContainerConfig{aws}, lib), "no autosave yet: fall back to the configured snapshot")
+
+	seedLibrary(t, lib, "autosave-localstack-aws", "2026-01-01T00-00-00.000Z", nil)
+	assert.Equal(t, "local:autosave-localstack-aws", snapshot.ConfiguredStartRef([]config.ContainerConfig{aws}, lib))
+	assert.Equal(t, "pod:my-baseline", snapshot.ConfiguredStartRef([]config.ContainerConfig{aws}, snapshot.Library{}), "a library that can't be located has no autosaves")
+
+	aws.Autosave = false
+	assert.Equal(t, "pod:my-baseline", snapshot.ConfiguredStartRef([]config.ContainerConfig{aws}, lib), "autosaves are ignored once autosave is off")
+
+	other := config.ContainerConfig{Type: config.EmulatorSnowflake, Snapshot: "pod:ignored"}
+	assert.Equal(t, "", snapshot.ConfiguredStartRef([]config.ContainerConfig{other}, lib), "only the AWS emulator starts from a snapshot")
+}
+
+func TestAutosaveHook(t *testing.T) {
+	t.Parallel()
+	baseURL := func(context.Context, config.ContainerConfig) string { return "http://host" }
+	aws := config.ContainerConfig{Type: config.EmulatorAWS, Tag: "latest", Autosave: true}
+
+	t.Run("saves a container with autosave on", func(t *testing.T) {
+		t.Parallel()
+		lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+		sink, _ := captureEvents(t)
+
+		snapshot.NewAutosaveHook(mockExporterReturning(t, []byte("zipdata")), lib, "", baseURL)(context.Background(), sink, aws)
+
+		entries, err := lib.Entries("autosave-localstack-aws")
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("skips a container with autosave off", func(t *testing.T) {
+		t.Parallel()
+		lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+		sink, getEvents := captureEvents(t)
+		off := aws
+		off.Autosave = false
+
+		snapshot.NewAutosaveHook(NewMockStateExporter(gomock.NewController(t)), lib, "", baseURL)(context.Background(), sink, off)
+
+		assert.NoDirExists(t, lib.Dir)
+		assert.Empty(t, getEvents())
+	})
+
+	t.Run("encrypt without a passphrase warns instead of saving in plaintext", func(t *testing.T) {
+		t.Parallel()
+		lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+		sink, getEvents := captureEvents(t)
+		encrypted := aws
+		encrypted.Encrypt = true
+
+		snapshot.NewAutosaveHook(NewMockStateExporter(gomock.NewController(t)), lib, "", baseURL)(context.Background(), sink, encrypted)
+
+		assert.NoDirExists(t, lib.Dir)
+		events := getEvents()
+		require.Len(t, events, 1)
+		msg, ok := events[0].(output.MessageEvent)
+		require.True(t, ok)
+		assert.Equal(t, output.SeverityWarning, msg.Severity)
+		assert.Contains(t, msg.Text, "LSTK_SNAPSHOT_PASSPHRASE is not")
+	})
+}
+
+func TestContainerEncryption(t *testing.T) {
+	t.Parallel()
+
+	enc, err := snapshot.ContainerEncryption(config.ContainerConfig{Type: config.EmulatorAWS}, "secret")
+	require.NoError(t, err)
+	assert.False(t, enc.Enabled(), "the passphrase alone must not turn encryption on")
+
+	enc, err = snapshot.ContainerEncryption(config.ContainerConfig{Type: config.EmulatorAWS, Encrypt: true}, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Encryption{Passphrase: "secret"}, enc)
+
+	_, err = snapshot.ContainerEncryption(config.ContainerConfig{Type: config.EmulatorAWS, Encrypt: true}, "")
+	assert.ErrorContains(t, err, "LSTK_SNAPSHOT_PASSPHRASE is not")
+}
@@ -0,0 +1,124 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+// ErrSnapshotTooNew indicates a local snapshot records a newer LocalStack
+// version than the running emulator, which cannot load state written by a
+// later release.
+var ErrSnapshotTooNew = errors.New("snapshot was saved by a newer LocalStack version")
+
+// Restarter restarts the emulator on the given image tag, so a snapshot saved
+// by another LocalStack version can be loaded into a matching one.
+type Restarter func(ctx context.Context, sink output.Sink, tag string) error
+
+// releaseRe matches the major and minor release a LocalStack version starts
+// with, e.g. "4.3" of "4.3.1.dev12" or "2026.4" of "2026.4.0".
+var releaseRe = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+type release struct{ major, minor int }
+
+func parseRelease(version string) (release, bool) {
+	m := releaseRe.FindStringSubmatch(strings.TrimSpace(version))
+	if m == nil {
+		return release{}, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return release{major, minor}, true
+}
+
+func (r release) before(o release) bool {
+	return r.major < o.major || (r.major == o.major && r.minor < o.minor)
+}
+
+// tag is the image tag that runs the release, e.g. "4.3".
+func (r release) tag() string {
+	return fmt.Sprintf("%d.%d", r.major, r.minor)
+}
+
+// checkCompatibility compares the LocalStack version the local snapshot at
+// src records with the running emulator's, before anything is uploaded; an
+// encrypted snapshot is decrypted with dec to read it. An older emulator
+// cannot load the snapshot: unless force is set, the user is offered a restart
+// on a matching image tag when restarter is set, and the load is refused
+// otherwise. A newer major release may still load it, so that only warns.
+// Snapshots that record no version, or that dec cannot open, are left to the
+// emulator to judge on import.
+func checkCompatibility(ctx context.Context, client LocalLoadClient, host, src string, dec Decryption, restarter Restarter, force bool, sink output.Sink) error {
+	meta, ok := readArchiveMetadata(src, dec)
+	if !ok {
+		return nil
+	}
+	saved, ok := parseRelease(meta.LocalStackVersion)
+	if !ok {
+		return nil
+	}
+	runningVersion, err := client.FetchVersion(ctx, host)
+	if err != nil {
+		return nil
+	}
+	running, ok := parseRelease(runningVersion)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case running.before(saved) && force:
+		sink.Emit(output.MessageEvent{
+			Severity: output.SeverityWarning,
+			Text: fmt.Sprintf("This snapshot was saved with LocalStack %s, newer than the running %s; loading it anyway as --force is set.",
+				meta.LocalStackVersion, runningVersion),
+		})
+	case running.before(saved):
+		return refuseTooNew(ctx, meta, runningVersion, saved.tag(), restarter, sink)
+	case running.major != saved.major:
+		sink.Emit(output.MessageEvent{
+			Severity: output.SeverityWarning,
+			Text: fmt.Sprintf("This snapshot was saved with LocalStack %s and the emulator runs %s; state may not load across major versions. If it fails, start LocalStack with image tag %q.",
+				meta.LocalStackVersion, runningVersion, saved.tag()),
+		})
+	}
+	return nil
+}
+
+func refuseTooNew(ctx context.Context, meta archiveMetadata, runningVersion, tag string, restarter Restarter, sink output.Sink) error {
+	if restarter != nil {
+		responseCh := make(chan output.InputResponse, 1)
+		sink.Emit(output.Confirm(
+			fmt.Sprintf("This snapshot was saved with LocalStack %s, newer than the running %s. Restart LocalStack on image tag %q to load it? Unsaved state will be lost", meta.LocalStackVersion, runningVersion, tag),
+			output.DefaultNo,
+			responseCh,
+		))
+		select {
+		case resp := <-responseCh:
+			if !resp.Cancelled && resp.SelectedKey == output.KeyYes {
+				return restarter(ctx, sink, tag)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	summary := fmt.Sprintf("It was saved with LocalStack %s, and the running emulator is %s", meta.LocalStackVersion, runningVersion)
+	if len(meta.Services) > 0 {
+		summary = fmt.Sprintf("Its %s state was saved with LocalStack %s, and the running emulator is %s", strings.Join(meta.Services, ", "), meta.LocalStackVersion, runningVersion)
+	}
+	sink.Emit(output.ErrorEvent{
+		Title:   "Snapshot requires a newer LocalStack version",
+		Summary: summary,
+		Actions: []output.ErrorAction{
+			{Label: "Start a matching emulator:", Value: fmt.Sprintf("set tag = %q in your config file, then run lstk restart", tag)},
+			{Label: "Try loading it anyway:", Value: "re-run the load with --force"},
+		},
+	})
+	return output.NewSilentError(fmt.Errorf("%w: saved with %s, running %s", ErrSnapshotTooNew, meta.LocalStackVersion, runningVersion))
+}
@@ -0,0 +1,186 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// savedBy is the archive comment of a snapshot of the s3 service saved by
+// LocalStack version.
+func savedBy(version string) string {
+	return `{"localstack_version":"` + version + `","services":["s3"]}`
+}
+
+func mockClientRunning(t *testing.T, version string, imports int) *MockLocalLoadClient {
+	t.Helper()
+	m := NewMockLocalLoadClient(gomock.NewController(t))
+	m.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return(version, nil)
+	m.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(imports)
+	return m
+}
+
+// answering returns a sink that answers every prompt with key, recording the
+// events it sees.
+func answering(key string) (output.Sink, func() []output.Event) {
+	var events []output.Event
+	sink := output.SinkFunc(func(event output.Event) {
+		events = append(events, event)
+		if req, ok := event.(output.UserInputRequestEvent); ok {
+			req.ResponseCh() <- output.InputResponse{SelectedKey: key}
+		}
+	})
+	return sink, func() []output.Event { return events }
+}
+
+func TestLoadLocal_SnapshotFromNewerVersionIsRefused(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.5.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.3.1", 0)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.ErrorIs(t, err, snapshot.ErrSnapshotTooNew)
+	assert.True(t, output.IsSilent(err))
+
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	assert.Equal(t, "Snapshot requires a newer LocalStack version", errEvent.Title)
+	assert.Equal(t, "Its s3 state was saved with LocalStack 4.5.0, and the running emulator is 4.3.1", errEvent.Summary)
+	require.Len(t, errEvent.Actions, 2)
+	assert.Equal(t, `set tag = "4.5" in your config file, then run lstk restart`, errEvent.Actions[0].Value)
+	assert.Equal(t, "re-run the load with --force", errEvent.Actions[1].Value)
+}
+
+func TestLoadLocal_SnapshotFromNewerVersionLoadsWhenForced(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.5.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.3.1", 1)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, true, sink)
+	require.NoError(t, err)
+
+	var warnings []string
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warnings = append(warnings, ev.Text)
+		}
+	}
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "saved with LocalStack 4.5.0, newer than the running 4.3.1")
+}
+
+func TestLoadLocal_EncryptedSnapshotFromNewerVersionIsRefused(t *testing.T) {
+	t.Parallel()
+	plain := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.5.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	archive, err := os.ReadFile(plain)
+	require.NoError(t, err)
+	recipient, err := age.NewScryptRecipient("correct horse")
+	require.NoError(t, err)
+	recipient.SetWorkFactor(10)
+	var sealed bytes.Buffer
+	w, err := age.Encrypt(&sealed, recipient)
+	require.NoError(t, err)
+	_, err = w.Write(archive)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	src := filepath.Join(t.TempDir(), "sealed.snapshot")
+	require.NoError(t, os.WriteFile(src, sealed.Bytes(), 0o600))
+
+	client := mockClientRunning(t, "4.3.1", 0)
+	sink, _ := captureEvents(t)
+	err = snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.ErrorIs(t, err, snapshot.ErrSnapshotTooNew)
+}
+
+func TestLoadLocal_SnapshotFromNewerVersionRestartsOnMatchingTag(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.5.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.3.1", 1)
+	sink, getEvents := answering(output.KeyYes)
+
+	var restartedOn string
+	restarter := func(_ context.Context, _ output.Sink, tag string) error {
+		restartedOn = tag
+		return nil
+	}
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, restarter, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, "4.5", restartedOn)
+
+	var loaded []output.SnapshotLoadedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.SnapshotLoadedEvent); ok {
+			loaded = append(loaded, ev)
+		}
+	}
+	require.Len(t, loaded, 1)
+	assert.Equal(t, []string{"s3"}, loaded[0].Services)
+}
+
+func TestLoadLocal_SnapshotFromNewerVersionRestartDeclined(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.5.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.3.1", 0)
+	sink, _ := answering(output.KeyNo)
+
+	restarter := func(context.Context, output.Sink, string) error {
+		t.Fatal("restarter must not run when the prompt is declined")
+		return nil
+	}
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, restarter, false, sink)
+	require.ErrorIs(t, err, snapshot.ErrSnapshotTooNew)
+}
+
+func TestLoadLocal_SnapshotFromOlderMajorVersionWarns(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("3.8.1"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.3.1", 1)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+
+	var warnings []string
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warnings = append(warnings, ev.Text)
+		}
+	}
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "saved with LocalStack 3.8.1")
+	assert.Contains(t, warnings[0], `image tag "3.8"`)
+}
+
+func TestLoadLocal_SnapshotFromSameMajorVersionLoadsQuietly(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", savedBy("4.3.0"), map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "a"})
+	client := mockClientRunning(t, "4.4.0.dev12", 1)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok {
+			assert.NotEqual(t, output.SeverityWarning, ev.Severity, "unexpected warning: %s", ev.Text)
+		}
+	}
+}
@@ -12,14 +12,15 @@ import (
 	"time"
 
 	"github.com/localstack/lstk/internal/validate"
+	"oras.land/oras-go/v2/registry"
 )
 
 // ErrHomeNotSet is returned when a path needs "~" expansion but no home directory was provided.
 var ErrHomeNotSet = errors.New("home directory is not set")
 
 var (
-	// ErrRemoteNotSupported is returned for remote schemes that are not yet
-	// implemented (e.g. oras://). S3 (s3://) is supported.
+	// ErrRemoteNotSupported is returned where a remote (s3:// or oras://) is not
+	// yet supported by a command; save, load and list support both.
 	ErrRemoteNotSupported = errors.New("remote destinations are not yet supported — coming soon")
 	// ErrUnknownScheme is returned for unrecognized URL schemes.
 	ErrUnknownScheme = errors.New("unrecognized destination scheme")
@@ -31,10 +32,11 @@ var (
 	// show, remove, versions, and S3 remotes).
 	ErrPodVersionNotSupported = errors.New("a specific snapshot version is not supported here")
 	// ErrVersionsRemoteUnsupported is returned when `snapshot versions` is given an
-	// s3:// ref. Version history comes from the LocalStack platform, which tracks it
-	// for pod: snapshots only, so this is a scope limit of the command rather than a
-	// missing feature — hence not ErrRemoteNotSupported's "coming soon" wording.
-	ErrVersionsRemoteUnsupported = errors.New("snapshot versions is only supported for Cloud Pods (pod: refs), not S3 remotes")
+	// s3:// or oras:// ref. Version history comes from the LocalStack platform, which
+	// tracks it for pod: snapshots only, so this is a scope limit of the command
+	// rather than a missing feature — hence not ErrRemoteNotSupported's "coming
+	// soon" wording.
+	ErrVersionsRemoteUnsupported = errors.New("snapshot versions is only supported for Cloud Pods (pod: refs), not S3 remotes or OCI registries (list the tags with lstk snapshot list oras://...)")
 )
 
 const (
@@ -58,6 +60,8 @@ const (
 	KindLocal DestinationKind = iota
 	KindPod
 	KindS3
+	KindOCI
+	KindLibrary
 )
 
 // Destination is the parsed result of a user-supplied snapshot destination.
@@ -65,13 +69,17 @@ const (
 // For KindPod, Value is the validated pod name (without the "pod:" prefix).
 // For KindS3, Value is the validated s3:// URL (bucket + optional key prefix), with
 // no credential query params — credentials are supplied separately at runtime.
+// For KindOCI, Value is the registry/repository:tag reference without the
+// oras:// prefix; credentials come from the Docker credential store at runtime.
+// For KindLibrary, Value is the validated name of a snapshot in the local
+// library (without the "local:" prefix).
 type Destination struct {
 	Kind  DestinationKind
 	Value string
 	// Version is the requested version of a KindPod snapshot; 0 means "latest".
 	// It is only ever non-zero for KindPod: local paths are never split on ":"
-	// (that would break Windows drive letters) and S3 remotes have no version
-	// addressing.
+	// (that would break Windows drive letters), S3 remotes have no version
+	// addressing and OCI references carry a tag instead.
 	Version int
 }
 
@@ -81,6 +89,11 @@ func IsS3Ref(ref string) bool {
 	return strings.HasPrefix(strings.ToLower(ref), "s3://")
 }
 
+// IsOCIRef reports whether ref is an oras:// reference to an OCI registry.
+func IsOCIRef(ref string) bool {
+	return strings.HasPrefix(strings.ToLower(ref), "oras://")
+}
+
 // ValidatePodName validates a user-supplied pod name (the identity of a snapshot
 // on a remote), using the same rules as pod: refs.
 func ValidatePodName(name string) error {
@@ -158,6 +171,18 @@ func parsePodRef(ref string, allowVersion bool) (Destination, error) {
 	return Destination{Kind: KindPod, Value: name, Version: version}, nil
 }
 
+// parseLibraryRef parses a "local:NAME" ref to a snapshot in the local library.
+// Names follow the pod name rules, which also keeps them safe as directory
+// names. Library snapshots have no version addressing: load and show always use
+// the newest save.
+func parseLibraryRef(ref string) (Destination, error) {
+	name := ref[len("local:"):]
+	if err := validate.PodName(name); err != nil {
+		return Destination{}, fmt.Errorf("invalid local snapshot name %q: %w", name, err)
+	}
+	return Destination{Kind: KindLibrary, Value: name}, nil
+}
+
 // DefaultRemotePodName generates a timestamped pod name used when saving to a
 // remote without an explicit name, mirroring local snapshot auto-naming.
 func DefaultRemotePodName(now time.Time) string {
@@ -185,6 +210,41 @@ func parseS3(ref string) (Destination, error) {
 	return Destination{Kind: KindS3, Value: ref}, nil
 }
 
+// parseOCI validates an oras:// reference and returns it as a KindOCI
+// destination. A reference without a tag or digest gets the "latest" tag, as
+// container images do. forSave rejects a digest, which only ever names
+// existing content.
+func parseOCI(ref string, forSave bool) (Destination, error) {
+	parsed, err := registry.ParseReference(ref[len("oras://"):])
+	if err != nil {
+		return Destination{}, fmt.Errorf("invalid oras:// reference %q: %w", ref, err)
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = defaultOCITag
+	}
+	if forSave && strings.Contains(parsed.Reference, ":") {
+		return Destination{}, fmt.Errorf("cannot save to a digest in %q: use a tag, e.g. oras://%s/%s:v1", ref, parsed.Registry, parsed.Repository)
+	}
+	return Destination{Kind: KindOCI, Value: parsed.String()}, nil
+}
+
+// ParseOCIRepository validates an oras:// reference to a repository, as
+// `snapshot list` takes, and returns it without the prefix. A tag is rejected
+// since list reports every tag.
+func ParseOCIRepository(ref string) (string, error) {
+	if !IsOCIRef(ref) {
+		return "", fmt.Errorf("%q is not an oras:// reference", ref)
+	}
+	parsed, err := registry.ParseReference(ref[len("oras://"):])
+	if err != nil {
+		return "", fmt.Errorf("invalid oras:// reference %q: %w", ref, err)
+	}
+	if parsed.Reference != "" {
+		return "", fmt.Errorf("drop the tag from %q: snapshot list lists every tag of oras://%s/%s", ref, parsed.Registry, parsed.Repository)
+	}
+	return parsed.Registry + "/" + parsed.Repository, nil
+}
+
 // ParseRemovable parses a ref for snapshot remove. Only cloud (pod:) refs are accepted;
 // local file paths are rejected because the CLI cannot delete local files.
 // A ":<version>" suffix is rejected: remove deletes the whole pod, so honouring
@@ -194,13 +254,21 @@ func ParseRemovable(ref, cwd, home string) (Destination, error) {
 	return parseCloudOnly(ref, cwd, home, "delete local files", false)
 }
 
-// ParseShowable parses a ref for snapshot show. Only cloud (pod:) refs are accepted;
-// local file paths are rejected because show only inspects cloud snapshots.
-// A ":<version>" suffix is allowed — show is read-only and every field it renders
-// is per-version in the platform response.
+// ParseShowable parses a ref for snapshot show. Cloud (pod:) refs, local
+// library (local:) refs and local snapshot files are accepted; a file must
+// exist, resolved as for load. S3 and OCI remotes are rejected.
+// A ":<version>" suffix is allowed on pod refs — show is read-only and every
+// field it renders is per-version in the platform response.
 // cwd and home are used to produce a human-readable path in error messages.
 func ParseShowable(ref, cwd, home string) (Destination, error) {
-	return parseCloudOnly(ref, cwd, home, "show local snapshots", true)
+	lower := strings.ToLower(ref)
+	if strings.HasPrefix(lower, "local:") {
+		return parseLibraryRef(ref)
+	}
+	if !strings.HasPrefix(lower, "pod:") && !strings.Contains(lower, "://") {
+		return ParseSource(ref, home)
+	}
+	return parseCloudOnly(ref, cwd, home, "show this snapshot", true)
 }
 
 // parseCloudOnly validates that ref is a cloud (pod:) reference, rejecting local
@@ -210,7 +278,7 @@ func ParseShowable(ref, cwd, home string) (Destination, error) {
 // fail loudly rather than silently widen the operation.
 func parseCloudOnly(ref, cwd, home, action string, allowVersion bool) (Destination, error) {
 	lower := strings.ToLower(ref)
-	if !strings.HasPrefix(lower, "pod:") && !strings.Contains(lower, "://") {
+	if !strings.HasPrefix(lower, "pod:") && !strings.HasPrefix(lower, "local:") && !strings.Contains(lower, "://") {
 		abs, _ := filepath.Abs(ref)
 		abs = withSnapshotExt(abs)
 		return Destination{}, fmt.Errorf("'%s' resolves to a local file (%s); CLI cannot %s", ref, displayPath(abs, cwd, home), action)
@@ -219,8 +287,11 @@ func parseCloudOnly(ref, cwd, home, action string, allowVersion bool) (Destinati
 	if err != nil {
 		return Destination{}, err
 	}
-	// remove/show/versions are cloud (pod:) only; S3 remotes are not yet supported here.
-	if dest.Kind == KindS3 {
+	if dest.Kind == KindLibrary {
+		return Destination{}, fmt.Errorf("'%s' is a local snapshot; CLI cannot %s — see its saves with lstk snapshot list --local and delete old ones with lstk snapshot prune", ref, action)
+	}
+	// remove/show/versions are cloud (pod:) only; S3 and OCI remotes are not yet supported here.
+	if dest.Kind == KindS3 || dest.Kind == KindOCI {
 		return Destination{}, ErrRemoteNotSupported
 	}
 	// Where a version cannot be honoured it must fail loudly rather than be
@@ -236,13 +307,12 @@ func parseCloudOnly(ref, cwd, home, action string, allowVersion bool) (Destinati
 // accepted. A ":<version>" suffix is rejected because this command lists every
 // version.
 //
-// An s3:// ref gets its own error rather than parseCloudOnly's
-// ErrRemoteNotSupported: that sentinel says "coming soon", which is right for
-// oras:// (unimplemented everywhere) but misleading here, since S3 remotes are
-// fully supported by save/load/list and it is only version history that is
-// pod-only.
+// An s3:// or oras:// ref gets its own error rather than parseCloudOnly's
+// ErrRemoteNotSupported: that sentinel says "coming soon", which is misleading
+// here, since both remotes are fully supported by save/load/list and it is only
+// version history that is pod-only.
 func ParseVersionable(ref, cwd, home string) (Destination, error) {
-	if IsS3Ref(ref) {
+	if IsS3Ref(ref) || IsOCIRef(ref) {
 		return Destination{}, ErrVersionsRemoteUnsupported
 	}
 	return parseCloudOnly(ref, cwd, home, "list versions of local snapshots", false)
@@ -281,10 +351,12 @@ func ParseSource(ref, home string) (Destination, error) {
 		return Destination{}, fmt.Errorf("'%s' is not a valid reference. Aliases use a single colon. Did you mean:\npod:%s", ref, podName)
 	case strings.HasPrefix(lower, "pod:"):
 		return parsePodRef(ref, true)
+	case strings.HasPrefix(lower, "local:"):
+		return parseLibraryRef(ref)
 	case strings.HasPrefix(lower, "s3://"):
 		return parseS3(ref)
 	case strings.HasPrefix(lower, "oras://"):
-		return Destination{}, ErrRemoteNotSupported
+		return parseOCI(ref, false)
 	case strings.Contains(lower, "://"):
 		scheme, _, _ := strings.Cut(ref, "://")
 		return Destination{}, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme+"://")
@@ -339,29 +411,32 @@ func resolveSourcePath(abs string) (string, error) {
 	return "", fmt.Errorf("snapshot file not found: %q (also tried %q and %q)", abs, withSnapshot, withZip)
 }
 
-// ParseDestination resolves a user-supplied destination to a local path (KindLocal) or validated pod name (KindPod).
+// ParseDestination resolves a user-supplied destination to a local path (KindLocal),
+// library name (KindLibrary), validated pod name (KindPod), s3:// URL (KindS3) or
+// oras:// reference (KindOCI). An empty dest is the local library under the
+// target emulator's default name, which SaveLibrary fills in, so the Value is
+// empty; a directory gets a timestamped file inside it.
 // home is used to expand a leading "~" or "~/"; pass "" to disable tilde expansion.
 func ParseDestination(dest, home string, now time.Time) (Destination, error) {
 	if dest == "" {
-		b := make([]byte, 2)
-		_, _ = rand.Read(b)
-		dest = "./" + now.UTC().Format("snapshot-2006-01-02T15-04-05") + "-" + fmt.Sprintf("%x", b)[:3]
-	} else {
-		lower := strings.ToLower(dest)
-		switch {
-		case strings.HasPrefix(lower, "pod://"):
-			podName := dest[len("pod://"):]
-			return Destination{}, fmt.Errorf("'%s' is not a valid reference. Aliases use a single colon. Did you mean:\npod:%s", dest, podName)
-		case strings.HasPrefix(lower, "pod:"):
-			return parsePodRef(dest, false)
-		case strings.HasPrefix(lower, "s3://"):
-			return parseS3(dest)
-		case strings.HasPrefix(lower, "oras://"):
-			return Destination{}, ErrRemoteNotSupported
-		case strings.Contains(lower, "://"):
-			scheme, _, _ := strings.Cut(dest, "://")
-			return Destination{}, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme+"://")
-		}
+		return Destination{Kind: KindLibrary}, nil
+	}
+	lower := strings.ToLower(dest)
+	switch {
+	case strings.HasPrefix(lower, "pod://"):
+		podName := dest[len("pod://"):]
+		return Destination{}, fmt.Errorf("'%s' is not a valid reference. Aliases use a single colon. Did you mean:\npod:%s", dest, podName)
+	case strings.HasPrefix(lower, "pod:"):
+		return parsePodRef(dest, false)
+	case strings.HasPrefix(lower, "local:"):
+		return parseLibraryRef(dest)
+	case strings.HasPrefix(lower, "s3://"):
+		return parseS3(dest)
+	case strings.HasPrefix(lower, "oras://"):
+		return parseOCI(dest, true)
+	case strings.Contains(lower, "://"):
+		scheme, _, _ := strings.Cut(dest, "://")
+		return Destination{}, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme+"://")
 	}
 
 	if dest == "~" || strings.HasPrefix(dest, "~/") || strings.HasPrefix(dest, `~\`) {
@@ -389,7 +464,9 @@ func ParseDestination(dest, home string, now time.Time) (Destination, error) {
 	}
 
 	if info, err := os.Stat(abs); err == nil && info.IsDir() {
-		return Destination{}, fmt.Errorf("%q is a directory — specify a file path like ./my-snapshot", abs)
+		b := make([]byte, 2)
+		_, _ = rand.Read(b)
+		abs = filepath.Join(abs, now.UTC().Format("snapshot-2006-01-02T15-04-05")+"-"+fmt.Sprintf("%x", b)[:3])
 	}
 
 	abs = withSnapshotExt(abs)
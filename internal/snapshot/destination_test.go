@@ -195,14 +195,14 @@ func TestParseVersionable(t *testing.T) {
 		assert.Contains(t, err.Error(), "S3 remotes")
 	})
 
-	// oras:// is unimplemented for every command, so "coming soon" is accurate
-	// there and the two errors must stay distinct.
-	t.Run("keeps coming-soon wording for oras", func(t *testing.T) {
+	// OCI tags take the place of versions; the error points at listing them.
+	t.Run("rejects oras remote with a versions-specific message", func(t *testing.T) {
 		t.Parallel()
 		_, err := snapshot.ParseVersionable("oras://registry/image", cwd, home)
 		require.Error(t, err)
-		assert.ErrorIs(t, err, snapshot.ErrRemoteNotSupported)
-		assert.NotErrorIs(t, err, snapshot.ErrVersionsRemoteUnsupported)
+		assert.ErrorIs(t, err, snapshot.ErrVersionsRemoteUnsupported)
+		assert.NotErrorIs(t, err, snapshot.ErrRemoteNotSupported)
+		assert.Contains(t, err.Error(), "lstk snapshot list oras://")
 	})
 
 	t.Run("rejects invalid pod name", func(t *testing.T) {
@@ -265,11 +265,27 @@ func TestParseShowable(t *testing.T) {
 		assert.Equal(t, "my-baseline", dest.Value)
 	})
 
-	t.Run("rejects local path", func(t *testing.T) {
+	t.Run("accepts existing local file", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "my-snapshot.snapshot")
+		require.NoError(t, os.WriteFile(path, []byte("zip"), 0o600))
+		dest, err := snapshot.ParseShowable(filepath.Join(dir, "my-snapshot"), cwd, home)
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.Destination{Kind: snapshot.KindLocal, Value: path}, dest)
+	})
+
+	t.Run("rejects missing local file", func(t *testing.T) {
 		t.Parallel()
-		_, err := snapshot.ParseShowable("./my-snapshot", cwd, home)
+		_, err := snapshot.ParseShowable(filepath.Join(t.TempDir(), "my-snapshot"), cwd, home)
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "show local snapshots")
+		assert.Contains(t, err.Error(), "snapshot file not found")
+	})
+
+	t.Run("rejects s3 remote", func(t *testing.T) {
+		t.Parallel()
+		_, err := snapshot.ParseShowable("s3://bucket/prefix", cwd, home)
+		require.ErrorIs(t, err, snapshot.ErrRemoteNotSupported)
 	})
 
 	t.Run("rejects invalid pod name", func(t *testing.T) {
@@ -278,6 +294,45 @@ func TestParseShowable(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid pod name")
 	})
+
+	t.Run("accepts local library ref", func(t *testing.T) {
+		t.Parallel()
+		dest, err := snapshot.ParseShowable("local:my-baseline", cwd, home)
+		require.NoError(t, err)
+		assert.Equal(t, snapshot.KindLibrary, dest.Kind)
+		assert.Equal(t, "my-baseline", dest.Value)
+	})
+}
+
+func TestParseLibraryRef(t *testing.T) {
+	t.Parallel()
+	home := t.TempDir()
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	src, err := snapshot.ParseSource("local:my-baseline", home)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Destination{Kind: snapshot.KindLibrary, Value: "my-baseline"}, src)
+
+	dest, err := snapshot.ParseDestination("local:my-baseline", home, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Destination{Kind: snapshot.KindLibrary, Value: "my-baseline"}, dest)
+
+	// The name becomes a directory in the library, so path separators and
+	// dot segments must never get through.
+	for _, ref := range []string{"local:", "local:../escape", "local:a/b", "local:my-baseline:3"} {
+		_, err := snapshot.ParseDestination(ref, home, time.Now())
+		assert.Error(t, err, ref)
+	}
+
+	for name, parse := range map[string]func(string, string, string) (snapshot.Destination, error){
+		"remove":   snapshot.ParseRemovable,
+		"versions": snapshot.ParseVersionable,
+	} {
+		_, err := parse("local:my-baseline", cwd, home)
+		require.Error(t, err, name)
+		assert.Contains(t, err.Error(), "lstk snapshot prune", name)
+	}
 }
 
 func TestParseSource(t *testing.T) {
@@ -309,7 +364,6 @@ func TestParseSource(t *testing.T) {
 		wantPath      string
 		wantPodName   string
 		wantErr       string
-		wantRemoteErr bool
 		wantSchemeErr bool
 	}
 
@@ -443,9 +497,27 @@ func TestParseSource(t *testing.T) {
 			wantErr: "missing bucket",
 		},
 		{
-			name:          "oras:// not supported",
-			input:         "oras://registry/image",
-			wantRemoteErr: true,
+			name:     "oras:// is an OCI remote, tagged latest by default",
+			input:    "oras://registry.example.com/team/image",
+			wantKind: snapshot.KindOCI,
+			wantPath: "registry.example.com/team/image:latest",
+		},
+		{
+			name:     "oras:// keeps an explicit tag",
+			input:    "oras://localhost:5000/image:v3",
+			wantKind: snapshot.KindOCI,
+			wantPath: "localhost:5000/image:v3",
+		},
+		{
+			name:     "oras:// accepts a digest",
+			input:    "oras://registry/image@sha256:" + strings.Repeat("a", 64),
+			wantKind: snapshot.KindOCI,
+			wantPath: "registry/image@sha256:" + strings.Repeat("a", 64),
+		},
+		{
+			name:    "oras:// requires a repository",
+			input:   "oras://registry",
+			wantErr: "invalid oras:// reference",
 		},
 		{
 			name:          "unknown scheme",
@@ -492,10 +564,6 @@ func TestParseSource(t *testing.T) {
 				assert.Contains(t, err.Error(), tc.wantErr)
 				return
 			}
-			if tc.wantRemoteErr {
-				require.ErrorIs(t, err, snapshot.ErrRemoteNotSupported)
-				return
-			}
 			if tc.wantSchemeErr {
 				require.ErrorIs(t, err, snapshot.ErrUnknownScheme)
 				return
@@ -619,15 +687,20 @@ func TestParseDestination(t *testing.T) {
 		wantPodName    string
 		wantPathRegexp string // used instead of wantPath when the result contains a random component
 		wantErr        string
-		wantRemoteErr  bool
 		wantSchemeErr  bool
 	}
 
 	tests := []testCase{
 		// --- default (empty input) ---
 		{
-			name:           "default",
-			input:          "",
+			name:     "default is the library",
+			input:    "",
+			wantKind: snapshot.KindLibrary,
+			wantPath: "",
+		},
+		{
+			name:           "directory gets a timestamped file",
+			input:          ".",
 			wantKind:       snapshot.KindLocal,
 			wantPathRegexp: regexp.QuoteMeta(filepath.Join(wd, "snapshot-2026-05-11T21-04-32-")) + `[0-9a-f]{3}\.snapshot`,
 		},
@@ -644,8 +717,9 @@ func TestParseDestination(t *testing.T) {
 			wantPath: filepath.Join(os.TempDir(), "state.snapshot"),
 		},
 		{
-			input:   "~",
-			wantErr: "is a directory",
+			input:          "~",
+			wantKind:       snapshot.KindLocal,
+			wantPathRegexp: regexp.QuoteMeta(filepath.Join(home, "snapshot-2026-05-11T21-04-32-")) + `[0-9a-f]{3}\.snapshot`,
 		},
 		{
 			// parent (~/) always exists
@@ -716,12 +790,24 @@ func TestParseDestination(t *testing.T) {
 
 		// --- remote: oras ---
 		{
-			input:         "oras://registry/image",
-			wantRemoteErr: true,
+			input:    "oras://registry/image",
+			wantKind: snapshot.KindOCI,
+			wantPath: "registry/image:latest",
 		},
 		{
-			input:         "ORAS://registry/image",
-			wantRemoteErr: true,
+			input:    "ORAS://registry/image:v3",
+			wantKind: snapshot.KindOCI,
+			wantPath: "registry/image:v3",
+		},
+		{
+			name:    "oras:// rejects a digest",
+			input:   "oras://registry/image@sha256:" + strings.Repeat("a", 64),
+			wantErr: "cannot save to a digest",
+		},
+		{
+			name:    "oras:// rejects an uppercase repository",
+			input:   "oras://registry/Image",
+			wantErr: "invalid oras:// reference",
 		},
 
 		// --- pod destinations ---
@@ -832,10 +918,6 @@ func TestParseDestination(t *testing.T) {
 				assert.Contains(t, err.Error(), tc.wantErr)
 				return
 			}
-			if tc.wantRemoteErr {
-				require.ErrorIs(t, err, snapshot.ErrRemoteNotSupported)
-				return
-			}
 			if tc.wantSchemeErr {
 				require.ErrorIs(t, err, snapshot.ErrUnknownScheme)
 				return
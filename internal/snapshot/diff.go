@@ -37,25 +37,8 @@ func DiffPod(ctx context.Context, rt runtime.Runtime, containers []config.Contai
 		return fmt.Errorf("pod snapshots require authentication — set LOCALSTACK_AUTH_TOKEN or run %q", "lstk login")
 	}
 
-	if err := rt.IsHealthy(ctx); err != nil {
-		rt.EmitUnhealthyError(sink, err)
-		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
-	}
-
-	runningContainers, err := container.RunningEmulators(ctx, rt, containers)
-	if err != nil {
-		return fmt.Errorf("checking emulator status: %w", err)
-	}
-
-	if len(runningContainers) == 0 {
-		sink.Emit(output.ErrorEvent{
-			Title: "LocalStack is not running",
-			Actions: []output.ErrorAction{
-				{Label: "Start LocalStack:", Value: "lstk"},
-				{Label: "See help:", Value: "lstk -h"},
-			},
-		})
-		return output.NewSilentError(fmt.Errorf("LocalStack is not running"))
+	if err := requireRunning(ctx, rt, containers, sink); err != nil {
+		return err
 	}
 
 	spinnerText := fmt.Sprintf("Checking diff for pod %q...", podName)
@@ -100,3 +83,29 @@ func DiffPod(ctx context.Context, rt runtime.Runtime, containers []config.Contai
 	})
 	return nil
 }
+
+// requireRunning fails unless an emulator in containers is running. A diff
+// compares against the running state, so unlike load it never auto-starts one.
+func requireRunning(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, sink output.Sink) error {
+	if err := rt.IsHealthy(ctx); err != nil {
+		rt.EmitUnhealthyError(sink, err)
+		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
+	}
+
+	runningContainers, err := container.RunningEmulators(ctx, rt, containers)
+	if err != nil {
+		return fmt.Errorf("checking emulator status: %w", err)
+	}
+
+	if len(runningContainers) == 0 {
+		sink.Emit(output.ErrorEvent{
+			Title: "LocalStack is not running",
+			Actions: []output.ErrorAction{
+				{Label: "Start LocalStack:", Value: "lstk"},
+				{Label: "See help:", Value: "lstk -h"},
+			},
+		})
+		return output.NewSilentError(fmt.Errorf("LocalStack is not running"))
+	}
+	return nil
+}
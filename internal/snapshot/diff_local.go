@@ -0,0 +1,213 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// runningStateLabel names the emulator's side of a DiffLocalRunning diff.
+const runningStateLabel = "running state"
+
+var (
+	accountIDSegment = regexp.MustCompile(`^\d{12}$`)
+	regionSegment    = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+)
+
+// DiffLocal compares two local snapshot files without contacting the emulator
+// or the platform, and emits a SnapshotDiffEvent with what target adds to or
+// changes from base, per service.
+func DiffLocal(base, target string, sink output.Sink) error {
+	cwd, _ := os.Getwd()
+	home, _ := os.UserHomeDir()
+
+	baseName, targetName := displayPath(base, cwd, home), displayPath(target, cwd, home)
+
+	baseEntries, err := readArchiveEntries(base)
+	if err != nil {
+		return emitDiffInvalidFile(baseName, err, sink)
+	}
+	targetEntries, err := readArchiveEntries(target)
+	if err != nil {
+		return emitDiffInvalidFile(targetName, err, sink)
+	}
+	sink.Emit(output.SnapshotDiffEvent{
+		Base:     baseName,
+		Target:   targetName,
+		Services: diffEntries(baseEntries, targetEntries),
+	})
+	return nil
+}
+
+// DiffLocalRunning compares the local snapshot file src against the running
+// emulator's current state, exported the same way a local save does. As with a
+// pod dry-run, additions and modifications are what src would bring to the
+// running state.
+func DiffLocalRunning(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, host, src string, sink output.Sink) error {
+	cwd, _ := os.Getwd()
+	home, _ := os.UserHomeDir()
+
+	if err := requireRunning(ctx, rt, containers, sink); err != nil {
+		return err
+	}
+	srcName := displayPath(src, cwd, home)
+	srcEntries, err := readArchiveEntries(src)
+	if err != nil {
+		return emitDiffInvalidFile(srcName, err, sink)
+	}
+
+	sink.Emit(output.SpinnerStart("Exporting running state..."))
+	runningEntries, err := exportArchiveEntries(ctx, exporter, host)
+	sink.Emit(output.SpinnerStop())
+	if err != nil {
+		if errors.Is(err, ErrSnapshotFeatureUnavailable) {
+			return emitFeatureUnavailableError(sink)
+		}
+		return err
+	}
+
+	sink.Emit(output.SnapshotDiffEvent{
+		Base:     runningStateLabel,
+		Target:   srcName,
+		Services: diffEntries(runningEntries, srcEntries),
+	})
+	return nil
+}
+
+func emitDiffInvalidFile(name string, err error, sink output.Sink) error {
+	sink.Emit(output.ErrorEvent{
+		Title:   "Could not diff snapshots",
+		Summary: fmt.Sprintf("%s: %v", name, err),
+	})
+	return output.NewSilentError(err)
+}
+
+// archiveEntry is what a diff compares of a file in a snapshot archive. The
+// zip directory records both, so comparing needs no decompression.
+type archiveEntry struct {
+	crc32 uint32
+	size  uint64
+}
+
+// readArchiveEntries lists the files in the snapshot archive at path.
+func readArchiveEntries(path string) (map[string]archiveEntry, error) {
+	r, err := openArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	entries := make(map[string]archiveEntry, len(r.File))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		entries[f.Name] = archiveEntry{crc32: f.CRC32, size: f.UncompressedSize64}
+	}
+	return entries, nil
+}
+
+// openArchive opens the snapshot archive at path for reading its directory. An
+// encrypted snapshot is reported as such rather than as an invalid file.
+func openArchive(path string) (*zip.ReadCloser, error) {
+	r, err := zip.OpenReader(path)
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+	if err != nil {
+		if isSealed(path) {
+			return nil, ErrSnapshotKeyRequired
+		}
+		return nil, ErrInvalidSnapshotFile
+	}
+	return r, nil
+}
+
+// exportArchiveEntries exports the running state to a temporary file and lists
+// the files in it.
+func exportArchiveEntries(ctx context.Context, exporter StateExporter, host string) (map[string]archiveEntry, error) {
+	f, err := os.CreateTemp("", "lstk-snapshot-*"+snapshotExt)
+	if err != nil {
+		return nil, fmt.Errorf("create temporary file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	if _, err := exporter.ExportState(ctx, host, nil, f); err != nil {
+		if errors.Is(err, ErrSnapshotFeatureUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("export state from LocalStack: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("write exported state: %w", err)
+	}
+	entries, err := readArchiveEntries(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("read exported state: %w", err)
+	}
+	return entries, nil
+}
+
+// diffEntries counts, per service, the files target adds to base and the files
+// it changes. A file only in base counts as a modification of its service too:
+// that service's state differs even though nothing was added.
+func diffEntries(base, target map[string]archiveEntry) map[string]output.SnapshotDiffServiceResult {
+	services := map[string]output.SnapshotDiffServiceResult{}
+	for name, t := range target {
+		service := archiveEntryService(name)
+		if service == "" {
+			continue
+		}
+		b, ok := base[name]
+		if ok && b == t {
+			continue
+		}
+		counts := services[service]
+		if ok {
+			counts.Modifications++
+		} else {
+			counts.Additions++
+		}
+		services[service] = counts
+	}
+	for name := range base {
+		service := archiveEntryService(name)
+		if _, ok := target[name]; ok || service == "" {
+			continue
+		}
+		counts := services[service]
+		counts.Modifications++
+		services[service] = counts
+	}
+	return services
+}
+
+// archiveEntryService returns the service a snapshot archive file belongs to,
+// or "" for archive metadata at the top level. Service state sits below a
+// top-level directory (e.g. api_states/ or assets/), possibly nested under an
+// account ID and region, so the service is the first segment below the
+// top-level directory that is neither.
+func archiveEntryService(name string) string {
+	segments := strings.Split(name, "/")
+	if len(segments) < 3 {
+		return ""
+	}
+	for _, s := range segments[1 : len(segments)-1] {
+		if accountIDSegment.MatchString(s) || regionSegment.MatchString(s) {
+			continue
+		}
+		return s
+	}
+	return ""
+}
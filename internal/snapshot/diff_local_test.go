@@ -0,0 +1,151 @@
+package snapshot_test
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// writeSnapshotArchive writes a snapshot zip with the given files to dir/name.
+// comment, when set, is the archive comment, where lstk records the LocalStack
+// version a snapshot was saved with.
+func writeSnapshotArchive(t *testing.T, dir, name, comment string, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	if comment != "" {
+		require.NoError(t, zw.SetComment(comment))
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+	return path
+}
+
+func diffEvent(t *testing.T, events []output.Event) output.SnapshotDiffEvent {
+	t.Helper()
+	for _, e := range events {
+		if ev, ok := e.(output.SnapshotDiffEvent); ok {
+			return ev
+		}
+	}
+	require.Fail(t, "SnapshotDiffEvent should have been emitted")
+	return output.SnapshotDiffEvent{}
+}
+
+func TestDiffLocal_CountsPerService(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	base := writeSnapshotArchive(t, dir, "a.snapshot", "", map[string]string{
+		"version.txt": "1",
+		"api_states/000000000000/us-east-1/s3/store.state":  "bucket-a",
+		"api_states/000000000000/us-east-1/sqs/store.state": "queue-a",
+		"api_states/000000000000/eu-west-1/sns/store.state": "topic-a",
+	})
+	target := writeSnapshotArchive(t, dir, "b.snapshot", "", map[string]string{
+		"version.txt": "2",
+		"api_states/000000000000/us-east-1/s3/store.state":  "bucket-a",
+		"api_states/000000000000/eu-west-1/s3/store.state":  "bucket-b",
+		"api_states/000000000000/us-east-1/sqs/store.state": "queue-b",
+		"assets/lambda/function.zip":                        "code",
+	})
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.DiffLocal(base, target, sink))
+
+	ev := diffEvent(t, getEvents())
+	assert.Empty(t, ev.PodName)
+	assert.Contains(t, ev.Base, "a.snapshot")
+	assert.Contains(t, ev.Target, "b.snapshot")
+	assert.Equal(t, map[string]output.SnapshotDiffServiceResult{
+		"s3":     {Additions: 1},
+		"sqs":    {Modifications: 1},
+		"sns":    {Modifications: 1},
+		"lambda": {Additions: 1},
+	}, ev.Services, "top-level metadata such as version.txt is not a service")
+}
+
+func TestDiffLocal_IdenticalArchives(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{"api_states/s3/store.state": "bucket-a"}
+	base := writeSnapshotArchive(t, dir, "a.snapshot", "", files)
+	target := writeSnapshotArchive(t, dir, "b.snapshot", "", files)
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.DiffLocal(base, target, sink))
+	assert.Empty(t, diffEvent(t, getEvents()).Services)
+}
+
+func TestDiffLocal_InvalidFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	base := writeSnapshotArchive(t, dir, "a.snapshot", "", map[string]string{"api_states/s3/store.state": "x"})
+	target := filepath.Join(dir, "b.snapshot")
+	require.NoError(t, os.WriteFile(target, []byte("not a zip"), 0o600))
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.DiffLocal(base, target, sink)
+	require.ErrorIs(t, err, snapshot.ErrInvalidSnapshotFile)
+	assert.True(t, output.IsSilent(err))
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	assert.Equal(t, "Could not diff snapshots", errEvent.Title)
+}
+
+func TestDiffLocalRunning_ComparesWithExportedState(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	running := writeSnapshotArchive(t, dir, "running.zip", "", map[string]string{
+		"api_states/s3/store.state":  "bucket-a",
+		"api_states/sqs/store.state": "queue-a",
+	})
+	runningZip, err := os.ReadFile(running)
+	require.NoError(t, err)
+	src := writeSnapshotArchive(t, dir, "baseline.snapshot", "", map[string]string{
+		"api_states/s3/store.state":       "bucket-a",
+		"api_states/sqs/store.state":      "queue-b",
+		"api_states/dynamodb/store.state": "table",
+	})
+
+	ctrl := gomock.NewController(t)
+	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	exporter.EXPECT().ExportState(gomock.Any(), "http://host", nil, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
+			_, err := dst.Write(runningZip)
+			return nil, err
+		},
+	)
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.DiffLocalRunning(context.Background(), healthyRunningMock(t), awsContainers, exporter, "http://host", src, sink))
+
+	ev := diffEvent(t, getEvents())
+	assert.Equal(t, "running state", ev.Base)
+	assert.Equal(t, map[string]output.SnapshotDiffServiceResult{
+		"sqs":      {Modifications: 1},
+		"dynamodb": {Additions: 1},
+	}, ev.Services)
+}
@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// ErrSnapshotKeyRequired indicates a local snapshot file is encrypted and no
+// passphrase or identity was given to open it.
+var ErrSnapshotKeyRequired = errors.New("snapshot is encrypted")
+
+// ErrWrongSnapshotKey indicates none of the given passphrase or identities
+// opens an encrypted local snapshot file.
+var ErrWrongSnapshotKey = errors.New("wrong key for encrypted snapshot")
+
+// ageHeader opens every file age writes in its binary (non-armored) format,
+// which is the only one lstk writes.
+var ageHeader = []byte("age-encryption.org/v1\n")
+
+// Encryption seals the local snapshot files lstk writes with age, either to a
+// passphrase or to one or more age public keys. The zero value writes plain
+// archives.
+type Encryption struct {
+	// Passphrase seals the file with an scrypt-derived key. age only allows a
+	// passphrase on its own, so it cannot be combined with Recipients.
+	Passphrase string
+	// Recipients are age public keys ("age1..."); any one of the matching
+	// identities opens the file.
+	Recipients []string
+}
+
+// Enabled reports whether files are sealed.
+func (e Encryption) Enabled() bool {
+	return e.Passphrase != "" || len(e.Recipients) > 0
+}
+
+// Validate checks the keys up front, so a typo fails before the emulator's
+// state is exported rather than after.
+func (e Encryption) Validate() error {
+	_, err := e.recipients()
+	return err
+}
+
+func (e Encryption) recipients() ([]age.Recipient, error) {
+	if e.Passphrase != "" {
+		if len(e.Recipients) > 0 {
+			return nil, errors.New("a snapshot is encrypted with either a passphrase or recipients, not both")
+		}
+		r, err := age.NewScryptRecipient(e.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("invalid passphrase: %w", err)
+		}
+		return []age.Recipient{r}, nil
+	}
+	var recipients []age.Recipient
+	for _, key := range e.Recipients {
+		parsed, err := age.ParseRecipients(strings.NewReader(key))
+		if err != nil {
+			// age leaves the key out of its error in case it is a secret key
+			// pasted by mistake; so does this one.
+			return nil, fmt.Errorf("invalid recipient: expected an age public key (age1...): %w", err)
+		}
+		recipients = append(recipients, parsed...)
+	}
+	return recipients, nil
+}
+
+// seal wraps dst so what is written to the result is encrypted per e. Closing
+// the result finishes the encrypted stream but leaves dst open. When e is not
+// enabled, writes pass straight through.
+func (e Encryption) seal(dst io.Writer) (io.WriteCloser, error) {
+	if !e.Enabled() {
+		return nopWriteCloser{dst}, nil
+	}
+	recipients, err := e.recipients()
+	if err != nil {
+		return nil, err
+	}
+	w, err := age.Encrypt(dst, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt snapshot: %w", err)
+	}
+	return w, nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// EncryptionUnsupportedError is returned when a save to a destination lstk
+// does not write itself asks for encryption: Cloud Pods are uploaded by the
+// emulator, in the layout it loads them back from, and an OCI artifact has to
+// stay readable by the registry tooling that pulls it.
+type EncryptionUnsupportedError struct {
+	Kind DestinationKind
+}
+
+func (e *EncryptionUnsupportedError) Error() string {
+	what := "Cloud Pods are"
+	if e.Kind == KindOCI {
+		what = "OCI snapshots are"
+	}
+	return fmt.Sprintf("--encrypt only applies to local snapshot files, local: saves and s3:// locations; %s written unencrypted, so save locally with --encrypt and upload the file instead", what)
+}
+
+// ResolveEncryption builds the encryption for a save to a destination of the
+// given kind. Recipients take the place of passphrase, so encrypt alone needs
+// passphrase (LSTK_SNAPSHOT_PASSPHRASE) to be set. Asking to encrypt a Cloud
+// Pod or OCI save returns an *EncryptionUnsupportedError.
+func ResolveEncryption(kind DestinationKind, encrypt bool, recipients []string, passphrase string) (Encryption, error) {
+	var enc Encryption
+	switch {
+	case len(recipients) > 0:
+		enc.Recipients = recipients
+	case encrypt:
+		if passphrase == "" {
+			return Encryption{}, errors.New("--encrypt needs a passphrase: set LSTK_SNAPSHOT_PASSPHRASE, or pass --recipient with an age public key")
+		}
+		enc.Passphrase = passphrase
+	}
+	if enc.Enabled() && (kind == KindPod || kind == KindOCI) {
+		return Encryption{}, &EncryptionUnsupportedError{Kind: kind}
+	}
+	return enc, enc.Validate()
+}
+
+// Decryption holds what may open an encrypted local snapshot file. It is only
+// consulted for files that turn out to be encrypted, so plain archives load
+// with or without it.
+type Decryption struct {
+	Passphrase string
+	// IdentityFiles are paths of age identity files, as written by age-keygen.
+	IdentityFiles []string
+}
+
+// Enabled reports whether d holds a passphrase or identity, and so may open an
+// encrypted snapshot.
+func (d Decryption) Enabled() bool {
+	return d.Passphrase != "" || len(d.IdentityFiles) > 0
+}
+
+func (d Decryption) identities() ([]age.Identity, error) {
+	var identities []age.Identity
+	if d.Passphrase != "" {
+		id, err := age.NewScryptIdentity(d.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("invalid passphrase: %w", err)
+		}
+		identities = append(identities, id)
+	}
+	for _, path := range d.IdentityFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("read identity file: %w", err)
+		}
+		parsed, err := age.ParseIdentities(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read identity file %s: %w", path, err)
+		}
+		identities = append(identities, parsed...)
+	}
+	return identities, nil
+}
+
+// openSnapshot opens the local snapshot file at src for reading, decrypting it
+// with dec when it is encrypted.
+func openSnapshot(src string, dec Decryption) (io.ReadCloser, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	br := bufio.NewReader(f)
+	if head, _ := br.Peek(len(ageHeader)); !bytes.Equal(head, ageHeader) {
+		return readCloser{br, f}, nil
+	}
+
+	identities, err := dec.requiredIdentities()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	r, err := age.Decrypt(br, identities...)
+	if err != nil {
+		_ = f.Close()
+		return nil, decryptError(err)
+	}
+	return readCloser{r, f}, nil
+}
+
+// openSnapshotAt is openSnapshot for random access, as an archive is read: it
+// returns the (decrypted) content of src and its size, and the file to close
+// once done with it. Decrypted content is read chunk by chunk from the file,
+// never held in full.
+func openSnapshotAt(src string, dec Decryption) (io.ReaderAt, int64, io.Closer, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, nil, fmt.Errorf("open snapshot: %w", err)
+	}
+	if !isSealed(src) {
+		return f, info.Size(), f, nil
+	}
+
+	identities, err := dec.requiredIdentities()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, nil, err
+	}
+	ra, size, err := age.DecryptReaderAt(f, info.Size(), identities...)
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, nil, decryptError(err)
+	}
+	return ra, size, f, nil
+}
+
+// requiredIdentities is identities, failing with ErrSnapshotKeyRequired when
+// dec holds none.
+func (d Decryption) requiredIdentities() ([]age.Identity, error) {
+	identities, err := d.identities()
+	if err == nil && len(identities) == 0 {
+		err = ErrSnapshotKeyRequired
+	}
+	return identities, err
+}
+
+func decryptError(err error) error {
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return ErrWrongSnapshotKey
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidSnapshotFile, err)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// isSealed reports whether the file at path is an encrypted snapshot.
+func isSealed(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, len(ageHeader))
+	_, err = io.ReadFull(f, head)
+	return err == nil && bytes.Equal(head, ageHeader)
+}
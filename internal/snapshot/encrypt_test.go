@@ -0,0 +1,173 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// saveEncrypted saves "ZIP_DATA" to a local file sealed per enc and returns its path.
+func saveEncrypted(t *testing.T, enc snapshot.Encryption) string {
+	t.Helper()
+	dest := filepath.Join(t.TempDir(), "sealed.snapshot")
+	sink, _ := captureEvents(t)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturning(t, []byte("ZIP_DATA")), "", dest, nil, enc, sink)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("age-encryption.org/v1\n")), "the file must be sealed with age")
+	assert.NotContains(t, string(data), "ZIP_DATA", "the plain archive must not reach disk")
+	return dest
+}
+
+// mockLocalClientImporting expects one import and records what it was sent.
+// It runs the version the snapshots saved here record, for the compatibility
+// check of those that record one.
+func mockLocalClientImporting(t *testing.T, got *[]byte) *MockLocalLoadClient {
+	t.Helper()
+	m := NewMockLocalLoadClient(gomock.NewController(t))
+	m.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	m.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, src io.Reader, _ string) error {
+			var err error
+			*got, err = io.ReadAll(src)
+			return err
+		},
+	)
+	return m
+}
+
+func TestEncryptedSnapshot_PassphraseRoundTrip(t *testing.T) {
+	t.Parallel()
+	src := saveEncrypted(t, snapshot.Encryption{Passphrase: "correct horse"})
+
+	var imported []byte
+	sink, _ := captureEvents(t)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", src, "", nil, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, "ZIP_DATA", string(imported), "the emulator must receive the decrypted archive")
+}
+
+func TestEncryptedSnapshot_RecipientRoundTrip(t *testing.T) {
+	t.Parallel()
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	src := saveEncrypted(t, snapshot.Encryption{Recipients: []string{id.Recipient().String()}})
+
+	keyFile := filepath.Join(t.TempDir(), "key.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte("# created: now\n"+id.String()+"\n"), 0o600))
+
+	var imported []byte
+	sink, _ := captureEvents(t)
+	err = snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", src, "", nil, snapshot.Decryption{IdentityFiles: []string{keyFile}}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, "ZIP_DATA", string(imported))
+}
+
+func TestEncryptedSnapshot_KeyErrors(t *testing.T) {
+	t.Parallel()
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	src := saveEncrypted(t, snapshot.Encryption{Recipients: []string{id.Recipient().String()}})
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherKey := filepath.Join(t.TempDir(), "other.txt")
+	require.NoError(t, os.WriteFile(otherKey, []byte(other.String()+"\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		dec     snapshot.Decryption
+		wantErr error
+		summary string
+	}{
+		{name: "no key", dec: snapshot.Decryption{}, wantErr: snapshot.ErrSnapshotKeyRequired, summary: "This snapshot is encrypted"},
+		{name: "wrong key", dec: snapshot.Decryption{IdentityFiles: []string{otherKey}}, wantErr: snapshot.ErrWrongSnapshotKey, summary: "The passphrase or identity does not open this encrypted snapshot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// No ImportState expectation: nothing may reach the emulator.
+			client := NewMockLocalLoadClient(gomock.NewController(t))
+			sink, getEvents := captureEvents(t)
+
+			err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, nil, tt.dec, nopStarter, nil, false, sink)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, output.IsSilent(err))
+
+			var errEvent *output.ErrorEvent
+			for _, e := range getEvents() {
+				if ev, ok := e.(output.ErrorEvent); ok {
+					errEvent = &ev
+				}
+			}
+			require.NotNil(t, errEvent)
+			assert.Equal(t, tt.summary, errEvent.Summary)
+		})
+	}
+}
+
+func TestEncryption_Validate(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, snapshot.Encryption{}.Validate())
+	assert.ErrorContains(t, snapshot.Encryption{Recipients: []string{"AGE-SECRET-KEY-1XYZ"}}.Validate(), "expected an age public key")
+	assert.NotContains(t, snapshot.Encryption{Recipients: []string{"AGE-SECRET-KEY-1XYZ"}}.Validate().Error(), "AGE-SECRET-KEY", "a pasted secret key must not be echoed")
+	assert.ErrorContains(t, snapshot.Encryption{Passphrase: "p", Recipients: []string{"age1x"}}.Validate(), "not both")
+}
+
+func TestDiffLocal_EncryptedFile(t *testing.T) {
+	t.Parallel()
+	base := writeSnapshotArchive(t, t.TempDir(), "a.snapshot", "", map[string]string{"api_states/s3/store.state": "x"})
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	target := saveEncrypted(t, snapshot.Encryption{Recipients: []string{id.Recipient().String()}})
+	sink, _ := captureEvents(t)
+
+	err = snapshot.DiffLocal(base, target, sink)
+	require.ErrorIs(t, err, snapshot.ErrSnapshotKeyRequired, "an encrypted file is reported as encrypted, not as invalid")
+}
+
+func TestResolveEncryption(t *testing.T) {
+	t.Parallel()
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	recipient := id.Recipient().String()
+
+	enc, err := snapshot.ResolveEncryption(snapshot.KindLocal, false, nil, "secret")
+	require.NoError(t, err)
+	assert.False(t, enc.Enabled(), "the passphrase alone must not turn encryption on")
+
+	enc, err = snapshot.ResolveEncryption(snapshot.KindLocal, true, nil, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Encryption{Passphrase: "secret"}, enc)
+
+	_, err = snapshot.ResolveEncryption(snapshot.KindLocal, true, nil, "")
+	assert.ErrorContains(t, err, "set LSTK_SNAPSHOT_PASSPHRASE")
+
+	enc, err = snapshot.ResolveEncryption(snapshot.KindS3, false, []string{recipient}, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Encryption{Recipients: []string{recipient}}, enc, "a recipient implies encrypt and replaces the passphrase")
+
+	_, err = snapshot.ResolveEncryption(snapshot.KindLibrary, false, []string{"not-a-key"}, "")
+	assert.ErrorContains(t, err, "invalid recipient")
+
+	for _, kind := range []snapshot.DestinationKind{snapshot.KindPod, snapshot.KindOCI} {
+		_, err = snapshot.ResolveEncryption(kind, true, nil, "secret")
+		var unsupported *snapshot.EncryptionUnsupportedError
+		require.ErrorAs(t, err, &unsupported)
+		assert.Equal(t, kind, unsupported.Kind)
+	}
+	_, err = snapshot.ResolveEncryption(snapshot.KindPod, false, nil, "secret")
+	assert.NoError(t, err, "a plain save to a pod is fine")
+}
@@ -6,3 +6,7 @@ var (
 	TemplatedRemoteURL = templatedRemoteURL
 	RemoteName         = remoteName
 )
+
+var FormatEvery = formatEvery
+
+var RequireServicesFilter = requireServicesFilter
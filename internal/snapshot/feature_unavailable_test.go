@@ -48,7 +48,7 @@ func TestLoadLocal_FeatureUnavailable(t *testing.T) {
 	client := mockLocalClientReturning(t, fmt.Errorf("import: %w", snapshot.ErrSnapshotFeatureUnavailable))
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	assertFeatureUnavailable(t, err, getEvents())
 }
 
@@ -56,11 +56,11 @@ func TestLoadPod_FeatureUnavailable(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil, snapshot.ErrSnapshotFeatureUnavailable)
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nil, nopStarter, sink)
 	assertFeatureUnavailable(t, err, getEvents())
 }
 
@@ -72,7 +72,7 @@ func TestSaveLocal_FeatureUnavailable(t *testing.T) {
 	sink, getEvents := captureEvents(t)
 
 	dest := writeSnapshotFile(t, "")
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	assertFeatureUnavailable(t, err, getEvents())
 }
 
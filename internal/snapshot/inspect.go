@@ -0,0 +1,102 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+// archiveSummary is what `snapshot show` reports of a local snapshot archive.
+type archiveSummary struct {
+	metadata  archiveMetadata
+	services  []string
+	accounts  []string
+	regions   []string
+	resources []output.SnapshotResourceLine
+}
+
+// summarizeArchive reads the snapshot archive at path without decompressing
+// it. The archive holds the emulator's state files rather than a resource
+// inventory, so each service is counted in state files, one per store.
+func summarizeArchive(path string) (archiveSummary, error) {
+	r, err := openArchive(path)
+	if err != nil {
+		return archiveSummary{}, err
+	}
+	defer func() { _ = r.Close() }()
+
+	files := map[string]int{}
+	accounts := map[string]int{}
+	regions := map[string]int{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		service := archiveEntryService(f.Name)
+		if service == "" {
+			continue
+		}
+		files[service]++
+		segments := strings.Split(f.Name, "/")
+		for _, s := range segments[1 : len(segments)-1] {
+			switch {
+			case accountIDSegment.MatchString(s):
+				accounts[s]++
+			case regionSegment.MatchString(s):
+				regions[s]++
+			}
+		}
+	}
+
+	services := sortedKeys(files)
+	resources := make([]output.SnapshotResourceLine, len(services))
+	for i, s := range services {
+		noun := "state files"
+		if files[s] == 1 {
+			noun = "state file"
+		}
+		resources[i] = output.SnapshotResourceLine{
+			Service: s,
+			Counts:  []output.SnapshotResourceCount{{Count: files[s], Noun: noun}},
+		}
+	}
+	return archiveSummary{
+		metadata:  parseArchiveMetadata(r.Comment),
+		services:  services,
+		accounts:  sortedKeys(accounts),
+		regions:   sortedKeys(regions),
+		resources: resources,
+	}, nil
+}
+
+// ShowLocal inspects the local snapshot file at path: which services, accounts
+// and regions it holds state for, and the LocalStack version it was taken with
+// when the archive records one. It needs neither the emulator nor a platform
+// account, so a snapshot can be checked before it is loaded.
+func ShowLocal(path string, sink output.Sink) error {
+	cwd, _ := os.Getwd()
+	home, _ := os.UserHomeDir()
+	name := displayPath(path, cwd, home)
+
+	summary, err := summarizeArchive(path)
+	if err != nil {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not show snapshot",
+			Summary: fmt.Sprintf("%s: %v", name, err),
+		})
+		return output.NewSilentError(err)
+	}
+
+	sink.Emit(output.DeferredEvent{Inner: output.SnapshotShownEvent{
+		Name:              name,
+		Size:              fileSize(path),
+		LocalStackVersion: summary.metadata.LocalStackVersion,
+		Services:          summary.services,
+		Accounts:          summary.accounts,
+		Regions:           summary.regions,
+		Resources:         summary.resources,
+	}})
+	return nil
+}
@@ -0,0 +1,86 @@
+package snapshot_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowLocal(t *testing.T) {
+	t.Parallel()
+	path := writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", "", map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":     "a",
+		"api_states/000000000000/eu-west-1/s3/store.state":     "b",
+		"api_states/111111111111/us-east-1/sqs/store.state":    "c",
+		"assets/lambda/function.zip":                           "code",
+		"api_states/000000000000/us-east-1/lambda/store.state": "d",
+	})
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ShowLocal(path, sink))
+
+	shown := deferredOf[output.SnapshotShownEvent](getEvents())
+	require.Len(t, shown, 1)
+	ev := shown[0]
+	assert.Equal(t, path, ev.Name)
+	assert.Empty(t, ev.Path, "a file is named by its path already")
+	assert.Nil(t, ev.Created)
+	assert.Positive(t, ev.Size)
+	assert.Empty(t, ev.LocalStackVersion, "an archive without metadata has no version")
+	assert.Equal(t, []string{"lambda", "s3", "sqs"}, ev.Services)
+	assert.Equal(t, []string{"000000000000", "111111111111"}, ev.Accounts)
+	assert.Equal(t, []string{"eu-west-1", "us-east-1"}, ev.Regions)
+	assert.Equal(t, []output.SnapshotResourceLine{
+		{Service: "lambda", Counts: []output.SnapshotResourceCount{{Count: 2, Noun: "state files"}}},
+		{Service: "s3", Counts: []output.SnapshotResourceCount{{Count: 2, Noun: "state files"}}},
+		{Service: "sqs", Counts: []output.SnapshotResourceCount{{Count: 1, Noun: "state file"}}},
+	}, ev.Resources)
+}
+
+func TestShowLocal_ReadsRecordedVersion(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "baseline.snapshot")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("api_states/s3/store.state")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, zw.SetComment(`{"localstack_version":"4.3.0"}`))
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ShowLocal(path, sink))
+
+	shown := deferredOf[output.SnapshotShownEvent](getEvents())
+	require.Len(t, shown, 1)
+	assert.Equal(t, "4.3.0", shown[0].LocalStackVersion)
+}
+
+func TestShowLocal_InvalidFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(path, []byte("not a zip"), 0o600))
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.ShowLocal(path, sink)
+	require.ErrorIs(t, err, snapshot.ErrInvalidSnapshotFile)
+	assert.True(t, output.IsSilent(err))
+
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	assert.Equal(t, "Could not show snapshot", errEvent.Title)
+}
@@ -0,0 +1,347 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// libraryTimeLayout names the entries of a library snapshot. It sorts
+// chronologically as a string and avoids ":", which Windows forbids in file
+// names; milliseconds keep back-to-back saves apart.
+const libraryTimeLayout = "2006-01-02T15-04-05.000Z"
+
+// ErrLibrarySnapshotNotFound indicates a local: ref names no snapshot in the library.
+var ErrLibrarySnapshotNotFound = errors.New("snapshot not found in the local library")
+
+// Library is the managed directory where `lstk snapshot save local:NAME` keeps
+// its snapshots. Each name is a subdirectory holding one timestamped .snapshot
+// file per save, so saving under an existing name adds a generation rather than
+// overwriting it; `snapshot prune` trims old generations.
+type Library struct {
+	Dir string
+}
+
+// LibraryEntry is one saved generation of a library snapshot.
+type LibraryEntry struct {
+	Name    string
+	Created time.Time
+	Path    string
+	Size    int64
+}
+
+// Ref renders the entry's name in the reference syntax the user types.
+func (e LibraryEntry) Ref() string {
+	return LibraryRef(e.Name)
+}
+
+// LibraryRef renders a library snapshot name as a "local:" reference.
+func LibraryRef(name string) string {
+	return "local:" + name
+}
+
+// Entries lists the library's snapshots, sorted by name and then newest first.
+// name, when non-empty, limits the listing to that snapshot's generations. A
+// library that was never written to has no entries rather than an error.
+func (l Library) Entries(name string) ([]LibraryEntry, error) {
+	names := []string{name}
+	if name == "" {
+		dirs, err := os.ReadDir(l.Dir)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot library: %w", err)
+		}
+		names = names[:0]
+		for _, d := range dirs {
+			if d.IsDir() {
+				names = append(names, d.Name())
+			}
+		}
+	}
+
+	var entries []LibraryEntry
+	for _, n := range names {
+		files, err := os.ReadDir(filepath.Join(l.Dir, n))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot library: %w", err)
+		}
+		for _, f := range files {
+			created, err := time.Parse(libraryTimeLayout, strings.TrimSuffix(f.Name(), snapshotExt))
+			if err != nil || f.IsDir() || filepath.Ext(f.Name()) != snapshotExt {
+				continue
+			}
+			var size int64
+			if info, err := f.Info(); err == nil {
+				size = info.Size()
+			}
+			entries = append(entries, LibraryEntry{
+				Name:    n,
+				Created: created,
+				Path:    filepath.Join(l.Dir, n, f.Name()),
+				Size:    size,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Created.After(entries[j].Created)
+	})
+	return entries, nil
+}
+
+// Latest returns the newest generation of the named snapshot, or
+// ErrLibrarySnapshotNotFound when there is none.
+func (l Library) Latest(name string) (LibraryEntry, error) {
+	entries, err := l.Entries(name)
+	if err != nil {
+		return LibraryEntry{}, err
+	}
+	if len(entries) == 0 {
+		return LibraryEntry{}, fmt.Errorf("%w: %s", ErrLibrarySnapshotNotFound, LibraryRef(name))
+	}
+	return entries[0], nil
+}
+
+// entryPath returns the file a save of name at now is written to.
+func (l Library) entryPath(name string, now time.Time) string {
+	return filepath.Join(l.Dir, name, now.UTC().Format(libraryTimeLayout)+snapshotExt)
+}
+
+// SaveLibrary saves the running emulator's state as a new generation of the
+// named snapshot in the local library, or of the target emulator's
+// DefaultSaveName when name is empty. services, when non-empty, limits the
+// save to that subset of services; enc, when enabled, encrypts the entry.
+func SaveLibrary(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, host string, lib Library, name string, services []string, enc Encryption, sink output.Sink) error {
+	if name == "" && len(containers) > 0 {
+		name = DefaultSaveName(containers[0].Name())
+	}
+	dest := lib.entryPath(name, time.Now())
+	var extracted []string
+	return save(ctx, rt, containers, sink,
+		fmt.Sprintf("Saving snapshot to %s...", LibraryRef(name)),
+		func() {
+			sink.Emit(output.LocalSnapshotSavedEvent{
+				Path:     LibraryRef(name),
+				Services: extracted,
+				Size:     fileSize(dest),
+			})
+		},
+		func() error {
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return fmt.Errorf("create snapshot library: %w", err)
+			}
+			var err error
+			extracted, err = exportFile(ctx, exporter, host, dest, services, enc)
+			return err
+		},
+	)
+}
+
+// LoadLibrary loads the newest generation of the named library snapshot,
+// checking its recorded LocalStack version the same way LoadLocal does.
+func LoadLibrary(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client LocalLoadClient, host string, lib Library, name, strategy string, services []string, dec Decryption, starter Starter, restarter Restarter, force bool, sink output.Sink) error {
+	entry, err := lib.Latest(name)
+	if errors.Is(err, ErrLibrarySnapshotNotFound) {
+		return emitLibraryNotFound(name, "Could not load snapshot", err, sink)
+	}
+	if err != nil {
+		return err
+	}
+	meta, _ := readArchiveMetadata(entry.Path, dec)
+	loaded := output.SnapshotLoadedEvent{Source: LibraryRef(name), Services: meta.Services}
+	return load(ctx, rt, containers, sink, starter,
+		func() error {
+			return checkCompatibility(ctx, client, host, entry.Path, dec, restarter, force, sink)
+		},
+		fmt.Sprintf("Loading snapshot from %s...", LibraryRef(name)),
+		func() {
+			sink.Emit(loaded)
+		},
+		func() error {
+			return importFile(ctx, client, host, entry.Path, strategy, services, dec, &loaded, sink)
+		},
+	)
+}
+
+func emitLibraryNotFound(name, title string, err error, sink output.Sink) error {
+	sink.Emit(output.ErrorEvent{
+		Title:   title,
+		Summary: fmt.Sprintf("No snapshot named '%s' in the local library", LibraryRef(name)),
+		Actions: []output.ErrorAction{
+			{Label: "List local snapshots:", Value: "lstk snapshot list --local"},
+		},
+	})
+	return output.NewSilentError(err)
+}
+
+// ListLibrary lists every generation of every snapshot in the local library,
+// with the services each one holds.
+func ListLibrary(lib Library, sink output.Sink) error {
+	entries, err := lib.Entries("")
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		sink.Emit(output.DeferredEvent{Inner: output.MessageEvent{Severity: output.SeverityNote, Text: "No local snapshots found"}})
+		return nil
+	}
+
+	noun := "snapshots"
+	if len(entries) == 1 {
+		noun = "snapshot"
+	}
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		services, err := archiveServices(e.Path)
+		servicesCell := orDash(strings.Join(services, ", "))
+		if errors.Is(err, ErrSnapshotKeyRequired) {
+			servicesCell = "(encrypted)"
+		}
+		rows[i] = []string{
+			e.Ref(),
+			e.Created.Format("2006-01-02 15:04 UTC"),
+			output.FormatBytes(e.Size),
+			servicesCell,
+		}
+	}
+	sink.Emit(output.DeferredEvent{Inner: output.MessageEvent{Severity: output.SeveritySecondary, Text: fmt.Sprintf("~ %d local %s\n", len(entries), noun)}})
+	// Services is the last column so it is the one the table shrinks to fit
+	// the terminal, as in `snapshot versions`.
+	sink.Emit(output.DeferredEvent{Inner: output.TableEvent{
+		Headers: []string{"Name", "Created", "Size", "Services"},
+		Rows:    rows,
+	}})
+	return nil
+}
+
+// ShowLibrary inspects the newest generation of the named library snapshot:
+// where it is stored, when it was saved, and which services, accounts and
+// regions it holds state for.
+func ShowLibrary(lib Library, name string, sink output.Sink) error {
+	entry, err := lib.Latest(name)
+	if errors.Is(err, ErrLibrarySnapshotNotFound) {
+		return emitLibraryNotFound(name, "Could not show snapshot", err, sink)
+	}
+	if err != nil {
+		return err
+	}
+
+	summary, err := summarizeArchive(entry.Path)
+	if err != nil {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not show snapshot",
+			Summary: fmt.Sprintf("%s: %v", entry.Ref(), err),
+		})
+		return output.NewSilentError(err)
+	}
+
+	home, _ := os.UserHomeDir()
+	created := entry.Created
+	sink.Emit(output.DeferredEvent{Inner: output.SnapshotShownEvent{
+		Name:              entry.Ref(),
+		Path:              displayPath(entry.Path, "", home),
+		Created:           &created,
+		Size:              entry.Size,
+		LocalStackVersion: summary.metadata.LocalStackVersion,
+		Services:          summary.services,
+		Accounts:          summary.accounts,
+		Regions:           summary.regions,
+		Resources:         summary.resources,
+	}})
+	return nil
+}
+
+// PruneLibrary deletes all but the newest keep generations of each library
+// snapshot, or only of the named one when name is non-empty. A name left with
+// no generations is removed from the library altogether.
+func PruneLibrary(lib Library, name string, keep int, sink output.Sink) error {
+	if keep < 0 {
+		return fmt.Errorf("--keep must be zero or more, got %d", keep)
+	}
+	entries, err := lib.Entries(name)
+	if err != nil {
+		return err
+	}
+	if name != "" && len(entries) == 0 {
+		return emitLibraryNotFound(name, "Could not prune snapshots", fmt.Errorf("%w: %s", ErrLibrarySnapshotNotFound, LibraryRef(name)), sink)
+	}
+
+	removed, size, err := pruneEntries(entries, keep)
+	if err != nil {
+		return err
+	}
+	sink.Emit(output.LocalSnapshotsPrunedEvent{Removed: removed, Size: size})
+	return nil
+}
+
+// pruneEntries deletes all but the newest keep of each name's entries, which
+// must be sorted as Entries returns them, and reports how many files it
+// removed and their total size.
+func pruneEntries(entries []LibraryEntry, keep int) (int, int64, error) {
+	var removed int
+	var size int64
+	kept := map[string]int{}
+	for _, e := range entries {
+		if kept[e.Name] < keep {
+			kept[e.Name]++
+			continue
+		}
+		if err := os.Remove(e.Path); err != nil {
+			return removed, size, fmt.Errorf("prune %s: %w", e.Ref(), err)
+		}
+		removed++
+		size += e.Size
+		if kept[e.Name] == 0 {
+			// Only succeeds once the directory is empty, so a name with stray
+			// files in it is left alone.
+			_ = os.Remove(filepath.Dir(e.Path))
+		}
+	}
+	return removed, size, nil
+}
+
+// archiveServices returns the sorted services whose state the snapshot archive
+// at path holds.
+func archiveServices(path string) ([]string, error) {
+	entries, err := readArchiveEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(serviceFileCounts(entries)), nil
+}
+
+// serviceFileCounts counts the files of each service in a snapshot archive.
+func serviceFileCounts(entries map[string]archiveEntry) map[string]int {
+	files := map[string]int{}
+	for name := range entries {
+		if service := archiveEntryService(name); service != "" {
+			files[service]++
+		}
+	}
+	return files
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
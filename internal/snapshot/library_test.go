@@ -0,0 +1,283 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// seedLibrary writes a library entry for name saved at the given timestamp,
+// formatted the way SaveLibrary names its files.
+func seedLibrary(t *testing.T, lib snapshot.Library, name, timestamp string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(lib.Dir, name)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	return writeSnapshotArchive(t, dir, timestamp+".snapshot", "", files)
+}
+
+func deferredOf[T output.Event](events []output.Event) []T {
+	var found []T
+	for _, e := range events {
+		if d, ok := e.(output.DeferredEvent); ok {
+			if ev, ok := d.Inner.(T); ok {
+				found = append(found, ev)
+			}
+		}
+	}
+	return found
+}
+
+func TestSaveLibrary_WithoutNameUsesTheEmulatorsDefault(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.SaveLibrary(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturning(t, []byte("ZIP_DATA")), "", lib, "", nil, snapshot.Encryption{}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries(snapshot.DefaultSaveName("localstack-aws"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	var saved []output.LocalSnapshotSavedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.LocalSnapshotSavedEvent); ok {
+			saved = append(saved, ev)
+		}
+	}
+	require.Len(t, saved, 1)
+	assert.Equal(t, "local:localstack-aws", saved[0].Path)
+}
+
+func TestSaveLibrary_AddsAGenerationPerSave(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	seedLibrary(t, lib, "my-baseline", "2026-01-01T00-00-00.000Z", nil)
+
+	ctrl := gomock.NewController(t)
+	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	exporter.EXPECT().ExportState(gomock.Any(), gomock.Any(), []string{"s3"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
+			_, err := dst.Write([]byte("ZIP_DATA"))
+			return []string{"s3"}, err
+		},
+	)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.SaveLibrary(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", lib, "my-baseline", []string{"s3"}, snapshot.Encryption{}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries("my-baseline")
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "a save under an existing name must not overwrite it")
+	data, err := os.ReadFile(entries[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, "ZIP_DATA", string(data), "the newest entry is the one just saved")
+
+	var saved *output.LocalSnapshotSavedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.LocalSnapshotSavedEvent); ok {
+			saved = &ev
+		}
+	}
+	require.NotNil(t, saved, "LocalSnapshotSavedEvent should have been emitted")
+	assert.Equal(t, "local:my-baseline", saved.Path)
+	assert.Equal(t, []string{"s3"}, saved.Services)
+	assert.Equal(t, int64(len("ZIP_DATA")), saved.Size)
+}
+
+func TestSaveLibrary_ExportErrorLeavesNoEntry(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	sink, _ := captureEvents(t)
+
+	err := snapshot.SaveLibrary(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturningError(t, errors.New("boom")), "", lib, "my-baseline", nil, snapshot.Encryption{}, sink)
+	require.Error(t, err)
+
+	entries, err := lib.Entries("")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLoadLibrary_LoadsNewestGeneration(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	seedLibrary(t, lib, "my-baseline", "2026-01-01T00-00-00.000Z", map[string]string{"api_states/s3/store.state": "old"})
+	newest := seedLibrary(t, lib, "my-baseline", "2026-02-01T00-00-00.000Z", map[string]string{"api_states/s3/store.state": "new"})
+	want, err := os.ReadFile(newest)
+	require.NoError(t, err)
+
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), "").DoAndReturn(
+		func(_ context.Context, _ string, src io.Reader, _ string) error {
+			got, err := io.ReadAll(src)
+			assert.Equal(t, want, got)
+			return err
+		},
+	)
+	sink, getEvents := captureEvents(t)
+
+	err = snapshot.LoadLibrary(context.Background(), healthyRunningMock(t), awsContainers, client, "", lib, "my-baseline", "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+
+	var loaded *output.SnapshotLoadedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.SnapshotLoadedEvent); ok {
+			loaded = &ev
+		}
+	}
+	require.NotNil(t, loaded)
+	assert.Equal(t, "local:my-baseline", loaded.Source)
+}
+
+func TestLoadLibrary_NotFound(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadLibrary(context.Background(), nil, awsContainers, client, "", lib, "missing", "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.ErrorIs(t, err, snapshot.ErrLibrarySnapshotNotFound)
+	assert.True(t, output.IsSilent(err))
+
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	require.Len(t, errEvent.Actions, 1)
+	assert.Equal(t, "lstk snapshot list --local", errEvent.Actions[0].Value)
+}
+
+func TestListLibrary(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	seedLibrary(t, lib, "beta", "2026-01-01T00-00-00.000Z", map[string]string{"api_states/000000000000/us-east-1/sqs/store.state": "q"})
+	seedLibrary(t, lib, "alpha", "2026-01-01T00-00-00.000Z", map[string]string{"api_states/s3/store.state": "b"})
+	seedLibrary(t, lib, "alpha", "2026-03-01T09-30-00.000Z", map[string]string{
+		"api_states/s3/store.state":     "b",
+		"api_states/lambda/store.state": "f",
+	})
+	// Files the library did not write are not entries.
+	require.NoError(t, os.WriteFile(filepath.Join(lib.Dir, "alpha", "notes.txt"), []byte("x"), 0o600))
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ListLibrary(lib, sink))
+
+	tables := deferredOf[output.TableEvent](getEvents())
+	require.Len(t, tables, 1)
+	assert.Equal(t, []string{"Name", "Created", "Size", "Services"}, tables[0].Headers)
+	require.Len(t, tables[0].Rows, 3)
+	assert.Equal(t, []string{"local:alpha", "2026-03-01 09:30 UTC"}, tables[0].Rows[0][:2], "newest generation first")
+	assert.Equal(t, "lambda, s3", tables[0].Rows[0][3])
+	assert.Equal(t, []string{"local:alpha", "2026-01-01 00:00 UTC"}, tables[0].Rows[1][:2])
+	assert.Equal(t, "local:beta", tables[0].Rows[2][0])
+	assert.Equal(t, "sqs", tables[0].Rows[2][3])
+}
+
+func TestListLibrary_Empty(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "never-written")}
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ListLibrary(lib, sink))
+
+	messages := deferredOf[output.MessageEvent](getEvents())
+	require.Len(t, messages, 1)
+	assert.Equal(t, "No local snapshots found", messages[0].Text)
+}
+
+func TestShowLibrary(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	seedLibrary(t, lib, "my-baseline", "2026-01-01T00-00-00.000Z", map[string]string{"api_states/sns/store.state": "t"})
+	newest := seedLibrary(t, lib, "my-baseline", "2026-02-01T00-00-00.000Z", map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state": "a",
+		"api_states/000000000000/eu-west-1/s3/store.state": "b",
+		"assets/lambda/function.zip":                       "code",
+	})
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ShowLibrary(lib, "my-baseline", sink))
+
+	shown := deferredOf[output.SnapshotShownEvent](getEvents())
+	require.Len(t, shown, 1)
+	ev := shown[0]
+	assert.Equal(t, "local:my-baseline", ev.Name)
+	assert.Equal(t, newest, ev.Path)
+	require.NotNil(t, ev.Created)
+	assert.Equal(t, "2026-02-01", ev.Created.Format("2006-01-02"))
+	assert.Positive(t, ev.Size)
+	assert.Equal(t, []string{"lambda", "s3"}, ev.Services)
+	assert.Equal(t, []output.SnapshotResourceLine{
+		{Service: "lambda", Counts: []output.SnapshotResourceCount{{Count: 1, Noun: "state file"}}},
+		{Service: "s3", Counts: []output.SnapshotResourceCount{{Count: 2, Noun: "state files"}}},
+	}, ev.Resources)
+}
+
+func TestPruneLibrary_KeepsNewestPerName(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	seedLibrary(t, lib, "alpha", "2026-01-01T00-00-00.000Z", nil)
+	seedLibrary(t, lib, "alpha", "2026-02-01T00-00-00.000Z", nil)
+	seedLibrary(t, lib, "alpha", "2026-03-01T00-00-00.000Z", nil)
+	seedLibrary(t, lib, "beta", "2026-01-01T00-00-00.000Z", nil)
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.PruneLibrary(lib, "", 1, sink))
+
+	entries, err := lib.Entries("")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "alpha", entries[0].Name)
+	assert.Equal(t, "2026-03-01", entries[0].Created.Format("2006-01-02"))
+	assert.Equal(t, "beta", entries[1].Name)
+
+	var pruned *output.LocalSnapshotsPrunedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.LocalSnapshotsPrunedEvent); ok {
+			pruned = &ev
+		}
+	}
+	require.NotNil(t, pruned)
+	assert.Equal(t, 2, pruned.Removed)
+	assert.Positive(t, pruned.Size)
+}
+
+func TestPruneLibrary_OnlyNamedSnapshot(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	seedLibrary(t, lib, "alpha", "2026-01-01T00-00-00.000Z", nil)
+	seedLibrary(t, lib, "beta", "2026-01-01T00-00-00.000Z", nil)
+	seedLibrary(t, lib, "beta", "2026-02-01T00-00-00.000Z", nil)
+	sink, _ := captureEvents(t)
+
+	require.NoError(t, snapshot.PruneLibrary(lib, "beta", 0, sink))
+
+	entries, err := lib.Entries("")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "alpha", entries[0].Name)
+	assert.NoDirExists(t, filepath.Join(lib.Dir, "beta"), "a name with no saves left is removed")
+}
+
+func TestPruneLibrary_UnknownName(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: t.TempDir()}
+	sink, _ := captureEvents(t)
+
+	err := snapshot.PruneLibrary(lib, "missing", 1, sink)
+	require.ErrorIs(t, err, snapshot.ErrLibrarySnapshotNotFound)
+	assert.True(t, output.IsSilent(err))
+}
@@ -71,26 +71,53 @@ func ValidateMergeStrategy(strategy string) error {
 // Starter is called to auto-start the emulator when none is running.
 type Starter func(ctx context.Context, sink output.Sink) error
 
+// ServiceResetter is satisfied by aws.Client.
+type ServiceResetter interface {
+	// ResetServiceState wipes one service's running state via
+	// POST /_localstack/state/{service}/reset. Used to implement overwrite for
+	// a partial load without touching the services it leaves out.
+	ResetServiceState(ctx context.Context, host, service string) error
+}
+
 // LocalLoadClient is satisfied by aws.Client.
 type LocalLoadClient interface {
+	ServiceResetter
 	// ImportState posts a zip to /_localstack/pods[?merge=strategy] and streams
 	// the NDJSON response. strategy is passed as-is; empty means server default.
 	ImportState(ctx context.Context, host string, src io.Reader, strategy string) error
 	// ResetState wipes all running state via POST /_localstack/state/reset.
 	// Used to implement overwrite client-side before importing.
 	ResetState(ctx context.Context, host string) error
+	// FetchVersion reports the running emulator's version, checked against
+	// the version a local snapshot records before it is uploaded.
+	FetchVersion(ctx context.Context, host string) (string, error)
 }
 
 // PodLoader is satisfied by aws.Client.
 type PodLoader interface {
+	ServiceResetter
 	// LoadPodSnapshot issues PUT /_localstack/pods/{name}?merge=strategy and
 	// streams the NDJSON response. version 0 loads the pod's latest version.
-	LoadPodSnapshot(ctx context.Context, host, podName string, version int, authToken, strategy string) ([]string, error)
+	// services, when non-empty, limits the load to that subset of services.
+	LoadPodSnapshot(ctx context.Context, host, podName string, version int, authToken, strategy string, services []string) ([]string, error)
+	// FetchVersion reports the running emulator's version, checked for
+	// support of the services filter before a partial load.
+	FetchVersion(ctx context.Context, host string) (string, error)
+}
+
+// decryptActions tell the user how to pass the key of an encrypted snapshot.
+var decryptActions = []output.ErrorAction{
+	{Label: "Passphrase:", Value: "set LSTK_SNAPSHOT_PASSPHRASE"},
+	{Label: "age identity:", Value: "--identity <key file>"},
 }
 
 // load is the shared entry point for both LoadLocal and LoadPod.
-// It checks runtime health, auto-starts the emulator if needed, then runs do().
-func load(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, sink output.Sink, starter Starter, spinnerText string, onSuccess func(), do func() error) (retErr error) {
+// It checks runtime health, auto-starts the emulator if needed, runs check (if
+// set) against the running emulator, then runs do().
+//
+// Every Load* function takes services, which when non-empty limits the load to
+// that subset of services; with the overwrite strategy only those are reset.
+func load(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, sink output.Sink, starter Starter, check func() error, spinnerText string, onSuccess func(), do func() error) (retErr error) {
 	if err := rt.IsHealthy(ctx); err != nil {
 		rt.EmitUnhealthyError(sink, err)
 		return output.NewSilentError(fmt.Errorf("runtime not healthy: %w", err))
@@ -119,6 +146,12 @@ func load(ctx context.Context, rt runtime.Runtime, containers []config.Container
 		}
 	}
 
+	if check != nil {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
 	sink.Emit(output.SpinnerStart(spinnerText))
 	defer func() {
 		sink.Emit(output.SpinnerStop())
@@ -145,6 +178,22 @@ func load(ctx context.Context, rt runtime.Runtime, containers []config.Container
 		})
 		return output.NewSilentError(err)
 	}
+	if errors.Is(err, ErrSnapshotKeyRequired) {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not load snapshot",
+			Summary: "This snapshot is encrypted",
+			Actions: decryptActions,
+		})
+		return output.NewSilentError(err)
+	}
+	if errors.Is(err, ErrWrongSnapshotKey) {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not load snapshot",
+			Summary: "The passphrase or identity does not open this encrypted snapshot",
+			Actions: decryptActions,
+		})
+		return output.NewSilentError(err)
+	}
 	if errors.Is(err, ErrPodNotFound) {
 		sink.Emit(output.ErrorEvent{
 			Title:   "Could not load snapshot",
@@ -158,36 +207,85 @@ func load(ctx context.Context, rt runtime.Runtime, containers []config.Container
 	return err
 }
 
-func LoadLocal(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client LocalLoadClient, host, src, strategy string, starter Starter, sink output.Sink) error {
+// LoadLocal loads the snapshot file at src. The LocalStack version it records
+// is checked against the running emulator first; restarter, when set, lets the
+// user restart on a matching image tag instead of the load being refused, and
+// force loads it regardless.
+func LoadLocal(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client LocalLoadClient, host, src, strategy string, services []string, dec Decryption, starter Starter, restarter Restarter, force bool, sink output.Sink) error {
 	cwd, _ := os.Getwd()
 	home, _ := os.UserHomeDir()
+	return loadFile(ctx, rt, containers, client, host, src, displayPath(src, cwd, home), strategy, services, dec, starter, restarter, force, sink)
+}
+
+// loadFile loads the snapshot file at src as LoadLocal does, reporting it as
+// source.
+func loadFile(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client LocalLoadClient, host, src, source, strategy string, services []string, dec Decryption, starter Starter, restarter Restarter, force bool, sink output.Sink) error {
+	meta, _ := readArchiveMetadata(src, dec)
+	loaded := output.SnapshotLoadedEvent{Source: source, Services: meta.Services}
 
 	return load(ctx, rt, containers, sink, starter,
+		func() error {
+			return checkCompatibility(ctx, client, host, src, dec, restarter, force, sink)
+		},
 		"Loading snapshot...",
 		func() {
-			sink.Emit(output.SnapshotLoadedEvent{Source: displayPath(src, cwd, home)})
+			sink.Emit(loaded)
 		},
 		func() error {
-			// overwrite is handled client-side: reset running state, then import
-			// with the server default (account-region-merge on clean state = overwrite).
-			if strategy == MergeStrategyOverwrite {
-				if err := client.ResetState(ctx, host); err != nil {
-					return fmt.Errorf("reset state: %w", err)
-				}
-				strategy = ""
-			}
-
-			f, err := os.Open(src)
-			if err != nil {
-				return fmt.Errorf("open snapshot: %w", err)
-			}
-			defer func() { _ = f.Close() }()
-
-			return client.ImportState(ctx, host, f, strategy)
+			return importFile(ctx, client, host, src, strategy, services, dec, &loaded, sink)
 		},
 	)
 }
 
+// importFile imports the snapshot archive at src into the running emulator,
+// decrypting it with dec on the way if it is encrypted. services, when
+// non-empty, limits the import to that subset of services, and the services
+// restored and skipped are recorded in loaded.
+func importFile(ctx context.Context, client LocalLoadClient, host, src, strategy string, services []string, dec Decryption, loaded *output.SnapshotLoadedEvent, sink output.Sink) error {
+	// Open, and so check the key, before an overwrite resets the running state.
+	var body io.Reader
+	if len(services) > 0 {
+		ra, size, f, err := openSnapshotAt(src, dec)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		filtered, sel, err := selectServices(ra, size, services)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = filtered.Close() }()
+		body = filtered
+		emitMissingServices(sel, sink)
+		loaded.Services, loaded.Skipped = sel.loaded, sel.skipped
+	} else {
+		r, err := openSnapshot(src, dec)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = r.Close() }()
+		body = r
+	}
+
+	// overwrite is handled client-side: reset running state, then import
+	// with the server default (account-region-merge on clean state = overwrite).
+	// A partial load resets only the services it restores.
+	if strategy == MergeStrategyOverwrite {
+		var err error
+		if len(services) > 0 {
+			err = resetServices(ctx, client, host, loaded.Services)
+		} else if err = client.ResetState(ctx, host); err != nil {
+			err = fmt.Errorf("reset state: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+		strategy = ""
+	}
+
+	return client.ImportState(ctx, host, body, strategy)
+}
+
 // LoadPod loads a platform-hosted cloud snapshot. version 0 loads the pod's
 // latest version; a non-zero version pins the load to that specific one.
 //
@@ -196,7 +294,10 @@ func LoadLocal(ctx context.Context, rt runtime.Runtime, containers []config.Cont
 // failure mode — and would fail in exactly the --endpoint-url case where the emulator
 // can reach the platform but lstk cannot. A missing version comes back from the
 // emulator instead (see isPodVersionNotFoundMsg).
-func LoadPod(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, loader PodLoader, host, podName string, version int, authToken, strategy string, starter Starter, sink output.Sink) error {
+//
+// The emulator reads the pod, so unlike a local file lstk cannot tell which of
+// its services are skipped when loading only some.
+func LoadPod(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, loader PodLoader, host, podName string, version int, authToken, strategy string, services []string, starter Starter, sink output.Sink) error {
 	if authToken == "" {
 		return fmt.Errorf("pod snapshots require authentication — set LOCALSTACK_AUTH_TOKEN or run %q", "lstk login")
 	}
@@ -206,18 +307,25 @@ func LoadPod(ctx context.Context, rt runtime.Runtime, containers []config.Contai
 		spinnerText = fmt.Sprintf("Loading snapshot from pod %q (version %d)...", podName, version)
 	}
 
-	var services []string
-	err := load(ctx, rt, containers, sink, starter,
+	var loaded []string
+	err := load(ctx, rt, containers, sink, starter, nil,
 		spinnerText,
 		func() {
+			emitUnrequestedServices(loaded, services, sink)
 			sink.Emit(output.SnapshotLoadedEvent{
 				Source:   PodRef(podName, version),
-				Services: services,
+				Services: loaded,
 			})
 		},
 		func() error {
-			var err error
-			services, err = loader.LoadPodSnapshot(ctx, host, podName, version, authToken, strategy)
+			if err := requireServicesFilter(ctx, loader, host, services, sink); err != nil {
+				return err
+			}
+			strategy, err := partialOverwrite(ctx, loader, host, strategy, services)
+			if err != nil {
+				return err
+			}
+			loaded, err = loader.LoadPodSnapshot(ctx, host, podName, version, authToken, strategy, services)
 			return err
 		},
 	)
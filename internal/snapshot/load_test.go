@@ -44,7 +44,7 @@ func TestLoadLocal_Success(t *testing.T) {
 	client := mockLocalClientReturning(t, nil)
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.NoError(t, err)
 
 	events := getEvents()
@@ -77,7 +77,7 @@ func TestLoadLocal_OverwriteStrategy(t *testing.T) {
 	client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), "").Return(nil)
 
 	sink := output.NewPlainSink(io.Discard)
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.NoError(t, err)
 }
 
@@ -90,7 +90,7 @@ func TestLoadLocal_ResetErrorAbortsImport(t *testing.T) {
 	client.EXPECT().ResetState(gomock.Any(), gomock.Any()).Return(fmt.Errorf("reset failed"))
 
 	sink := output.NewPlainSink(io.Discard)
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "reset failed")
 }
@@ -101,7 +101,7 @@ func TestLoadLocal_ImportError(t *testing.T) {
 	client := mockLocalClientReturning(t, fmt.Errorf("incompatible version"))
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "incompatible version")
 }
@@ -113,7 +113,7 @@ func TestLoadLocal_IncompatibleSnapshot_StructuredError(t *testing.T) {
 	client := mockLocalClientReturning(t, importErr)
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err), "incompatible-snapshot error should be silent so it isn't double-rendered")
 
@@ -133,11 +133,11 @@ func TestLoadPod_IncompatibleSnapshot_StructuredError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
 	loadErr := fmt.Errorf("%w: Pod state is incompatible with the current LocalStack version", snapshot.ErrIncompatibleSnapshot)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any(), gomock.Any()).
 		Return(nil, loadErr)
 
 	sink, getEvents := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nil, nopStarter, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 
@@ -158,11 +158,11 @@ func TestLoadPod_PinnedVersion(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 3, "test-token", snapshot.MergeStrategyAccountRegion).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 3, "test-token", snapshot.MergeStrategyAccountRegion, gomock.Any()).
 		Return([]string{"s3"}, nil)
 
 	sink, getEvents := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 3, "test-token", snapshot.MergeStrategyAccountRegion, nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 3, "test-token", snapshot.MergeStrategyAccountRegion, nil, nopStarter, sink)
 	require.NoError(t, err)
 
 	var loaded *output.SnapshotLoadedEvent
@@ -179,11 +179,11 @@ func TestLoadPod_UnpinnedVersionOmitsSuffix(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any(), gomock.Any()).
 		Return([]string{"s3"}, nil)
 
 	sink, getEvents := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nil, nopStarter, sink)
 	require.NoError(t, err)
 
 	var loaded *output.SnapshotLoadedEvent
@@ -204,11 +204,11 @@ func TestLoadPod_VersionNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
 	const serverMsg = "Unable to load pod my-baseline with version 9. The maximum version available in the remote storage is 3"
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 9, "test-token", gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 9, "test-token", gomock.Any(), gomock.Any()).
 		Return(nil, fmt.Errorf("%w: %s", snapshot.ErrPodVersionNotFound, serverMsg))
 
 	sink, getEvents := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 9, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 9, "test-token", "", nil, nopStarter, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 	assert.ErrorIs(t, err, snapshot.ErrPodVersionNotFound)
@@ -232,7 +232,7 @@ func TestLoadLocal_FileNotFound(t *testing.T) {
 	client := NewMockLocalLoadClient(ctrl)
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", "/no/such/file.zip", "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", "/no/such/file.zip", "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.Error(t, err)
 }
 
@@ -256,7 +256,7 @@ func TestLoadLocal_EmulatorNotRunning_AutoStarts(t *testing.T) {
 	}
 
 	sink := output.NewPlainSink(io.Discard)
-	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", starter, sink)
+	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", nil, snapshot.Decryption{}, starter, nil, false, sink)
 	require.NoError(t, err)
 	assert.True(t, starterCalled, "starter should have been called when emulator is not running")
 }
@@ -273,7 +273,7 @@ func TestLoadLocal_EmulatorNotRunning_NoStarter(t *testing.T) {
 	src := writeSnapshotFile(t, "ZIP_DATA")
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", nil, sink)
+	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nil, nil, false, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 
@@ -298,7 +298,7 @@ func TestLoadLocal_UnhealthyRuntime(t *testing.T) {
 	src := writeSnapshotFile(t, "ZIP_DATA")
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", nopStarter, sink)
+	err := snapshot.LoadLocal(context.Background(), mockRT, awsContainers, client, "", src, "", nil, snapshot.Decryption{}, nopStarter, nil, false, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 }
@@ -307,11 +307,11 @@ func TestLoadPod_Success(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", "").
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", "", gomock.Any()).
 		Return([]string{"s3", "dynamodb"}, nil)
 
 	sink, getEvents := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nil, nopStarter, sink)
 	require.NoError(t, err)
 
 	events := getEvents()
@@ -342,7 +342,7 @@ func TestLoadPod_NoAuthToken(t *testing.T) {
 	loader := NewMockPodLoader(ctrl)
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.LoadPod(context.Background(), runtime.NewMockRuntime(ctrl), awsContainers, loader, "", "my-baseline", 0, "", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), runtime.NewMockRuntime(ctrl), awsContainers, loader, "", "my-baseline", 0, "", "", nil, nopStarter, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "authentication")
 }
@@ -351,11 +351,11 @@ func TestLoadPod_LoaderError(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-baseline", 0, "test-token", gomock.Any(), gomock.Any()).
 		Return(nil, fmt.Errorf("platform unreachable"))
 
 	sink, _ := captureEvents(t)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-baseline", 0, "test-token", "", nil, nopStarter, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "platform unreachable")
 }
@@ -364,11 +364,11 @@ func TestLoadPod_WithMergeStrategy(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", snapshot.MergeStrategyService).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", snapshot.MergeStrategyService, gomock.Any()).
 		Return([]string{"s3"}, nil)
 
 	sink := output.NewPlainSink(io.Discard)
-	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-pod", 0, "tok", snapshot.MergeStrategyService, nopStarter, sink)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-pod", 0, "tok", snapshot.MergeStrategyService, nil, nopStarter, sink)
 	require.NoError(t, err)
 }
 
@@ -381,7 +381,7 @@ func TestLoadPod_EmulatorNotRunning_AutoStarts(t *testing.T) {
 	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	loader := NewMockPodLoader(ctrl)
-	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", gomock.Any()).
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", gomock.Any(), gomock.Any()).
 		Return([]string{"s3"}, nil)
 
 	var starterCalled bool
@@ -391,7 +391,7 @@ func TestLoadPod_EmulatorNotRunning_AutoStarts(t *testing.T) {
 	}
 
 	sink := output.NewPlainSink(io.Discard)
-	err := snapshot.LoadPod(context.Background(), mockRT, awsContainers, loader, "", "my-pod", 0, "tok", "", starter, sink)
+	err := snapshot.LoadPod(context.Background(), mockRT, awsContainers, loader, "", "my-pod", 0, "tok", "", nil, starter, sink)
 	require.NoError(t, err)
 	assert.True(t, starterCalled, "starter should have been called when emulator is not running")
 }
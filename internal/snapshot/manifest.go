@@ -0,0 +1,142 @@
+//go:generate mockgen -source=manifest.go -destination=mock_resource_fetcher_test.go -package=snapshot_test
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/emulator"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+const (
+	ManifestFormatJSON = "json"
+	ManifestFormatYAML = "yaml"
+)
+
+func ValidateManifestFormat(format string) error {
+	switch format {
+	case ManifestFormatJSON, ManifestFormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown manifest format %q: use json or yaml", format)
+	}
+}
+
+// ResourceFetcher is satisfied by aws.Client.
+type ResourceFetcher interface {
+	// FetchResources reads the running emulator's /_localstack/resources inventory.
+	FetchResources(ctx context.Context, host string) ([]emulator.Resource, error)
+}
+
+// Manifest is the running emulator's resource inventory in a stable form,
+// meant to be committed and diffed: entries are sorted and carry only fields
+// that identify a resource, not when or how it was created.
+type Manifest struct {
+	Resources []ManifestResource `json:"resources" yaml:"resources"`
+}
+
+// ManifestResource is one deployed resource. Type is the CloudFormation-style
+// type (e.g. "AWS::S3::Bucket") and Identifier the ID the emulator reports,
+// often an ARN.
+type ManifestResource struct {
+	Type       string `json:"type" yaml:"type"`
+	Identifier string `json:"identifier" yaml:"identifier"`
+	Account    string `json:"account" yaml:"account"`
+	Region     string `json:"region" yaml:"region"`
+}
+
+func newManifest(resources []emulator.Resource) Manifest {
+	m := Manifest{Resources: make([]ManifestResource, 0, len(resources))}
+	for _, r := range resources {
+		m.Resources = append(m.Resources, ManifestResource{
+			Type:       r.Type,
+			Identifier: r.ID,
+			Account:    r.Account,
+			Region:     r.Region,
+		})
+	}
+	sort.Slice(m.Resources, func(i, j int) bool {
+		a, b := m.Resources[i], m.Resources[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Account != b.Account {
+			return a.Account < b.Account
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		return a.Identifier < b.Identifier
+	})
+	return m
+}
+
+// encode writes m to w in format, which ValidateManifestFormat has accepted.
+func (m Manifest) encode(w io.Writer, format string) error {
+	if format == ManifestFormatYAML {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(m); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// ExportManifest writes the running emulator's resource manifest in format to
+// dest, or to out when dest is empty. Progress goes to sink, so it stays out
+// of a manifest written to stdout.
+func ExportManifest(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, fetcher ResourceFetcher, host, format, dest string, out io.Writer, sink output.Sink) error {
+	cwd, _ := os.Getwd()
+	home, _ := os.UserHomeDir()
+	var manifest Manifest
+	return save(ctx, rt, containers, sink,
+		"Exporting resource manifest...",
+		func() {
+			if dest != "" {
+				sink.Emit(output.ResourceManifestExportedEvent{
+					Path:      displayPath(dest, cwd, home),
+					Resources: len(manifest.Resources),
+				})
+			}
+		},
+		func() error {
+			resources, err := fetcher.FetchResources(ctx, host)
+			if err != nil {
+				return err
+			}
+			manifest = newManifest(resources)
+			if dest == "" {
+				return manifest.encode(out, format)
+			}
+			return writeManifest(manifest, dest, format)
+		},
+	)
+}
+
+// writeManifest writes m to a new file at dest, removing it if encoding fails.
+func writeManifest(m Manifest, dest, format string) error {
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("write manifest to %s: %w", dest, err)
+	}
+	if err := m.encode(f, format); err != nil {
+		_ = f.Close()
+		_ = os.Remove(dest)
+		return fmt.Errorf("write manifest to %s: %w", dest, err)
+	}
+	return f.Close()
+}
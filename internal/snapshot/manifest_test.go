@@ -0,0 +1,147 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/emulator"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var deployedResources = []emulator.Resource{
+	{Service: "SQS", Name: "orders", Region: "us-east-1", Account: "000000000000", Type: "AWS::SQS::Queue", ID: "https://sqs.us-east-1.localhost.localstack.cloud:4566/000000000000/orders"},
+	{Service: "S3", Name: "uploads", Region: "us-east-1", Account: "000000000000", Type: "AWS::S3::Bucket", ID: "uploads"},
+	{Service: "S3", Name: "assets", Region: "eu-west-1", Account: "000000000000", Type: "AWS::S3::Bucket", ID: "assets"},
+}
+
+func mockFetcherReturning(t *testing.T, resources []emulator.Resource, err error) *MockResourceFetcher {
+	t.Helper()
+	m := NewMockResourceFetcher(gomock.NewController(t))
+	m.EXPECT().FetchResources(gomock.Any(), gomock.Any()).Return(resources, err)
+	return m
+}
+
+func TestExportManifest_JSON(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), healthyRunningMock(t), awsContainers, mockFetcherReturning(t, deployedResources, nil), "", snapshot.ManifestFormatJSON, "", &out, sink)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "resources": [
+    {
+      "type": "AWS::S3::Bucket",
+      "identifier": "assets",
+      "account": "000000000000",
+      "region": "eu-west-1"
+    },
+    {
+      "type": "AWS::S3::Bucket",
+      "identifier": "uploads",
+      "account": "000000000000",
+      "region": "us-east-1"
+    },
+    {
+      "type": "AWS::SQS::Queue",
+      "identifier": "https://sqs.us-east-1.localhost.localstack.cloud:4566/000000000000/orders",
+      "account": "000000000000",
+      "region": "us-east-1"
+    }
+  ]
+}
+`, out.String())
+	for _, e := range getEvents() {
+		_, ok := e.(output.ResourceManifestExportedEvent)
+		assert.False(t, ok, "a manifest on stdout is not reported as written to a file")
+	}
+}
+
+func TestExportManifest_YAML(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	sink, _ := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), healthyRunningMock(t), awsContainers, mockFetcherReturning(t, deployedResources[1:2], nil), "", snapshot.ManifestFormatYAML, "", &out, sink)
+	require.NoError(t, err)
+	assert.Equal(t, `resources:
+  - type: AWS::S3::Bucket
+    identifier: uploads
+    account: "000000000000"
+    region: us-east-1
+`, out.String())
+}
+
+func TestExportManifest_NoResources(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	sink, _ := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), healthyRunningMock(t), awsContainers, mockFetcherReturning(t, nil, nil), "", snapshot.ManifestFormatJSON, "", &out, sink)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"resources": []}`, out.String())
+}
+
+func TestExportManifest_ToFile(t *testing.T) {
+	t.Parallel()
+	dest := filepath.Join(t.TempDir(), "resources.yaml")
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), healthyRunningMock(t), awsContainers, mockFetcherReturning(t, deployedResources, nil), "", snapshot.ManifestFormatYAML, dest, nil, sink)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "identifier: assets")
+
+	var exported []output.ResourceManifestExportedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ResourceManifestExportedEvent); ok {
+			exported = append(exported, ev)
+		}
+	}
+	require.Len(t, exported, 1)
+	assert.Equal(t, 3, exported[0].Resources)
+}
+
+func TestExportManifest_FetchError(t *testing.T) {
+	t.Parallel()
+	dest := filepath.Join(t.TempDir(), "resources.json")
+	sink, _ := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), healthyRunningMock(t), awsContainers, mockFetcherReturning(t, nil, errors.New("failed to fetch resources: status 500")), "", snapshot.ManifestFormatJSON, dest, nil, sink)
+	require.ErrorContains(t, err, "status 500")
+	assert.NoFileExists(t, dest)
+}
+
+func TestExportManifest_EmulatorNotRunning(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRT := runtime.NewMockRuntime(ctrl)
+	mockRT.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	mockRT.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(false, nil)
+	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	var out bytes.Buffer
+	sink, _ := captureEvents(t)
+
+	err := snapshot.ExportManifest(context.Background(), mockRT, awsContainers, NewMockResourceFetcher(ctrl), "", snapshot.ManifestFormatJSON, "", &out, sink)
+	require.Error(t, err)
+	assert.True(t, output.IsSilent(err))
+	assert.Empty(t, out.String())
+}
+
+func TestValidateManifestFormat(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, snapshot.ValidateManifestFormat("json"))
+	assert.NoError(t, snapshot.ValidateManifestFormat("yaml"))
+	assert.EqualError(t, snapshot.ValidateManifestFormat("toml"), `unknown manifest format "toml": use json or yaml`)
+}
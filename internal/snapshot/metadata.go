@@ -0,0 +1,105 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// archiveMetadata is what lstk records about the emulator a local snapshot was
+// taken from, as JSON in the archive's zip comment: the emulator ignores the
+// comment on import, so recording it never changes what a snapshot loads.
+// Archives without one, e.g. saved by older lstk versions, leave every field
+// empty.
+type archiveMetadata struct {
+	LocalStackVersion string   `json:"localstack_version,omitempty"`
+	Services          []string `json:"services,omitempty"`
+}
+
+func parseArchiveMetadata(comment string) archiveMetadata {
+	var m archiveMetadata
+	if strings.HasPrefix(strings.TrimSpace(comment), "{") {
+		_ = json.Unmarshal([]byte(comment), &m)
+	}
+	return m
+}
+
+// readArchiveMetadata returns the metadata recorded in the local snapshot file
+// at path, decrypting it with dec when it is encrypted. ok is false when there
+// is none to read: the file cannot be opened or decrypted, is not a zip
+// archive, or records nothing.
+func readArchiveMetadata(path string, dec Decryption) (m archiveMetadata, ok bool) {
+	ra, size, f, err := openSnapshotAt(path, dec)
+	if err != nil {
+		return archiveMetadata{}, false
+	}
+	defer func() { _ = f.Close() }()
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return archiveMetadata{}, false
+	}
+	m = parseArchiveMetadata(zr.Comment)
+	return m, m.LocalStackVersion != ""
+}
+
+// exportWithMetadata exports the running emulator's state to dst and records
+// the emulator's version and the exported services in the archive. The version
+// is best-effort: a snapshot is still worth saving without it.
+func exportWithMetadata(ctx context.Context, exporter StateExporter, host string, services []string, dst io.Writer) ([]string, error) {
+	version, _ := exporter.FetchVersion(ctx, host)
+	cw := &commentWriter{w: dst}
+	extracted, err := exporter.ExportState(ctx, host, services, cw)
+	if err != nil {
+		return nil, fmt.Errorf("export state from LocalStack: %w", err)
+	}
+	comment, err := json.Marshal(archiveMetadata{LocalStackVersion: version, Services: extracted})
+	if err != nil {
+		return nil, fmt.Errorf("record snapshot metadata: %w", err)
+	}
+	if err := cw.finish(comment); err != nil {
+		return nil, fmt.Errorf("write snapshot: %w", err)
+	}
+	return extracted, nil
+}
+
+// eocdLen is the size of a zip end-of-central-directory record that carries no
+// comment. It always ends the archive, so the comment length is its last field.
+const eocdLen = 22
+
+var eocdSignature = []byte{'P', 'K', 0x05, 0x06}
+
+// commentWriter passes a zip archive through to w, holding back its last
+// eocdLen bytes so finish can add a comment without buffering the archive.
+type commentWriter struct {
+	w    io.Writer
+	tail []byte
+}
+
+func (c *commentWriter) Write(p []byte) (int, error) {
+	c.tail = append(c.tail, p...)
+	if n := len(c.tail) - eocdLen; n > 0 {
+		if _, err := c.w.Write(c.tail[:n]); err != nil {
+			return 0, err
+		}
+		c.tail = append(c.tail[:0], c.tail[n:]...)
+	}
+	return len(p), nil
+}
+
+// finish writes the held-back end of the archive with comment recorded in it.
+// Anything that does not end in a comment-less end-of-central-directory record
+// is written through unchanged, comment and all.
+func (c *commentWriter) finish(comment []byte) error {
+	if len(c.tail) == eocdLen && bytes.HasPrefix(c.tail, eocdSignature) &&
+		binary.LittleEndian.Uint16(c.tail[20:]) == 0 && len(comment) <= 0xffff {
+		binary.LittleEndian.PutUint16(c.tail[20:], uint16(len(comment)))
+		c.tail = append(c.tail, comment...)
+	}
+	_, err := c.w.Write(c.tail)
+	return err
+}
@@ -0,0 +1,53 @@
+package snapshot_test
+
+import (
+	"archive/zip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLocal_RecordsEmulatorVersion(t *testing.T) {
+	t.Parallel()
+	dest := filepath.Join(t.TempDir(), "snap")
+	body, err := os.ReadFile(writeSnapshotArchive(t, t.TempDir(), "export.zip", "", map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "state"}))
+	require.NoError(t, err)
+	exporter := mockExporterReturning(t, body)
+	sink, _ := captureEvents(t)
+
+	require.NoError(t, snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink))
+
+	r, err := zip.OpenReader(dest)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+	assert.JSONEq(t, `{"localstack_version":"4.3.0"}`, r.Comment)
+	require.Len(t, r.File, 1)
+	f, err := r.File[0].Open()
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "state", string(content), "recording metadata must leave the archive's entries intact")
+}
+
+func TestSaveLocal_KeepsAnExistingComment(t *testing.T) {
+	t.Parallel()
+	dest := filepath.Join(t.TempDir(), "snap")
+	body, err := os.ReadFile(writeSnapshotArchive(t, t.TempDir(), "export.zip", "written by the emulator", map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "state"}))
+	require.NoError(t, err)
+	exporter := mockExporterReturning(t, body)
+	sink, _ := captureEvents(t)
+
+	require.NoError(t, snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink))
+
+	r, err := zip.OpenReader(dest)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+	assert.Equal(t, "written by the emulator", r.Comment)
+}
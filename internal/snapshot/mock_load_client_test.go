@@ -17,6 +17,44 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockServiceResetter is a mock of ServiceResetter interface.
+type MockServiceResetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceResetterMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceResetterMockRecorder is the mock recorder for MockServiceResetter.
+type MockServiceResetterMockRecorder struct {
+	mock *MockServiceResetter
+}
+
+// NewMockServiceResetter creates a new mock instance.
+func NewMockServiceResetter(ctrl *gomock.Controller) *MockServiceResetter {
+	mock := &MockServiceResetter{ctrl: ctrl}
+	mock.recorder = &MockServiceResetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceResetter) EXPECT() *MockServiceResetterMockRecorder {
+	return m.recorder
+}
+
+// ResetServiceState mocks base method.
+func (m *MockServiceResetter) ResetServiceState(ctx context.Context, host, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetServiceState", ctx, host, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetServiceState indicates an expected call of ResetServiceState.
+func (mr *MockServiceResetterMockRecorder) ResetServiceState(ctx, host, service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetServiceState", reflect.TypeOf((*MockServiceResetter)(nil).ResetServiceState), ctx, host, service)
+}
+
 // MockLocalLoadClient is a mock of LocalLoadClient interface.
 type MockLocalLoadClient struct {
 	ctrl     *gomock.Controller
@@ -41,6 +79,21 @@ func (m *MockLocalLoadClient) EXPECT() *MockLocalLoadClientMockRecorder {
 	return m.recorder
 }
 
+// FetchVersion mocks base method.
+func (m *MockLocalLoadClient) FetchVersion(ctx context.Context, host string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchVersion", ctx, host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchVersion indicates an expected call of FetchVersion.
+func (mr *MockLocalLoadClientMockRecorder) FetchVersion(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVersion", reflect.TypeOf((*MockLocalLoadClient)(nil).FetchVersion), ctx, host)
+}
+
 // ImportState mocks base method.
 func (m *MockLocalLoadClient) ImportState(ctx context.Context, host string, src io.Reader, strategy string) error {
 	m.ctrl.T.Helper()
@@ -55,6 +108,20 @@ func (mr *MockLocalLoadClientMockRecorder) ImportState(ctx, host, src, strategy
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportState", reflect.TypeOf((*MockLocalLoadClient)(nil).ImportState), ctx, host, src, strategy)
 }
 
+// ResetServiceState mocks base method.
+func (m *MockLocalLoadClient) ResetServiceState(ctx context.Context, host, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetServiceState", ctx, host, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetServiceState indicates an expected call of ResetServiceState.
+func (mr *MockLocalLoadClientMockRecorder) ResetServiceState(ctx, host, service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetServiceState", reflect.TypeOf((*MockLocalLoadClient)(nil).ResetServiceState), ctx, host, service)
+}
+
 // ResetState mocks base method.
 func (m *MockLocalLoadClient) ResetState(ctx context.Context, host string) error {
 	m.ctrl.T.Helper()
@@ -93,17 +160,46 @@ func (m *MockPodLoader) EXPECT() *MockPodLoaderMockRecorder {
 	return m.recorder
 }
 
+// FetchVersion mocks base method.
+func (m *MockPodLoader) FetchVersion(ctx context.Context, host string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchVersion", ctx, host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchVersion indicates an expected call of FetchVersion.
+func (mr *MockPodLoaderMockRecorder) FetchVersion(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVersion", reflect.TypeOf((*MockPodLoader)(nil).FetchVersion), ctx, host)
+}
+
 // LoadPodSnapshot mocks base method.
-func (m *MockPodLoader) LoadPodSnapshot(ctx context.Context, host, podName string, version int, authToken, strategy string) ([]string, error) {
+func (m *MockPodLoader) LoadPodSnapshot(ctx context.Context, host, podName string, version int, authToken, strategy string, services []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadPodSnapshot", ctx, host, podName, version, authToken, strategy)
+	ret := m.ctrl.Call(m, "LoadPodSnapshot", ctx, host, podName, version, authToken, strategy, services)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoadPodSnapshot indicates an expected call of LoadPodSnapshot.
-func (mr *MockPodLoaderMockRecorder) LoadPodSnapshot(ctx, host, podName, version, authToken, strategy any) *gomock.Call {
+func (mr *MockPodLoaderMockRecorder) LoadPodSnapshot(ctx, host, podName, version, authToken, strategy, services any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPodSnapshot", reflect.TypeOf((*MockPodLoader)(nil).LoadPodSnapshot), ctx, host, podName, version, authToken, strategy, services)
+}
+
+// ResetServiceState mocks base method.
+func (m *MockPodLoader) ResetServiceState(ctx context.Context, host, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetServiceState", ctx, host, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetServiceState indicates an expected call of ResetServiceState.
+func (mr *MockPodLoaderMockRecorder) ResetServiceState(ctx, host, service any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPodSnapshot", reflect.TypeOf((*MockPodLoader)(nil).LoadPodSnapshot), ctx, host, podName, version, authToken, strategy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetServiceState", reflect.TypeOf((*MockPodLoader)(nil).ResetServiceState), ctx, host, service)
 }
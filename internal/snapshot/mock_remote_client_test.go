@@ -11,6 +11,7 @@ package snapshot_test
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	snapshot "github.com/localstack/lstk/internal/snapshot"
@@ -41,6 +42,21 @@ func (m *MockRemoteClient) EXPECT() *MockRemoteClientMockRecorder {
 	return m.recorder
 }
 
+// FetchVersion mocks base method.
+func (m *MockRemoteClient) FetchVersion(ctx context.Context, host string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchVersion", ctx, host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchVersion indicates an expected call of FetchVersion.
+func (mr *MockRemoteClientMockRecorder) FetchVersion(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVersion", reflect.TypeOf((*MockRemoteClient)(nil).FetchVersion), ctx, host)
+}
+
 // ListPodsRemote mocks base method.
 func (m *MockRemoteClient) ListPodsRemote(ctx context.Context, host, remoteName string, params map[string]string, authToken, creator string) ([]snapshot.RemotePod, error) {
 	m.ctrl.T.Helper()
@@ -57,18 +73,18 @@ func (mr *MockRemoteClientMockRecorder) ListPodsRemote(ctx, host, remoteName, pa
 }
 
 // LoadPodRemote mocks base method.
-func (m *MockRemoteClient) LoadPodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken, strategy string) ([]string, error) {
+func (m *MockRemoteClient) LoadPodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken, strategy string, services []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoadPodRemote", ctx, host, podName, remoteName, params, authToken, strategy)
+	ret := m.ctrl.Call(m, "LoadPodRemote", ctx, host, podName, remoteName, params, authToken, strategy, services)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoadPodRemote indicates an expected call of LoadPodRemote.
-func (mr *MockRemoteClientMockRecorder) LoadPodRemote(ctx, host, podName, remoteName, params, authToken, strategy any) *gomock.Call {
+func (mr *MockRemoteClientMockRecorder) LoadPodRemote(ctx, host, podName, remoteName, params, authToken, strategy, services any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPodRemote", reflect.TypeOf((*MockRemoteClient)(nil).LoadPodRemote), ctx, host, podName, remoteName, params, authToken, strategy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPodRemote", reflect.TypeOf((*MockRemoteClient)(nil).LoadPodRemote), ctx, host, podName, remoteName, params, authToken, strategy, services)
 }
 
 // RegisterRemote mocks base method.
@@ -85,6 +101,20 @@ func (mr *MockRemoteClientMockRecorder) RegisterRemote(ctx, host, name, remoteUR
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRemote", reflect.TypeOf((*MockRemoteClient)(nil).RegisterRemote), ctx, host, name, remoteURL)
 }
 
+// ResetServiceState mocks base method.
+func (m *MockRemoteClient) ResetServiceState(ctx context.Context, host, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetServiceState", ctx, host, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetServiceState indicates an expected call of ResetServiceState.
+func (mr *MockRemoteClientMockRecorder) ResetServiceState(ctx, host, service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetServiceState", reflect.TypeOf((*MockRemoteClient)(nil).ResetServiceState), ctx, host, service)
+}
+
 // S3BucketExists mocks base method.
 func (m *MockRemoteClient) S3BucketExists(ctx context.Context, bucket string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -114,3 +144,253 @@ func (mr *MockRemoteClientMockRecorder) SavePodRemote(ctx, host, podName, remote
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePodRemote", reflect.TypeOf((*MockRemoteClient)(nil).SavePodRemote), ctx, host, podName, remoteName, params, authToken, services)
 }
+
+// MockS3ObjectStore is a mock of S3ObjectStore interface.
+type MockS3ObjectStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockS3ObjectStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockS3ObjectStoreMockRecorder is the mock recorder for MockS3ObjectStore.
+type MockS3ObjectStoreMockRecorder struct {
+	mock *MockS3ObjectStore
+}
+
+// NewMockS3ObjectStore creates a new mock instance.
+func NewMockS3ObjectStore(ctrl *gomock.Controller) *MockS3ObjectStore {
+	mock := &MockS3ObjectStore{ctrl: ctrl}
+	mock.recorder = &MockS3ObjectStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockS3ObjectStore) EXPECT() *MockS3ObjectStoreMockRecorder {
+	return m.recorder
+}
+
+// GetS3Object mocks base method.
+func (m *MockS3ObjectStore) GetS3Object(ctx context.Context, s3URL, name string, creds snapshot.S3Credentials, dst io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetS3Object", ctx, s3URL, name, creds, dst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetS3Object indicates an expected call of GetS3Object.
+func (mr *MockS3ObjectStoreMockRecorder) GetS3Object(ctx, s3URL, name, creds, dst any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetS3Object", reflect.TypeOf((*MockS3ObjectStore)(nil).GetS3Object), ctx, s3URL, name, creds, dst)
+}
+
+// PutS3Object mocks base method.
+func (m *MockS3ObjectStore) PutS3Object(ctx context.Context, s3URL, name string, creds snapshot.S3Credentials, body io.Reader, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutS3Object", ctx, s3URL, name, creds, body, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutS3Object indicates an expected call of PutS3Object.
+func (mr *MockS3ObjectStoreMockRecorder) PutS3Object(ctx, s3URL, name, creds, body, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutS3Object", reflect.TypeOf((*MockS3ObjectStore)(nil).PutS3Object), ctx, s3URL, name, creds, body, size)
+}
+
+// S3BucketExists mocks base method.
+func (m *MockS3ObjectStore) S3BucketExists(ctx context.Context, bucket string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "S3BucketExists", ctx, bucket)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// S3BucketExists indicates an expected call of S3BucketExists.
+func (mr *MockS3ObjectStoreMockRecorder) S3BucketExists(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "S3BucketExists", reflect.TypeOf((*MockS3ObjectStore)(nil).S3BucketExists), ctx, bucket)
+}
+
+// MockEncryptedS3Client is a mock of EncryptedS3Client interface.
+type MockEncryptedS3Client struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptedS3ClientMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptedS3ClientMockRecorder is the mock recorder for MockEncryptedS3Client.
+type MockEncryptedS3ClientMockRecorder struct {
+	mock *MockEncryptedS3Client
+}
+
+// NewMockEncryptedS3Client creates a new mock instance.
+func NewMockEncryptedS3Client(ctrl *gomock.Controller) *MockEncryptedS3Client {
+	mock := &MockEncryptedS3Client{ctrl: ctrl}
+	mock.recorder = &MockEncryptedS3ClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptedS3Client) EXPECT() *MockEncryptedS3ClientMockRecorder {
+	return m.recorder
+}
+
+// FetchVersion mocks base method.
+func (m *MockEncryptedS3Client) FetchVersion(ctx context.Context, host string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchVersion", ctx, host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchVersion indicates an expected call of FetchVersion.
+func (mr *MockEncryptedS3ClientMockRecorder) FetchVersion(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVersion", reflect.TypeOf((*MockEncryptedS3Client)(nil).FetchVersion), ctx, host)
+}
+
+// GetS3Object mocks base method.
+func (m *MockEncryptedS3Client) GetS3Object(ctx context.Context, s3URL, name string, creds snapshot.S3Credentials, dst io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetS3Object", ctx, s3URL, name, creds, dst)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetS3Object indicates an expected call of GetS3Object.
+func (mr *MockEncryptedS3ClientMockRecorder) GetS3Object(ctx, s3URL, name, creds, dst any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetS3Object", reflect.TypeOf((*MockEncryptedS3Client)(nil).GetS3Object), ctx, s3URL, name, creds, dst)
+}
+
+// ImportState mocks base method.
+func (m *MockEncryptedS3Client) ImportState(ctx context.Context, host string, src io.Reader, strategy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportState", ctx, host, src, strategy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportState indicates an expected call of ImportState.
+func (mr *MockEncryptedS3ClientMockRecorder) ImportState(ctx, host, src, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportState", reflect.TypeOf((*MockEncryptedS3Client)(nil).ImportState), ctx, host, src, strategy)
+}
+
+// ListPodsRemote mocks base method.
+func (m *MockEncryptedS3Client) ListPodsRemote(ctx context.Context, host, remoteName string, params map[string]string, authToken, creator string) ([]snapshot.RemotePod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPodsRemote", ctx, host, remoteName, params, authToken, creator)
+	ret0, _ := ret[0].([]snapshot.RemotePod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPodsRemote indicates an expected call of ListPodsRemote.
+func (mr *MockEncryptedS3ClientMockRecorder) ListPodsRemote(ctx, host, remoteName, params, authToken, creator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodsRemote", reflect.TypeOf((*MockEncryptedS3Client)(nil).ListPodsRemote), ctx, host, remoteName, params, authToken, creator)
+}
+
+// LoadPodRemote mocks base method.
+func (m *MockEncryptedS3Client) LoadPodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken, strategy string, services []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadPodRemote", ctx, host, podName, remoteName, params, authToken, strategy, services)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadPodRemote indicates an expected call of LoadPodRemote.
+func (mr *MockEncryptedS3ClientMockRecorder) LoadPodRemote(ctx, host, podName, remoteName, params, authToken, strategy, services any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPodRemote", reflect.TypeOf((*MockEncryptedS3Client)(nil).LoadPodRemote), ctx, host, podName, remoteName, params, authToken, strategy, services)
+}
+
+// PutS3Object mocks base method.
+func (m *MockEncryptedS3Client) PutS3Object(ctx context.Context, s3URL, name string, creds snapshot.S3Credentials, body io.Reader, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutS3Object", ctx, s3URL, name, creds, body, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutS3Object indicates an expected call of PutS3Object.
+func (mr *MockEncryptedS3ClientMockRecorder) PutS3Object(ctx, s3URL, name, creds, body, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutS3Object", reflect.TypeOf((*MockEncryptedS3Client)(nil).PutS3Object), ctx, s3URL, name, creds, body, size)
+}
+
+// RegisterRemote mocks base method.
+func (m *MockEncryptedS3Client) RegisterRemote(ctx context.Context, host, name, remoteURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterRemote", ctx, host, name, remoteURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterRemote indicates an expected call of RegisterRemote.
+func (mr *MockEncryptedS3ClientMockRecorder) RegisterRemote(ctx, host, name, remoteURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRemote", reflect.TypeOf((*MockEncryptedS3Client)(nil).RegisterRemote), ctx, host, name, remoteURL)
+}
+
+// ResetServiceState mocks base method.
+func (m *MockEncryptedS3Client) ResetServiceState(ctx context.Context, host, service string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetServiceState", ctx, host, service)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetServiceState indicates an expected call of ResetServiceState.
+func (mr *MockEncryptedS3ClientMockRecorder) ResetServiceState(ctx, host, service any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetServiceState", reflect.TypeOf((*MockEncryptedS3Client)(nil).ResetServiceState), ctx, host, service)
+}
+
+// ResetState mocks base method.
+func (m *MockEncryptedS3Client) ResetState(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetState", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetState indicates an expected call of ResetState.
+func (mr *MockEncryptedS3ClientMockRecorder) ResetState(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetState", reflect.TypeOf((*MockEncryptedS3Client)(nil).ResetState), ctx, host)
+}
+
+// S3BucketExists mocks base method.
+func (m *MockEncryptedS3Client) S3BucketExists(ctx context.Context, bucket string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "S3BucketExists", ctx, bucket)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// S3BucketExists indicates an expected call of S3BucketExists.
+func (mr *MockEncryptedS3ClientMockRecorder) S3BucketExists(ctx, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "S3BucketExists", reflect.TypeOf((*MockEncryptedS3Client)(nil).S3BucketExists), ctx, bucket)
+}
+
+// SavePodRemote mocks base method.
+func (m *MockEncryptedS3Client) SavePodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken string, services []string) (snapshot.PodSaveResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePodRemote", ctx, host, podName, remoteName, params, authToken, services)
+	ret0, _ := ret[0].(snapshot.PodSaveResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavePodRemote indicates an expected call of SavePodRemote.
+func (mr *MockEncryptedS3ClientMockRecorder) SavePodRemote(ctx, host, podName, remoteName, params, authToken, services any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePodRemote", reflect.TypeOf((*MockEncryptedS3Client)(nil).SavePodRemote), ctx, host, podName, remoteName, params, authToken, services)
+}
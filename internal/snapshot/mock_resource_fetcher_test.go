@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: manifest.go
+//
+// Generated by this command:
+//
+//	mockgen -source=manifest.go -destination=mock_resource_fetcher_test.go -package=snapshot_test
+//
+
+// Package snapshot_test is a generated GoMock package.
+package snapshot_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	emulator "github.com/localstack/lstk/internal/emulator"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResourceFetcher is a mock of ResourceFetcher interface.
+type MockResourceFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockResourceFetcherMockRecorder is the mock recorder for MockResourceFetcher.
+type MockResourceFetcherMockRecorder struct {
+	mock *MockResourceFetcher
+}
+
+// NewMockResourceFetcher creates a new mock instance.
+func NewMockResourceFetcher(ctrl *gomock.Controller) *MockResourceFetcher {
+	mock := &MockResourceFetcher{ctrl: ctrl}
+	mock.recorder = &MockResourceFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceFetcher) EXPECT() *MockResourceFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchResources mocks base method.
+func (m *MockResourceFetcher) FetchResources(ctx context.Context, host string) ([]emulator.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchResources", ctx, host)
+	ret0, _ := ret[0].([]emulator.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchResources indicates an expected call of FetchResources.
+func (mr *MockResourceFetcherMockRecorder) FetchResources(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchResources", reflect.TypeOf((*MockResourceFetcher)(nil).FetchResources), ctx, host)
+}
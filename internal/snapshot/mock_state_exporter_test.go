@@ -57,6 +57,21 @@ func (mr *MockStateExporterMockRecorder) ExportState(ctx, host, services, dst an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportState", reflect.TypeOf((*MockStateExporter)(nil).ExportState), ctx, host, services, dst)
 }
 
+// FetchVersion mocks base method.
+func (m *MockStateExporter) FetchVersion(ctx context.Context, host string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchVersion", ctx, host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchVersion indicates an expected call of FetchVersion.
+func (mr *MockStateExporterMockRecorder) FetchVersion(ctx, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchVersion", reflect.TypeOf((*MockStateExporter)(nil).FetchVersion), ctx, host)
+}
+
 // MockPodSaver is a mock of PodSaver interface.
 type MockPodSaver struct {
 	ctrl     *gomock.Controller
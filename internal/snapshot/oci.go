@@ -0,0 +1,324 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+const (
+	// ociArtifactType marks a manifest as a LocalStack snapshot, so load can
+	// tell one apart from a container image or another tool's artifact.
+	ociArtifactType = "application/vnd.localstack.snapshot.v1"
+	// ociLayerMediaType is the media type of the single layer holding the
+	// snapshot archive, byte for byte what a local save writes.
+	ociLayerMediaType = "application/vnd.localstack.snapshot.layer.v1+zip"
+	// ociServicesAnnotation lists the services captured, comma-separated.
+	ociServicesAnnotation = "cloud.localstack.snapshot.services"
+	// defaultOCITag is used for an oras:// reference without a tag.
+	defaultOCITag = "latest"
+)
+
+// ErrNotSnapshotArtifact is returned when an oras:// reference resolves to
+// something other than a snapshot pushed by lstk (e.g. a container image).
+var ErrNotSnapshotArtifact = errors.New("not a LocalStack snapshot")
+
+// newOCIRepository returns a client for the repository in ref (an oras://
+// reference without the prefix), authenticating with the credentials `docker
+// login` stored. Loopback registries are reached over plain HTTP, as Docker
+// does for localhost.
+func newOCIRepository(ref string) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oras:// reference %q: %w", "oras://"+ref, err)
+	}
+	store, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("read Docker credentials: %w", err)
+	}
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Cache:      auth.NewCache(),
+		Credential: credentials.Credential(store),
+	}
+	repo.PlainHTTP = isLoopbackRegistry(repo.Reference.Registry)
+	return repo, nil
+}
+
+func isLoopbackRegistry(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ociError adds the likely fix to a registry failure: an authentication error
+// almost always means `docker login` has not been run for that registry.
+func ociError(action string, ref registry.Reference, err error) error {
+	var resp *errcode.ErrorResponse
+	if errors.As(err, &resp) && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) {
+		return fmt.Errorf("%s: %w — log in with %q", action, err, "docker login "+ref.Registry)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// SaveOCI saves the running emulator's state and pushes it to the OCI registry
+// reference ref (registry/repository:tag, without the oras:// prefix) as a
+// single-layer artifact. services, when non-empty, limits the save to that
+// subset of services.
+func SaveOCI(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, host, ref string, services []string, sink output.Sink) error {
+	repo, err := newOCIRepository(ref)
+	if err != nil {
+		return err
+	}
+	location := "oras://" + repo.Reference.Registry + "/" + repo.Reference.Repository
+	var extracted []string
+	var size int64
+	return save(ctx, rt, containers, sink,
+		fmt.Sprintf("Saving snapshot to oras://%s...", ref),
+		func() {
+			sink.Emit(output.RemoteSnapshotSavedEvent{
+				PodName:  repo.Reference.Reference,
+				Location: location,
+				Services: extracted,
+				Size:     size,
+			})
+		},
+		func() error {
+			f, err := os.CreateTemp("", "lstk-snapshot-*"+snapshotExt)
+			if err != nil {
+				return fmt.Errorf("create temporary file: %w", err)
+			}
+			defer func() {
+				_ = f.Close()
+				_ = os.Remove(f.Name())
+			}()
+
+			hash := sha256.New()
+			counter := &countingWriter{w: io.MultiWriter(f, hash)}
+			extracted, err = exporter.ExportState(ctx, host, services, counter)
+			if err != nil {
+				return fmt.Errorf("export state from LocalStack: %w", err)
+			}
+			size = counter.n
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return fmt.Errorf("read exported state: %w", err)
+			}
+
+			layer := ocispec.Descriptor{
+				MediaType: ociLayerMediaType,
+				Digest:    digest.NewDigest(digest.SHA256, hash),
+				Size:      size,
+				Annotations: map[string]string{
+					ocispec.AnnotationTitle: path.Base(repo.Reference.Repository) + snapshotExt,
+				},
+			}
+			if err := repo.Push(ctx, layer, f); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+				return ociError("push snapshot", repo.Reference, err)
+			}
+			annotations := map[string]string{}
+			if len(extracted) > 0 {
+				annotations[ociServicesAnnotation] = strings.Join(extracted, ",")
+			}
+			manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, ociArtifactType, oras.PackManifestOptions{
+				Layers:              []ocispec.Descriptor{layer},
+				ManifestAnnotations: annotations,
+			})
+			if err != nil {
+				return ociError("push snapshot manifest", repo.Reference, err)
+			}
+			if err := repo.Tag(ctx, manifest, repo.Reference.Reference); err != nil {
+				return ociError("tag snapshot", repo.Reference, err)
+			}
+			return nil
+		},
+	)
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// LoadOCI pulls the snapshot at the OCI registry reference ref and loads it
+// into the running emulator, starting it first if needed.
+func LoadOCI(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client LocalLoadClient, host, ref, strategy string, services []string, starter Starter, sink output.Sink) error {
+	repo, err := newOCIRepository(ref)
+	if err != nil {
+		return err
+	}
+	loaded := output.SnapshotLoadedEvent{Source: "oras://" + ref}
+	err = load(ctx, rt, containers, sink, starter, nil,
+		fmt.Sprintf("Loading snapshot from oras://%s...", ref),
+		func() {
+			sink.Emit(loaded)
+		},
+		func() error {
+			src, pulled, err := pullOCISnapshot(ctx, repo)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = os.Remove(src) }()
+			loaded.Services = pulled
+			return importFile(ctx, client, host, src, strategy, services, Decryption{}, &loaded, sink)
+		},
+	)
+	// Handled here rather than in load(), which has no registry to point at.
+	if errors.Is(err, errdef.ErrNotFound) {
+		repository := "oras://" + repo.Reference.Registry + "/" + repo.Reference.Repository
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not load snapshot",
+			Summary: fmt.Sprintf("oras://%s was not found in the registry", ref),
+			Actions: []output.ErrorAction{
+				{Label: "List the tags:", Value: "lstk snapshot list " + repository},
+			},
+		})
+		return output.NewSilentError(err)
+	}
+	if errors.Is(err, ErrNotSnapshotArtifact) {
+		sink.Emit(output.ErrorEvent{
+			Title:   "Could not load snapshot",
+			Summary: fmt.Sprintf("oras://%s is not a LocalStack snapshot", ref),
+		})
+		return output.NewSilentError(err)
+	}
+	return err
+}
+
+// pullOCISnapshot downloads the snapshot layer of repo's reference to a
+// temporary file, verifying its digest, and returns the file and the services
+// recorded at save time. The caller removes the file.
+func pullOCISnapshot(ctx context.Context, repo *remote.Repository) (string, []string, error) {
+	desc, rc, err := repo.FetchReference(ctx, repo.Reference.Reference)
+	if err != nil {
+		return "", nil, ociError("fetch snapshot manifest", repo.Reference, err)
+	}
+	manifestBytes, err := content.ReadAll(rc, desc)
+	_ = rc.Close()
+	if err != nil {
+		return "", nil, ociError("fetch snapshot manifest", repo.Reference, err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil || manifest.ArtifactType != ociArtifactType {
+		return "", nil, ErrNotSnapshotArtifact
+	}
+	var layer *ocispec.Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == ociLayerMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return "", nil, ErrNotSnapshotArtifact
+	}
+
+	blob, err := repo.Fetch(ctx, *layer)
+	if err != nil {
+		return "", nil, ociError("pull snapshot", repo.Reference, err)
+	}
+	defer func() { _ = blob.Close() }()
+	f, err := os.CreateTemp("", "lstk-snapshot-*"+snapshotExt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create temporary file: %w", err)
+	}
+	verify := content.NewVerifyReader(blob, *layer)
+	_, err = io.Copy(f, verify)
+	if err == nil {
+		err = verify.Verify()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", nil, ociError("pull snapshot", repo.Reference, err)
+	}
+
+	var services []string
+	if s := manifest.Annotations[ociServicesAnnotation]; s != "" {
+		services = strings.Split(s, ",")
+	}
+	return f.Name(), services, nil
+}
+
+// ListOCI lists the tags of the OCI registry repository (registry/repository,
+// without the oras:// prefix). Unlike ListRemoteS3 it needs no emulator: lstk
+// talks to the registry itself.
+func ListOCI(ctx context.Context, repository string, sink output.Sink) error {
+	repo, err := newOCIRepository(repository)
+	if err != nil {
+		return err
+	}
+	location := "oras://" + repository
+
+	sink.Emit(output.SpinnerStart("Fetching snapshots"))
+	var tags []string
+	err = repo.Tags(ctx, "", func(page []string) error {
+		tags = append(tags, page...)
+		return nil
+	})
+	sink.Emit(output.SpinnerStop())
+	// A registry reports a repository nothing was pushed to yet as not found.
+	var resp *errcode.ErrorResponse
+	if errors.As(err, &resp) && resp.StatusCode == http.StatusNotFound {
+		err = nil
+	}
+	if err != nil {
+		return ociError("list snapshots on "+location, repo.Reference, err)
+	}
+
+	if len(tags) == 0 {
+		sink.Emit(output.DeferredEvent{Inner: output.MessageEvent{Severity: output.SeverityNote, Text: fmt.Sprintf("No snapshots found on %s", location)}})
+		return nil
+	}
+	noun := "snapshots"
+	if len(tags) == 1 {
+		noun = "snapshot"
+	}
+	rows := make([][]string, len(tags))
+	for i, tag := range tags {
+		rows[i] = []string{tag, location + ":" + tag}
+	}
+	sink.Emit(output.DeferredEvent{Inner: output.MessageEvent{Severity: output.SeveritySecondary, Text: fmt.Sprintf("~ %d %s\n", len(tags), noun)}})
+	sink.Emit(output.DeferredEvent{Inner: output.TableEvent{
+		Headers: []string{"Tag", "Reference"},
+		Rows:    rows,
+	}})
+	return nil
+}
@@ -0,0 +1,152 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// startRegistry serves an in-process OCI registry and returns its host:port.
+// Loopback registries are reached over plain HTTP, as with Docker.
+func startRegistry(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestOCI_SaveListLoadRoundTrip(t *testing.T) {
+	t.Parallel()
+	host := startRegistry(t)
+
+	ctrl := gomock.NewController(t)
+	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	exporter.EXPECT().ExportState(gomock.Any(), gomock.Any(), []string{"s3"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
+			_, err := dst.Write([]byte("ZIP_DATA"))
+			return []string{"s3"}, err
+		},
+	)
+	sink, getEvents := captureEvents(t)
+	err := snapshot.SaveOCI(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", host+"/team/baseline:v3", []string{"s3"}, sink)
+	require.NoError(t, err)
+
+	var saved *output.RemoteSnapshotSavedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.RemoteSnapshotSavedEvent); ok {
+			saved = &ev
+		}
+	}
+	require.NotNil(t, saved, "RemoteSnapshotSavedEvent should have been emitted")
+	assert.Equal(t, "v3", saved.PodName)
+	assert.Equal(t, "oras://"+host+"/team/baseline", saved.Location)
+	assert.Equal(t, []string{"s3"}, saved.Services)
+	assert.Equal(t, int64(len("ZIP_DATA")), saved.Size)
+
+	listSink, getListEvents := captureEvents(t)
+	require.NoError(t, snapshot.ListOCI(context.Background(), host+"/team/baseline", listSink))
+	var table *output.TableEvent
+	for _, e := range getListEvents() {
+		if d, ok := e.(output.DeferredEvent); ok {
+			if ev, ok := d.Inner.(output.TableEvent); ok {
+				table = &ev
+			}
+		}
+	}
+	require.NotNil(t, table, "list should emit a table")
+	assert.Equal(t, [][]string{{"v3", "oras://" + host + "/team/baseline:v3"}}, table.Rows)
+
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	var imported string
+	client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), "").DoAndReturn(
+		func(_ context.Context, _ string, src io.Reader, _ string) error {
+			b, err := io.ReadAll(src)
+			imported = string(b)
+			return err
+		},
+	)
+	loadSink, getLoadEvents := captureEvents(t)
+	err = snapshot.LoadOCI(context.Background(), healthyRunningMock(t), awsContainers, client, "", host+"/team/baseline:v3", "", nil, nopStarter, loadSink)
+	require.NoError(t, err)
+	assert.Equal(t, "ZIP_DATA", imported, "the pulled layer must be the exported archive, byte for byte")
+
+	var loaded *output.SnapshotLoadedEvent
+	for _, e := range getLoadEvents() {
+		if ev, ok := e.(output.SnapshotLoadedEvent); ok {
+			loaded = &ev
+		}
+	}
+	require.NotNil(t, loaded, "SnapshotLoadedEvent should have been emitted")
+	assert.Equal(t, "oras://"+host+"/team/baseline:v3", loaded.Source)
+	assert.Equal(t, []string{"s3"}, loaded.Services)
+}
+
+func TestLoadOCI_TagNotFound(t *testing.T) {
+	t.Parallel()
+	host := startRegistry(t)
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadOCI(context.Background(), healthyRunningMock(t), awsContainers, client, "", host+"/team/baseline:missing", "", nil, nopStarter, sink)
+	require.Error(t, err)
+	assert.True(t, output.IsSilent(err), "the error is reported through the sink")
+
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	assert.Contains(t, errEvent.Summary, "was not found")
+	require.Len(t, errEvent.Actions, 1)
+	assert.Equal(t, "lstk snapshot list oras://"+host+"/team/baseline", errEvent.Actions[0].Value)
+}
+
+func TestListOCI_EmptyRepository(t *testing.T) {
+	t.Parallel()
+	host := startRegistry(t)
+	sink, getEvents := captureEvents(t)
+
+	require.NoError(t, snapshot.ListOCI(context.Background(), host+"/team/nothing-yet", sink))
+
+	var note string
+	for _, e := range getEvents() {
+		if d, ok := e.(output.DeferredEvent); ok {
+			if ev, ok := d.Inner.(output.MessageEvent); ok {
+				note = ev.Text
+			}
+		}
+	}
+	assert.Equal(t, "No snapshots found on oras://"+host+"/team/nothing-yet", note)
+}
+
+func TestSaveOCI_ExportErrorPushesNothing(t *testing.T) {
+	t.Parallel()
+	host := startRegistry(t)
+	sink, _ := captureEvents(t)
+
+	err := snapshot.SaveOCI(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturningError(t, errors.New("boom")), "", host+"/team/baseline:v1", nil, sink)
+	require.Error(t, err)
+
+	listSink, getListEvents := captureEvents(t)
+	require.NoError(t, snapshot.ListOCI(context.Background(), host+"/team/baseline", listSink))
+	for _, e := range getListEvents() {
+		if d, ok := e.(output.DeferredEvent); ok {
+			_, isTable := d.Inner.(output.TableEvent)
+			assert.False(t, isTable, "a failed export must not leave a tag behind")
+		}
+	}
+}
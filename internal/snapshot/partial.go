@@ -0,0 +1,193 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+// serviceSelection is how a --services filter applies to a snapshot archive.
+type serviceSelection struct {
+	// loaded are the requested services the archive holds state for.
+	loaded []string
+	// skipped are the services the archive holds that were not requested.
+	skipped []string
+	// missing are the requested services the archive holds no state for.
+	missing []string
+}
+
+// selectServices reads the archive in ra (size bytes long) and returns a copy
+// of it holding only the state of services, plus top-level archive metadata the
+// emulator needs to import it. The copy is written as it is read, so neither
+// archive is ever held in memory; the caller must close it, which stops the
+// copy if it was not read to the end.
+func selectServices(ra io.ReaderAt, size int64, services []string) (io.ReadCloser, serviceSelection, error) {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		return nil, serviceSelection{}, ErrInvalidSnapshotFile
+	}
+
+	held := map[string]bool{}
+	for _, f := range zr.File {
+		if s := archiveEntryService(f.Name); s != "" {
+			held[s] = slices.Contains(services, s)
+		}
+	}
+	var sel serviceSelection
+	for _, s := range sortedKeys(held) {
+		if held[s] {
+			sel.loaded = append(sel.loaded, s)
+		} else {
+			sel.skipped = append(sel.skipped, s)
+		}
+	}
+	for _, s := range services {
+		if _, ok := held[s]; !ok {
+			sel.missing = append(sel.missing, s)
+		}
+	}
+	if len(sel.loaded) == 0 {
+		if len(sel.skipped) == 0 {
+			return nil, sel, fmt.Errorf("snapshot holds no state for %s, nor for any other service", strings.Join(services, ", "))
+		}
+		return nil, sel, fmt.Errorf("snapshot holds no state for %s; it holds state for %s", strings.Join(services, ", "), strings.Join(sel.skipped, ", "))
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeSelected(pw, zr, held))
+	}()
+	return pr, sel, nil
+}
+
+// writeSelected writes to w the entries of zr that are not the state of a
+// service held maps to false.
+func writeSelected(w io.Writer, zr *zip.Reader, held map[string]bool) error {
+	zw := zip.NewWriter(w)
+	for _, f := range zr.File {
+		if s := archiveEntryService(f.Name); s != "" && !held[s] {
+			continue
+		}
+		if err := zw.Copy(f); err != nil {
+			return fmt.Errorf("select services: %w", err)
+		}
+	}
+	if err := zw.SetComment(zr.Comment); err != nil {
+		return fmt.Errorf("select services: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("select services: %w", err)
+	}
+	return nil
+}
+
+// emitMissingServices warns about requested services a partial load could not
+// restore because the snapshot holds no state for them.
+func emitMissingServices(sel serviceSelection, sink output.Sink) {
+	if len(sel.missing) == 0 {
+		return
+	}
+	sink.Emit(output.MessageEvent{
+		Severity: output.SeverityWarning,
+		Text:     fmt.Sprintf("The snapshot holds no state for %s; loading %s only.", strings.Join(sel.missing, ", "), strings.Join(sel.loaded, ", ")),
+	})
+}
+
+// resetServices implements the overwrite strategy of a partial load
+// client-side: only the selected services are wiped before the import, so
+// the state of every other service survives. Callers then import with the
+// server default (account-region-merge on clean state = overwrite).
+func resetServices(ctx context.Context, resetter ServiceResetter, host string, services []string) error {
+	for _, s := range services {
+		if err := resetter.ResetServiceState(ctx, host, s); err != nil {
+			return fmt.Errorf("reset %s state: %w", s, err)
+		}
+	}
+	return nil
+}
+
+// ErrServicesFilterUnsupported indicates the running emulator predates the
+// services filter of pod loads, and would restore every service a pod or S3
+// snapshot holds.
+var ErrServicesFilterUnsupported = errors.New("the emulator does not support loading only some services")
+
+// versionFetcher reports the running emulator's version.
+type versionFetcher interface {
+	FetchVersion(ctx context.Context, host string) (string, error)
+}
+
+// servicesFilterRelease is the first LocalStack release whose pod loads honour
+// the attributes.services filter of the load request: LocalStack 4.4 added it,
+// and earlier emulators ignore the attribute and restore every service.
+func servicesFilterRelease() release {
+	return release{4, 4}
+}
+
+// requireServicesFilter refuses a partial load the emulator performs itself
+// (pods and S3 remotes) when the running emulator predates the services
+// filter, before anything is reset or imported. An emulator whose version
+// cannot be parsed is given the benefit of the doubt; emitUnrequestedServices
+// still reports any service it restores beyond the selection.
+func requireServicesFilter(ctx context.Context, client versionFetcher, host string, services []string, sink output.Sink) error {
+	if len(services) == 0 {
+		return nil
+	}
+	version, err := client.FetchVersion(ctx, host)
+	if err != nil {
+		return fmt.Errorf("check --services support: %w", err)
+	}
+	running, ok := parseRelease(version)
+	if !ok || !running.before(servicesFilterRelease()) {
+		return nil
+	}
+	sink.Emit(output.ErrorEvent{
+		Title:   "Could not load snapshot",
+		Summary: fmt.Sprintf("LocalStack %s cannot load only some services of this snapshot; --services needs %s or later", version, servicesFilterRelease().tag()),
+		Actions: []output.ErrorAction{
+			{Label: "Load every service:", Value: "run the command again without --services"},
+			{Label: "Upgrade the emulator:", Value: fmt.Sprintf("set tag = %q or later in your config file, then run lstk restart", servicesFilterRelease().tag())},
+		},
+	})
+	return output.NewSilentError(fmt.Errorf("%w: running %s", ErrServicesFilterUnsupported, version))
+}
+
+// partialOverwrite resets the selected services of a partial load the emulator
+// performs itself (pods and S3 remotes) when strategy is overwrite, since the
+// emulator's overwrite would wipe every service. It returns the strategy to
+// pass on to the emulator.
+func partialOverwrite(ctx context.Context, resetter ServiceResetter, host, strategy string, services []string) (string, error) {
+	if strategy != MergeStrategyOverwrite || len(services) == 0 {
+		return strategy, nil
+	}
+	if err := resetServices(ctx, resetter, host, services); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// emitUnrequestedServices warns when the emulator restored services outside
+// a partial load's selection, which it does if it predates the filter.
+func emitUnrequestedServices(loaded, services []string, sink output.Sink) {
+	if len(services) == 0 {
+		return
+	}
+	var extra []string
+	for _, s := range loaded {
+		if !slices.Contains(services, s) {
+			extra = append(extra, s)
+		}
+	}
+	if len(extra) == 0 {
+		return
+	}
+	sink.Emit(output.MessageEvent{
+		Severity: output.SeverityWarning,
+		Text:     fmt.Sprintf("LocalStack also loaded %s: this emulator version does not support --services for this snapshot.", strings.Join(extra, ", ")),
+	})
+}
@@ -0,0 +1,267 @@
+package snapshot_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func writePartialArchive(t *testing.T) string {
+	t.Helper()
+	return writeSnapshotArchive(t, t.TempDir(), "baseline.snapshot", "", map[string]string{
+		"version.txt": "1",
+		"api_states/000000000000/us-east-1/s3/store.state":       "bucket",
+		"api_states/000000000000/us-east-1/dynamodb/store.state": "table",
+		"api_states/000000000000/us-east-1/sqs/store.state":      "queue",
+		"assets/lambda/fn.zip":                                   "code",
+	})
+}
+
+func entryNames(t *testing.T, data []byte) []string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func loadedEvent(t *testing.T, events []output.Event) output.SnapshotLoadedEvent {
+	t.Helper()
+	for _, e := range events {
+		if ev, ok := e.(output.SnapshotLoadedEvent); ok {
+			return ev
+		}
+	}
+	require.Fail(t, "SnapshotLoadedEvent should have been emitted")
+	return output.SnapshotLoadedEvent{}
+}
+
+func TestLoadLocal_ServicesImportsOnlySelected(t *testing.T) {
+	t.Parallel()
+	src := writePartialArchive(t)
+
+	var imported []byte
+	sink, getEvents := captureEvents(t)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", src, "", []string{"s3", "dynamodb"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"api_states/000000000000/us-east-1/dynamodb/store.state",
+		"api_states/000000000000/us-east-1/s3/store.state",
+		"version.txt",
+	}, entryNames(t, imported))
+
+	loaded := loadedEvent(t, getEvents())
+	assert.Equal(t, []string{"dynamodb", "s3"}, loaded.Services)
+	assert.Equal(t, []string{"lambda", "sqs"}, loaded.Skipped)
+}
+
+func TestLoadLocal_ServicesWarnsAboutMissing(t *testing.T) {
+	t.Parallel()
+	src := writePartialArchive(t)
+
+	var imported []byte
+	sink, getEvents := captureEvents(t)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", src, "", []string{"s3", "kinesis"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+
+	var warnings []string
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warnings = append(warnings, ev.Text)
+		}
+	}
+	assert.Equal(t, []string{"The snapshot holds no state for kinesis; loading s3 only."}, warnings)
+}
+
+func TestLoadLocal_ServicesNoneHeld(t *testing.T) {
+	t.Parallel()
+	src := writePartialArchive(t)
+
+	// No ImportState expectation: nothing matching must not reach the emulator.
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	sink := output.NewPlainSink(io.Discard)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", []string{"kinesis"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot holds no state for kinesis; it holds state for dynamodb, lambda, s3, sqs")
+}
+
+func TestLoadLocal_ServicesNoServiceStateHeld(t *testing.T) {
+	t.Parallel()
+	src := writeSnapshotArchive(t, t.TempDir(), "empty.snapshot", "", map[string]string{"version.txt": "1"})
+
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	sink := output.NewPlainSink(io.Discard)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", []string{"s3"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snapshot holds no state for s3, nor for any other service")
+}
+
+func TestLoadLocal_ServicesFromEncryptedSnapshot(t *testing.T) {
+	t.Parallel()
+	archive, err := os.ReadFile(writePartialArchive(t))
+	require.NoError(t, err)
+	src := filepath.Join(t.TempDir(), "sealed.snapshot")
+	sink, _ := captureEvents(t)
+	require.NoError(t, snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturning(t, archive), "", src, nil, snapshot.Encryption{Passphrase: "correct horse"}, sink))
+
+	var imported []byte
+	err = snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, mockLocalClientImporting(t, &imported), "", src, "", []string{"s3"}, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"api_states/000000000000/us-east-1/s3/store.state",
+		"version.txt",
+	}, entryNames(t, imported))
+}
+
+func TestLoadLocal_ServicesImportFailingBeforeReadingReturns(t *testing.T) {
+	t.Parallel()
+	src := writePartialArchive(t)
+
+	client := NewMockLocalLoadClient(gomock.NewController(t))
+	client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+	sink := output.NewPlainSink(io.Discard)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, "", []string{"s3"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestLoadLocal_ServicesOverwriteResetsOnlySelected(t *testing.T) {
+	t.Parallel()
+	src := writePartialArchive(t)
+
+	ctrl := gomock.NewController(t)
+	client := NewMockLocalLoadClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().ResetServiceState(gomock.Any(), gomock.Any(), "s3").Return(nil),
+		client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), "").Return(nil),
+	)
+
+	sink := output.NewPlainSink(io.Discard)
+	err := snapshot.LoadLocal(context.Background(), healthyRunningMock(t), awsContainers, client, "", src, snapshot.MergeStrategyOverwrite, []string{"s3"}, snapshot.Decryption{}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+}
+
+func TestLoadPod_ServicesOverwriteResetsOnlySelected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	loader := NewMockPodLoader(ctrl)
+	gomock.InOrder(
+		loader.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("2026.4.0", nil),
+		loader.EXPECT().ResetServiceState(gomock.Any(), gomock.Any(), "s3").Return(nil),
+		loader.EXPECT().ResetServiceState(gomock.Any(), gomock.Any(), "dynamodb").Return(nil),
+		loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", "", []string{"s3", "dynamodb"}).
+			Return([]string{"s3", "dynamodb"}, nil),
+	)
+
+	sink, getEvents := captureEvents(t)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-pod", 0, "tok", snapshot.MergeStrategyOverwrite, []string{"s3", "dynamodb"}, nopStarter, sink)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"s3", "dynamodb"}, loadedEvent(t, getEvents()).Services)
+}
+
+func TestLoadPod_ServicesWarnsWhenEmulatorIgnoresFilter(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	loader := NewMockPodLoader(ctrl)
+	// A version that cannot be parsed does not stop the load.
+	loader.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("latest", nil)
+	loader.EXPECT().LoadPodSnapshot(gomock.Any(), gomock.Any(), "my-pod", 0, "tok", "", []string{"s3"}).
+		Return([]string{"s3", "sqs"}, nil)
+
+	sink, getEvents := captureEvents(t)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-pod", 0, "tok", "", []string{"s3"}, nopStarter, sink)
+	require.NoError(t, err)
+
+	var warned bool
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warned = true
+			assert.Contains(t, ev.Text, "LocalStack also loaded sqs")
+		}
+	}
+	assert.True(t, warned, "an unrequested service should be warned about")
+}
+
+func TestLoadPod_ServicesRefusedBeforeResetOnAnEmulatorWithoutTheFilter(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	loader := NewMockPodLoader(ctrl)
+	// No ResetServiceState or LoadPodSnapshot: nothing is touched.
+	loader.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil)
+
+	sink, getEvents := captureEvents(t)
+	err := snapshot.LoadPod(context.Background(), healthyRunningMock(t), awsContainers, loader, "", "my-pod", 0, "tok", snapshot.MergeStrategyOverwrite, []string{"s3"}, nopStarter, sink)
+	require.ErrorIs(t, err, snapshot.ErrServicesFilterUnsupported)
+
+	var errEvent *output.ErrorEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.ErrorEvent); ok {
+			errEvent = &ev
+		}
+	}
+	require.NotNil(t, errEvent)
+	assert.Contains(t, errEvent.Summary, "LocalStack 4.3.0")
+}
+
+func TestLoadRemoteS3_ServicesRefusedBeforeResetOnAnEmulatorWithoutTheFilter(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	client := NewMockRemoteClient(ctrl)
+	client.EXPECT().S3BucketExists(gomock.Any(), "bucket").Return(true, nil)
+	client.EXPECT().RegisterRemote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	client.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil)
+
+	sink, _ := captureEvents(t)
+	err := snapshot.LoadRemoteS3(context.Background(), healthyRunningMock(t), awsContainers, client, "", "my-pod", "s3://bucket/pods", snapshot.S3Credentials{}, "", snapshot.MergeStrategyOverwrite, []string{"s3"}, nopStarter, sink)
+	require.ErrorIs(t, err, snapshot.ErrServicesFilterUnsupported)
+}
+
+func TestRequireServicesFilter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		version string
+		refused bool
+	}{
+		{version: "3.8.1", refused: true},
+		{version: "4.3.9", refused: true},
+		{version: "4.3.0.dev120", refused: true},
+		{version: "4.4.0", refused: false},
+		{version: "4.4.0.dev3", refused: false},
+		{version: "4.5.1", refused: false},
+		{version: "2026.4.0", refused: false},
+		{version: "latest", refused: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			client := NewMockPodLoader(ctrl)
+			client.EXPECT().FetchVersion(gomock.Any(), "host").Return(tt.version, nil)
+
+			sink, _ := captureEvents(t)
+			err := snapshot.RequireServicesFilter(context.Background(), client, "host", []string{"s3"}, sink)
+			if tt.refused {
+				require.ErrorIs(t, err, snapshot.ErrServicesFilterUnsupported)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/localstack/lstk/internal/config"
@@ -46,6 +49,10 @@ func (c S3Credentials) params() map[string]string {
 	return p
 }
 
+// ErrS3ObjectNotFound is returned by S3ObjectStore.GetS3Object for an object
+// that does not exist.
+var ErrS3ObjectNotFound = errors.New("S3 object not found")
+
 // RemotePod is a snapshot listed on a remote storage backend.
 type RemotePod struct {
 	Name       string
@@ -67,11 +74,39 @@ type RemoteClient interface {
 	// services, when non-empty, limits the save to that subset of services.
 	SavePodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken string, services []string) (PodSaveResult, error)
 	// LoadPodRemote loads podName from the named remote with the given merge strategy.
-	LoadPodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken, strategy string) ([]string, error)
+	// services, when non-empty, limits the load to that subset of services.
+	LoadPodRemote(ctx context.Context, host, podName, remoteName string, params map[string]string, authToken, strategy string, services []string) ([]string, error)
+	// ResetServiceState wipes one service's running state, as ServiceResetter.
+	ResetServiceState(ctx context.Context, host, service string) error
+	// FetchVersion reports the running emulator's version, checked for
+	// support of the services filter before a partial load.
+	FetchVersion(ctx context.Context, host string) (string, error)
 	// ListPodsRemote lists the snapshots stored on the named remote.
 	ListPodsRemote(ctx context.Context, host, remoteName string, params map[string]string, authToken, creator string) ([]RemotePod, error)
 }
 
+// S3ObjectStore reads and writes objects in an S3 bucket directly, for the
+// encrypted snapshots lstk uploads itself. Satisfied by aws.Client.
+type S3ObjectStore interface {
+	// S3BucketExists reports whether the named S3 bucket exists, as on RemoteClient.
+	S3BucketExists(ctx context.Context, bucket string) (bool, error)
+	// PutS3Object uploads size bytes of body as the object name under the
+	// s3:// location s3URL.
+	PutS3Object(ctx context.Context, s3URL, name string, creds S3Credentials, body io.Reader, size int64) error
+	// GetS3Object downloads the object name under s3URL into dst, returning
+	// ErrS3ObjectNotFound when it does not exist.
+	GetS3Object(ctx context.Context, s3URL, name string, creds S3Credentials, dst io.Writer) error
+}
+
+// EncryptedS3Client is what loading a possibly encrypted S3 snapshot needs:
+// the object store for lstk's own encrypted uploads, the local import for
+// them, and the remote API for plain pods. Satisfied by aws.Client.
+type EncryptedS3Client interface {
+	RemoteClient
+	LocalLoadClient
+	S3ObjectStore
+}
+
 // s3RemoteBucket extracts the bucket name from an s3:// URL and reports whether
 // the URL targets a local-testing endpoint (an IP or host.docker.internal), which
 // mirrors the emulator's own detection. For local endpoints the bucket is the
@@ -92,7 +127,9 @@ func s3RemoteBucket(s3URL string) (bucket string, isLocalEndpoint bool) {
 // does not exist, so lstk never relies on the emulator auto-creating it. Local
 // testing endpoints are skipped, and a check that cannot be performed (e.g. no
 // network) is surfaced as a warning rather than a hard failure.
-func ensureBucketExists(ctx context.Context, client RemoteClient, s3URL string, sink output.Sink) error {
+func ensureBucketExists(ctx context.Context, client interface {
+	S3BucketExists(ctx context.Context, bucket string) (bool, error)
+}, s3URL string, sink output.Sink) error {
 	bucket, isLocal := s3RemoteBucket(s3URL)
 	if isLocal || bucket == "" {
 		return nil
@@ -166,29 +203,124 @@ func SaveRemoteS3(ctx context.Context, rt runtime.Runtime, containers []config.C
 	)
 }
 
+// encryptedObjectName is the object an encrypted save of podName is uploaded
+// as, next to the pods the emulator writes under the same s3:// location.
+func encryptedObjectName(podName string) string {
+	return podName + snapshotExt
+}
+
+// SaveRemoteS3Encrypted saves the running emulator's state to podName in the
+// S3 bucket identified by s3URL, sealed per enc. The emulator's S3 remote
+// writes pods as they are, so lstk exports the state itself, encrypts it into
+// a temporary file, and uploads that as a single object.
+func SaveRemoteS3Encrypted(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, store S3ObjectStore, host, podName, s3URL string, creds S3Credentials, services []string, enc Encryption, sink output.Sink) error {
+	if err := ensureBucketExists(ctx, store, s3URL, sink); err != nil {
+		return err
+	}
+	var (
+		extracted []string
+		size      int64
+	)
+	return save(ctx, rt, containers, sink,
+		fmt.Sprintf("Saving encrypted snapshot to %s...", s3URL),
+		func() {
+			sink.Emit(output.RemoteSnapshotSavedEvent{
+				PodName:  podName,
+				Location: s3URL,
+				Services: extracted,
+				Size:     size,
+			})
+		},
+		func() error {
+			dir, err := os.MkdirTemp("", "lstk-snapshot-")
+			if err != nil {
+				return fmt.Errorf("create temporary directory: %w", err)
+			}
+			defer func() { _ = os.RemoveAll(dir) }()
+			path := filepath.Join(dir, encryptedObjectName(podName))
+			if extracted, err = exportFile(ctx, exporter, host, path, services, enc); err != nil {
+				return err
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("open snapshot: %w", err)
+			}
+			defer func() { _ = f.Close() }()
+			size = fileSize(path)
+			if err := store.PutS3Object(ctx, s3URL, encryptedObjectName(podName), creds, f, size); err != nil {
+				return fmt.Errorf("upload to %s: %w", s3URL, err)
+			}
+			return nil
+		},
+	)
+}
+
+// LoadRemoteS3Encrypted loads podName from the S3 bucket identified by s3URL
+// when lstk saved it there encrypted, decrypting it with dec and checking its
+// recorded LocalStack version as LoadLocal does. A pod without an encrypted
+// upload is loaded through the emulator as LoadRemoteS3 does.
+func LoadRemoteS3Encrypted(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client EncryptedS3Client, host, podName, s3URL string, creds S3Credentials, authToken, strategy string, services []string, dec Decryption, starter Starter, restarter Restarter, force bool, sink output.Sink) error {
+	dir, err := os.MkdirTemp("", "lstk-snapshot-")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, encryptedObjectName(podName))
+
+	if err := downloadS3Object(ctx, client, s3URL, encryptedObjectName(podName), creds, path, sink); err != nil {
+		if errors.Is(err, ErrS3ObjectNotFound) {
+			return LoadRemoteS3(ctx, rt, containers, client, host, podName, s3URL, creds, authToken, strategy, services, starter, sink)
+		}
+		return err
+	}
+	return loadFile(ctx, rt, containers, client, host, path, fmt.Sprintf("%s (%s)", s3URL, podName), strategy, services, dec, starter, restarter, force, sink)
+}
+
+// downloadS3Object downloads the object name under s3URL to the file at path.
+func downloadS3Object(ctx context.Context, store S3ObjectStore, s3URL, name string, creds S3Credentials, path string, sink output.Sink) error {
+	sink.Emit(output.SpinnerStart(fmt.Sprintf("Downloading snapshot from %s...", s3URL)))
+	defer sink.Emit(output.SpinnerStop())
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	if err := store.GetS3Object(ctx, s3URL, name, creds, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 // LoadRemoteS3 loads podName from the S3 bucket identified by s3URL into the
 // running emulator, starting it first if needed.
-func LoadRemoteS3(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client RemoteClient, host, podName, s3URL string, creds S3Credentials, authToken, strategy string, starter Starter, sink output.Sink) error {
+func LoadRemoteS3(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client RemoteClient, host, podName, s3URL string, creds S3Credentials, authToken, strategy string, services []string, starter Starter, sink output.Sink) error {
 	if err := ensureBucketExists(ctx, client, s3URL, sink); err != nil {
 		return err
 	}
 	name := remoteName(s3URL)
 	remoteURL := templatedRemoteURL(s3URL, creds.SessionToken != "")
-	var services []string
-	return load(ctx, rt, containers, sink, starter,
+	var loaded []string
+	return load(ctx, rt, containers, sink, starter, nil,
 		fmt.Sprintf("Loading snapshot %q from %s...", podName, s3URL),
 		func() {
+			emitUnrequestedServices(loaded, services, sink)
 			sink.Emit(output.SnapshotLoadedEvent{
 				Source:   fmt.Sprintf("%s (%s)", s3URL, podName),
-				Services: services,
+				Services: loaded,
 			})
 		},
 		func() error {
 			if err := client.RegisterRemote(ctx, host, name, remoteURL); err != nil {
 				return fmt.Errorf("register S3 remote: %w", err)
 			}
-			var err error
-			services, err = client.LoadPodRemote(ctx, host, podName, name, creds.params(), authToken, strategy)
+			if err := requireServicesFilter(ctx, client, host, services, sink); err != nil {
+				return err
+			}
+			strategy, err := partialOverwrite(ctx, client, host, strategy, services)
+			if err != nil {
+				return err
+			}
+			loaded, err = client.LoadPodRemote(ctx, host, podName, name, creds.params(), authToken, strategy, services)
 			return err
 		},
 	)
@@ -210,3 +210,68 @@ func TestListRemoteS3_RendersTable(t *testing.T) {
 	assert.Len(t, table.Rows, 2)
 	assert.Equal(t, []string{"pod-a", "3"}, table.Rows[0])
 }
+
+func TestRemoteS3Encrypted_RoundTrip(t *testing.T) {
+	t.Parallel()
+	const s3URL = "s3://my-bucket/prefix"
+	creds := snapshot.S3Credentials{AccessKeyID: "a", SecretAccessKey: "b"}
+	sink, _ := captureEvents(t)
+
+	store := NewMockS3ObjectStore(gomock.NewController(t))
+	store.EXPECT().S3BucketExists(gomock.Any(), "my-bucket").Return(true, nil)
+	var uploaded []byte
+	store.EXPECT().PutS3Object(gomock.Any(), s3URL, "my-pod.snapshot", creds, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string, _ snapshot.S3Credentials, body io.Reader, size int64) error {
+			var err error
+			uploaded, err = io.ReadAll(body)
+			assert.Equal(t, int64(len(uploaded)), size)
+			return err
+		},
+	)
+	err := snapshot.SaveRemoteS3Encrypted(context.Background(), healthyRunningMock(t), awsContainers, mockExporterReturning(t, []byte("ZIP_DATA")), store, "", "my-pod", s3URL, creds, nil, snapshot.Encryption{Passphrase: "correct horse"}, sink)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(uploaded), "age-encryption.org/v1\n"), "the upload must be sealed with age")
+	assert.NotContains(t, string(uploaded), "ZIP_DATA", "the plain archive must not leave the machine")
+
+	client := NewMockEncryptedS3Client(gomock.NewController(t))
+	client.EXPECT().GetS3Object(gomock.Any(), s3URL, "my-pod.snapshot", creds, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string, _ snapshot.S3Credentials, dst io.Writer) error {
+			_, err := dst.Write(uploaded)
+			return err
+		},
+	)
+	client.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	var imported []byte
+	client.EXPECT().ImportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, src io.Reader, _ string) error {
+			var err error
+			imported, err = io.ReadAll(src)
+			return err
+		},
+	)
+	err = snapshot.LoadRemoteS3Encrypted(context.Background(), healthyRunningMock(t), awsContainers, client, "", "my-pod", s3URL, creds, "", "", nil, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	assert.Equal(t, "ZIP_DATA", string(imported), "the emulator must receive the decrypted archive")
+}
+
+func TestLoadRemoteS3Encrypted_WithoutAnUploadLoadsThePod(t *testing.T) {
+	t.Parallel()
+	const s3URL = "s3://my-bucket/prefix"
+	client := NewMockEncryptedS3Client(gomock.NewController(t))
+	client.EXPECT().GetS3Object(gomock.Any(), s3URL, "my-pod.snapshot", gomock.Any(), gomock.Any()).Return(snapshot.ErrS3ObjectNotFound)
+	client.EXPECT().S3BucketExists(gomock.Any(), "my-bucket").Return(true, nil)
+	client.EXPECT().RegisterRemote(gomock.Any(), gomock.Any(), snapshot.RemoteName(s3URL), gomock.Any()).Return(nil)
+	client.EXPECT().LoadPodRemote(gomock.Any(), gomock.Any(), "my-pod", snapshot.RemoteName(s3URL), gomock.Any(), "", "", gomock.Any()).Return([]string{"s3"}, nil)
+	sink, getEvents := captureEvents(t)
+
+	err := snapshot.LoadRemoteS3Encrypted(context.Background(), healthyRunningMock(t), awsContainers, client, "", "my-pod", s3URL, snapshot.S3Credentials{AccessKeyID: "a", SecretAccessKey: "b"}, "", "", nil, snapshot.Decryption{Passphrase: "correct horse"}, nopStarter, nil, false, sink)
+	require.NoError(t, err)
+	var loaded []output.SnapshotLoadedEvent
+	for _, e := range getEvents() {
+		if ev, ok := e.(output.SnapshotLoadedEvent); ok {
+			loaded = append(loaded, ev)
+		}
+	}
+	require.Len(t, loaded, 1)
+	assert.Equal(t, []string{"s3"}, loaded[0].Services)
+}
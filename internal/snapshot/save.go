@@ -20,6 +20,9 @@ import (
 // []string reports what was actually captured.
 type StateExporter interface {
 	ExportState(ctx context.Context, host string, services []string, dst io.Writer) ([]string, error)
+	// FetchVersion reports the running emulator's version, which local
+	// snapshots record so a load can check it before uploading.
+	FetchVersion(ctx context.Context, host string) (string, error)
 }
 
 // PodSaveResult holds the metadata returned by the platform after a successful pod save.
@@ -76,8 +79,9 @@ func save(ctx context.Context, rt runtime.Runtime, containers []config.Container
 }
 
 // SaveLocal saves the running emulator's state to a local file. services, when
-// non-empty, limits the save to that subset of services.
-func SaveLocal(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, host, dest string, services []string, sink output.Sink) error {
+// non-empty, limits the save to that subset of services. With enc enabled the
+// file is encrypted as it is written, so the plain archive never touches disk.
+func SaveLocal(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter StateExporter, host, dest string, services []string, enc Encryption, sink output.Sink) error {
 	cwd, _ := os.Getwd()
 	home, _ := os.UserHomeDir()
 	var extracted []string
@@ -91,22 +95,40 @@ func SaveLocal(ctx context.Context, rt runtime.Runtime, containers []config.Cont
 			})
 		},
 		func() error {
-			w, err := os.Create(dest)
-			if err != nil {
-				return fmt.Errorf("save to %s: %w", dest, err)
-			}
-			var exportErr error
-			extracted, exportErr = exporter.ExportState(ctx, host, services, w)
-			if exportErr != nil {
-				_ = w.Close()
-				_ = os.Remove(dest)
-				return fmt.Errorf("export state from LocalStack: %w", exportErr)
-			}
-			return w.Close()
+			var err error
+			extracted, err = exportFile(ctx, exporter, host, dest, services, enc)
+			return err
 		},
 	)
 }
 
+// exportFile exports the running state to a new file at dest, sealed per enc.
+// A failed export removes the partial file.
+func exportFile(ctx context.Context, exporter StateExporter, host, dest string, services []string, enc Encryption) ([]string, error) {
+	f, err := os.Create(dest)
+	if err != nil {
+		return nil, fmt.Errorf("save to %s: %w", dest, err)
+	}
+	w, err := enc.seal(f)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(dest)
+		return nil, err
+	}
+	extracted, err := exportWithMetadata(ctx, exporter, host, services, w)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(dest)
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(dest)
+		return nil, fmt.Errorf("encrypt snapshot: %w", err)
+	}
+	return extracted, f.Close()
+}
+
 // fileSize returns dest's size on disk, or 0 if it can't be stat'd. Used for
 // best-effort size reporting after a save has already succeeded.
 func fileSize(dest string) int64 {
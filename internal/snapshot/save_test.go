@@ -39,6 +39,7 @@ func mockExporterReturning(t *testing.T, body []byte) *MockStateExporter {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	m := NewMockStateExporter(ctrl)
+	m.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 	m.EXPECT().ExportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
 			_, err := dst.Write(body)
@@ -52,6 +53,7 @@ func mockExporterReturningError(t *testing.T, exportErr error) *MockStateExporte
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	m := NewMockStateExporter(ctrl)
+	m.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 	m.EXPECT().ExportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, exportErr)
 	return m
 }
@@ -65,7 +67,7 @@ func TestSave_Success(t *testing.T) {
 	exporter := mockExporterReturning(t, []byte("ZIP_DATA"))
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.NoError(t, err)
 
 	data, err := os.ReadFile(filepath.Join(dir, "snap"))
@@ -101,6 +103,7 @@ func TestSaveLocal_WithServices(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 	exporter.EXPECT().ExportState(gomock.Any(), gomock.Any(), []string{"s3", "dynamodb"}, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
 			_, err := dst.Write([]byte("ZIP_DATA"))
@@ -109,7 +112,7 @@ func TestSaveLocal_WithServices(t *testing.T) {
 	)
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, []string{"s3", "dynamodb"}, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, []string{"s3", "dynamodb"}, snapshot.Encryption{}, sink)
 	require.NoError(t, err)
 
 	var saved output.LocalSnapshotSavedEvent
@@ -133,12 +136,13 @@ func TestSave_EmulatorNotRunning(t *testing.T) {
 	mockRT.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 
 	dir := t.TempDir()
 	dest := filepath.Join(dir, "snap")
 	sink, getEvents := captureEvents(t)
 
-	err := snapshot.SaveLocal(context.Background(), mockRT, awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), mockRT, awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 
@@ -164,12 +168,13 @@ func TestSave_UnhealthyRuntime(t *testing.T) {
 	mockRT.EXPECT().EmitUnhealthyError(gomock.Any(), gomock.Any())
 
 	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 
 	dir := t.TempDir()
 	dest := filepath.Join(dir, "snap")
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.SaveLocal(context.Background(), mockRT, awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), mockRT, awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.Error(t, err)
 	assert.True(t, output.IsSilent(err))
 }
@@ -181,7 +186,7 @@ func TestSave_ExporterError(t *testing.T) {
 	exporter := mockExporterReturningError(t, fmt.Errorf("connection refused"))
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "connection refused")
 
@@ -194,9 +199,10 @@ func TestSave_DestinationDirNotExist(t *testing.T) {
 	dest := "/no/such/dir/snap"
 	ctrl := gomock.NewController(t)
 	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "save to")
 }
@@ -211,7 +217,7 @@ func TestSave_OverwritesExistingFile(t *testing.T) {
 	exporter := mockExporterReturning(t, []byte("NEW"))
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(context.Background(), healthyRunningMock(t), awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.NoError(t, err)
 
 	data, err := os.ReadFile(path)
@@ -235,7 +241,7 @@ func TestSave_ContextCancelled(t *testing.T) {
 
 	sink := output.NewPlainSink(io.Discard)
 
-	err := snapshot.SaveLocal(ctx, mockRT, awsContainers, exporter, "", dest, nil, sink)
+	err := snapshot.SaveLocal(ctx, mockRT, awsContainers, exporter, "", dest, nil, snapshot.Encryption{}, sink)
 	require.Error(t, err)
 }
 
@@ -0,0 +1,214 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/container"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// scheduledPrefix marks the library names `snapshot schedule` saves under by
+// default, as autosavePrefix does for autosaves.
+const scheduledPrefix = "scheduled-"
+
+const (
+	// DefaultScheduleEvery and DefaultScheduleKeep are a schedule's interval
+	// and library generations when the user sets neither.
+	DefaultScheduleEvery = 15 * time.Minute
+	DefaultScheduleKeep  = 8
+	// minScheduleEvery keeps a schedule from exporting state back to back: a
+	// save of a busy emulator can itself take several seconds.
+	minScheduleEvery = time.Minute
+)
+
+// ScheduledName returns the library name `snapshot schedule` saves the named
+// container's state under when no destination is given.
+func ScheduledName(containerName string) string {
+	return libraryName(scheduledPrefix, containerName)
+}
+
+// ParseScheduleDestination accepts the destinations a schedule can save to
+// repeatedly without overwriting: local: names, whose saves are kept side by
+// side, and pods, which version every save. An empty arg is the library, under
+// the ScheduledName Schedule fills in. keepSet rejects --keep for a pod.
+func ParseScheduleDestination(arg string, keepSet bool) (Destination, error) {
+	if arg == "" {
+		return Destination{Kind: KindLibrary}, nil
+	}
+	dest, err := ParseDestination(arg, "", time.Now())
+	if err != nil {
+		return Destination{}, err
+	}
+	switch dest.Kind {
+	case KindLibrary:
+		return dest, nil
+	case KindPod:
+		if keepSet {
+			return Destination{}, errors.New("--keep only applies to local: saves; a Cloud Pod keeps every version")
+		}
+		return dest, nil
+	default:
+		return Destination{}, fmt.Errorf("%q: snapshot schedule saves to the local library or a Cloud Pod — use local:NAME or pod:NAME", arg)
+	}
+}
+
+// ScheduleClient is satisfied by aws.Client.
+type ScheduleClient interface {
+	StateExporter
+	PodSaver
+}
+
+// ScheduleOptions configures Schedule.
+type ScheduleOptions struct {
+	// Dest is where each save goes: a KindLibrary name, whose generations are
+	// rotated, or a KindPod name, where each save adds a pod version.
+	Dest Destination
+	Lib  Library
+	// Every is the time between saves; the first save is made straight away.
+	Every time.Duration
+	// Keep bounds the library generations kept. Pods keep every version.
+	Keep      int
+	AuthToken string
+	Services  []string
+	// Encryption seals each library save. Pods are written by the emulator, so
+	// it does not apply to them.
+	Encryption Encryption
+	// Passphrase is LSTK_SNAPSHOT_PASSPHRASE, which seals library saves
+	// without Encryption when the container sets encrypt.
+	Passphrase string
+}
+
+// Validate checks the interval the user asked for and, for a library
+// destination, the number of generations to keep.
+func (o ScheduleOptions) Validate() error {
+	if o.Every < minScheduleEvery {
+		return fmt.Errorf("--every must be at least %s, got %s", formatEvery(minScheduleEvery), o.Every)
+	}
+	if o.Dest.Kind == KindLibrary && o.Keep < 1 {
+		return fmt.Errorf("--keep must be at least 1, got %d", o.Keep)
+	}
+	return nil
+}
+
+// Schedule saves the running emulator's state to opts.Dest every opts.Every
+// until ctx is canceled, which is how the user stops it and so not an error.
+// A save that fails, or is skipped because the emulator is down, is reported
+// as a warning and the schedule carries on; only a plan without the snapshot
+// feature ends it, as no later save could succeed either.
+//
+// Callers check opts with Validate first. A library destination without a name saves under ScheduledName of the
+// target emulator, encrypted per its config when opts.Encryption is not set.
+func Schedule(ctx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client ScheduleClient, host string, opts ScheduleOptions, sink output.Sink) error {
+	if opts.Dest.Kind == KindLibrary {
+		if opts.Dest.Value == "" && len(containers) > 0 {
+			opts.Dest.Value = ScheduledName(containers[0].Name())
+		}
+		if !opts.Encryption.Enabled() && len(containers) > 0 {
+			enc, err := ContainerEncryption(containers[0], opts.Passphrase)
+			if err != nil {
+				return err
+			}
+			opts.Encryption = enc
+		}
+	}
+	if opts.Dest.Kind == KindPod && opts.AuthToken == "" {
+		return fmt.Errorf("pod snapshots require authentication — set LOCALSTACK_AUTH_TOKEN or run %q", "lstk login")
+	}
+	if err := requireRunning(ctx, rt, containers, sink); err != nil {
+		return err
+	}
+	emitExperimentalWarning(containers, sink)
+
+	target := LibraryRef(opts.Dest.Value)
+	keeping := fmt.Sprintf(", keeping the newest %d", opts.Keep)
+	if opts.Dest.Kind == KindPod {
+		target = "pod:" + opts.Dest.Value
+		keeping = ""
+	}
+	sink.Emit(output.MessageEvent{
+		Severity: output.SeverityNote,
+		Text:     fmt.Sprintf("Saving a snapshot to %s every %s%s. Press Ctrl-C to stop.", target, formatEvery(opts.Every), keeping),
+	})
+
+	ticker := time.NewTicker(opts.Every)
+	defer ticker.Stop()
+
+	down := false
+	for {
+		running, err := container.RunningEmulators(ctx, rt, containers)
+		if ctx.Err() != nil {
+			return nil
+		}
+		switch {
+		case err != nil:
+			sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: fmt.Sprintf("Skipped a scheduled save: checking emulator status: %v", err)})
+		case len(running) == 0:
+			// Once per outage: the emulator may be restarted while the schedule runs.
+			if !down {
+				sink.Emit(output.MessageEvent{Severity: output.SeverityWarning, Text: "LocalStack is not running; scheduled saves resume once it is back"})
+			}
+			down = true
+		default:
+			down = false
+			err := scheduledSave(ctx, client, host, opts, sink)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, ErrSnapshotFeatureUnavailable) {
+				return emitFeatureUnavailableError(sink)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scheduledSave makes one save of a schedule, reporting a failure as a warning.
+func scheduledSave(ctx context.Context, client ScheduleClient, host string, opts ScheduleOptions, sink output.Sink) error {
+	if opts.Dest.Kind == KindLibrary {
+		return saveRotating(ctx, client, host, opts.Lib, opts.Dest.Value, opts.Services, opts.Keep, opts.Encryption, sink)
+	}
+
+	sink.Emit(output.SpinnerStart(fmt.Sprintf("Saving snapshot to pod %q...", opts.Dest.Value)))
+	result, err := client.SavePodSnapshot(ctx, host, opts.Dest.Value, opts.AuthToken, opts.Services)
+	sink.Emit(output.SpinnerStop())
+	if err != nil {
+		if !errors.Is(err, ErrSnapshotFeatureUnavailable) && ctx.Err() == nil {
+			sink.Emit(output.MessageEvent{
+				Severity: output.SeverityWarning,
+				Text:     fmt.Sprintf("Could not save snapshot to pod %q: %v", opts.Dest.Value, err),
+			})
+		}
+		return err
+	}
+	sink.Emit(output.PodSnapshotSavedEvent{
+		PodName:  opts.Dest.Value,
+		Version:  result.Version,
+		Services: result.Services,
+		Size:     result.Size,
+	})
+	return nil
+}
+
+// formatEvery renders an interval the way it is typed, e.g. "15m" rather than
+// time.Duration's "15m0s".
+func formatEvery(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
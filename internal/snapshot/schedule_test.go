@@ -0,0 +1,299 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/snapshot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type scheduleClient struct {
+	*MockStateExporter
+	*MockPodSaver
+}
+
+// stopAfterSaves returns a sink that records events and cancels the schedule
+// once n saves have been reported.
+func stopAfterSaves(cancel context.CancelFunc, n int) (output.Sink, func() []output.Event) {
+	var events []output.Event
+	saves := 0
+	sink := output.SinkFunc(func(event output.Event) {
+		events = append(events, event)
+		switch event.(type) {
+		case output.LocalSnapshotSavedEvent, output.PodSnapshotSavedEvent:
+			if saves++; saves == n {
+				cancel()
+			}
+		}
+	})
+	return sink, func() []output.Event { return events }
+}
+
+func warningsIn(events []output.Event) []string {
+	var warnings []string
+	for _, e := range events {
+		if ev, ok := e.(output.MessageEvent); ok && ev.Severity == output.SeverityWarning {
+			warnings = append(warnings, ev.Text)
+		}
+	}
+	return warnings
+}
+
+func TestScheduledName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "scheduled-localstack-aws", snapshot.ScheduledName("localstack-aws"))
+}
+
+func TestSchedule_RotatesLibraryGenerations(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rt := runtime.NewMockRuntime(ctrl)
+	rt.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil).AnyTimes()
+	exporter := NewMockStateExporter(ctrl)
+	exporter.EXPECT().FetchVersion(gomock.Any(), gomock.Any()).Return("4.3.0", nil).AnyTimes()
+	exporter.EXPECT().ExportState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, _ []string, dst io.Writer) ([]string, error) {
+			_, err := dst.Write([]byte("zipdata"))
+			return nil, err
+		},
+	).Times(3)
+
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, getEvents := stopAfterSaves(cancel, 3)
+
+	err := snapshot.Schedule(ctx, rt, awsContainers, scheduleClient{MockStateExporter: exporter}, "http://host", snapshot.ScheduleOptions{
+		Dest:  snapshot.Destination{Kind: snapshot.KindLibrary, Value: "scheduled-localstack-aws"},
+		Lib:   lib,
+		Every: 10 * time.Millisecond,
+		Keep:  2,
+	}, sink)
+	require.NoError(t, err, "stopping the schedule is not an error")
+
+	entries, err := lib.Entries("scheduled-localstack-aws")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "only the newest --keep saves are kept")
+	assert.Empty(t, warningsIn(getEvents()))
+}
+
+func TestSchedule_EncryptsLibrarySaves(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rt := runtime.NewMockRuntime(ctrl)
+	rt.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil).AnyTimes()
+
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, _ := stopAfterSaves(cancel, 1)
+
+	err := snapshot.Schedule(ctx, rt, awsContainers, scheduleClient{MockStateExporter: mockExporterReturning(t, []byte("zipdata"))}, "http://host", snapshot.ScheduleOptions{
+		Dest:       snapshot.Destination{Kind: snapshot.KindLibrary, Value: "scheduled-localstack-aws"},
+		Lib:        lib,
+		Every:      time.Hour,
+		Keep:       2,
+		Encryption: snapshot.Encryption{Passphrase: "correct horse"},
+	}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries("scheduled-localstack-aws")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	got, err := os.ReadFile(entries[0].Path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(got, []byte("age-encryption.org/v1\n")), "the save must be sealed with age")
+}
+
+func TestSchedule_SkipsSavesWhileStopped(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rt := runtime.NewMockRuntime(ctrl)
+	rt.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	rt.EXPECT().FindRunningByImage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	gomock.InOrder(
+		rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil),
+		rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(false, nil).Times(2),
+		rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil),
+	)
+
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, getEvents := stopAfterSaves(cancel, 1)
+
+	err := snapshot.Schedule(ctx, rt, awsContainers, scheduleClient{MockStateExporter: mockExporterReturning(t, []byte("zipdata"))}, "http://host", snapshot.ScheduleOptions{
+		Dest:  snapshot.Destination{Kind: snapshot.KindLibrary, Value: "soak"},
+		Lib:   lib,
+		Every: 10 * time.Millisecond,
+		Keep:  1,
+	}, sink)
+	require.NoError(t, err)
+
+	warnings := warningsIn(getEvents())
+	require.Len(t, warnings, 1, "an outage is reported once, not on every tick")
+	assert.Contains(t, warnings[0], "scheduled saves resume once it is back")
+}
+
+func TestSchedule_FailedSaveCarriesOn(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	rt := runtime.NewMockRuntime(ctrl)
+	rt.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil).AnyTimes()
+	saver := NewMockPodSaver(ctrl)
+	gomock.InOrder(
+		saver.EXPECT().SavePodSnapshot(gomock.Any(), "http://host", "nightly", "token", gomock.Any()).Return(snapshot.PodSaveResult{}, errors.New("connection reset")),
+		saver.EXPECT().SavePodSnapshot(gomock.Any(), "http://host", "nightly", "token", gomock.Any()).Return(snapshot.PodSaveResult{Version: 4}, nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, getEvents := stopAfterSaves(cancel, 1)
+
+	err := snapshot.Schedule(ctx, rt, awsContainers, scheduleClient{MockPodSaver: saver}, "http://host", snapshot.ScheduleOptions{
+		Dest:      snapshot.Destination{Kind: snapshot.KindPod, Value: "nightly"},
+		Every:     10 * time.Millisecond,
+		AuthToken: "token",
+	}, sink)
+	require.NoError(t, err)
+
+	events := getEvents()
+	warnings := warningsIn(events)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "connection reset")
+	saved, ok := events[len(events)-1].(output.PodSnapshotSavedEvent)
+	require.True(t, ok, "the schedule must carry on to the next save")
+	assert.Equal(t, 4, saved.Version)
+}
+
+func TestSchedule_FeatureUnavailableEndsSchedule(t *testing.T) {
+	t.Parallel()
+	sink, _ := captureEvents(t)
+	err := snapshot.Schedule(context.Background(), scheduleRunningMock(t), awsContainers,
+		scheduleClient{MockStateExporter: mockExporterReturningError(t, snapshot.ErrSnapshotFeatureUnavailable)}, "http://host",
+		snapshot.ScheduleOptions{
+			Dest:  snapshot.Destination{Kind: snapshot.KindLibrary, Value: "soak"},
+			Lib:   snapshot.Library{Dir: t.TempDir()},
+			Every: time.Hour,
+			Keep:  1,
+		}, sink)
+	require.Error(t, err)
+	assert.True(t, output.IsSilent(err))
+}
+
+func scheduleRunningMock(t *testing.T) *runtime.MockRuntime {
+	t.Helper()
+	rt := runtime.NewMockRuntime(gomock.NewController(t))
+	rt.EXPECT().IsHealthy(gomock.Any()).Return(nil)
+	rt.EXPECT().IsRunning(gomock.Any(), "localstack-aws").Return(true, nil).AnyTimes()
+	return rt
+}
+
+func TestFormatEvery(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "15m", snapshot.FormatEvery(15*time.Minute))
+	assert.Equal(t, "1h", snapshot.FormatEvery(time.Hour))
+	assert.Equal(t, "1h30m", snapshot.FormatEvery(90*time.Minute))
+	assert.Equal(t, "1m30s", snapshot.FormatEvery(90*time.Second))
+}
+
+func TestParseScheduleDestination(t *testing.T) {
+	t.Parallel()
+	dest, err := snapshot.ParseScheduleDestination("", true)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Destination{Kind: snapshot.KindLibrary}, dest, "no destination: the library, named by Schedule")
+
+	dest, err = snapshot.ParseScheduleDestination("local:soak", true)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Destination{Kind: snapshot.KindLibrary, Value: "soak"}, dest)
+
+	dest, err = snapshot.ParseScheduleDestination("pod:nightly", false)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Destination{Kind: snapshot.KindPod, Value: "nightly"}, dest)
+
+	_, err = snapshot.ParseScheduleDestination("pod:nightly", true)
+	assert.ErrorContains(t, err, "a Cloud Pod keeps every version")
+
+	_, err = snapshot.ParseScheduleDestination("./soak.snapshot", false)
+	assert.ErrorContains(t, err, "use local:NAME or pod:NAME", "a file would be overwritten on every save")
+}
+
+func TestScheduleOptions_Validate(t *testing.T) {
+	t.Parallel()
+	library := snapshot.Destination{Kind: snapshot.KindLibrary}
+	pod := snapshot.Destination{Kind: snapshot.KindPod, Value: "nightly"}
+	tests := []struct {
+		name    string
+		opts    snapshot.ScheduleOptions
+		wantErr string
+	}{
+		{"defaults", snapshot.ScheduleOptions{Dest: library, Every: snapshot.DefaultScheduleEvery, Keep: snapshot.DefaultScheduleKeep}, ""},
+		{"shortest interval", snapshot.ScheduleOptions{Dest: library, Every: time.Minute, Keep: 1}, ""},
+		{"interval too short", snapshot.ScheduleOptions{Dest: library, Every: 59 * time.Second, Keep: 1}, "--every must be at least 1m, got 59s"},
+		{"nothing kept", snapshot.ScheduleOptions{Dest: library, Every: time.Hour}, "--keep must be at least 1, got 0"},
+		{"pods keep every version", snapshot.ScheduleOptions{Dest: pod, Every: time.Hour}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSchedule_DefaultLibraryNameAndConfigEncryption(t *testing.T) {
+	t.Parallel()
+	lib := snapshot.Library{Dir: filepath.Join(t.TempDir(), "snapshots")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, _ := stopAfterSaves(cancel, 1)
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS, Encrypt: true}}
+
+	err := snapshot.Schedule(ctx, scheduleRunningMock(t), containers, scheduleClient{MockStateExporter: mockExporterReturning(t, []byte("zipdata"))}, "http://host", snapshot.ScheduleOptions{
+		Dest:       snapshot.Destination{Kind: snapshot.KindLibrary},
+		Lib:        lib,
+		Every:      time.Hour,
+		Keep:       2,
+		Passphrase: "correct horse",
+	}, sink)
+	require.NoError(t, err)
+
+	entries, err := lib.Entries("scheduled-localstack-aws")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	got, err := os.ReadFile(entries[0].Path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(got, []byte("age-encryption.org/v1\n")), "encrypt = true must seal the save")
+}
+
+func TestSchedule_ConfigEncryptionWithoutPassphraseFails(t *testing.T) {
+	t.Parallel()
+	containers := []config.ContainerConfig{{Type: config.EmulatorAWS, Encrypt: true}}
+
+	err := snapshot.Schedule(context.Background(), runtime.NewMockRuntime(gomock.NewController(t)), containers, scheduleClient{}, "http://host", snapshot.ScheduleOptions{
+		Dest:  snapshot.Destination{Kind: snapshot.KindLibrary},
+		Every: time.Hour,
+		Keep:  2,
+	}, output.NewPlainSink(io.Discard))
+	assert.ErrorContains(t, err, "LSTK_SNAPSHOT_PASSPHRASE is not")
+}
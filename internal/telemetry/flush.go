@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/localstack/lstk/internal/proc"
 	"github.com/localstack/lstk/internal/tracing"
 	"github.com/localstack/lstk/internal/version"
 )
@@ -112,7 +113,7 @@ func spawnDetachedFlusher(ctx context.Context, endpoint string, events []eventBo
 		return
 	}
 	cmd := exec.Command(exe, FlushCommandName, "--endpoint", endpoint)
-	cmd.SysProcAttr = detachedSysProcAttr()
+	cmd.SysProcAttr = proc.DetachedSysProcAttr()
 	if traceEnv := tracing.SubprocessEnv(ctx); len(traceEnv) > 0 {
 		cmd.Env = append(os.Environ(), traceEnv...)
 	}
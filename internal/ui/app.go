@@ -55,8 +55,10 @@ type App struct {
 	pullProgress     components.PullProgress
 	errorDisplay     components.ErrorDisplay
 	lines            []styledLine
-	bufferedLines    []styledLine // lines waiting for spinner to finish
-	deferredOutput   string       // plain-text output printed after TUI exits (e.g. long tables)
+	bufferedLines    []styledLine                // lines waiting for spinner to finish
+	deferredOutput   string                      // plain-text output printed after TUI exits (e.g. long tables)
+	statusRefresh    *output.StatusRefreshEvent  // latest `status --watch` refresh, redrawn in place
+	requestSummary   *output.RequestSummaryEvent // `logs requests` tally so far, redrawn in place
 	width            int
 	cancel           func()
 	pendingInput     *output.UserInputRequestEvent
@@ -331,7 +333,35 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.addLine(styledLine{text: style.Render(text)})
 		a.addLine(blank)
 		return a, nil
-	case output.InstanceInfoEvent, output.PodSnapshotSavedEvent, output.LocalSnapshotSavedEvent, output.RemoteSnapshotSavedEvent, output.SnapshotLoadedEvent:
+	case output.InstanceInfoEvent:
+		if line, ok := output.FormatEventLine(msg); ok {
+			// Services that are not ready are what a user reads status for, so
+			// that line is highlighted rather than left in secondary text.
+			healthy, unhealthy, found := strings.Cut(line, "\n"+output.UnhealthyServicesPrefix)
+			a.addSuccessLines(healthy)
+			if found {
+				a.addLine(styledLine{text: styles.Warning.Render(output.UnhealthyServicesPrefix + unhealthy)})
+			}
+		}
+		return a, nil
+	case output.StatusRefreshEvent:
+		a.statusRefresh = &msg
+		return a, nil
+	case output.RequestEvent:
+		// The request itself is printed above the program by RunRequests.
+		if a.requestSummary == nil {
+			a.requestSummary = &output.RequestSummaryEvent{}
+		}
+		a.requestSummary.Add(msg)
+		return a, nil
+	case output.RequestSummaryEvent:
+		a.requestSummary = &msg
+		return a, nil
+	case output.EmulatorNotRunningEvent:
+		note := output.MessageEvent{Severity: output.SeverityNote, Text: msg.DisplayName + " is not running"}
+		a.addLine(styledLine{text: components.RenderMessage(note), message: &note})
+		return a, nil
+	case output.PodSnapshotSavedEvent, output.LocalSnapshotSavedEvent, output.RemoteSnapshotSavedEvent, output.SnapshotLoadedEvent, output.LocalSnapshotsPrunedEvent, output.ResourceManifestExportedEvent, output.InitStepEvent:
 		if line, ok := output.FormatEventLine(msg.(output.Event)); ok {
 			a.addSuccessLines(line)
 		}
@@ -344,6 +374,11 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.deferredOutput += styled
 		}
 		return a, nil
+	case output.DoctorCheckEvent:
+		for _, line := range renderDoctorCheck(msg) {
+			a.addLine(styledLine{text: line})
+		}
+		return a, nil
 	case output.SnapshotDiffEvent:
 		if line, ok := output.FormatEventLine(msg); ok {
 			a.addSuccessLines(line)
@@ -568,6 +603,13 @@ func (a App) View() string {
 		sb.WriteString("\n")
 	}
 
+	if a.statusRefresh != nil {
+		sb.WriteString(renderStatusRefresh(*a.statusRefresh, a.width))
+	}
+	if a.requestSummary != nil {
+		sb.WriteString(renderRequestSummary(*a.requestSummary))
+	}
+
 	if spinnerView := a.spinner.View(); spinnerView != "" {
 		sb.WriteString(spinnerView)
 		sb.WriteString("\n")
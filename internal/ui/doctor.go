@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/ui/styles"
+)
+
+// renderDoctorCheck renders a check of `lstk doctor` as lines, its marker
+// colored by the outcome and the suggested fixes as secondary text.
+func renderDoctorCheck(e output.DoctorCheckEvent) []string {
+	text, _ := output.FormatEventLine(e)
+	lines := strings.Split(text, "\n")
+	marker := output.CheckMarker(e.Status)
+	rest := strings.TrimPrefix(lines[0], marker)
+	switch e.Status {
+	case output.CheckPass:
+		marker = styles.Success.Render(marker)
+	case output.CheckWarn:
+		marker = styles.Warning.Render(marker)
+	case output.CheckFail:
+		marker = styles.LogError.Render(marker)
+	default:
+		marker, rest = styles.Secondary.Render(marker), styles.Secondary.Render(rest)
+	}
+	lines[0] = marker + rest
+	for i := 1; i < len(lines); i++ {
+		lines[i] = styles.SecondaryMessage.Render(lines[i])
+	}
+	return lines
+}
@@ -11,8 +11,14 @@ import (
 
 // renderLogLineEvent renders a streamed log line — the styled "source | " prefix
 // followed by the wrapped, continuation-indented body — to fit the given width.
+// Lines tagged with an emulator are prefixed with it instead, so interleaved
+// logs of several emulators stay distinguishable.
 func renderLogLineEvent(ev output.LogLineEvent, width int) string {
-	prefix := styles.Secondary.Render(ev.Source + " | ")
+	source := ev.Source
+	if ev.Emulator != "" {
+		source = ev.Emulator
+	}
+	prefix := styles.Secondary.Render(source + " | ")
 	prefixWidth := lipgloss.Width(prefix)
 	availableWidth := max(0, width-prefixWidth)
 	return prefix + renderLogLine(ev.Line, ev.Level, availableWidth, prefixWidth)
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/ui/styles"
+)
+
+// renderRequest renders one request of `lstk logs requests`, colored by how
+// it failed, if it did.
+func renderRequest(e output.RequestEvent) string {
+	line, _ := output.FormatEventLine(e)
+	switch {
+	case e.Status >= 500:
+		return styles.LogError.Render(line)
+	case e.Failed():
+		return styles.Warning.Render(line)
+	}
+	return line
+}
+
+// renderRequestSummary renders the error rate of every operation seen so far,
+// which `lstk logs requests` keeps below the requests and redraws in place.
+// Operations with errors are highlighted.
+func renderRequestSummary(e output.RequestSummaryEvent) string {
+	text, ok := output.FormatEventLine(e)
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n")
+	// The total, then the table: a header and a row per operation.
+	for i, line := range strings.Split(text, "\n") {
+		switch {
+		case i == 0:
+			line = styles.Highlight.Render(line)
+		case i == 1:
+			line = styles.SecondaryMessage.Render(line)
+		case e.Operations[i-2].Errors > 0:
+			line = styles.Warning.Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
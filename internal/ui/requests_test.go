@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+func TestAppKeepsRequestSummaryAsRequestsArrive(t *testing.T) {
+	t.Parallel()
+
+	var model any = NewApp("", "", "", nil, withoutHeader())
+	for _, req := range []output.RequestEvent{
+		{Service: "s3", Operation: "GetObject", Status: 404, ErrorCode: "NoSuchKey"},
+		{Service: "s3", Operation: "GetObject", Status: 200},
+		{Service: "sqs", Operation: "SendMessage", Status: 200},
+	} {
+		model, _ = model.(App).Update(req)
+	}
+	got := stripANSI(model.(App).View())
+
+	for _, want := range []string{
+		"3 requests, 1 failed (33.3%)",
+		"  s3       GetObject    2         1       50.0%",
+		"  sqs      SendMessage  1         0       0.0%",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "NoSuchKey") {
+		t.Errorf("requests are printed above the program, not kept in its view:\n%s", got)
+	}
+}
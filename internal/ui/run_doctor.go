@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/localstack/lstk/internal/doctor"
+	"github.com/localstack/lstk/internal/output"
+)
+
+func RunDoctor(parentCtx context.Context, opts doctor.Options) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return doctor.Run(ctx, sink, opts)
+	})
+}
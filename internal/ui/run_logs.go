@@ -32,18 +32,26 @@ type programLogPrinter struct {
 // wedging the caller; the abandoned goroutine only leaks in that shutdown
 // race, and dies with the process.
 func (l programLogPrinter) PrintLogLine(event output.LogLineEvent) {
+	printAbove(l.ctx, l.p, renderLogLineEvent(event, 0))
+}
+
+// printAbove prints line permanently above the Program, giving up once ctx is
+// done (see PrintLogLine).
+func printAbove(ctx context.Context, p printlner, line string) {
 	done := make(chan struct{})
 	go func() {
-		l.p.Println(renderLogLineEvent(event, 0))
+		p.Println(line)
 		close(done)
 	}()
 	select {
 	case <-done:
-	case <-l.ctx.Done():
+	case <-ctx.Done():
 	}
 }
 
-func RunLogs(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, follow bool, tail string, verbose bool) error {
+// RunLogs shows the emulator logs, or with previous the saved logs of the last
+// session that ended.
+func RunLogs(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, follow bool, tail string, filter container.LogFilter, previous bool) error {
 	ctx, cancel := context.WithCancel(parentCtx)
 	defer cancel()
 
@@ -53,7 +61,12 @@ func RunLogs(parentCtx context.Context, rt runtime.Runtime, containers []config.
 
 	go func() {
 		sink := output.NewStreamingLogSink(programSender{p: p}, programLogPrinter{p: p, ctx: ctx})
-		err := container.Logs(ctx, rt, sink, containers, follow, tail, verbose)
+		var err error
+		if previous {
+			err = container.PreviousLogs(ctx, rt, sink, containers, tail, filter, config.SessionLogDir)
+		} else {
+			err = container.Logs(ctx, rt, sink, containers, follow, tail, filter)
+		}
 		runErrCh <- err
 		if err != nil && !errors.Is(err, context.Canceled) {
 			p.Send(runErrMsg{err: err})
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/container"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+)
+
+// RunRequests shows the requests the emulators served as rows printed above
+// the TUI, like log lines, under which the App keeps a per-operation summary
+// that it updates as requests arrive.
+func RunRequests(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, follow bool, tail string, filter container.RequestFilter) error {
+	ctx, cancel := context.WithCancel(parentCtx)
+	defer cancel()
+
+	app := NewApp("", "", "", cancel, withoutHeader())
+	p := tea.NewProgram(app, tea.WithInput(os.Stdin), tea.WithOutput(os.Stdout))
+	runErrCh := make(chan error, 1)
+
+	go func() {
+		sender := programSender{p: p}
+		sink := output.SinkFunc(func(event output.Event) {
+			if req, ok := event.(output.RequestEvent); ok {
+				printAbove(ctx, p, renderRequest(req))
+			}
+			sender.Send(event)
+		})
+		err := container.Requests(ctx, rt, sink, containers, follow, tail, filter)
+		runErrCh <- err
+		if err != nil && !errors.Is(err, context.Canceled) {
+			p.Send(runErrMsg{err: err})
+			return
+		}
+		p.Send(runDoneMsg{})
+	}()
+
+	model, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	if app, ok := model.(App); ok && app.Err() != nil {
+		return output.NewSilentError(app.Err())
+	}
+
+	runErr := <-runErrCh
+	if runErr != nil && !errors.Is(runErr, context.Canceled) {
+		return runErr
+	}
+
+	return nil
+}
@@ -14,3 +14,9 @@ func RunSnapshotDiff(parentCtx context.Context, rt runtime.Runtime, containers [
 		return snapshot.DiffPod(ctx, rt, containers, client, host, podName, version, authToken, strategy, sink)
 	})
 }
+
+func RunSnapshotDiffLocalRunning(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter snapshot.StateExporter, host, src string) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.DiffLocalRunning(ctx, rt, containers, exporter, host, src, sink)
+	})
+}
@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/localstack/lstk/internal/config"
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/runtime"
+	"github.com/localstack/lstk/internal/snapshot"
+)
+
+func RunSnapshotExport(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, fetcher snapshot.ResourceFetcher, host, format, dest string) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.ExportManifest(ctx, rt, containers, fetcher, host, format, dest, nil, sink)
+	})
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"context"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/snapshot"
+)
+
+func RunSnapshotListLibrary(parentCtx context.Context, lib snapshot.Library) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.ListLibrary(lib, sink)
+	})
+}
+
+func RunSnapshotShowLibrary(parentCtx context.Context, lib snapshot.Library, name string) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.ShowLibrary(lib, name, sink)
+	})
+}
+
+func RunSnapshotPrune(parentCtx context.Context, lib snapshot.Library, name string, keep int) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.PruneLibrary(lib, name, keep, sink)
+	})
+}
@@ -15,13 +15,17 @@ type SnapshotLoadClient interface {
 	snapshot.PodLoader
 }
 
-func RunSnapshotLoad(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client SnapshotLoadClient, host string, src snapshot.Destination, authToken, strategy string, starter snapshot.Starter) error {
+func RunSnapshotLoad(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client SnapshotLoadClient, host string, src snapshot.Destination, lib snapshot.Library, authToken, strategy string, services []string, dec snapshot.Decryption, starter snapshot.Starter, restarter snapshot.Restarter, force bool) error {
 	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
 		switch src.Kind {
 		case snapshot.KindPod:
-			return snapshot.LoadPod(ctx, rt, containers, client, host, src.Value, src.Version, authToken, strategy, starter, sink)
+			return snapshot.LoadPod(ctx, rt, containers, client, host, src.Value, src.Version, authToken, strategy, services, starter, sink)
+		case snapshot.KindOCI:
+			return snapshot.LoadOCI(ctx, rt, containers, client, host, src.Value, strategy, services, starter, sink)
+		case snapshot.KindLibrary:
+			return snapshot.LoadLibrary(ctx, rt, containers, client, host, lib, src.Value, strategy, services, dec, starter, restarter, force, sink)
 		default:
-			return snapshot.LoadLocal(ctx, rt, containers, client, host, src.Value, strategy, starter, sink)
+			return snapshot.LoadLocal(ctx, rt, containers, client, host, src.Value, strategy, services, dec, starter, restarter, force, sink)
 		}
 	})
 }
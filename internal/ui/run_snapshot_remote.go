@@ -15,9 +15,21 @@ func RunSnapshotSaveRemoteS3(parentCtx context.Context, rt runtime.Runtime, cont
 	})
 }
 
-func RunSnapshotLoadRemoteS3(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client snapshot.RemoteClient, host, podName, s3URL string, creds snapshot.S3Credentials, authToken, strategy string, starter snapshot.Starter) error {
+func RunSnapshotSaveRemoteS3Encrypted(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, exporter snapshot.StateExporter, store snapshot.S3ObjectStore, host, podName, s3URL string, creds snapshot.S3Credentials, services []string, enc snapshot.Encryption) error {
 	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
-		return snapshot.LoadRemoteS3(ctx, rt, containers, client, host, podName, s3URL, creds, authToken, strategy, starter, sink)
+		return snapshot.SaveRemoteS3Encrypted(ctx, rt, containers, exporter, store, host, podName, s3URL, creds, services, enc, sink)
+	})
+}
+
+func RunSnapshotLoadRemoteS3(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client snapshot.RemoteClient, host, podName, s3URL string, creds snapshot.S3Credentials, authToken, strategy string, services []string, starter snapshot.Starter) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.LoadRemoteS3(ctx, rt, containers, client, host, podName, s3URL, creds, authToken, strategy, services, starter, sink)
+	})
+}
+
+func RunSnapshotLoadRemoteS3Encrypted(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client snapshot.EncryptedS3Client, host, podName, s3URL string, creds snapshot.S3Credentials, authToken, strategy string, services []string, dec snapshot.Decryption, starter snapshot.Starter, restarter snapshot.Restarter, force bool) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.LoadRemoteS3Encrypted(ctx, rt, containers, client, host, podName, s3URL, creds, authToken, strategy, services, dec, starter, restarter, force, sink)
 	})
 }
 
@@ -26,3 +38,9 @@ func RunSnapshotListRemoteS3(parentCtx context.Context, rt runtime.Runtime, cont
 		return snapshot.ListRemoteS3(ctx, rt, containers, client, host, s3URL, creds, authToken, sink)
 	})
 }
+
+func RunSnapshotListOCI(parentCtx context.Context, repository string) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.ListOCI(ctx, repository, sink)
+	})
+}
@@ -16,13 +16,17 @@ type SnapshotClient interface {
 	snapshot.PodSaver
 }
 
-func RunSnapshotSave(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client SnapshotClient, host string, dest snapshot.Destination, authToken string, services []string) error {
+func RunSnapshotSave(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, client SnapshotClient, host string, dest snapshot.Destination, lib snapshot.Library, authToken string, services []string, enc snapshot.Encryption) error {
 	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
 		switch dest.Kind {
 		case snapshot.KindPod:
 			return snapshot.SavePod(ctx, rt, containers, client, host, dest.Value, authToken, services, sink)
+		case snapshot.KindOCI:
+			return snapshot.SaveOCI(ctx, rt, containers, client, host, dest.Value, services, sink)
+		case snapshot.KindLibrary:
+			return snapshot.SaveLibrary(ctx, rt, containers, client, host, lib, dest.Value, services, enc, sink)
 		default:
-			return snapshot.SaveLocal(ctx, rt, containers, client, host, dest.Value, services, sink)
+			return snapshot.SaveLocal(ctx, rt, containers, client, host, dest.Value, services, enc, sink)
 		}
 	})
 }
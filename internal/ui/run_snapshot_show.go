@@ -12,3 +12,9 @@ func RunSnapshotShow(parentCtx context.Context, inspector snapshot.CloudPodInspe
 		return snapshot.Show(ctx, inspector, authToken, podName, version, sink)
 	})
 }
+
+func RunSnapshotShowLocal(parentCtx context.Context, path string) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return snapshot.ShowLocal(path, sink)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/localstack/lstk/internal/config"
@@ -24,6 +25,21 @@ func RunStatusExternal(parentCtx context.Context, target *endpoint.Target, clien
 	})
 }
 
+// RunStatusWatch keeps the TUI open on a view of the emulators that is redrawn
+// every interval, until the user quits.
+func RunStatusWatch(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, localStackHost string, clients map[config.EmulatorType]emulator.Client, interval time.Duration) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return container.WatchStatus(ctx, rt, containers, localStackHost, clients, interval, sink)
+	})
+}
+
+// RunStatusWatchExternal is RunStatusWatch for an externally-managed endpoint.
+func RunStatusWatchExternal(parentCtx context.Context, target *endpoint.Target, clients map[config.EmulatorType]emulator.Client, interval time.Duration) error {
+	return runWithTUI(parentCtx, withoutHeader(), func(ctx context.Context, sink output.Sink) error {
+		return container.WatchStatusExternal(ctx, target, clients, interval, sink)
+	})
+}
+
 func RunStatus(parentCtx context.Context, rt runtime.Runtime, containers []config.ContainerConfig, localStackHost string, clients map[config.EmulatorType]emulator.Client) error {
 	ctx, cancel := context.WithCancel(parentCtx)
 	defer cancel()
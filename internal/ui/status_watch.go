@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/localstack/lstk/internal/output"
+	"github.com/localstack/lstk/internal/ui/components"
+	"github.com/localstack/lstk/internal/ui/styles"
+	"github.com/localstack/lstk/internal/ui/wrap"
+)
+
+// renderStatusRefresh renders the latest refresh of `lstk status --watch`,
+// which replaces the previous one on screen rather than scrolling. Resources
+// that appeared since the previous refresh are marked "+", and those that
+// disappeared stay listed for one refresh marked "-".
+func renderStatusRefresh(e output.StatusRefreshEvent, width int) string {
+	var sb strings.Builder
+	for _, em := range e.Emulators {
+		renderWatchedEmulator(&sb, em, width)
+		sb.WriteString("\n")
+	}
+	footer := fmt.Sprintf("Refreshed at %s · every %s · press q to quit", e.Time.Format("15:04:05"), e.Interval)
+	sb.WriteString(styles.Secondary.Render(footer))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func renderWatchedEmulator(sb *strings.Builder, em output.WatchedEmulator, width int) {
+	if !em.Running {
+		note := output.MessageEvent{Severity: output.SeverityNote, Text: em.Info.EmulatorName + " is not running"}
+		sb.WriteString(components.RenderWrappedMessage(note, width))
+		sb.WriteString("\n")
+	} else if line, ok := output.FormatEventLine(em.Info); ok {
+		healthy, unhealthy, found := strings.Cut(line, "\n"+output.UnhealthyServicesPrefix)
+		for i, part := range strings.Split(healthy, "\n") {
+			if i == 0 {
+				part = strings.Replace(part, output.SuccessMarker(), styles.Success.Render(output.SuccessMarker()), 1)
+				sb.WriteString(wrap.HardWrap(part, width))
+			} else {
+				sb.WriteString(styles.SecondaryMessage.Render(wrap.HardWrap(part, width)))
+			}
+			sb.WriteString("\n")
+		}
+		if found {
+			sb.WriteString(styles.Warning.Render(output.UnhealthyServicesPrefix + unhealthy))
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(em.ServiceChanges) > 0 {
+		changes := make([]string, len(em.ServiceChanges))
+		for i, c := range em.ServiceChanges {
+			from, to := c.From, c.To
+			if from == "" {
+				from = "none"
+			}
+			if to == "" {
+				to = "gone"
+			}
+			changes[i] = fmt.Sprintf("%s %s → %s", c.Service, from, to)
+		}
+		sb.WriteString(styles.Highlight.Render(wrap.HardWrap("• Changed: "+strings.Join(changes, ", "), width)))
+		sb.WriteString("\n")
+	}
+	if em.Err != "" {
+		warning := output.MessageEvent{Severity: output.SeverityWarning, Text: em.Err}
+		sb.WriteString(components.RenderWrappedMessage(warning, width))
+		sb.WriteString("\n")
+	}
+
+	if em.Info.Type == "aws" && em.Running {
+		renderWatchedResources(sb, em)
+	}
+}
+
+func renderWatchedResources(sb *strings.Builder, em output.WatchedEmulator) {
+	if len(em.Resources) == 0 && len(em.Removed) == 0 {
+		sb.WriteString("\n")
+		sb.WriteString(components.RenderMessage(output.MessageEvent{Severity: output.SeverityNote, Text: "No resources deployed"}))
+		sb.WriteString("\n")
+		return
+	}
+
+	added := make(map[output.StatusResource]bool, len(em.Added))
+	for _, r := range em.Added {
+		added[r] = true
+	}
+	services := map[string]struct{}{}
+	rows := make([][]string, 0, len(em.Resources)+len(em.Removed))
+	for _, r := range em.Resources {
+		rows = append(rows, []string{r.Service, r.Name, r.Region, r.Account})
+		services[r.Service] = struct{}{}
+	}
+	for _, r := range em.Removed {
+		rows = append(rows, []string{r.Service, r.Name, r.Region, r.Account})
+	}
+
+	summary := fmt.Sprintf("~ %d resources · %d services", len(em.Resources), len(services))
+	if len(em.Added) > 0 || len(em.Removed) > 0 {
+		summary += fmt.Sprintf(" (+%d, -%d)", len(em.Added), len(em.Removed))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(styles.Highlight.Render(summary))
+	sb.WriteString("\n\n")
+
+	table, ok := output.FormatEventLine(output.TableEvent{
+		Headers: []string{"Service", "Resource", "Region", "Account"},
+		Rows:    rows,
+	})
+	if !ok {
+		return
+	}
+	// Each table line after the header is indented by two spaces; the first
+	// becomes the marker of a row that changed.
+	for i, line := range strings.Split(table, "\n") {
+		row := i - 1
+		switch {
+		case i == 0:
+			line = styles.SecondaryMessage.Render(line)
+		case row >= len(em.Resources):
+			line = styles.LogError.Render("-" + line[1:])
+		case added[em.Resources[row]]:
+			line = styles.Success.Render("+" + line[1:])
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/localstack/lstk/internal/output"
+)
+
+func TestRenderStatusRefreshMarksAddedAndRemovedResources(t *testing.T) {
+	t.Parallel()
+
+	bucket := output.StatusResource{Service: "S3", Name: "uploads", Region: "us-east-1", Account: "000000000000"}
+	queue := output.StatusResource{Service: "SQS", Name: "orders", Region: "us-east-1", Account: "000000000000"}
+	topic := output.StatusResource{Service: "SNS", Name: "events", Region: "us-east-1", Account: "000000000000"}
+	got := stripANSI(renderStatusRefresh(output.StatusRefreshEvent{
+		Time:     time.Date(2026, 5, 4, 10, 0, 0, 0, time.Local),
+		Interval: 3 * time.Second,
+		Emulators: []output.WatchedEmulator{
+			{
+				Info:           output.InstanceInfoEvent{Type: "aws", EmulatorName: "LocalStack AWS Emulator", Host: "localhost.localstack.cloud:4566"},
+				Running:        true,
+				WasRunning:     true,
+				Resources:      []output.StatusResource{bucket, queue},
+				Added:          []output.StatusResource{queue},
+				Removed:        []output.StatusResource{topic},
+				ServiceChanges: []output.ServiceStateChange{{Service: "sqs", From: "available", To: "running"}},
+			},
+			{Info: output.InstanceInfoEvent{Type: "snowflake", EmulatorName: "LocalStack Snowflake Emulator"}},
+		},
+	}, 120))
+
+	for _, want := range []string{
+		"LocalStack AWS Emulator is running",
+		"• Endpoint: localhost.localstack.cloud:4566",
+		"• Changed: sqs available → running",
+		"~ 2 resources · 2 services (+1, -1)",
+		"  S3       uploads",
+		"+ SQS      orders",
+		"- SNS      events",
+		"LocalStack Snowflake Emulator is not running",
+		"Refreshed at 10:00:00 · every 3s · press q to quit",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in:\n%s", want, got)
+		}
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 func updateHomebrew(ctx context.Context, sink output.Sink) error {
 	cmd := exec.CommandContext(ctx, "brew", "upgrade", "localstack/tap/lstk")
-	w := newLogLineWriter(sink, output.LogSourceBrew)
+	w := output.NewLogLineWriter(sink, output.LogSourceBrew)
 	cmd.Stdout = w
 	cmd.Stderr = w
 	err := cmd.Run()
@@ -9,7 +9,7 @@ import (
 
 func updateNPM(ctx context.Context, sink output.Sink) error {
 	cmd := exec.CommandContext(ctx, "npm", "install", "-g", "@localstack/lstk@latest")
-	w := newLogLineWriter(sink, output.LogSourceNPM)
+	w := output.NewLogLineWriter(sink, output.LogSourceNPM)
 	cmd.Stdout = w
 	cmd.Stderr = w
 	err := cmd.Run()
@@ -1,11 +1,9 @@
 package update
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/localstack/lstk/internal/output"
 	"github.com/localstack/lstk/internal/version"
@@ -83,48 +81,6 @@ func applyUpdate(ctx context.Context, sink output.Sink, latest, githubToken stri
 	return info.Method.String(), nil
 }
 
-// logLineWriter adapts an output.Sink into an io.Writer, emitting each
-// complete line as a LogLineEvent. Partial writes are buffered until a
-// newline arrives.
-type logLineWriter struct {
-	mu     sync.Mutex
-	sink   output.Sink
-	source string
-	buf    []byte
-}
-
-func newLogLineWriter(sink output.Sink, source string) *logLineWriter {
-	return &logLineWriter{sink: sink, source: source}
-}
-
-func (w *logLineWriter) Write(p []byte) (int, error) {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	w.buf = append(w.buf, p...)
-	for {
-		i := bytes.IndexByte(w.buf, '\n')
-		if i < 0 {
-			break
-		}
-		line := string(w.buf[:i])
-		w.buf = w.buf[i+1:]
-		if line != "" {
-			w.sink.Emit(output.LogLineEvent{Source: w.source, Line: line, Level: output.LogLevelUnknown})
-		}
-	}
-	return len(p), nil
-}
-
-// Flush emits any remaining buffered content that didn't end with a newline.
-func (w *logLineWriter) Flush() {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	if len(w.buf) > 0 {
-		w.sink.Emit(output.LogLineEvent{Source: w.source, Line: string(w.buf), Level: output.LogLevelUnknown})
-		w.buf = nil
-	}
-}
-
 // normalizeVersion strips a leading "v" prefix for comparison.
 func normalizeVersion(v string) string {
 	return strings.TrimPrefix(v, "v")
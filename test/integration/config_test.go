@@ -1,10 +1,12 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/localstack/lstk/internal/snap"
@@ -75,9 +77,46 @@ func TestLocalConfigTakesPrecedence(t *testing.T) {
 	require.NoError(t, err, stderr)
 	requireExitCode(t, 0, err)
 
-	expectedLocalPath, err := filepath.Abs(localConfigFile)
-	require.NoError(t, err)
-	assertSamePath(t, expectedLocalPath, stdout)
+	// The project file is layered over the global config, so both are listed,
+	// most specific first.
+	paths := strings.Split(stdout, "\n")
+	require.Len(t, paths, 2, stdout)
+	assertSamePath(t, localConfigFile, paths[0])
+	assertSamePath(t, filepath.Join(tmpHome, ".config", "lstk", "config.toml"), paths[1])
+}
+
+func TestProjectConfigIsFoundFromSubdirectory(t *testing.T) {
+	t.Parallel()
+	tmpHome := t.TempDir()
+	projectDir := t.TempDir()
+	xdgOverride := filepath.Join(tmpHome, "xdg-config-home")
+
+	projectConfigFile := filepath.Join(projectDir, "lstk.toml")
+	globalConfigFile := filepath.Join(tmpHome, ".config", "lstk", "config.toml")
+	writeConfigFile(t, projectConfigFile)
+	writeConfigFile(t, globalConfigFile)
+	workDir := filepath.Join(projectDir, "services", "api")
+	require.NoError(t, os.MkdirAll(workDir, 0755))
+
+	stdout, stderr, err := runLstk(t, testContext(t), workDir, testEnvWithHome(tmpHome, xdgOverride), "config", "path", "--json")
+	require.NoError(t, err, stderr)
+	requireExitCode(t, 0, err)
+
+	envelope := decodeEnvelope(t, stdout)
+	var data struct {
+		Path    string `json:"path"`
+		Sources []struct {
+			Path  string `json:"path"`
+			Scope string `json:"scope"`
+		} `json:"sources"`
+	}
+	require.NoError(t, json.Unmarshal(envelope.Data, &data))
+	assertSamePath(t, projectConfigFile, data.Path)
+	require.Len(t, data.Sources, 2)
+	assertSamePath(t, projectConfigFile, data.Sources[0].Path)
+	assert.Equal(t, "project", data.Sources[0].Scope)
+	assertSamePath(t, globalConfigFile, data.Sources[1].Path)
+	assert.Equal(t, "global", data.Sources[1].Scope)
 }
 
 func TestXDGConfigTakesPrecedence(t *testing.T) {
@@ -187,7 +226,7 @@ container_name = "my emulator"
 	snap.Match(t, sanitizeOutput(stderr))
 }
 
-func TestStartWithMultipleContainersFailsFast(t *testing.T) {
+func TestConfigWithSharedPortFails(t *testing.T) {
 	t.Parallel()
 	configContent := `
 [[containers]]
@@ -196,20 +235,42 @@ port = "4566"
 
 [[containers]]
 type = "snowflake"
-port = "4567"
+port = "4566"
 `
 	configFile := filepath.Join(t.TempDir(), "config.toml")
 	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
 
-	// The guard runs at the very top of container.Start, before any Docker health
-	// check, auth, or image pull — so start fails fast even without a daemon/token.
-	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "--config", configFile, "start")
+	// Rejected at config load, so the failure needs no Docker daemon and no token.
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "--config", configFile, "stop")
 	require.Error(t, err)
 	requireExitCode(t, 1, err)
 	snap.Match(t, sanitizeOutput(stdout))
 	snap.Match(t, sanitizeOutput(stderr))
 }
 
+func TestStopUnknownInstanceFails(t *testing.T) {
+	t.Parallel()
+	configContent := `
+[[containers]]
+type = "aws"
+port = "4566"
+instance = "pristine"
+
+[[containers]]
+type = "aws"
+port = "4567"
+instance = "scratch"
+`
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+	// Resolved against the config before the runtime is contacted, so no Docker daemon is needed.
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "--config", configFile, "stop", "--instance", "other")
+	require.Error(t, err)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, `no emulator instance named "other" is configured`)
+}
+
 func TestLegacyYAMLConfigGivesHelpfulError(t *testing.T) {
 	t.Parallel()
 	tmpHome := t.TempDir()
@@ -247,6 +308,54 @@ port = "4566"
 	requireExitCode(t, 0, err)
 }
 
+func TestConfigSetKeepsCommentsAndGetReadsItBack(t *testing.T) {
+	t.Parallel()
+	configContent := `# team defaults
+[[containers]]
+type = "aws"    # keep this comment
+tag = "latest"
+port = "4566"
+`
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+	e := testEnvWithHome(t.TempDir(), "")
+
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), e, "--config", configFile, "config", "set", "containers[0].tag", "2026.4")
+	require.NoError(t, err, stderr)
+
+	got, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "# team defaults")
+	assert.Contains(t, string(got), `type = "aws"    # keep this comment`)
+	assert.Contains(t, string(got), "tag = '2026.4'")
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), e, "--config", configFile, "config", "get", "containers[0].tag")
+	require.NoError(t, err, stderr)
+	assert.Equal(t, "2026.4", stdout)
+}
+
+func TestConfigValidateReportsEveryProblemWithLine(t *testing.T) {
+	t.Parallel()
+	configContent := `[[containers]]
+type = "aws"
+port = "70000"
+
+[[containers]]
+type = "snowflake"
+port = "4567"
+env = ["missing"]
+`
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0644))
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "--config", configFile, "config", "validate")
+	require.Error(t, err)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stdout, configFile+":3: port 70000 is out of range")
+	assert.Contains(t, stdout, configFile+`:8: environment "missing" referenced in container config not found`)
+	assert.Contains(t, stderr, "found 2 problems")
+}
+
 func testEnvWithHome(tmpHome, xdgConfigHome string) []string {
 	e := env.Without("HOME", "XDG_CONFIG_HOME", "APPDATA", "USERPROFILE", "HOMEDRIVE", "HOMEPATH")
 	switch runtime.GOOS {
@@ -304,3 +413,29 @@ func normalizedPath(path string) string {
 	}
 	return filepath.Clean(absPath)
 }
+
+func TestProjectVolumesNeedTrust(t *testing.T) {
+	t.Parallel()
+	tmpHome := t.TempDir()
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "lstk.toml"), []byte(`
+[[containers]]
+type = "aws"
+tag = "latest"
+port = "4566"
+volumes = ["./persist:/var/lib/localstack"]
+`), 0644))
+	e := testEnvWithHome(tmpHome, filepath.Join(tmpHome, "xdg-config-home"))
+
+	_, stderr, err := runLstk(t, testContext(t), projectDir, e, "volume", "path")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "lstk config trust")
+
+	stdout, stderr, err := runLstk(t, testContext(t), projectDir, e, "config", "trust")
+	require.NoError(t, err, stderr)
+	assert.Contains(t, stdout, "Trusted ")
+
+	stdout, stderr, err = runLstk(t, testContext(t), projectDir, e, "volume", "path")
+	require.NoError(t, err, stderr)
+	assertSamePath(t, filepath.Join(projectDir, "persist"), stdout)
+}
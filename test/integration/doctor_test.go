@@ -0,0 +1,64 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/test/integration/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorJSONReportsBrokenConfigAsFailedCheck(t *testing.T) {
+	t.Parallel()
+	tmpHome := t.TempDir()
+	workDir := t.TempDir()
+	configFile := filepath.Join(workDir, "lstk.toml")
+	require.NoError(t, os.WriteFile(configFile, []byte("[[containers]\ntype = \"aws\"\n"), 0644))
+
+	e := append(testEnvWithHome(tmpHome, ""), string(env.AuthToken)+"=ls-doctor-secret-token")
+	stdout, stderr, err := runLstk(t, testContext(t), workDir, e, "doctor", "--json")
+	require.NoError(t, err, stderr)
+	requireExitCode(t, 0, err)
+
+	envelope := decodeEnvelope(t, stdout)
+	assert.Equal(t, "doctor", envelope.Command)
+	assert.Equal(t, "ok", envelope.Status)
+	assert.NotContains(t, stdout, "ls-doctor-secret-token", "the support bundle must not hold the auth token")
+
+	var data struct {
+		Checks []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+			Detail string `json:"detail"`
+		} `json:"checks"`
+		Summary struct {
+			Passed   int `json:"passed"`
+			Warnings int `json:"warnings"`
+			Failures int `json:"failures"`
+			Skipped  int `json:"skipped"`
+		} `json:"summary"`
+		Environment struct {
+			LstkVersion string `json:"lstkVersion"`
+			OS          string `json:"os"`
+		} `json:"environment"`
+	}
+	require.NoError(t, json.Unmarshal(envelope.Data, &data))
+	require.NotEmpty(t, data.Checks)
+	assert.Equal(t, "Config", data.Checks[0].Name)
+	assert.Equal(t, "fail", data.Checks[0].Status)
+	assert.Contains(t, data.Checks[0].Detail, "lstk.toml")
+	assert.Equal(t, len(data.Checks), data.Summary.Passed+data.Summary.Warnings+data.Summary.Failures+data.Summary.Skipped)
+	assert.NotEmpty(t, data.Environment.LstkVersion)
+	assert.NotEmpty(t, data.Environment.OS)
+
+	var authCheck string
+	for _, c := range data.Checks {
+		if c.Name == "Auth token" {
+			authCheck = c.Status
+		}
+	}
+	assert.Equal(t, "pass", authCheck)
+}
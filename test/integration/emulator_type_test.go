@@ -135,9 +135,9 @@ func TestStartTypeFlagErrorsWhenImageSet(t *testing.T) {
 	assert.Equal(t, content, string(data))
 }
 
-// TestStartTypeErrorsOnMultipleBlocks verifies the switch refuses a config with
-// more than one [[containers]] block before mutating it, so neither block's type
-// is rewritten by a start that cannot succeed anyway.
+// TestStartTypeErrorsOnMultipleBlocks verifies that with several [[containers]]
+// blocks, a --type that matches none of them is refused before the config is
+// mutated, so neither block's type is rewritten.
 func TestStartTypeErrorsOnMultipleBlocks(t *testing.T) {
 	t.Parallel()
 	e, _ := typeTestEnv(t)
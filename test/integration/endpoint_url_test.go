@@ -1,12 +1,18 @@
 package integration_test
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/localstack/lstk/internal/snap"
 	"github.com/localstack/lstk/test/integration/env"
@@ -256,6 +262,34 @@ func TestStatusEndpointURLRendersReducedOutput(t *testing.T) {
 	snap.Match(t, sanitizeOutput(stdout))
 }
 
+func TestStatusEndpointURLJSONReportsServiceStates(t *testing.T) {
+	t.Parallel()
+	srv := awsHealthServer(t)
+	defer srv.Close()
+
+	e := env.With(env.DisableEvents, "1").WithHome(t.TempDir())
+	e = append(e, unreachableDockerHost)
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), e, "--endpoint-url", srv.URL, "status", "--json")
+	require.NoError(t, err, "stderr: %s", stderr)
+
+	envelope := decodeEnvelope(t, stdout)
+	var data struct {
+		Emulators []struct {
+			Type     string            `json:"type"`
+			Running  bool              `json:"running"`
+			Host     string            `json:"host"`
+			Services map[string]string `json:"services"`
+		} `json:"emulators"`
+	}
+	require.NoError(t, json.Unmarshal(envelope.Data, &data))
+	require.Len(t, data.Emulators, 1)
+	assert.Equal(t, "aws", data.Emulators[0].Type)
+	assert.True(t, data.Emulators[0].Running)
+	assert.Equal(t, srv.URL, data.Emulators[0].Host)
+	assert.Equal(t, map[string]string{"s3": "available", "sqs": "available"}, data.Emulators[0].Services)
+}
+
 // TestStatusEndpointURLShowsResources proves the resources bug fix: `status`
 // against an externally-managed endpoint reports deployed resources for an
 // AWS-typed target exactly as it does for a Docker-managed one — deployed
@@ -402,3 +436,86 @@ func TestCDKAWSEndpointURLWrongTypeFails(t *testing.T) {
 	require.Error(t, err)
 	snap.Match(t, sanitizeOutput(stdout))
 }
+
+// TestStatusWatchEndpointURLStreamsDiffs proves `status --watch` without a
+// terminal streams NDJSON: a full snapshot first, then a diff line naming the
+// resource that appeared and the one that disappeared.
+func TestStatusWatchEndpointURLStreamsDiffs(t *testing.T) {
+	t.Parallel()
+	var resourceCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_localstack/health":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"version":  "3.0.2",
+				"services": map[string]string{"s3": "available", "sqs": "available"},
+			})
+		case "/_localstack/resources":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			if resourceCalls.Add(1) == 1 {
+				_, _ = fmt.Fprintln(w, `{"AWS::S3::Bucket": [{"region_name": "us-east-1", "account_id": "000000000000", "id": "old-bucket"}]}`)
+				return
+			}
+			_, _ = fmt.Fprintln(w, `{"AWS::S3::Bucket": [{"region_name": "us-east-1", "account_id": "000000000000", "id": "new-bucket"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	binPath, err := filepath.Abs(binaryPath())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(testContext(t), 30*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, binPath, "--endpoint-url", srv.URL, "status", "--watch", "--interval", "1s")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(env.With(env.DisableEvents, "1").WithHome(t.TempDir()), unreachableDockerHost)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	type line struct {
+		Type string `json:"type"`
+		Data struct {
+			Emulators []struct {
+				Type      string `json:"type"`
+				Running   bool   `json:"running"`
+				Resources []struct {
+					Name string `json:"name"`
+				} `json:"resources"`
+				Added []struct {
+					Name string `json:"name"`
+				} `json:"added"`
+				Removed []struct {
+					Name string `json:"name"`
+				} `json:"removed"`
+			} `json:"emulators"`
+		} `json:"data"`
+	}
+	scanner := bufio.NewScanner(stdout)
+	var lines []line
+	for len(lines) < 2 && scanner.Scan() {
+		var l line
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &l), "line: %s", scanner.Text())
+		lines = append(lines, l)
+	}
+	require.Len(t, lines, 2)
+
+	assert.Equal(t, "snapshot", lines[0].Type)
+	require.Len(t, lines[0].Data.Emulators, 1)
+	assert.True(t, lines[0].Data.Emulators[0].Running)
+	require.Len(t, lines[0].Data.Emulators[0].Resources, 1)
+	assert.Equal(t, "old-bucket", lines[0].Data.Emulators[0].Resources[0].Name)
+
+	assert.Equal(t, "diff", lines[1].Type)
+	require.Len(t, lines[1].Data.Emulators, 1)
+	require.Len(t, lines[1].Data.Emulators[0].Added, 1)
+	assert.Equal(t, "new-bucket", lines[1].Data.Emulators[0].Added[0].Name)
+	require.Len(t, lines[1].Data.Emulators[0].Removed, 1)
+	assert.Equal(t, "old-bucket", lines[1].Data.Emulators[0].Removed[0].Name)
+}
@@ -17,7 +17,7 @@ import (
 
 func TestJSONFlagRejectsUnannotatedBuiltinCommand(t *testing.T) {
 	t.Parallel()
-	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "status", "--json")
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""), "logout", "--json")
 	requireExitCode(t, 1, err)
 	decodeEnvelope(t, stdout)
 	snap.MatchJSON(t, []byte(stdout))
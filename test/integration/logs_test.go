@@ -206,6 +206,158 @@ func TestLogsWithoutTailShowsAllLines(t *testing.T) {
 	}
 }
 
+func TestLogsQueryFilters(t *testing.T) {
+	requireDocker(t)
+	cleanup()
+	t.Cleanup(cleanup)
+
+	ctx := testContext(t)
+	startTestContainer(t, ctx)
+	writeLogLines(t, ctx, []string{
+		"2026-07-07T10:05:11.240  INFO --- [  MainThread] l.s.lambda.invocation : query-info-marker",
+		"2026-07-07T10:05:12.240 ERROR --- [  MainThread] l.s.lambda.invocation : query-lambda-error-marker",
+		"  ResourceNotFoundException: query-traceback-marker",
+		"2026-07-07T10:05:13.240 ERROR --- [  MainThread] l.s.sqs.provider_utils : query-sqs-error-marker",
+	})
+
+	configFile := writeAwsConfig(t)
+	stdout, stderr, err := runLstk(t, ctx, "", env.Without(), "--config", configFile, "logs",
+		"--level", "error", "--logger", "l.s.lambda*", "--since", "2026-07-07T10:05:00Z")
+	require.NoError(t, err, "lstk logs with a query should exit cleanly, stderr: %s", stderr)
+	assert.Contains(t, stdout, "query-lambda-error-marker")
+	assert.Contains(t, stdout, "query-traceback-marker", "a traceback follows its record's header")
+	assert.NotContains(t, stdout, "query-info-marker", "lines below --level must be hidden")
+	assert.NotContains(t, stdout, "query-sqs-error-marker", "lines of other loggers must be hidden")
+
+	stdout, stderr, err = runLstk(t, ctx, "", env.Without(), "--config", configFile, "logs", "--grep", "query-.*-error", "--tail", "1")
+	require.NoError(t, err, "lstk logs --grep should exit cleanly, stderr: %s", stderr)
+	assert.Contains(t, stdout, "query-sqs-error-marker")
+	assert.NotContains(t, stdout, "query-lambda-error-marker", "--tail counts only lines matching --grep")
+}
+
+func TestLogsJSONStreamsLogLines(t *testing.T) {
+	requireDocker(t)
+	cleanup()
+	t.Cleanup(cleanup)
+
+	ctx := testContext(t)
+	startTestContainer(t, ctx)
+	writeLogLines(t, ctx, []string{
+		"2026-07-07T10:05:12.240 ERROR --- [   Thread-12] l.s.lambda.invocation : json-error-marker",
+	})
+
+	configFile := writeAwsConfig(t)
+	stdout, stderr, err := runLstk(t, ctx, "", env.Without(), "--config", configFile, "--json", "logs", "--grep", "json-error-marker")
+	require.NoError(t, err, "lstk --json logs should exit cleanly, stderr: %s", stderr)
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs","type":"log","data":{"timestamp":"2026-07-07T10:05:12.240Z","level":"error","thread":"Thread-12","logger":"l.s.lambda.invocation","message":"json-error-marker"}}`, strings.TrimSpace(stdout))
+}
+
+func TestLogsJSONReportsErrorLine(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeAwsConfig(t)
+	stdout, _, err := runLstk(t, testContext(t), "", env.Without(), "--config", configFile, "--json", "logs", "--level", "fatal")
+	requireExitCode(t, 1, err)
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs","type":"error","data":{"code":"VALIDATION_ERROR","category":"USAGE","message":"invalid log level \"fatal\": expected debug, info, warn or error","retryable":false}}`, strings.TrimSpace(stdout))
+}
+
+func TestLogsRejectsInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeAwsConfig(t)
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--level", "fatal"}, `invalid log level "fatal"`},
+		{[]string{"--logger", "l.s.[lambda"}, `invalid logger pattern "l.s.[lambda"`},
+		{[]string{"--since", "yesterday"}, `invalid --since value "yesterday"`},
+		{[]string{"--since", "5m", "--until", "10m"}, "--until must not be before --since"},
+		{[]string{"--grep", "("}, `invalid --grep value "("`},
+		{[]string{"--follow", "--until", "5m"}, "--until cannot be combined with --follow"},
+	} {
+		args := append([]string{"--config", configFile, "logs"}, tc.args...)
+		_, stderr, err := runLstk(t, testContext(t), "", env.Without(), args...)
+		requireExitCode(t, 1, err)
+		assert.Contains(t, stderr, tc.want, strings.Join(tc.args, " "))
+	}
+}
+
+func TestLogsRequests(t *testing.T) {
+	requireDocker(t)
+	cleanup()
+	t.Cleanup(cleanup)
+
+	ctx := testContext(t)
+	startTestContainer(t, ctx)
+	writeLogLines(t, ctx, []string{
+		"2026-07-07T10:05:11.240  INFO --- [et.reactor-0] localstack.request.aws : AWS s3.CreateBucket => 200",
+		"2026-07-07T10:05:11.300  INFO --- [et.reactor-1] localstack.request.http : GET /_localstack/health => 200",
+		"2026-07-07T10:05:12.240  INFO --- [et.reactor-0] localstack.request.aws : AWS s3.GetObject => 404 (NoSuchKey)",
+	})
+
+	configFile := writeAwsConfig(t)
+	stdout, stderr, err := runLstk(t, ctx, "", env.Without(), "--config", configFile, "logs", "requests")
+	require.NoError(t, err, "lstk logs requests should exit cleanly, stderr: %s", stderr)
+	assert.Contains(t, stdout, "10:05:12.240  404  s3.GetObject")
+	assert.Contains(t, stdout, "NoSuchKey")
+	assert.Contains(t, stdout, "2 requests, 1 failed (50.0%)")
+	assert.NotContains(t, stdout, "/_localstack/health", "lstk's own polling is hidden without --verbose")
+
+	stdout, stderr, err = runLstk(t, ctx, "", env.Without(), "--config", configFile, "--json", "logs", "requests", "--errors")
+	require.NoError(t, err, "lstk --json logs requests should exit cleanly, stderr: %s", stderr)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"request","data":{"timestamp":"2026-07-07T10:05:12.240Z","service":"s3","operation":"GetObject","status":404,"errorCode":"NoSuchKey"}}`, lines[0])
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"summary","data":{"operations":[{"service":"s3","operation":"GetObject","requests":1,"errors":1,"errorRate":1}]}}`, lines[1])
+}
+
+func TestLogsRequestsRejectsInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeAwsConfig(t)
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--status", "4x"}, `invalid status "4x"`},
+		{[]string{"--operation", "Get[Object"}, `invalid operation pattern "Get[Object"`},
+		{[]string{"--tail", "bogus"}, `invalid --tail value "bogus"`},
+		{[]string{"--follow", "--until", "5m"}, "--until cannot be combined with --follow"},
+	} {
+		args := append([]string{"--config", configFile, "logs", "requests"}, tc.args...)
+		_, stderr, err := runLstk(t, testContext(t), "", env.Without(), args...)
+		requireExitCode(t, 1, err)
+		assert.Contains(t, stderr, tc.want, strings.Join(tc.args, " "))
+	}
+
+	stdout, _, err := runLstk(t, testContext(t), "", env.Without(), "--config", configFile, "--json", "logs", "requests", "--status", "600")
+	requireExitCode(t, 1, err)
+	assert.JSONEq(t, `{"schemaVersion":1,"command":"logs requests","type":"error","data":{"code":"VALIDATION_ERROR","category":"USAGE","message":"invalid status \"600\": expected a status code such as 404 or a class such as 4xx","retryable":false}}`, strings.TrimSpace(stdout))
+}
+
+func TestLogsPreviousRejectsFollow(t *testing.T) {
+	t.Parallel()
+
+	configFile := writeAwsConfig(t)
+	_, stderr, err := runLstk(t, testContext(t), "", env.Without(), "--config", configFile, "logs", "--previous", "--follow")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "--previous cannot be combined with --follow")
+}
+
+func TestLogsPreviousWithoutSavedSession(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	configFile := writeAwsConfig(t)
+	e := env.WithHome(home).
+		With(env.Key("XDG_CACHE_HOME"), filepath.Join(home, ".cache")).
+		With(env.Key("LOCALAPPDATA"), filepath.Join(home, "AppData", "Local"))
+	_, stderr, err := runLstk(t, testContext(t), "", e, "--config", configFile, "logs", "--previous")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "no saved logs of a previous")
+}
+
 func TestLogsTailRejectsInvalidValue(t *testing.T) {
 	t.Parallel()
 
@@ -43,6 +43,8 @@ var (
 	// prefix (dkr_/sys_/gen_) depends on whether Docker or /etc/machine-id is
 	// available on the host, and the rest is a host-derived hash or random id.
 	sanitizeExtIDRe = regexp.MustCompile(`(?m)^((?:SESSION|MACHINE)_ID=).*$`)
+	// The one-time note for a legacy .lstk/config.toml names the temp workDir.
+	sanitizeNoteWorkDirRe = regexp.MustCompile(`(?m)^(> Note: )\S+(/\.lstk/config\.toml)`)
 	// Defense in depth: a real auth token must never land in a committed
 	// snapshot or a failure diff, even if a test forgets to strip it.
 	sanitizeExtTokenRe = regexp.MustCompile(`(?m)^(AUTH_TOKEN=).*$`)
@@ -71,6 +73,7 @@ func sanitizeOutput(s string) string {
 	s = sanitizeExtPathRe.ReplaceAllString(s, "${1}<path>")
 	s = sanitizeExtIDRe.ReplaceAllString(s, "${1}<id>")
 	s = sanitizeExtTokenRe.ReplaceAllString(s, "${1}<redacted>")
+	s = sanitizeNoteWorkDirRe.ReplaceAllString(s, "${1}<workdir>${2}")
 	// Width-preserving masks: these values appear in aligned tables, so the
 	// placeholder is padded to the original value's width to keep the
 	// snapshot's columns readable.
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotSaveRecordsEmulatorVersion saves a local snapshot and shows it:
+// the version reported by the emulator's health endpoint is recorded in the file.
+func TestSnapshotSaveRecordsEmulatorVersion(t *testing.T) {
+	t.Parallel()
+	state := snapshotZip(t, map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "bucket"})
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_localstack/pods/state" {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(state)
+			return
+		}
+		health.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	ctx := testContext(t)
+	_, stderr, err := runLstk(t, ctx, dir, diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "save", "./baseline")
+	require.NoError(t, err, stderr)
+
+	stdout, stderr, err := runLstk(t, ctx, dir, diffEnv(t), "--non-interactive", "snapshot", "show", "./baseline.snapshot")
+	require.NoError(t, err, stderr)
+	assert.Contains(t, stdout, "3.0.2")
+}
+
+// TestSnapshotLoadRefusesNewerSnapshot loads a snapshot saved by a newer
+// LocalStack than the running one: nothing is uploaded and the matching image
+// tag is suggested.
+func TestSnapshotLoadRefusesNewerSnapshot(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("api_states/000000000000/us-east-1/s3/store.state")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("bucket"))
+	require.NoError(t, err)
+	require.NoError(t, zw.SetComment(`{"localstack_version":"4.5.0","services":["s3"]}`))
+	require.NoError(t, zw.Close())
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "newer.snapshot"), buf.Bytes(), 0o600))
+
+	var imported atomic.Bool
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/_localstack/pods" {
+			imported.Store(true)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		health.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	stdout, _, err := runLstk(t, testContext(t), dir, diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "./newer.snapshot")
+	requireExitCode(t, 1, err)
+	assert.False(t, imported.Load(), "a snapshot from a newer version must not be uploaded")
+	assert.Contains(t, stdout, "Snapshot requires a newer LocalStack version")
+	assert.Contains(t, stdout, "saved with LocalStack 4.5.0, and the running emulator is 3.0.2")
+	assert.Contains(t, stdout, `set tag = "4.5" in your config file`)
+}
@@ -0,0 +1,130 @@
+package integration_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/snap"
+	"github.com/localstack/lstk/test/integration/env"
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// snapshotEntries reads the files of a snapshot archive. Saves record metadata
+// in the archive's comment, so round trips compare entries rather than bytes.
+func snapshotEntries(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	entries := make(map[string]string, len(r.File))
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		entries[f.Name] = string(content)
+	}
+	return entries
+}
+
+func diffEnv(t *testing.T) []string {
+	t.Helper()
+	e := env.Environ(testEnvWithHome(t.TempDir(), "")).With(env.DisableEvents, "1")
+	return append(e, unreachableDockerHost)
+}
+
+// TestSnapshotDiffLocalFiles compares two snapshot files with neither an
+// emulator nor a platform account: Docker is unreachable and no auth token set.
+func TestSnapshotDiffLocalFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "before.snapshot"), snapshotZip(t, map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":  "bucket-a",
+		"api_states/000000000000/us-east-1/sqs/store.state": "queue-a",
+	}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "after.snapshot"), snapshotZip(t, map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":       "bucket-a",
+		"api_states/000000000000/us-east-1/sqs/store.state":      "queue-b",
+		"api_states/000000000000/us-east-1/dynamodb/store.state": "table",
+	}), 0o600))
+
+	stdout, stderr, err := runLstk(t, testContext(t), dir, diffEnv(t),
+		"--non-interactive", "snapshot", "diff", "./before.snapshot", "./after",
+	)
+	require.NoError(t, err, "lstk snapshot diff failed: %s", stderr)
+	snap.Match(t, sanitizeOutput(stdout))
+}
+
+// TestSnapshotDiffLocalAgainstRunning compares a file with the state the
+// emulator exports.
+func TestSnapshotDiffLocalAgainstRunning(t *testing.T) {
+	t.Parallel()
+	running := snapshotZip(t, map[string]string{"api_states/s3/store.state": "bucket-a"})
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_localstack/pods/state" {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(running)
+			return
+		}
+		health.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "baseline.snapshot"), snapshotZip(t, map[string]string{
+		"api_states/s3/store.state":  "bucket-b",
+		"api_states/sqs/store.state": "queue",
+	}), 0o600))
+
+	stdout, stderr, err := runLstk(t, testContext(t), dir, diffEnv(t),
+		"--non-interactive", "--endpoint-url", srv.URL, "snapshot", "diff", "baseline.snapshot",
+	)
+	require.NoError(t, err, "lstk snapshot diff failed: %s", stderr)
+	snap.Match(t, sanitizeOutput(stdout))
+}
+
+func TestSnapshotDiffRejectsTwoNonLocalRefs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.snapshot"), snapshotZip(t, nil), 0o600))
+
+	_, stderr, err := runLstk(t, testContext(t), dir, diffEnv(t),
+		"--non-interactive", "snapshot", "diff", "a.snapshot", "pod:my-baseline",
+	)
+	requireExitCode(t, 1, err)
+	snap.Match(t, sanitizeOutput(stderr))
+}
+
+func TestSnapshotDiffInvalidFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.snapshot"), snapshotZip(t, nil), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.snapshot"), []byte("not a snapshot"), 0o600))
+
+	stdout, _, err := runLstk(t, testContext(t), dir, diffEnv(t),
+		"--non-interactive", "snapshot", "diff", "a.snapshot", "b.snapshot",
+	)
+	requireExitCode(t, 1, err)
+	snap.Match(t, sanitizeOutput(stdout))
+}
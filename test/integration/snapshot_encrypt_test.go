@@ -0,0 +1,130 @@
+package integration_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotEncryptedRoundTrip saves an encrypted local snapshot and loads it
+// back, with and without the passphrase.
+func TestSnapshotEncryptedRoundTrip(t *testing.T) {
+	t.Parallel()
+	state := snapshotZip(t, map[string]string{"api_states/000000000000/us-east-1/secretsmanager/store.state": "s3cr3t"})
+	var imported []byte
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_localstack/pods/state":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(state)
+		case r.Method == http.MethodPost && r.URL.Path == "/_localstack/pods":
+			imported, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		default:
+			health.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	withPassphrase := append(diffEnv(t), "LSTK_SNAPSHOT_PASSPHRASE=correct horse")
+	ctx := testContext(t)
+
+	stdout, stderr, err := runLstk(t, ctx, dir, withPassphrase, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "save", "./sealed", "--encrypt")
+	require.NoError(t, err, "save failed: %s", stderr)
+	assert.Contains(t, stdout, "Snapshot saved to")
+	sealed, err := os.ReadFile(filepath.Join(dir, "sealed.snapshot"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(sealed, []byte("age-encryption.org/v1\n")))
+	assert.NotContains(t, string(sealed), "s3cr3t")
+
+	stdout, _, err = runLstk(t, ctx, dir, diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "./sealed.snapshot")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stdout, "This snapshot is encrypted")
+	assert.Nil(t, imported, "nothing may be imported without the key")
+
+	wrongPassphrase := append(diffEnv(t), "LSTK_SNAPSHOT_PASSPHRASE=wrong")
+	stdout, _, err = runLstk(t, ctx, dir, wrongPassphrase, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "./sealed.snapshot")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stdout, "does not open this encrypted snapshot")
+
+	_, stderr, err = runLstk(t, ctx, dir, withPassphrase, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "./sealed.snapshot")
+	require.NoError(t, err, "load failed: %s", stderr)
+	assert.Equal(t, snapshotEntries(t, state), snapshotEntries(t, imported), "load must import the decrypted archive")
+}
+
+func TestSnapshotEncryptRejectsRemoteDestination(t *testing.T) {
+	t.Parallel()
+	e := append(diffEnv(t), "LSTK_SNAPSHOT_PASSPHRASE=correct horse")
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), e,
+		"--non-interactive", "snapshot", "save", "pod:my-baseline", "--encrypt",
+	)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "--encrypt only applies to local snapshot files, local: saves and s3:// locations; Cloud Pods are written unencrypted")
+}
+
+// TestSnapshotEncryptedS3RoundTrip saves an encrypted snapshot to an S3 bucket
+// on a local endpoint and loads it back. The mock server plays both the
+// emulator and S3.
+func TestSnapshotEncryptedS3RoundTrip(t *testing.T) {
+	t.Parallel()
+	state := snapshotZip(t, map[string]string{"api_states/000000000000/us-east-1/secretsmanager/store.state": "s3cr3t"})
+	var (
+		mu       sync.Mutex
+		uploaded []byte
+		imported []byte
+	)
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/_localstack/pods/state":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(state)
+		case r.Method == http.MethodPost && r.URL.Path == "/_localstack/pods":
+			imported, _ = io.ReadAll(r.Body)
+		case r.URL.Path == "/my-bucket/my-pod.snapshot":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test/") {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if r.Method == http.MethodPut {
+				uploaded, _ = io.ReadAll(r.Body)
+				return
+			}
+			_, _ = w.Write(uploaded)
+		default:
+			health.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	s3URL := "s3://" + strings.TrimPrefix(srv.URL, "http://") + "/my-bucket"
+	e := append(diffEnv(t), "LSTK_SNAPSHOT_PASSPHRASE=correct horse", "AWS_ACCESS_KEY_ID=test", "AWS_SECRET_ACCESS_KEY=test")
+	ctx := testContext(t)
+
+	stdout, stderr, err := runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "save", "my-pod", s3URL, "--encrypt")
+	require.NoError(t, err, "save failed: %s", stderr)
+	assert.Contains(t, stdout, "Snapshot saved to "+s3URL)
+	mu.Lock()
+	assert.True(t, bytes.HasPrefix(uploaded, []byte("age-encryption.org/v1\n")), "the upload must be sealed with age")
+	assert.NotContains(t, string(uploaded), "s3cr3t")
+	mu.Unlock()
+
+	_, stderr, err = runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "my-pod", s3URL)
+	require.NoError(t, err, "load failed: %s", stderr)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, snapshotEntries(t, state), snapshotEntries(t, imported), "load must import the decrypted archive")
+}
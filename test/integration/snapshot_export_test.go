@@ -0,0 +1,63 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/snap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resourcesServer serves a health endpoint and a resources inventory spread
+// over several NDJSON lines, listed out of order.
+func resourcesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_localstack/resources" {
+			health.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = fmt.Fprintln(w, `{"AWS::SQS::Queue": [{"region_name": "us-east-1", "account_id": "000000000000", "id": "arn:aws:sqs:us-east-1:000000000000:orders"}]}`)
+		_, _ = fmt.Fprintln(w, `{"AWS::S3::Bucket": [{"region_name": "us-east-1", "account_id": "000000000000", "id": "uploads"}, {"region_name": "eu-west-1", "account_id": "111111111111", "id": "assets"}]}`)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSnapshotExportJSON(t *testing.T) {
+	t.Parallel()
+	srv := resourcesServer(t)
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "export")
+	require.NoError(t, err, stderr)
+	snap.Match(t, stdout)
+}
+
+func TestSnapshotExportYAMLToFile(t *testing.T) {
+	t.Parallel()
+	srv := resourcesServer(t)
+	dir := t.TempDir()
+
+	stdout, stderr, err := runLstk(t, testContext(t), dir, diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "export", "--format", "yaml", "resources.yaml")
+	require.NoError(t, err, stderr)
+	assert.Contains(t, stdout, "Resource manifest written to ./resources.yaml (3 resources)")
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "resources.yaml"))
+	require.NoError(t, err)
+	snap.Match(t, string(manifest))
+}
+
+func TestSnapshotExportRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), diffEnv(t), "--non-interactive", "snapshot", "export", "--format", "toml")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, `unknown manifest format "toml": use json or yaml`)
+}
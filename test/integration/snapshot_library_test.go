@@ -0,0 +1,162 @@
+package integration_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/localstack/lstk/internal/snap"
+	"github.com/localstack/lstk/test/integration/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// libraryEnv points the local snapshot library at dataHome/lstk/snapshots.
+// Docker is unreachable: the library commands never need it.
+func libraryEnv(t *testing.T, dataHome string) []string {
+	t.Helper()
+	e := env.Environ(testEnvWithHome(t.TempDir(), "")).With(env.DisableEvents, "1")
+	return append(e, unreachableDockerHost, "XDG_DATA_HOME="+dataHome)
+}
+
+func seedLibraryEntry(t *testing.T, dataHome, name, timestamp string, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(dataHome, "lstk", "snapshots", name)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	path := filepath.Join(dir, timestamp+".snapshot")
+	require.NoError(t, os.WriteFile(path, snapshotZip(t, files), 0o600))
+	return path
+}
+
+// TestSnapshotLibraryRoundTrip saves to the local library, finds the save in
+// list and show, and loads it back.
+func TestSnapshotLibraryRoundTrip(t *testing.T) {
+	t.Parallel()
+	state := snapshotZip(t, map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "bucket"})
+	var imported []byte
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/_localstack/pods/state":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(state)
+		case r.Method == http.MethodPost && r.URL.Path == "/_localstack/pods":
+			imported, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		default:
+			health.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dataHome := t.TempDir()
+	e := libraryEnv(t, dataHome)
+	ctx := testContext(t)
+
+	stdout, stderr, err := runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "save", "local:my-baseline")
+	require.NoError(t, err, "save failed: %s", stderr)
+	assert.Contains(t, stdout, "Snapshot saved to local:my-baseline")
+	saved, err := filepath.Glob(filepath.Join(dataHome, "lstk", "snapshots", "my-baseline", "*.snapshot"))
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+
+	stdout, stderr, err = runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "snapshot", "list", "--local")
+	require.NoError(t, err, "list failed: %s", stderr)
+	assert.Contains(t, stdout, "1 local snapshot")
+	assert.Contains(t, stdout, "local:my-baseline")
+
+	stdout, stderr, err = runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "snapshot", "show", "local:my-baseline")
+	require.NoError(t, err, "show failed: %s", stderr)
+	assert.Contains(t, stdout, saved[0])
+	assert.Regexp(t, `Services\s+s3`, stdout)
+
+	stdout, stderr, err = runLstk(t, ctx, t.TempDir(), e, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "load", "local:my-baseline")
+	require.NoError(t, err, "load failed: %s", stderr)
+	assert.Contains(t, stdout, "local:my-baseline")
+	assert.Equal(t, snapshotEntries(t, state), snapshotEntries(t, imported), "load must import the saved archive")
+}
+
+func TestSnapshotSaveWithoutDestinationUsesLibrary(t *testing.T) {
+	t.Parallel()
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_localstack/pods/state" {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(minimalStateZip(t))
+			return
+		}
+		health.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	dataHome := t.TempDir()
+	cwd := t.TempDir()
+
+	stdout, stderr, err := runLstk(t, testContext(t), cwd, libraryEnv(t, dataHome), "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "save")
+	require.NoError(t, err, "save failed: %s", stderr)
+	assert.Contains(t, stdout, "Snapshot saved to local:localstack-aws")
+	saved, err := filepath.Glob(filepath.Join(dataHome, "lstk", "snapshots", "localstack-aws", "*.snapshot"))
+	require.NoError(t, err)
+	assert.Len(t, saved, 1)
+	inCwd, err := os.ReadDir(cwd)
+	require.NoError(t, err)
+	assert.Empty(t, inCwd, "a save without destination must not write to the working directory")
+}
+
+func TestSnapshotListLocalEmpty(t *testing.T) {
+	t.Parallel()
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "list", "--local",
+	)
+	require.NoError(t, err, "list failed: %s", stderr)
+	snap.Match(t, sanitizeOutput(stdout))
+}
+
+func TestSnapshotShowLocalNotFound(t *testing.T) {
+	t.Parallel()
+	stdout, _, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "show", "local:missing",
+	)
+	requireExitCode(t, 1, err)
+	snap.Match(t, sanitizeOutput(stdout))
+}
+
+func TestSnapshotPruneKeepsNewest(t *testing.T) {
+	t.Parallel()
+	dataHome := t.TempDir()
+	oldest := seedLibraryEntry(t, dataHome, "alpha", "2026-01-01T00-00-00.000Z", nil)
+	older := seedLibraryEntry(t, dataHome, "alpha", "2026-02-01T00-00-00.000Z", nil)
+	newest := seedLibraryEntry(t, dataHome, "alpha", "2026-03-01T00-00-00.000Z", nil)
+	other := seedLibraryEntry(t, dataHome, "beta", "2026-01-01T00-00-00.000Z", nil)
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, dataHome),
+		"--non-interactive", "snapshot", "prune", "--keep", "1", "local:alpha",
+	)
+	require.NoError(t, err, "prune failed: %s", stderr)
+	snap.Match(t, sanitizeOutput(stdout))
+
+	assert.NoFileExists(t, oldest)
+	assert.NoFileExists(t, older)
+	assert.FileExists(t, newest)
+	assert.FileExists(t, other, "prune with a name leaves other names alone")
+}
+
+func TestSnapshotPruneRequiresKeep(t *testing.T) {
+	t.Parallel()
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "prune",
+	)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, `required flag(s) "keep" not set`)
+}
+
+func TestSnapshotPruneRejectsNonLibraryRef(t *testing.T) {
+	t.Parallel()
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "prune", "--keep", "1", "pod:my-baseline",
+	)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "only applies to the local library")
+}
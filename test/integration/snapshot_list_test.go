@@ -3,8 +3,12 @@ package integration_test
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -187,3 +191,52 @@ func TestSnapshotListInteractive(t *testing.T) {
 	require.NoError(t, err, "interactive lstk snapshot list failed")
 	assert.Contains(t, out, "my-pod")
 }
+
+// TestSnapshotListOCITags lists the tags of an oras:// repository. It needs no
+// emulator, and the registry credentials come from the Docker config in HOME,
+// as written by `docker login`.
+func TestSnapshotListOCITags(t *testing.T) {
+	t.Parallel()
+
+	const wantAuth = "Basic " + "dXNlcjpzZWNyZXQ=" // user:secret
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != wantAuth {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v2/team/baseline/tags/list" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"team/baseline","tags":["v1","v3"]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	registry := strings.TrimPrefix(srv.URL, "http://")
+
+	home := t.TempDir()
+	dockerConfig := fmt.Sprintf(`{"auths":{%q:{"auth":"dXNlcjpzZWNyZXQ="}}}`, registry)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".docker"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".docker", "config.json"), []byte(dockerConfig), 0o600))
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(),
+		testEnvWithHome(home, ""),
+		"--non-interactive", "snapshot", "list", "oras://"+registry+"/team/baseline",
+	)
+	require.NoError(t, err, "lstk snapshot list oras:// failed: %s", stderr)
+	assert.Contains(t, stdout, "2 snapshots")
+	assert.Contains(t, stdout, "oras://"+registry+"/team/baseline:v1")
+	assert.Contains(t, stdout, "oras://"+registry+"/team/baseline:v3")
+}
+
+func TestSnapshotListOCIRejectsTag(t *testing.T) {
+	t.Parallel()
+
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(),
+		testEnvWithHome(t.TempDir(), ""),
+		"--non-interactive", "snapshot", "list", "oras://registry/team/baseline:v3",
+	)
+	requireExitCode(t, 1, err)
+	snap.Match(t, sanitizeOutput(stderr))
+}
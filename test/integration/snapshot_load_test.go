@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -147,9 +148,9 @@ func writeTestSnapFile(t *testing.T, dir, name string) string {
 
 // --- no Docker required (parallel) ---
 
-func TestSnapshotLoadRemoteRejected(t *testing.T) {
+func TestSnapshotLoadOCIInvalidReferenceRejected(t *testing.T) {
 	t.Parallel()
-	for _, ref := range []string{"oras://registry/image"} {
+	for _, ref := range []string{"oras://registry", "oras://registry/Image:v1"} {
 		t.Run(ref, func(t *testing.T) {
 			t.Parallel()
 			ctx := testContext(t)
@@ -344,6 +345,56 @@ func TestSnapshotLoadLocalOverwriteStrategy(t *testing.T) {
 	assert.True(t, wasReset(), "/_localstack/state/reset should have been called for overwrite strategy")
 }
 
+// TestSnapshotLoadLocalServices loads a subset of a local snapshot's services:
+// only their state is uploaded, an overwrite resets only them, and the other
+// services are reported as skipped.
+func TestSnapshotLoadLocalServices(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "baseline.snapshot"), snapshotZip(t, map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":       "bucket",
+		"api_states/000000000000/us-east-1/dynamodb/store.state": "table",
+		"api_states/000000000000/us-east-1/sqs/store.state":      "queue",
+	}), 0o600))
+
+	var imported []byte
+	var resets []string
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/_localstack/pods":
+			imported, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/_localstack/state/"):
+			resets = append(resets, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		default:
+			health.ServeHTTP(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	stdout, stderr, err := runLstk(t, testContext(t), dir, diffEnv(t), "--non-interactive", "--endpoint-url", srv.URL,
+		"snapshot", "load", "./baseline.snapshot", "--services", "s3,dynamodb", "--merge=overwrite")
+	require.NoError(t, err, stderr)
+
+	assert.Equal(t, map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":       "bucket",
+		"api_states/000000000000/us-east-1/dynamodb/store.state": "table",
+	}, snapshotEntries(t, imported))
+	assert.Equal(t, []string{"/_localstack/state/dynamodb/reset", "/_localstack/state/s3/reset"}, resets)
+	assert.Contains(t, stdout, "Services: dynamodb, s3")
+	assert.Contains(t, stdout, "Skipped: sqs")
+}
+
+func TestSnapshotLoadServicesRejectsDryRun(t *testing.T) {
+	t.Parallel()
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), testEnvWithHome(t.TempDir(), ""),
+		"--non-interactive", "snapshot", "load", "pod:my-baseline", "--dry-run", "--services", "s3")
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "--dry-run cannot be combined with --services")
+}
+
 func TestSnapshotLoadPodSuccess(t *testing.T) {
 	requireDocker(t)
 	cleanup()
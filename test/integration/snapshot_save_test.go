@@ -96,7 +96,7 @@ func mockStateServerCapturing(t *testing.T) (*httptest.Server, func() string) {
 	}
 }
 
-func TestSnapshotSaveDefaultDestination(t *testing.T) {
+func TestSnapshotSaveToDirectory(t *testing.T) {
 	requireDocker(t)
 	cleanup()
 	t.Cleanup(cleanup)
@@ -108,7 +108,7 @@ func TestSnapshotSaveDefaultDestination(t *testing.T) {
 
 	stdout, stderr, err := runLstk(t, ctx, dir,
 		env.Environ(testEnvWithHome(t.TempDir(), "")).With(env.LocalStackHost, lsHost(srv)),
-		"--non-interactive", "snapshot", "save",
+		"--non-interactive", "snapshot", "save", ".",
 	)
 	require.NoError(t, err, "lstk snapshot save failed: %s", stderr)
 	assert.Contains(t, stdout, "Snapshot saved")
@@ -222,10 +222,11 @@ func TestSnapshotSaveOverwritesExistingFile(t *testing.T) {
 	assert.NotEqual(t, "OLD", string(data), "file should have been overwritten")
 }
 
-func TestSnapshotSaveRemoteRejected(t *testing.T) {
+func TestSnapshotSaveOCIInvalidReferenceRejected(t *testing.T) {
 	t.Parallel()
 	for _, dest := range []string{
-		"oras://registry/my-snap",
+		"oras://registry",
+		"oras://registry/my-snap@sha256:" + strings.Repeat("a", 64),
 	} {
 		t.Run(dest, func(t *testing.T) {
 			t.Parallel()
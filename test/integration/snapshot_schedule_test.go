@@ -0,0 +1,76 @@
+package integration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotScheduleSavesUntilInterrupted runs a schedule long enough for its
+// first save, which is made straight away, then stops it as Ctrl-C would.
+func TestSnapshotScheduleSavesUntilInterrupted(t *testing.T) {
+	t.Parallel()
+	state := snapshotZip(t, map[string]string{"api_states/000000000000/us-east-1/s3/store.state": "bucket"})
+	health := awsHealthHandler()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_localstack/pods/state" {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(state)
+			return
+		}
+		health.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	binPath, err := filepath.Abs(binaryPath())
+	require.NoError(t, err)
+	dataHome := t.TempDir()
+	out := &syncBuffer{}
+	cmd := exec.CommandContext(testContext(t), binPath, "--non-interactive", "--endpoint-url", srv.URL, "snapshot", "schedule", "--every", "1h", "--keep", "2", "local:soak")
+	cmd.Dir = t.TempDir()
+	cmd.Env = libraryEnv(t, dataHome)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	require.NoError(t, cmd.Start())
+
+	deadline := time.Now().Add(30 * time.Second)
+	for !strings.Contains(out.String(), "Snapshot saved to") {
+		if time.Now().After(deadline) {
+			t.Fatalf("schedule never saved; output so far:\n%s", out.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, cmd.Process.Signal(os.Interrupt))
+	require.NoError(t, cmd.Wait(), "stopping a schedule is not a failure: %s", out.String())
+
+	assert.Contains(t, out.String(), "Saving a snapshot to local:soak every 1h, keeping the newest 2. Press Ctrl-C to stop.")
+	saves, err := os.ReadDir(filepath.Join(dataHome, "lstk", "snapshots", "soak"))
+	require.NoError(t, err)
+	assert.Len(t, saves, 1)
+}
+
+func TestSnapshotScheduleRejectsShortInterval(t *testing.T) {
+	t.Parallel()
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "schedule", "--every", "10s",
+	)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "--every must be at least 1m,")
+}
+
+func TestSnapshotScheduleRejectsFileDestination(t *testing.T) {
+	t.Parallel()
+	_, stderr, err := runLstk(t, testContext(t), t.TempDir(), libraryEnv(t, t.TempDir()),
+		"--non-interactive", "snapshot", "schedule", "./soak.snapshot",
+	)
+	requireExitCode(t, 1, err)
+	assert.Contains(t, stderr, "use local:NAME or pod:NAME")
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -162,7 +164,33 @@ func TestSnapshotShowSendsBasicAuthHeader(t *testing.T) {
 	assert.Equal(t, expected, auth)
 }
 
-func TestSnapshotShowRejectsLocalPath(t *testing.T) {
+// TestSnapshotShowLocalFile inspects a snapshot file from its archive alone:
+// neither the platform nor an emulator is contacted.
+func TestSnapshotShowLocalFile(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("platform must not be called for a local file; got %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "baseline.snapshot"), snapshotZip(t, map[string]string{
+		"api_states/000000000000/us-east-1/s3/store.state":  "a",
+		"api_states/000000000000/eu-west-1/s3/store.state":  "b",
+		"api_states/000000000000/us-east-1/sqs/store.state": "c",
+	}), 0o600))
+
+	stdout, stderr, err := runLstk(t, testContext(t), dir,
+		listEnv(t, srv, "test-token"),
+		"--non-interactive", "snapshot", "show", "./baseline",
+	)
+	require.NoError(t, err, "show failed: %s", stderr)
+	snap.Match(t, sanitizeOutput(stdout))
+}
+
+func TestSnapshotShowLocalFileNotFound(t *testing.T) {
 	t.Parallel()
 
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -176,7 +204,7 @@ func TestSnapshotShowRejectsLocalPath(t *testing.T) {
 		"--non-interactive", "snapshot", "show", "./my-snapshot",
 	)
 	requireExitCode(t, 1, err)
-	snap.Match(t, sanitizeOutput(stderr))
+	assert.Contains(t, stderr, "snapshot file not found")
 }
 
 func TestSnapshotShowNotFound(t *testing.T) {
@@ -154,10 +154,9 @@ func TestSnapshotVersionsRejectsS3Ref(t *testing.T) {
 	snap.Match(t, sanitizeOutput(stderr))
 }
 
-// TestSnapshotVersionsOrasKeepsComingSoon: oras:// is unimplemented for every
-// command, so the generic wording is correct there — the S3 fix must not have
-// changed it.
-func TestSnapshotVersionsOrasKeepsComingSoon(t *testing.T) {
+// TestSnapshotVersionsRejectsOrasRef: OCI tags take the place of versions, so
+// the error points at listing them rather than saying "coming soon".
+func TestSnapshotVersionsRejectsOrasRef(t *testing.T) {
 	t.Parallel()
 
 	srv := mockNeverCalledPlatform(t)
@@ -0,0 +1,58 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/localstack/lstk/test/integration/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitEndpointURLReportsReadyServices(t *testing.T) {
+	t.Parallel()
+	srv := awsHealthServer(t)
+	defer srv.Close()
+
+	e := env.With(env.DisableEvents, "1").WithHome(t.TempDir())
+	e = append(e, unreachableDockerHost)
+
+	stdout, stderr, err := runLstk(t, testContext(t), t.TempDir(), e, "--endpoint-url", srv.URL, "wait", "--services", "s3,SQS", "--json")
+	require.NoError(t, err, "stderr: %s", stderr)
+
+	envelope := decodeEnvelope(t, stdout)
+	assert.Equal(t, "ok", envelope.Status)
+	var data struct {
+		Emulators []struct {
+			Type     string            `json:"type"`
+			URL      string            `json:"url"`
+			Services map[string]string `json:"services"`
+		} `json:"emulators"`
+	}
+	require.NoError(t, json.Unmarshal(envelope.Data, &data))
+	require.Len(t, data.Emulators, 1)
+	assert.Equal(t, "aws", data.Emulators[0].Type)
+	assert.Equal(t, srv.URL, data.Emulators[0].URL)
+	assert.Equal(t, map[string]string{"s3": "available", "sqs": "available"}, data.Emulators[0].Services)
+}
+
+func TestWaitEndpointURLTimesOut(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(nil)
+	url := srv.URL
+	srv.Close()
+
+	e := env.With(env.DisableEvents, "1").WithHome(t.TempDir())
+	e = append(e, unreachableDockerHost)
+
+	stdout, _, err := runLstk(t, testContext(t), t.TempDir(), e, "--endpoint-url", url, "wait", "--timeout", "2s", "--json")
+	requireExitCode(t, 1, err)
+
+	envelope := decodeEnvelope(t, stdout)
+	assert.Equal(t, "error", envelope.Status)
+	require.NotNil(t, envelope.Error)
+	assert.Equal(t, "EMULATOR_NOT_READY", envelope.Error.Code)
+	assert.True(t, envelope.Error.Retryable)
+	assert.Equal(t, "The emulator at "+url+" is not ready after 2s", envelope.Error.Message)
+}